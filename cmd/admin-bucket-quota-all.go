@@ -0,0 +1,137 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// bucketQuotaUsage is the quota configured on a bucket and its current usage.
+type bucketQuotaUsage struct {
+	Bucket    string  `json:"bucket"`
+	Quota     uint64  `json:"quota,omitempty"`
+	QuotaType string  `json:"type,omitempty"`
+	Usage     uint64  `json:"usage"`
+	Percent   float64 `json:"percent,omitempty"`
+}
+
+// percentOfQuota returns the percentage of the quota used, -1 for unlimited buckets.
+func (b bucketQuotaUsage) percentOfQuota() float64 {
+	if b.Quota == 0 {
+		return -1
+	}
+	return float64(b.Usage) * 100 / float64(b.Quota)
+}
+
+// quotaAllMessage lists the quota and usage of all the buckets of an alias.
+type quotaAllMessage struct {
+	messageBase
+	Status  string             `json:"status"`
+	Buckets []bucketQuotaUsage `json:"buckets"`
+}
+
+func (q quotaAllMessage) String() string {
+	bucketLen := len("Bucket")
+	for _, b := range q.Buckets {
+		if len(b.Bucket) > bucketLen {
+			bucketLen = len(b.Bucket)
+		}
+	}
+	table := newPrettyTable("  ",
+		Field{"QuotaBucket", bucketLen},
+		Field{"QuotaInfo", 20},
+		Field{"QuotaInfo", 10},
+		Field{"QuotaInfo", -1},
+	)
+	lines := []string{console.Colorize("QuotaHeader", newPrettyTable("  ",
+		Field{"", bucketLen}, Field{"", 20}, Field{"", 10}, Field{"", -1},
+	).buildRow("Bucket", "Quota", "Usage", "Used"))}
+	for _, b := range q.Buckets {
+		quota, used := "unlimited", "-"
+		if b.Quota > 0 {
+			quota = fmt.Sprintf("%s (%s)", humanize.IBytes(b.Quota), b.QuotaType)
+			used = fmt.Sprintf("%.1f%%", b.Percent)
+		}
+		lines = append(lines, table.buildRow(b.Bucket, quota, humanize.IBytes(b.Usage), used))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// JSON prints the buckets, an empty array when there are none.
+func (q quotaAllMessage) JSON() string {
+	q.Status = "success"
+	if q.Buckets == nil {
+		q.Buckets = []bucketQuotaUsage{}
+	}
+	jsonMessageBytes, e := json.MarshalIndent(q, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// sortQuotaUsage sorts buckets by name, by decreasing usage or by
+// decreasing percent of quota used, unlimited buckets last.
+func sortQuotaUsage(buckets []bucketQuotaUsage, by string) {
+	sort.SliceStable(buckets, func(i, j int) bool {
+		switch by {
+		case "usage":
+			if buckets[i].Usage != buckets[j].Usage {
+				return buckets[i].Usage > buckets[j].Usage
+			}
+		case "percent":
+			if pi, pj := buckets[i].percentOfQuota(), buckets[j].percentOfQuota(); pi != pj {
+				return pi > pj
+			}
+		}
+		return buckets[i].Bucket < buckets[j].Bucket
+	})
+}
+
+// getAllBucketsQuota returns the quota and the usage of all the buckets.
+func getAllBucketsQuota(client *madmin.AdminClient) ([]bucketQuotaUsage, *probe.Error) {
+	accountInfo, e := client.AccountInfo(globalContext, madmin.AccountOpts{})
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	buckets := make([]bucketQuotaUsage, 0, len(accountInfo.Buckets))
+	for _, bucket := range accountInfo.Buckets {
+		qCfg, err := getCurrentBucketQuota(client, bucket.Name)
+		if err != nil {
+			return nil, err.Trace(bucket.Name)
+		}
+		b := bucketQuotaUsage{
+			Bucket:    bucket.Name,
+			Quota:     qCfg.Quota,
+			QuotaType: string(qCfg.Type),
+			Usage:     bucket.Size,
+		}
+		if b.Quota > 0 {
+			b.Percent = b.percentOfQuota()
+		}
+		buckets = append(buckets, b)
+	}
+	return buckets, nil
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestGetAllBucketsQuota(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/minio/admin/v3/accountinfo":
+			w.Write([]byte(`{"buckets":[{"name":"logs","size":750},{"name":"media","size":4096}]}`))
+		case "/minio/admin/v3/get-bucket-quota":
+			if r.URL.Query().Get("bucket") == "logs" {
+				w.Write([]byte(`{"quota":1000,"quotatype":"hard"}`))
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"Code":"XMinioAdminNoSuchQuotaConfiguration","Message":"no quota"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, e := madmin.New(strings.TrimPrefix(server.URL, "http://"), "minio", "minio123", false)
+	if e != nil {
+		t.Fatal(e)
+	}
+	buckets, err := getAllBucketsQuota(client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []bucketQuotaUsage{
+		{Bucket: "logs", Quota: 1000, QuotaType: "hard", Usage: 750, Percent: 75},
+		{Bucket: "media", Usage: 4096},
+	}
+	if !reflect.DeepEqual(buckets, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, buckets)
+	}
+}
+
+func TestSortQuotaUsage(t *testing.T) {
+	buckets := []bucketQuotaUsage{
+		{Bucket: "c", Quota: 100, Usage: 10, Percent: 10},
+		{Bucket: "a", Usage: 500},
+		{Bucket: "b", Quota: 100, Usage: 90, Percent: 90},
+	}
+	testCases := []struct {
+		by       string
+		expected []string
+	}{
+		{"name", []string{"a", "b", "c"}},
+		{"usage", []string{"a", "b", "c"}},
+		{"percent", []string{"b", "c", "a"}},
+	}
+	for _, testCase := range testCases {
+		sortQuotaUsage(buckets, testCase.by)
+		var names []string
+		for _, b := range buckets {
+			names = append(names, b.Bucket)
+		}
+		if !reflect.DeepEqual(names, testCase.expected) {
+			t.Fatalf("%s: expected %v, got %v", testCase.by, testCase.expected, names)
+		}
+	}
+}
+
+func TestQuotaAllMessage(t *testing.T) {
+	if got := (quotaAllMessage{}).JSON(); !strings.Contains(got, `"buckets":[]`) || !strings.Contains(got, `"version":"`+jsonSchemaVersion+`"`) {
+		t.Fatalf("expected an empty array of buckets, got %s", got)
+	}
+	msg := quotaAllMessage{Buckets: []bucketQuotaUsage{
+		{Bucket: "logs", Quota: 1000, QuotaType: "hard", Usage: 750, Percent: 75},
+		{Bucket: "media", Usage: 4096},
+	}}
+	if got := msg.JSON(); !strings.Contains(got, `"buckets":[`) || !strings.Contains(got, `"percent":75`) {
+		t.Fatalf("unexpected JSON %s", got)
+	}
+	lines := strings.Split(msg.String(), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[1], "75.0%") || !strings.Contains(lines[2], "unlimited") {
+		t.Fatalf("unexpected table %q", lines)
+	}
+}
@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	humanize "github.com/dustin/go-humanize"
 	"github.com/fatih/color"
@@ -38,18 +39,50 @@ var adminQuotaFlags = []cli.Flag{
 		Name:  "clear",
 		Usage: "clears bucket quota configured for bucket",
 	},
+	cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "show the current and proposed quota without changing it",
+	},
+	cli.BoolFlag{
+		Name:  "all",
+		Usage: "list the quota and usage of all buckets",
+	},
+	cli.StringFlag{
+		Name:  "sort",
+		Usage: "sort the buckets listed with --all by 'name', 'usage' or 'percent' of quota used",
+		Value: "name",
+	},
 }
 
 // quotaMessage container for content message structure
 type quotaMessage struct {
+	messageBase
 	op        string
 	Status    string `json:"status"`
 	Bucket    string `json:"bucket"`
 	Quota     uint64 `json:"quota,omitempty"`
 	QuotaType string `json:"type,omitempty"`
+
+	// Set only with --dry-run.
+	DryRun           bool   `json:"dryRun,omitempty"`
+	CurrentQuota     uint64 `json:"currentQuota,omitempty"`
+	CurrentQuotaType string `json:"currentType,omitempty"`
+}
+
+// describeQuota returns a human readable quota, or "no quota" when unset.
+func describeQuota(quota uint64, quotaType string) string {
+	if quota == 0 {
+		return "no quota"
+	}
+	return fmt.Sprintf("%s quota of %s", quotaType, humanize.IBytes(quota))
 }
 
 func (q quotaMessage) String() string {
+	if q.DryRun {
+		return console.Colorize("QuotaInfo",
+			fmt.Sprintf("Dry run: bucket quota on `%s` would change from %s to %s", q.Bucket,
+				describeQuota(q.CurrentQuota, q.CurrentQuotaType), describeQuota(q.Quota, q.QuotaType)))
+	}
 	switch q.op {
 	case "set":
 		return console.Colorize("QuotaMessage",
@@ -81,7 +114,7 @@ var adminBucketQuotaCmd = cli.Command{
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET [--hard QUOTA | --clear]
+  {{.HelpName}} TARGET [--hard QUOTA | --clear | --all]
 
 QUOTA
   quota accepts human-readable case-insensitive number
@@ -102,6 +135,12 @@ EXAMPLES:
 
   4. Clear bucket quota configured for bucket "mybucket" on MinIO.
      {{.Prompt}} {{.HelpName}} myminio/mybucket --clear
+
+  5. Review the change of setting a hard quota of 1gb for a bucket "mybucket" on MinIO, without applying it.
+     {{.Prompt}} {{.HelpName}} myminio/mybucket --hard 1GB --dry-run
+
+  6. List the quota and usage of all buckets on MinIO, the most used first.
+     {{.Prompt}} {{.HelpName}} myminio --all --sort percent
 `,
 }
 
@@ -110,14 +149,49 @@ func checkAdminBucketQuotaSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) == 0 || len(ctx.Args()) > 1 {
 		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1) // last argument is exit code
 	}
+	if ctx.Bool("dry-run") && !ctx.IsSet("hard") && !ctx.Bool("clear") {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--dry-run requires --hard or --clear.")
+	}
+	if ctx.Bool("all") {
+		if ctx.IsSet("hard") || ctx.Bool("clear") {
+			fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--all cannot be used with --hard or --clear.")
+		}
+		if _, bucket := url2Alias(ctx.Args().Get(0)); strings.Trim(bucket, "/") != "" {
+			fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--all expects an alias without a bucket.")
+		}
+	}
+	switch ctx.String("sort") {
+	case "name", "usage", "percent":
+	default:
+		fatalIf(errInvalidArgument().Trace(ctx.String("sort")), "--sort accepts 'name', 'usage' or 'percent'.")
+	}
+}
+
+// getCurrentBucketQuota returns the quota configured on bucket, an
+// empty quota is returned when none is configured.
+func getCurrentBucketQuota(client *madmin.AdminClient, bucket string) (madmin.BucketQuota, *probe.Error) {
+	qCfg, e := client.GetBucketQuota(globalContext, bucket)
+	if e != nil {
+		if madmin.ToErrorResponse(e).Code == "XMinioAdminNoSuchQuotaConfiguration" {
+			return madmin.BucketQuota{}, nil
+		}
+		return qCfg, probe.NewError(e)
+	}
+	return qCfg, nil
 }
 
 // mainAdminBucketQuota is the handler for "mc admin bucket quota" command.
 func mainAdminBucketQuota(ctx *cli.Context) error {
 	checkAdminBucketQuotaSyntax(ctx)
+	dryRun := ctx.Bool("dry-run")
+	if (ctx.IsSet("hard") || ctx.IsSet("clear")) && !dryRun {
+		fatalIfReadOnly("admin bucket quota")
+	}
 
 	console.SetColor("QuotaMessage", color.New(color.FgGreen))
 	console.SetColor("QuotaInfo", color.New(color.FgBlue))
+	console.SetColor("QuotaBucket", color.New(color.FgYellow))
+	console.SetColor("QuotaHeader", color.New(color.Bold))
 
 	// Get the alias parameter from cli
 	args := ctx.Args()
@@ -128,11 +202,31 @@ func mainAdminBucketQuota(ctx *cli.Context) error {
 	fatalIf(err, "Unable to initialize admin connection.")
 
 	_, targetURL := url2Alias(args[0])
-	if ctx.IsSet("hard") {
+	if ctx.Bool("all") {
+		buckets, err := getAllBucketsQuota(client)
+		fatalIf(err.Trace(args...), "Unable to get the quota of all buckets")
+		sortQuotaUsage(buckets, ctx.String("sort"))
+		printMsg(quotaAllMessage{Buckets: buckets})
+	} else if ctx.IsSet("hard") {
 		qType := madmin.HardQuota
 		quotaStr := ctx.String("hard")
 		quota, e := humanize.ParseBytes(quotaStr)
 		fatalIf(probe.NewError(e).Trace(quotaStr), "Unable to parse quota")
+		if dryRun {
+			current, err := getCurrentBucketQuota(client, targetURL)
+			fatalIf(err.Trace(args...), "Unable to get bucket quota")
+			printMsg(quotaMessage{
+				op:               "set",
+				Bucket:           targetURL,
+				Quota:            quota,
+				QuotaType:        string(qType),
+				DryRun:           true,
+				CurrentQuota:     current.Quota,
+				CurrentQuotaType: string(current.Type),
+				Status:           "success",
+			})
+			return nil
+		}
 		if e = client.SetBucketQuota(globalContext, targetURL, &madmin.BucketQuota{Quota: quota, Type: qType}); e != nil {
 			fatalIf(probe.NewError(e).Trace(args...), "Unable to set bucket quota")
 		}
@@ -144,6 +238,19 @@ func mainAdminBucketQuota(ctx *cli.Context) error {
 			Status:    "success",
 		})
 	} else if ctx.Bool("clear") {
+		if dryRun {
+			current, err := getCurrentBucketQuota(client, targetURL)
+			fatalIf(err.Trace(args...), "Unable to get bucket quota")
+			printMsg(quotaMessage{
+				op:               "unset",
+				Bucket:           targetURL,
+				DryRun:           true,
+				CurrentQuota:     current.Quota,
+				CurrentQuotaType: string(current.Type),
+				Status:           "success",
+			})
+			return nil
+		}
 		if err := client.SetBucketQuota(globalContext, targetURL, &madmin.BucketQuota{}); err != nil {
 			fatalIf(probe.NewError(err).Trace(args...), "Unable to clear bucket quota config")
 		}
@@ -168,15 +168,20 @@ func (l logMessage) String() string {
 	return fmt.Sprintf("%s\n", logMsg)
 }
 
-// mainAdminConsole - the entry function of console command
-func mainAdminConsole(ctx *cli.Context) error {
-	// Check for command syntax
-	checkAdminLogSyntax(ctx)
+// setLogColors sets the colors used to print log entries.
+func setLogColors() {
 	console.SetColor("LogMessage", color.New(color.Bold, color.FgRed))
 	console.SetColor("Api", color.New(color.Bold, color.FgWhite))
 	for _, c := range colors {
 		console.SetColor(fmt.Sprintf("Node%d", c), color.New(c))
 	}
+}
+
+// mainAdminConsole - the entry function of console command
+func mainAdminConsole(ctx *cli.Context) error {
+	// Check for command syntax
+	checkAdminLogSyntax(ctx)
+	setLogColors()
 	aliasedURL := ctx.Args().Get(0)
 	var node string
 	if len(ctx.Args()) > 1 {
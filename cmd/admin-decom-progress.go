@@ -0,0 +1,204 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// Interval between two status requests while watching a decommission.
+const decomWatchInterval = 2 * time.Second
+
+// Decommission states of a pool.
+const (
+	decomStateNone      = "none"
+	decomStateStarting  = "starting"
+	decomStateDraining  = "draining"
+	decomStateComplete  = "complete"
+	decomStateFailed    = "failed"
+	decomStateCancelled = "canceled"
+)
+
+// decomProgressMessage is a progress record of the decommission of a pool.
+// The server reports the used space of the pool only, so the progress is
+// measured in bytes migrated off the pool.
+type decomProgressMessage struct {
+	Status         string    `json:"status"`
+	Pool           string    `json:"pool"`
+	State          string    `json:"state"`
+	StartTime      time.Time `json:"startTime,omitempty"`
+	TotalSize      uint64    `json:"totalSize"`
+	BytesMigrated  uint64    `json:"bytesMigrated"`
+	BytesRemaining uint64    `json:"bytesRemaining"`
+	BytesPerSec    uint64    `json:"bytesPerSec"`
+	ETASeconds     int64     `json:"etaSeconds,omitempty"`
+}
+
+// newDecomProgressMessage computes the progress of the decommission
+// described by info at now, info is nil for a pool never decommissioned.
+func newDecomProgressMessage(pool string, info *madmin.PoolDecommissionInfo, now time.Time) decomProgressMessage {
+	msg := decomProgressMessage{Status: "success", Pool: pool, State: decomStateNone}
+	if info == nil {
+		return msg
+	}
+	msg.StartTime = info.StartTime
+	msg.TotalSize = uint64(info.TotalSize)
+
+	usedStart := info.TotalSize - info.StartSize
+	usedCurrent := info.TotalSize - info.CurrentSize
+	if usedCurrent > 0 {
+		msg.BytesRemaining = uint64(usedCurrent)
+	}
+	if usedStart > usedCurrent {
+		msg.BytesMigrated = uint64(usedStart - usedCurrent)
+		if elapsed := now.Sub(info.StartTime); elapsed >= time.Second {
+			msg.BytesPerSec = msg.BytesMigrated / uint64(elapsed/time.Second)
+		}
+		if msg.BytesPerSec > 0 {
+			msg.ETASeconds = int64(msg.BytesRemaining / msg.BytesPerSec)
+		}
+	}
+
+	switch {
+	case info.Complete:
+		msg.State = decomStateComplete
+		msg.ETASeconds = 0
+	case info.Failed:
+		msg.State = decomStateFailed
+		msg.ETASeconds = 0
+	case info.Canceled:
+		msg.State = decomStateCancelled
+		msg.ETASeconds = 0
+	case info.StartTime.IsZero():
+	case msg.BytesMigrated == 0:
+		msg.State = decomStateStarting
+	default:
+		msg.State = decomStateDraining
+	}
+	return msg
+}
+
+// done tells if the decommission does not progress anymore.
+func (d decomProgressMessage) done() bool {
+	return d.State != decomStateStarting && d.State != decomStateDraining
+}
+
+func (d decomProgressMessage) String() string {
+	switch d.State {
+	case decomStateNone:
+		return fmt.Sprintf("Pool %s is not scheduled for decommissioning", d.Pool)
+	case decomStateStarting:
+		return fmt.Sprintf("Decommissioning of pool %s is starting...", d.Pool)
+	case decomStateComplete:
+		return fmt.Sprintf("Decommission of pool %s is complete, %s migrated", d.Pool, humanize.IBytes(d.BytesMigrated))
+	case decomStateFailed:
+		return fmt.Sprintf("Decommission of pool %s failed after migrating %s, please retry again", d.Pool, humanize.IBytes(d.BytesMigrated))
+	case decomStateCancelled:
+		return fmt.Sprintf("Decommission of pool %s was canceled after migrating %s", d.Pool, humanize.IBytes(d.BytesMigrated))
+	}
+	eta := "unknown"
+	if d.ETASeconds > 0 {
+		eta = (time.Duration(d.ETASeconds) * time.Second).String()
+	}
+	return fmt.Sprintf("Decommissioning %s: %s migrated, %s remaining at %s/sec, ETA %s",
+		d.Pool, humanize.IBytes(d.BytesMigrated), humanize.IBytes(d.BytesRemaining), humanize.IBytes(d.BytesPerSec), eta)
+}
+
+func (d decomProgressMessage) JSON() string {
+	msgBytes, e := json.MarshalIndent(d, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// watchDecommission reports the progress of the decommission of pool until
+// it is complete, failed or canceled. A progress record is printed at every
+// status request with --json, a spinner line is updated in place otherwise.
+func watchDecommission(ctx context.Context, client *madmin.AdminClient, pool string) *probe.Error {
+	progress := func() (decomProgressMessage, *probe.Error) {
+		status, e := client.StatusPool(ctx, pool)
+		if e != nil {
+			return decomProgressMessage{}, probe.NewError(e).Trace(pool)
+		}
+		return newDecomProgressMessage(pool, status.Decommission, time.Now().UTC()), nil
+	}
+
+	if globalJSON {
+		for {
+			msg, err := progress()
+			if err != nil {
+				return err
+			}
+			printMsg(msg)
+			if msg.done() {
+				return nil
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(decomWatchInterval):
+			}
+		}
+	}
+
+	spinners := []string{"∙∙∙", "●∙∙", "∙●∙", "∙∙●"}
+	printLine := func(msg decomProgressMessage, sp string, rewind int) {
+		console.RewindLines(rewind)
+		console.Printf("%s %s %s\n", infoText(dot), greenText(msg.String()), infoText(sp))
+	}
+
+	msg, err := progress()
+	if err != nil {
+		return err
+	}
+	if msg.done() {
+		printLine(msg, check, 0)
+		return nil
+	}
+	printLine(msg, spinners[0], 0)
+
+	spin := time.NewTicker(500 * time.Millisecond) // 2 fps
+	defer spin.Stop()
+	refresh := time.NewTicker(decomWatchInterval)
+	defer refresh.Stop()
+	for i := 1; ; i++ {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-refresh.C:
+			if msg, err = progress(); err != nil {
+				return err
+			}
+			if msg.done() {
+				printLine(msg, check, 1)
+				os.Stdout.Sync()
+				return nil
+			}
+		case <-spin.C:
+		}
+		printLine(msg, spinners[i%len(spinners)], 1)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestDecomProgressMessage(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	pool := "http://server{5...8}/disk{1...4}"
+
+	if msg := newDecomProgressMessage(pool, nil, start); msg.State != decomStateNone || !msg.done() {
+		t.Fatalf("expected a pool without decommission info to be %q, got %q", decomStateNone, msg.State)
+	}
+
+	// 100 bytes in use at start, 40 left after 10 seconds.
+	info := &madmin.PoolDecommissionInfo{StartTime: start, TotalSize: 1000, StartSize: 900, CurrentSize: 960}
+	msg := newDecomProgressMessage(pool, info, start.Add(10*time.Second))
+	if msg.State != decomStateDraining || msg.done() {
+		t.Fatalf("expected state %q, got %q", decomStateDraining, msg.State)
+	}
+	if msg.BytesMigrated != 60 || msg.BytesRemaining != 40 || msg.BytesPerSec != 6 || msg.ETASeconds != 6 {
+		t.Fatalf("unexpected progress %+v", msg)
+	}
+
+	// No elapsed time yet, the rate and ETA are unknown.
+	if msg = newDecomProgressMessage(pool, info, start); msg.BytesPerSec != 0 || msg.ETASeconds != 0 {
+		t.Fatalf("expected no rate nor ETA, got %+v", msg)
+	}
+
+	info.CurrentSize = info.StartSize
+	if msg = newDecomProgressMessage(pool, info, start.Add(time.Second)); msg.State != decomStateStarting {
+		t.Fatalf("expected state %q, got %q", decomStateStarting, msg.State)
+	}
+
+	info.CurrentSize, info.Complete = info.TotalSize, true
+	if msg = newDecomProgressMessage(pool, info, start.Add(time.Minute)); msg.State != decomStateComplete || !msg.done() || msg.ETASeconds != 0 {
+		t.Fatalf("expected state %q, got %+v", decomStateComplete, msg)
+	}
+}
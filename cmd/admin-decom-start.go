@@ -33,12 +33,12 @@ var adminDecommissionStartCmd = cli.Command{
 	Action:       mainAdminDecommissionStart,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        globalFlags,
+	Flags:        append(decomWatchFlags, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET
+  {{.HelpName}} [FLAGS] TARGET POOL
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -46,6 +46,8 @@ FLAGS:
 EXAMPLES:
   1. Start decommissioning a pool for removal.
      {{.Prompt}} {{.HelpName}} myminio/ http://server{5...8}/disk{1...4}
+  2. Start decommissioning a pool and follow its progress until it ends.
+     {{.Prompt}} {{.HelpName}} --watch myminio/ http://server{5...8}/disk{1...4}
 `,
 }
 
@@ -98,5 +100,9 @@ func mainAdminDecommissionStart(ctx *cli.Context) error {
 		Status: "success",
 		Pool:   args.Get(1),
 	})
+
+	if ctx.Bool("watch") {
+		fatalIf(watchDecommission(globalContext, client, args.Get(1)).Trace(args...), "Unable to get status per pool")
+	}
 	return nil
 }
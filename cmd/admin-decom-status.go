@@ -36,12 +36,12 @@ var adminDecommissionStatusCmd = cli.Command{
 	Action:       mainAdminDecommissionStatus,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        globalFlags,
+	Flags:        append(decomWatchFlags, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET
+  {{.HelpName}} [FLAGS] TARGET [POOL]
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -51,6 +51,8 @@ EXAMPLES:
      {{.Prompt}} {{.HelpName}} myminio/ http://server{5...8}/disk{1...4}
   2. List all current decommissioning status of all pools.
      {{.Prompt}} {{.HelpName}} myminio/
+  3. Follow the decommissioning of a pool with migrated bytes, rate and ETA until it ends.
+     {{.Prompt}} {{.HelpName}} --watch myminio/ http://server{5...8}/disk{1...4}
 `,
 }
 
@@ -59,6 +61,17 @@ func checkAdminDecommissionStatusSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) > 2 || len(ctx.Args()) == 0 {
 		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1) // last argument is exit code
 	}
+	if ctx.Bool("watch") && len(ctx.Args()) != 2 {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--watch requires a pool to follow.")
+	}
+}
+
+// decomWatchFlags are the flags following the progress of a decommission.
+var decomWatchFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "watch",
+		Usage: "follow the decommissioning progress until it is complete, failed or canceled",
+	},
 }
 
 // mainAdminDecommissionStatus is the handle for "mc admin decomission status" command.
@@ -75,6 +88,11 @@ func mainAdminDecommissionStatus(ctx *cli.Context) error {
 	fatalIf(err, "Unable to initialize admin connection.")
 
 	if pool := args.Get(1); pool != "" {
+		if ctx.Bool("watch") {
+			fatalIf(watchDecommission(globalContext, client, pool).Trace(args...), "Unable to get status per pool")
+			return nil
+		}
+
 		poolStatus, e := client.StatusPool(globalContext, pool)
 		fatalIf(probe.NewError(e).Trace(args...), "Unable to get status per pool")
 
@@ -85,6 +103,11 @@ func mainAdminDecommissionStatus(ctx *cli.Context) error {
 			return nil
 		}
 
+		if poolStatus.Decommission == nil {
+			errorIf(errDummy().Trace(args...), "This pool is currently not scheduled for decomissioning")
+			return nil
+		}
+
 		var msg string
 		if poolStatus.Decommission.Complete {
 			msg = color.GreenString(fmt.Sprintf("Decommission of pool %s is complete, you may now remove it from server command line", poolStatus.CmdLine))
@@ -564,6 +564,9 @@ func transformScanArg(scanArg string) madmin.HealScanMode {
 func mainAdminHeal(ctx *cli.Context) error {
 	// Check for command syntax
 	checkAdminHealSyntax(ctx)
+	if !ctx.Bool("dry-run") {
+		fatalIfReadOnly("admin heal")
+	}
 
 	// Get the alias parameter from cli
 	args := ctx.Args()
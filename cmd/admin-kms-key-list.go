@@ -0,0 +1,156 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+var adminKMSKeyListCmd = cli.Command{
+	Name:         "list",
+	Usage:        "list the master keys at the KMS",
+	Action:       mainAdminKMSKeyList,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET [PATTERN]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. List all master keys of a MinIO server/cluster.
+     $ {{.HelpName}} play
+  2. List the master keys of a MinIO server/cluster whose name starts with 'my-'.
+     $ {{.HelpName}} play 'my-*'
+`,
+}
+
+// kmsKeyInfo describes a master key as returned by the KMS.
+type kmsKeyInfo struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	CreatedBy string    `json:"createdBy,omitempty"`
+}
+
+type kmsKeyListMsg struct {
+	Status string `json:"status"`
+	kmsKeyInfo
+}
+
+func (k kmsKeyListMsg) JSON() string {
+	k.Status = "success"
+	kmsBytes, e := json.MarshalIndent(k, "", "    ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(kmsBytes)
+}
+
+func (k kmsKeyListMsg) String() string {
+	msg := console.Colorize("KeyName", k.Name)
+	if !k.CreatedAt.IsZero() {
+		msg += fmt.Sprintf(" (created %s", k.CreatedAt.Format(printDate))
+		if k.CreatedBy != "" {
+			msg += " by " + k.CreatedBy
+		}
+		msg += ")"
+	}
+	return msg
+}
+
+// listKMSKeys lists the master keys matching pattern, the request is
+// built by hand since madmin does not provide a key listing API yet.
+func listKMSKeys(ctx context.Context, client *madmin.AdminClient, pattern string) ([]kmsKeyInfo, *probe.Error) {
+	// GET /minio/admin/v3/kms/key/list?pattern=<pattern>
+	qv := url.Values{}
+	qv.Set("pattern", pattern)
+	resp, e := client.ExecuteMethod(ctx, http.MethodGet, madmin.RequestData{
+		RelPath:     "/v3/kms/key/list",
+		QueryValues: qv,
+	})
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// Limit the error response to 100K
+		body, e := ioutil.ReadAll(io.LimitReader(resp.Body, 100<<10))
+		if e != nil {
+			return nil, probe.NewError(e)
+		}
+		errResp := madmin.ErrorResponse{}
+		if json.Unmarshal(body, &errResp) != nil || errResp.Message == "" {
+			msg := strings.TrimSpace(string(body))
+			if msg == "" {
+				msg = resp.Status
+			}
+			return nil, probe.NewError(fmt.Errorf("unexpected response from the server: %s", msg))
+		}
+		return nil, probe.NewError(errResp)
+	}
+
+	var keys []kmsKeyInfo
+	if e = json.NewDecoder(resp.Body).Decode(&keys); e != nil {
+		return nil, probe.NewError(e)
+	}
+	return keys, nil
+}
+
+// mainAdminKMSKeyList is the handler for the "mc admin kms key list" command.
+func mainAdminKMSKeyList(ctx *cli.Context) error {
+	if len(ctx.Args()) == 0 || len(ctx.Args()) > 2 {
+		cli.ShowCommandHelpAndExit(ctx, "list", 1) // last argument is exit code
+	}
+
+	console.SetColor("KeyName", color.New(color.FgBlue, color.Bold))
+
+	aliasedURL := ctx.Args().Get(0)
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err.Trace(aliasedURL), "Unable to get a configured admin connection.")
+
+	pattern := "*"
+	if len(ctx.Args()) == 2 {
+		pattern = ctx.Args().Get(1)
+	}
+	keys, err := listKMSKeys(globalContext, client, pattern)
+	fatalIf(err.Trace(aliasedURL, pattern), "Failed to list master keys")
+
+	for _, key := range keys {
+		printMsg(kmsKeyListMsg{kmsKeyInfo: key})
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestListKMSKeys(t *testing.T) {
+	testCases := []struct {
+		status int
+		body   string
+		keys   int
+		err    string
+	}{
+		{http.StatusOK, `[{"name":"my-key","createdAt":"2022-01-02T15:04:05Z"},{"name":"my-other-key"}]`, 2, ""},
+		{http.StatusOK, `[{"name":`, 0, "unexpected EOF"},
+		{http.StatusForbidden, `{"Code":"AccessDenied","Message":"Access Denied."}`, 0, "Access Denied."},
+		{http.StatusNotFound, `404 page not found`, 0, "404 page not found"},
+	}
+	for i, testCase := range testCases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/minio/admin/v3/kms/key/list" || r.URL.Query().Get("pattern") != "my-*" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(testCase.status)
+			w.Write([]byte(testCase.body))
+		}))
+		client, e := madmin.New(strings.TrimPrefix(server.URL, "http://"), "minio", "minio123", false)
+		if e != nil {
+			t.Fatal(e)
+		}
+		keys, err := listKMSKeys(context.Background(), client, "my-*")
+		server.Close()
+		if testCase.err != "" {
+			if err == nil || !strings.Contains(err.ToGoError().Error(), testCase.err) {
+				t.Fatalf("Test %d: expected error %q, got %v", i+1, testCase.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if len(keys) != testCase.keys {
+			t.Fatalf("Test %d: expected %d keys, got %d", i+1, testCase.keys, len(keys))
+		}
+	}
+}
@@ -19,10 +19,12 @@ package cmd
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/console"
 )
@@ -67,10 +69,18 @@ func mainAdminKMSKeyStatus(ctx *cli.Context) error {
 	if len(ctx.Args()) == 2 {
 		keyID = ctx.Args().Get(1)
 	}
+	// Report whether the KMS endpoints are reachable, the key
+	// status is still reported when this is not possible.
+	kmsStatus, e := client.KMSStatus(globalContext)
+	errorIf(probe.NewError(e).Trace(ctx.Args().Get(0)), "Unable to get the KMS status.")
+
 	status, e := client.GetKeyStatus(globalContext, keyID)
 	fatalIf(probe.NewError(e), "Failed to get status information")
 
 	printMsg(kmsKeyStatusMsg{
+		KMS:           kmsStatus.Name,
+		Endpoints:     kmsStatus.Endpoints,
+		DefaultKeyID:  kmsStatus.DefaultKeyID,
 		KeyID:         status.KeyID,
 		Encryption:    status.EncryptionErr == "",
 		Decryption:    status.DecryptionErr == "",
@@ -81,12 +91,15 @@ func mainAdminKMSKeyStatus(ctx *cli.Context) error {
 }
 
 type kmsKeyStatusMsg struct {
-	KeyID         string `json:"keyId"`
-	Encryption    bool   `json:"encryption"`
-	Decryption    bool   `json:"decryption"`
-	EncryptionErr string `json:"encryptionError,omitempty"`
-	DecryptionErr string `json:"decryptionError,omitempty"`
-	Status        string `json:"status"`
+	KMS           string                      `json:"kms,omitempty"`
+	Endpoints     map[string]madmin.ItemState `json:"endpoints,omitempty"`
+	DefaultKeyID  string                      `json:"defaultKeyId,omitempty"`
+	KeyID         string                      `json:"keyId"`
+	Encryption    bool                        `json:"encryption"`
+	Decryption    bool                        `json:"decryption"`
+	EncryptionErr string                      `json:"encryptionError,omitempty"`
+	DecryptionErr string                      `json:"decryptionError,omitempty"`
+	Status        string                      `json:"status"`
 }
 
 func (s kmsKeyStatusMsg) JSON() string {
@@ -101,7 +114,27 @@ func (s kmsKeyStatusMsg) JSON() string {
 }
 
 func (s kmsKeyStatusMsg) String() string {
-	msg := fmt.Sprintf("Key: %s\n", s.KeyID)
+	var msg string
+	if s.KMS != "" {
+		msg += fmt.Sprintf("KMS: %s\n", s.KMS)
+		endpoints := make([]string, 0, len(s.Endpoints))
+		for endpoint := range s.Endpoints {
+			endpoints = append(endpoints, endpoint)
+		}
+		sort.Strings(endpoints)
+		for _, endpoint := range endpoints {
+			if state := s.Endpoints[endpoint]; state == madmin.ItemOnline {
+				msg += "   - " + endpoint + " " + console.Colorize("StatusSuccess", "✔") + "\n"
+			} else {
+				msg += fmt.Sprintf("   - %s %s (%s)\n", endpoint, console.Colorize("StatusError", "✗"), state)
+			}
+		}
+	}
+	msg += fmt.Sprintf("Key: %s", s.KeyID)
+	if s.KeyID == s.DefaultKeyID {
+		msg += " (default)"
+	}
+	msg += "\n"
 	if s.Encryption {
 		msg += "   - Encryption " + console.Colorize("StatusSuccess", "✔") + "\n"
 	} else {
@@ -21,12 +21,13 @@ import "github.com/minio/cli"
 
 var adminKMSKeySubcommands = []cli.Command{
 	adminKMSCreateKeyCmd,
+	adminKMSKeyListCmd,
 	adminKMSKeyStatusCmd,
 }
 
 var adminKMSKeyCmd = cli.Command{
 	Name:            "key",
-	Usage:           "manage KMS master keys: Request key status information",
+	Usage:           "manage KMS master keys: create, list and request key status information",
 	Action:          mainAdminKMSKey,
 	Before:          setGlobalsFromContext,
 	Flags:           globalFlags,
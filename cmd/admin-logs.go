@@ -0,0 +1,203 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/minio/cli"
+	"github.com/minio/madmin-go"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+const (
+	// Delay before reconnecting to a server which ended the log stream.
+	logReconnectDelay = 3 * time.Second
+	// Longest delay between reconnections to a server failing the log stream.
+	logReconnectCap = time.Minute
+)
+
+var adminLogsFlags = []cli.Flag{
+	cli.IntFlag{
+		Name:  "last, tail, l",
+		Usage: "show last n log entries before following new ones",
+		Value: 10,
+	},
+	cli.StringFlag{
+		Name:  "type, t",
+		Usage: "list error logs by type. Valid options are '[minio, application, all]'",
+		Value: "all",
+	},
+	cli.StringFlag{
+		Name:  "level",
+		Usage: "show only log entries of the comma separated levels, e.g. 'ERROR,FATAL'",
+	},
+}
+
+var adminLogsCmd = cli.Command{
+	Name:            "logs",
+	Usage:           "follow error logs of MinIO server",
+	Action:          mainAdminLogs,
+	OnUsageError:    onUsageError,
+	Before:          setGlobalsFromContext,
+	Flags:           append(adminLogsFlags, globalFlags...),
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET [NODENAME]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show the last 100 log entries of a MinIO server with alias 'myminio' and follow new ones.
+     {{.Prompt}} {{.HelpName}} --last 100 myminio
+
+  2. Follow the application error logs of node 'node1' on MinIO server with alias 'myminio'.
+     {{.Prompt}} {{.HelpName}} --type application myminio node1
+
+  3. Follow only fatal log entries of MinIO server with alias 'myminio', one JSON document per entry.
+     {{.Prompt}} {{.HelpName}} --level FATAL --json myminio
+`,
+}
+
+func checkAdminLogsSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) == 0 || len(ctx.Args()) > 2 {
+		cli.ShowCommandHelpAndExit(ctx, "logs", 1) // last argument is exit code
+	}
+	if ctx.Int("last") < 0 {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "please set a proper value for --last, for example: '--last 5' to display last 5 logs")
+	}
+	logType := strings.ToLower(ctx.String("type"))
+	if logType != "minio" && logType != "application" && logType != "all" {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "Invalid value for --type flag. Valid options are [minio, application, all]")
+	}
+}
+
+// logEntryKey identifies a log entry to skip the entries replayed when
+// reconnecting to the server.
+func logEntryKey(l madmin.LogInfo) string {
+	key := l.NodeName + "|" + l.Time + "|" + l.ConsoleMsg + "|" + l.Message
+	if l.Trace != nil {
+		key += "|" + l.Trace.Message
+	}
+	return key
+}
+
+// recentLogs remembers the keys of the most recent log entries.
+type recentLogs struct {
+	keys  []string
+	index map[string]bool
+	size  int
+}
+
+func newRecentLogs(size int) *recentLogs {
+	return &recentLogs{index: make(map[string]bool), size: size}
+}
+
+// seen records key and reports whether it was already recorded.
+func (r *recentLogs) seen(key string) bool {
+	if r.index[key] {
+		return true
+	}
+	r.index[key] = true
+	r.keys = append(r.keys, key)
+	if len(r.keys) > r.size {
+		delete(r.index, r.keys[0])
+		r.keys = r.keys[1:]
+	}
+	return false
+}
+
+// mainAdminLogs - the entry function of logs command
+func mainAdminLogs(ctx *cli.Context) error {
+	// Check for command syntax
+	checkAdminLogsSyntax(ctx)
+	setLogColors()
+
+	aliasedURL := ctx.Args().Get(0)
+	node := ctx.Args().Get(1)
+	last := ctx.Int("last")
+	logType := strings.ToLower(ctx.String("type"))
+
+	levels := make(map[string]bool)
+	for _, level := range strings.Split(ctx.String("level"), ",") {
+		if level = strings.TrimSpace(level); level != "" {
+			levels[strings.ToUpper(level)] = true
+		}
+	}
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err.Trace(aliasedURL), "Unable to initialize admin client.")
+
+	ctxt, cancel := context.WithCancel(globalContext)
+	defer cancel()
+
+	// Entries replayed on reconnection are printed only once.
+	recent := newRecentLogs(last + 1000)
+	delay := logReconnectDelay
+	for {
+		var failed bool
+		for logInfo := range client.GetLogs(ctxt, node, last, logType) {
+			if logInfo.Err != nil {
+				if ctxt.Err() != nil {
+					return nil
+				}
+				errorIf(probe.NewError(logInfo.Err).Trace(aliasedURL), "Unable to listen to logs.")
+				failed = true
+				continue
+			}
+			// The server streams again, reset the backoff.
+			delay = logReconnectDelay
+			if recent.seen(logEntryKey(logInfo)) {
+				continue
+			}
+			if len(levels) > 0 && !levels[strings.ToUpper(logInfo.Level)] {
+				continue
+			}
+			// drop nodeName from output if specified as cli arg
+			if node != "" {
+				logInfo.NodeName = ""
+			}
+			printMsg(logMessage{LogInfo: logInfo})
+		}
+
+		// The server ended the stream, reconnect unless canceled, backing
+		// off while the server keeps failing.
+		select {
+		case <-ctxt.Done():
+			return nil
+		case <-time.After(delay):
+		}
+		if failed {
+			if delay *= 2; delay > logReconnectCap {
+				delay = logReconnectCap
+			}
+		}
+		if !globalJSON && !globalQuiet {
+			console.Infoln(fmt.Sprintf("Reconnecting to `%s`...", aliasedURL))
+		}
+	}
+}
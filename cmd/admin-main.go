@@ -39,7 +39,9 @@ var adminCmdSubcommands = []cli.Command{
 	adminReplicateCmd,
 	adminConfigCmd,
 	adminDecommissionCmd,
+	adminRebalanceCmd,
 	adminHealCmd,
+	adminScannerCmd,
 	adminPrometheusCmd,
 	adminKMSCmd,
 	adminHealthCmd(),
@@ -51,6 +53,7 @@ var adminCmdSubcommands = []cli.Command{
 	adminTopCmd,
 	adminTraceCmd,
 	adminConsoleCmd,
+	adminLogsCmd,
 	adminClusterCmd,
 }
 
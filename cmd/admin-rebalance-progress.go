@@ -0,0 +1,318 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// Interval between two status requests while watching a rebalance.
+const rebalanceWatchInterval = 2 * time.Second
+
+// Rebalance states of a pool, as reported by the server.
+const (
+	rebalPoolStarted   = "Started"
+	rebalPoolCompleted = "Completed"
+	rebalPoolStopped   = "Stopped"
+	rebalPoolFailed    = "Failed"
+)
+
+// Overall rebalance states.
+const (
+	rebalanceStateNone     = "none"
+	rebalanceStateRunning  = "running"
+	rebalanceStateComplete = "complete"
+	rebalanceStateStopped  = "stopped"
+	rebalanceStateFailed   = "failed"
+)
+
+// rebalPoolProgress is the progress of the rebalance of a pool.
+type rebalPoolProgress struct {
+	NumObjects  uint64        `json:"objects"`
+	NumVersions uint64        `json:"versions"`
+	Bytes       uint64        `json:"bytes"`
+	Bucket      string        `json:"bucket"`
+	Object      string        `json:"object"`
+	Elapsed     time.Duration `json:"elapsed"`
+	ETA         time.Duration `json:"eta"`
+}
+
+// rebalancePoolStatus is the status of the rebalance of a pool, Used
+// is the fraction of the capacity of the pool in use.
+type rebalancePoolStatus struct {
+	ID       int               `json:"id"`
+	Status   string            `json:"status"`
+	Used     float64           `json:"used"`
+	Progress rebalPoolProgress `json:"progress,omitempty"`
+}
+
+// rebalanceStatus is the status of a rebalance of all pools.
+type rebalanceStatus struct {
+	ID        string                `json:"id"`
+	StoppedAt time.Time             `json:"stoppedAt,omitempty"`
+	Pools     []rebalancePoolStatus `json:"pools"`
+}
+
+// doRebalanceRequest sends a rebalance admin request and returns the
+// response body, the requests are built by hand since madmin does not
+// provide a rebalance API yet.
+func doRebalanceRequest(ctx context.Context, client *madmin.AdminClient, method, op string) ([]byte, error) {
+	// <method> /minio/admin/v3/rebalance/<op>
+	resp, e := client.ExecuteMethod(ctx, method, madmin.RequestData{
+		RelPath: "/v3/rebalance/" + op,
+	})
+	if e != nil {
+		return nil, e
+	}
+	defer resp.Body.Close()
+
+	body, e := io.ReadAll(resp.Body)
+	if e != nil {
+		return nil, e
+	}
+	if resp.StatusCode != http.StatusOK {
+		errResp := madmin.ErrorResponse{}
+		if json.Unmarshal(body, &errResp) != nil || errResp.Message == "" {
+			return nil, fmt.Errorf("unexpected response from the server: %s", resp.Status)
+		}
+		return nil, errResp
+	}
+	return body, nil
+}
+
+// startRebalance starts rebalancing all pools and returns the ID of the rebalance.
+func startRebalance(ctx context.Context, client *madmin.AdminClient) (string, error) {
+	body, e := doRebalanceRequest(ctx, client, http.MethodPost, "start")
+	if e != nil {
+		return "", e
+	}
+	var started struct {
+		ID string `json:"id"`
+	}
+	if e = json.Unmarshal(body, &started); e != nil {
+		return "", e
+	}
+	return started.ID, nil
+}
+
+// getRebalanceStatus returns the status of the ongoing or last rebalance.
+func getRebalanceStatus(ctx context.Context, client *madmin.AdminClient) (rebalanceStatus, error) {
+	var status rebalanceStatus
+	body, e := doRebalanceRequest(ctx, client, http.MethodGet, "status")
+	if e != nil {
+		return status, e
+	}
+	e = json.Unmarshal(body, &status)
+	return status, e
+}
+
+// stopRebalance stops the ongoing rebalance.
+func stopRebalance(ctx context.Context, client *madmin.AdminClient) error {
+	_, e := doRebalanceRequest(ctx, client, http.MethodPost, "stop")
+	return e
+}
+
+// rebalancePoolMessage is the progress of the rebalance of a pool.
+type rebalancePoolMessage struct {
+	Pool        int     `json:"pool"`
+	Status      string  `json:"status"`
+	UsedPercent float64 `json:"usedPercent"`
+	Objects     uint64  `json:"objects"`
+	Versions    uint64  `json:"versions"`
+	BytesMoved  uint64  `json:"bytesMoved"`
+	ETASeconds  int64   `json:"etaSeconds,omitempty"`
+}
+
+// rebalanceProgressMessage is a progress record of a rebalance, along
+// with the balance of each pool.
+type rebalanceProgressMessage struct {
+	Status     string                 `json:"status"`
+	ID         string                 `json:"id,omitempty"`
+	State      string                 `json:"state"`
+	Objects    uint64                 `json:"objects"`
+	BytesMoved uint64                 `json:"bytesMoved"`
+	ETASeconds int64                  `json:"etaSeconds,omitempty"`
+	Pools      []rebalancePoolMessage `json:"pools"`
+}
+
+// newRebalanceProgressMessage sums up the progress of the pools of status,
+// the rebalance ends when the last of them does.
+func newRebalanceProgressMessage(status rebalanceStatus) rebalanceProgressMessage {
+	msg := rebalanceProgressMessage{Status: "success", ID: status.ID, State: rebalanceStateNone}
+	var running, completed, failed bool
+	for _, pool := range status.Pools {
+		poolMsg := rebalancePoolMessage{
+			Pool:        pool.ID,
+			Status:      pool.Status,
+			UsedPercent: pool.Used * 100,
+			Objects:     pool.Progress.NumObjects,
+			Versions:    pool.Progress.NumVersions,
+			BytesMoved:  pool.Progress.Bytes,
+		}
+		switch pool.Status {
+		case rebalPoolStarted:
+			running = true
+			poolMsg.ETASeconds = int64(pool.Progress.ETA / time.Second)
+			if poolMsg.ETASeconds > msg.ETASeconds {
+				msg.ETASeconds = poolMsg.ETASeconds
+			}
+		case rebalPoolCompleted:
+			completed = true
+		case rebalPoolFailed:
+			failed = true
+		}
+		msg.Objects += poolMsg.Objects
+		msg.BytesMoved += poolMsg.BytesMoved
+		msg.Pools = append(msg.Pools, poolMsg)
+	}
+
+	switch {
+	case !status.StoppedAt.IsZero():
+		msg.State = rebalanceStateStopped
+	case running:
+		msg.State = rebalanceStateRunning
+	case failed:
+		msg.State = rebalanceStateFailed
+	case completed:
+		msg.State = rebalanceStateComplete
+	}
+	if msg.State != rebalanceStateRunning {
+		msg.ETASeconds = 0
+	}
+	return msg
+}
+
+// done tells if the rebalance does not progress anymore.
+func (r rebalanceProgressMessage) done() bool {
+	return r.State != rebalanceStateRunning
+}
+
+func (r rebalanceProgressMessage) String() string {
+	var b strings.Builder
+	switch r.State {
+	case rebalanceStateNone:
+		return "No rebalance is in progress"
+	case rebalanceStateRunning:
+		eta := "unknown"
+		if r.ETASeconds > 0 {
+			eta = (time.Duration(r.ETASeconds) * time.Second).String()
+		}
+		fmt.Fprintf(&b, "Rebalancing: %s moved, %d object(s), ETA %s", humanize.IBytes(r.BytesMoved), r.Objects, eta)
+	default:
+		fmt.Fprintf(&b, "Rebalance %s: %s moved, %d object(s)", r.State, humanize.IBytes(r.BytesMoved), r.Objects)
+	}
+	for _, pool := range r.Pools {
+		fmt.Fprintf(&b, "\n  Pool %s: %.2f%% used, %s, %s moved, %d object(s)",
+			humanize.Ordinal(pool.Pool+1), pool.UsedPercent, strings.ToLower(pool.Status), humanize.IBytes(pool.BytesMoved), pool.Objects)
+	}
+	return b.String()
+}
+
+func (r rebalanceProgressMessage) JSON() string {
+	msgBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// watchRebalance reports the progress of the rebalance until it is
+// complete, failed or stopped. A progress record is printed at every
+// status request with --json, the progress lines are updated in place
+// along with a spinner otherwise.
+func watchRebalance(ctx context.Context, client *madmin.AdminClient) *probe.Error {
+	progress := func() (rebalanceProgressMessage, *probe.Error) {
+		status, e := getRebalanceStatus(ctx, client)
+		if e != nil {
+			return rebalanceProgressMessage{}, probe.NewError(e)
+		}
+		return newRebalanceProgressMessage(status), nil
+	}
+
+	if globalJSON {
+		for {
+			msg, err := progress()
+			if err != nil {
+				return err
+			}
+			printMsg(msg)
+			if msg.done() {
+				return nil
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(rebalanceWatchInterval):
+			}
+		}
+	}
+
+	spinners := []string{"∙∙∙", "●∙∙", "∙●∙", "∙∙●"}
+	printed := 0
+	printLines := func(msg rebalanceProgressMessage, sp string) {
+		console.RewindLines(printed)
+		lines := strings.Split(msg.String(), "\n")
+		console.Printf("%s %s %s\n", infoText(dot), greenText(lines[0]), infoText(sp))
+		for _, line := range lines[1:] {
+			console.Println(line)
+		}
+		printed = len(lines)
+	}
+
+	msg, err := progress()
+	if err != nil {
+		return err
+	}
+	if msg.done() {
+		printLines(msg, check)
+		return nil
+	}
+	printLines(msg, spinners[0])
+
+	spin := time.NewTicker(500 * time.Millisecond) // 2 fps
+	defer spin.Stop()
+	refresh := time.NewTicker(rebalanceWatchInterval)
+	defer refresh.Stop()
+	for i := 1; ; i++ {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-refresh.C:
+			if msg, err = progress(); err != nil {
+				return err
+			}
+			if msg.done() {
+				printLines(msg, check)
+				os.Stdout.Sync()
+				return nil
+			}
+		case <-spin.C:
+		}
+		printLines(msg, spinners[i%len(spinners)])
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewRebalanceProgressMessage(t *testing.T) {
+	testCases := []struct {
+		status  rebalanceStatus
+		state   string
+		objects uint64
+		bytes   uint64
+		eta     int64
+	}{
+		{rebalanceStatus{}, rebalanceStateNone, 0, 0, 0},
+		{rebalanceStatus{ID: "r1", Pools: []rebalancePoolStatus{
+			{ID: 0, Status: rebalPoolStarted, Used: 0.8, Progress: rebalPoolProgress{NumObjects: 10, Bytes: 1024, ETA: time.Minute}},
+			{ID: 1, Status: rebalPoolStarted, Used: 0.2, Progress: rebalPoolProgress{NumObjects: 5, Bytes: 512, ETA: 2 * time.Minute}},
+			{ID: 2, Status: rebalPoolCompleted, Used: 0.5},
+		}}, rebalanceStateRunning, 15, 1536, 120},
+		{rebalanceStatus{ID: "r2", Pools: []rebalancePoolStatus{
+			{ID: 0, Status: rebalPoolCompleted, Progress: rebalPoolProgress{NumObjects: 3, Bytes: 30}},
+			{ID: 1, Status: rebalPoolCompleted, Progress: rebalPoolProgress{NumObjects: 4, Bytes: 40}},
+		}}, rebalanceStateComplete, 7, 70, 0},
+		{rebalanceStatus{ID: "r3", Pools: []rebalancePoolStatus{
+			{ID: 0, Status: rebalPoolCompleted},
+			{ID: 1, Status: rebalPoolFailed},
+		}}, rebalanceStateFailed, 0, 0, 0},
+		{rebalanceStatus{ID: "r4", StoppedAt: time.Now(), Pools: []rebalancePoolStatus{
+			{ID: 0, Status: rebalPoolStopped, Progress: rebalPoolProgress{NumObjects: 1, Bytes: 1, ETA: time.Hour}},
+		}}, rebalanceStateStopped, 1, 1, 0},
+	}
+
+	for i, testCase := range testCases {
+		msg := newRebalanceProgressMessage(testCase.status)
+		if msg.State != testCase.state || msg.Objects != testCase.objects || msg.BytesMoved != testCase.bytes || msg.ETASeconds != testCase.eta {
+			t.Errorf("Test %d: expected %s/%d/%d/%d, got %s/%d/%d/%d", i+1,
+				testCase.state, testCase.objects, testCase.bytes, testCase.eta,
+				msg.State, msg.Objects, msg.BytesMoved, msg.ETASeconds)
+		}
+		if msg.done() != (testCase.state != rebalanceStateRunning) {
+			t.Errorf("Test %d: unexpected done() for state %s", i+1, msg.State)
+		}
+		if len(msg.Pools) != len(testCase.status.Pools) {
+			t.Errorf("Test %d: expected %d pools, got %d", i+1, len(testCase.status.Pools), len(msg.Pools))
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+var adminRebalanceStartCmd = cli.Command{
+	Name:         "start",
+	Usage:        "start rebalancing objects across all pools",
+	Action:       mainAdminRebalanceStart,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(rebalanceWatchFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Start rebalancing objects across the pools of a cluster, e.g. after a new pool was added.
+     {{.Prompt}} {{.HelpName}} myminio/
+  2. Start rebalancing and follow its progress until it ends.
+     {{.Prompt}} {{.HelpName}} --watch myminio/
+`,
+}
+
+// checkAdminRebalanceStartSyntax - validate all the passed arguments
+func checkAdminRebalanceStartSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1) // last argument is exit code
+	}
+}
+
+// startRebalanceMessage is container for rebalance start success messages.
+type startRebalanceMessage struct {
+	Status string `json:"status"`
+	ID     string `json:"id"`
+}
+
+// String colorized construct a string message.
+func (s startRebalanceMessage) String() string {
+	return console.Colorize("RebalanceStarted", "Rebalance started successfully with ID `"+s.ID+"`.")
+}
+
+// JSON jsonified rebalance start message.
+func (s startRebalanceMessage) JSON() string {
+	startRebalanceBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(startRebalanceBytes)
+}
+
+// mainAdminRebalanceStart is the handle for "mc admin rebalance start" command.
+func mainAdminRebalanceStart(ctx *cli.Context) error {
+	checkAdminRebalanceStartSyntax(ctx)
+
+	// Additional command speific theme customization.
+	console.SetColor("RebalanceStarted", color.New(color.FgGreen, color.Bold))
+
+	// Get the alias parameter from cli
+	args := ctx.Args()
+	aliasedURL := filepath.Clean(args.Get(0))
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	id, e := startRebalance(globalContext, client)
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to start rebalance")
+
+	printMsg(startRebalanceMessage{
+		Status: "success",
+		ID:     id,
+	})
+
+	if ctx.Bool("watch") {
+		fatalIf(watchRebalance(globalContext, client).Trace(args...), "Unable to get rebalance status")
+	}
+	return nil
+}
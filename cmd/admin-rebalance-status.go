@@ -0,0 +1,87 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var adminRebalanceStatusCmd = cli.Command{
+	Name:         "status",
+	Usage:        "show the progress of the rebalance and the balance of each pool",
+	Action:       mainAdminRebalanceStatus,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(rebalanceWatchFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show the bytes and objects moved by the ongoing rebalance, the usage of each pool and the ETA.
+     {{.Prompt}} {{.HelpName}} myminio/
+  2. Follow the rebalance until it ends, printing a progress record at each update.
+     {{.Prompt}} {{.HelpName}} --watch --json myminio/
+`,
+}
+
+var rebalanceWatchFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "watch",
+		Usage: "follow the rebalance progress until it is complete, failed or stopped",
+	},
+}
+
+// checkAdminRebalanceStatusSyntax - validate all the passed arguments
+func checkAdminRebalanceStatusSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1) // last argument is exit code
+	}
+}
+
+// mainAdminRebalanceStatus is the handle for "mc admin rebalance status" command.
+func mainAdminRebalanceStatus(ctx *cli.Context) error {
+	checkAdminRebalanceStatusSyntax(ctx)
+
+	// Get the alias parameter from cli
+	args := ctx.Args()
+	aliasedURL := filepath.Clean(args.Get(0))
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	if ctx.Bool("watch") {
+		fatalIf(watchRebalance(globalContext, client).Trace(args...), "Unable to get rebalance status")
+		return nil
+	}
+
+	status, e := getRebalanceStatus(globalContext, client)
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to get rebalance status")
+
+	printMsg(newRebalanceProgressMessage(status))
+	return nil
+}
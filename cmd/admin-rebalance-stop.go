@@ -0,0 +1,101 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+var adminRebalanceStopCmd = cli.Command{
+	Name:         "stop",
+	Usage:        "stop the ongoing rebalance",
+	Action:       mainAdminRebalanceStop,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Stop the ongoing rebalance, the objects already moved stay in their new pool.
+     {{.Prompt}} {{.HelpName}} myminio/
+`,
+}
+
+// checkAdminRebalanceStopSyntax - validate all the passed arguments
+func checkAdminRebalanceStopSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1) // last argument is exit code
+	}
+}
+
+// stopRebalanceMessage is container for rebalance stop success messages.
+type stopRebalanceMessage struct {
+	Status string `json:"status"`
+	Target string `json:"target"`
+}
+
+// String colorized construct a string message.
+func (s stopRebalanceMessage) String() string {
+	return console.Colorize("RebalanceStopped", "Rebalance stopped successfully for `"+s.Target+"`.")
+}
+
+// JSON jsonified rebalance stop message.
+func (s stopRebalanceMessage) JSON() string {
+	stopRebalanceBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(stopRebalanceBytes)
+}
+
+// mainAdminRebalanceStop is the handle for "mc admin rebalance stop" command.
+func mainAdminRebalanceStop(ctx *cli.Context) error {
+	checkAdminRebalanceStopSyntax(ctx)
+
+	// Additional command speific theme customization.
+	console.SetColor("RebalanceStopped", color.New(color.FgGreen, color.Bold))
+
+	// Get the alias parameter from cli
+	args := ctx.Args()
+	aliasedURL := filepath.Clean(args.Get(0))
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	e := stopRebalance(globalContext, client)
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to stop rebalance")
+
+	printMsg(stopRebalanceMessage{
+		Status: "success",
+		Target: aliasedURL,
+	})
+	return nil
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"github.com/minio/cli"
+)
+
+var adminRebalanceSubcommands = []cli.Command{
+	adminRebalanceStartCmd,
+	adminRebalanceStatusCmd,
+	adminRebalanceStopCmd,
+}
+
+var adminRebalanceCmd = cli.Command{
+	Name:            "rebalance",
+	Usage:           "manage MinIO server pool rebalancing",
+	Action:          mainAdminRebalance,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	Subcommands:     adminRebalanceSubcommands,
+	HideHelpCommand: true,
+}
+
+// mainAdminRebalance is the handle for "mc admin rebalance" command.
+func mainAdminRebalance(ctx *cli.Context) error {
+	commandNotFound(ctx, adminRebalanceSubcommands)
+	return nil
+	// Sub-commands like "start", "status" have their own main.
+}
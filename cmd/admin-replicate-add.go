@@ -18,6 +18,8 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
 	"strings"
 
 	"github.com/fatih/color"
@@ -41,6 +43,8 @@ var adminReplicateAddCmd = cli.Command{
 USAGE:
   {{.HelpName}} ALIAS1 ALIAS2 [ALIAS3...]
 
+  All sites must be reachable and be distinct deployments.
+
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
@@ -72,6 +76,26 @@ func (m successMessage) String() string {
 	return console.Colorize("UserMessage", strings.Join(messages, "\n"))
 }
 
+// checkReplicateSites verifies that all sites are reachable and are
+// distinct deployments before configuring their replication.
+func checkReplicateSites(ctx context.Context, names []string, clients []*madmin.AdminClient) *probe.Error {
+	deployments := make(map[string]string, len(clients))
+	for i, admClient := range clients {
+		info, e := admClient.ServerInfo(ctx)
+		if e != nil {
+			return probe.NewError(fmt.Errorf("site `%s` at `%s` is not reachable: %w", names[i], admClient.GetEndpointURL(), e))
+		}
+		if info.DeploymentID == "" {
+			continue
+		}
+		if other, ok := deployments[info.DeploymentID]; ok {
+			return probe.NewError(fmt.Errorf("sites `%s` and `%s` are the same deployment", other, names[i]))
+		}
+		deployments[info.DeploymentID] = names[i]
+	}
+	return nil
+}
+
 func mainAdminReplicateAdd(ctx *cli.Context) error {
 	{
 		// Check argument count
@@ -93,9 +117,11 @@ func mainAdminReplicateAdd(ctx *cli.Context) error {
 	fatalIf(err, "Unable to initialize admin connection.")
 
 	ps := make([]madmin.PeerSite, 0, len(ctx.Args()))
+	clients := make([]*madmin.AdminClient, 0, len(ctx.Args()))
 	for _, clusterName := range ctx.Args() {
 		admClient, err := newAdminClient(clusterName)
 		fatalIf(err, "unable to initialize admin connection")
+		clients = append(clients, admClient)
 
 		ak, sk := admClient.GetAccessAndSecretKey()
 		ps = append(ps, madmin.PeerSite{
@@ -106,6 +132,8 @@ func mainAdminReplicateAdd(ctx *cli.Context) error {
 		})
 	}
 
+	fatalIf(checkReplicateSites(globalContext, args, clients).Trace(args...), "Unable to add sites for replication")
+
 	res, e := client.SiteReplicationAdd(globalContext, ps)
 	fatalIf(probe.NewError(e).Trace(args...), "Unable to add sites for replication")
 
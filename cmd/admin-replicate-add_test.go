@@ -0,0 +1,73 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+// newInfoServer returns a server answering the server info admin API
+// with deploymentID.
+func newInfoServer(deploymentID string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/minio/admin/v3/info" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"deploymentID":"` + deploymentID + `"}`))
+	}))
+}
+
+func TestCheckReplicateSites(t *testing.T) {
+	site1, site2, site3 := newInfoServer("d1"), newInfoServer("d2"), newInfoServer("d1")
+	defer site1.Close()
+	defer site2.Close()
+	defer site3.Close()
+	down := newInfoServer("d4")
+	down.Close()
+
+	newClients := func(servers ...*httptest.Server) []*madmin.AdminClient {
+		clients := make([]*madmin.AdminClient, 0, len(servers))
+		for _, server := range servers {
+			clnt, e := madmin.New(strings.TrimPrefix(server.URL, "http://"), "minio", "minio123", false)
+			if e != nil {
+				t.Fatal(e)
+			}
+			clients = append(clients, clnt)
+		}
+		return clients
+	}
+
+	if err := checkReplicateSites(context.Background(), []string{"site1", "site2"}, newClients(site1, site2)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err := checkReplicateSites(context.Background(), []string{"site1", "site3"}, newClients(site1, site3))
+	if err == nil || !strings.Contains(err.ToGoError().Error(), "same deployment") {
+		t.Fatalf("expected an error for the same deployment, got %v", err)
+	}
+	err = checkReplicateSites(context.Background(), []string{"site1", "down"}, newClients(site1, down))
+	if err == nil || !strings.Contains(err.ToGoError().Error(), "`down`") {
+		t.Fatalf("expected an error for an unreachable site, got %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+var adminScannerSpeedCmd = cli.Command{
+	Name:         "speed",
+	Usage:        "set the speed of the background scanner",
+	Action:       mainAdminScannerSpeed,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET SPEED
+
+SPEED:
+  fastest, fast, default, slow or slowest. Slower scanners use less
+  resources, delaying the data usage updates and the lifecycle actions.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Slow down the scanner during the peak hours.
+     {{.Prompt}} {{.HelpName}} myminio/ slow
+
+  2. Restore the default speed of the scanner.
+     {{.Prompt}} {{.HelpName}} myminio/ default
+`,
+}
+
+// scannerSpeedMessage reports the speed set on the scanner.
+type scannerSpeedMessage struct {
+	Status      string `json:"status"`
+	Speed       string `json:"speed"`
+	Restart     bool   `json:"restart"`
+	targetAlias string
+}
+
+func (s scannerSpeedMessage) String() string {
+	msg := console.Colorize("ScannerSpeed", fmt.Sprintf("Scanner speed set to `%s`", s.Speed))
+	if !s.Restart {
+		return msg + console.Colorize("ScannerSpeed", ", effective immediately.")
+	}
+	suggestion := color.RedString("mc admin service restart %s", s.targetAlias)
+	return msg + console.Colorize("ScannerSpeed", fmt.Sprintf(", please restart your server '%s' to apply it.", suggestion))
+}
+
+func (s scannerSpeedMessage) JSON() string {
+	s.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// checkAdminScannerSpeedSyntax - validate all the passed arguments
+func checkAdminScannerSpeedSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1) // last argument is exit code
+	}
+	speed := ctx.Args().Get(1)
+	for _, s := range scannerSpeeds {
+		if speed == s {
+			return
+		}
+	}
+	fatalIf(errInvalidArgument().Trace(speed), "Invalid scanner speed, valid speeds are: "+strings.Join(scannerSpeeds, ", ")+".")
+}
+
+// mainAdminScannerSpeed is the handle for "mc admin scanner speed" command.
+func mainAdminScannerSpeed(ctx *cli.Context) error {
+	checkAdminScannerSpeedSyntax(ctx)
+	fatalIfReadOnly("admin scanner speed")
+
+	console.SetColor("ScannerSpeed", color.New(color.FgGreen, color.Bold))
+
+	// Get the alias parameter from cli
+	args := ctx.Args()
+	aliasedURL := filepath.Clean(args.Get(0))
+	speed := args.Get(1)
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	restart, e := client.SetConfigKV(globalContext, "scanner speed="+speed)
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to set the scanner speed")
+
+	printMsg(scannerSpeedMessage{
+		Speed:       speed,
+		Restart:     restart,
+		targetAlias: aliasedURL,
+	})
+	return nil
+}
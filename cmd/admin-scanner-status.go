@@ -0,0 +1,128 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+var adminScannerStatusCmd = cli.Command{
+	Name:         "status",
+	Usage:        "show the progress and the speed of the background scanner",
+	Action:       mainAdminScannerStatus,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show the objects scanned, the last usage update and the speed of the scanner.
+     {{.Prompt}} {{.HelpName}} myminio/
+`,
+}
+
+// scannerStatusMessage reports the progress and the speed of the scanner.
+type scannerStatusMessage struct {
+	Status         string    `json:"status"`
+	Speed          string    `json:"speed"`
+	ObjectsScanned int64     `json:"objectsScanned"`
+	LastUpdate     time.Time `json:"lastUpdate"`
+	Buckets        uint64    `json:"buckets"`
+	Objects        uint64    `json:"objects"`
+}
+
+func (s scannerStatusMessage) String() string {
+	lastUpdate := "never"
+	if !s.LastUpdate.IsZero() {
+		lastUpdate = fmt.Sprintf("%s (%s)", s.LastUpdate.Format(printDate), humanize.Time(s.LastUpdate))
+	}
+	lines := []string{
+		fmt.Sprintf("%s %s", console.Colorize("ScannerKey", "Speed:          "), console.Colorize("ScannerValue", s.Speed)),
+		fmt.Sprintf("%s %s", console.Colorize("ScannerKey", "Objects scanned:"), console.Colorize("ScannerValue", humanize.Comma(s.ObjectsScanned))),
+		fmt.Sprintf("%s %s", console.Colorize("ScannerKey", "Usage updated:  "), console.Colorize("ScannerValue", lastUpdate)),
+		fmt.Sprintf("%s %s", console.Colorize("ScannerKey", "Usage:          "), console.Colorize("ScannerValue",
+			fmt.Sprintf("%s object(s) in %s bucket(s)", humanize.Comma(int64(s.Objects)), humanize.Comma(int64(s.Buckets))))),
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (s scannerStatusMessage) JSON() string {
+	s.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// checkAdminScannerStatusSyntax - validate all the passed arguments
+func checkAdminScannerStatusSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1) // last argument is exit code
+	}
+}
+
+// mainAdminScannerStatus is the handle for "mc admin scanner status" command.
+func mainAdminScannerStatus(ctx *cli.Context) error {
+	checkAdminScannerStatusSyntax(ctx)
+
+	console.SetColor("ScannerKey", color.New(color.FgCyan))
+	console.SetColor("ScannerValue", color.New(color.FgWhite, color.Bold))
+
+	// Get the alias parameter from cli
+	args := ctx.Args()
+	aliasedURL := filepath.Clean(args.Get(0))
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	healState, e := client.BackgroundHealStatus(globalContext)
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to get the scanner status")
+
+	usage, e := client.DataUsageInfo(globalContext)
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to get the data usage")
+
+	config, e := client.GetConfigKV(globalContext, "scanner")
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to get the scanner configuration")
+
+	printMsg(scannerStatusMessage{
+		Speed:          parseScannerSpeed(config),
+		ObjectsScanned: healState.ScannedItemsCount,
+		LastUpdate:     usage.LastUpdate,
+		Buckets:        usage.BucketsCount,
+		Objects:        usage.ObjectsTotalCount,
+	})
+	return nil
+}
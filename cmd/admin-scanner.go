@@ -0,0 +1,71 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+
+	"github.com/minio/cli"
+)
+
+var adminScannerSubcommands = []cli.Command{
+	adminScannerStatusCmd,
+	adminScannerSpeedCmd,
+}
+
+var adminScannerCmd = cli.Command{
+	Name:            "scanner",
+	Usage:           "monitor and tune the MinIO background scanner",
+	Action:          mainAdminScanner,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	Subcommands:     adminScannerSubcommands,
+	HideHelpCommand: true,
+}
+
+// scannerSpeeds are the speeds of the scanner, from the fastest to the slowest.
+var scannerSpeeds = []string{"fastest", "fast", "default", "slow", "slowest"}
+
+// parseScannerSpeed returns the speed of the scanner configuration
+// returned by the server, "default" when not configured.
+func parseScannerSpeed(config []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(config))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || fields[0] != "scanner" {
+			continue
+		}
+		for _, field := range fields[1:] {
+			if speed := strings.TrimPrefix(field, "speed="); speed != field {
+				if speed = strings.Trim(speed, `"`); speed != "" {
+					return speed
+				}
+			}
+		}
+	}
+	return "default"
+}
+
+// mainAdminScanner is the handle for "mc admin scanner" command.
+func mainAdminScanner(ctx *cli.Context) error {
+	commandNotFound(ctx, adminScannerSubcommands)
+	return nil
+	// Sub-commands like "status", "speed" have their own main.
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseScannerSpeed(t *testing.T) {
+	testCases := []struct {
+		config string
+		speed  string
+	}{
+		{"scanner speed=slow\n", "slow"},
+		{"scanner delay=10 max_wait=15s cycle=1m speed=fast", "fast"},
+		{`scanner speed="fastest" delay=10`, "fastest"},
+		{"scanner delay=10 max_wait=15s cycle=1m", "default"},
+		{"# scanner speed=slow\nheal max_io=100", "default"},
+		{"", "default"},
+	}
+	for i, testCase := range testCases {
+		if speed := parseScannerSpeed([]byte(testCase.config)); speed != testCase.speed {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.speed, speed)
+		}
+	}
+}
+
+func TestScannerSpeedMessage(t *testing.T) {
+	msg := scannerSpeedMessage{Speed: "slow", targetAlias: "myminio"}
+	if s := msg.String(); !strings.Contains(s, "effective immediately") {
+		t.Fatalf("expected the speed to be effective immediately, got %s", s)
+	}
+	msg.Restart = true
+	if s := msg.String(); !strings.Contains(s, "mc admin service restart myminio") {
+		t.Fatalf("expected a restart suggestion, got %s", s)
+	}
+}
@@ -20,6 +20,7 @@ package cmd
 import (
 	"context"
 	"os"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/minio/cli"
@@ -44,6 +45,11 @@ var adminTopAPIFlags = []cli.Flag{
 		Name:  "errors, e",
 		Usage: "summarize current API calls throwing only errors",
 	},
+	cli.DurationFlag{
+		Name:  "interval",
+		Usage: "sampling window of the snapshot printed with --json",
+		Value: 5 * time.Second,
+	},
 }
 
 var adminTopAPICmd = cli.Command{
@@ -69,6 +75,9 @@ EXAMPLES:
 
    2. Display current in-progress all 's3.PutObject' API calls.
       {{.Prompt}} {{.HelpName}} --name s3.PutObject myminio/
+
+   3. Print a single JSON snapshot of the busiest APIs and buckets over 10 seconds.
+      {{.Prompt}} {{.HelpName}} --json --interval 10s myminio/
 `,
 }
 
@@ -77,6 +86,35 @@ func checkAdminTopAPISyntax(ctx *cli.Context) {
 	if len(ctx.Args()) == 0 || len(ctx.Args()) > 1 {
 		cli.ShowCommandHelpAndExit(ctx, "api", 1) // last argument is exit code
 	}
+	if ctx.Duration("interval") <= 0 {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--interval must be a positive duration")
+	}
+}
+
+// topAPISnapshot aggregates matching trace events for the given
+// interval and returns the resulting statistics.
+func topAPISnapshot(ctx context.Context, traceCh <-chan madmin.ServiceTraceInfo, mopts matchOpts, interval time.Duration) *topAPIAggregate {
+	stats := newTopAPIAggregate()
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return stats
+		case <-timer.C:
+			return stats
+		case apiCallInfo, ok := <-traceCh:
+			if !ok {
+				return stats
+			}
+			if apiCallInfo.Err != nil {
+				fatalIf(probe.NewError(apiCallInfo.Err), "Unable to fetch top API events")
+			}
+			if matchTrace(mopts, apiCallInfo) {
+				stats.add(apiCallInfo.Trace)
+			}
+		}
+	}
 }
 
 func mainAdminTopAPI(ctx *cli.Context) error {
@@ -105,6 +143,13 @@ func mainAdminTopAPI(ctx *cli.Context) error {
 
 	// Start listening on all trace activity.
 	traceCh := client.ServiceTrace(ctxt, opts)
+
+	if globalJSON {
+		interval := ctx.Duration("interval")
+		printMsg(newTopAPIMessage(topAPISnapshot(ctxt, traceCh, mopts, interval), interval))
+		return nil
+	}
+
 	done := make(chan struct{})
 
 	p := tea.NewProgram(initTraceUI())
@@ -125,9 +170,6 @@ func mainAdminTopAPI(ctx *cli.Context) error {
 					apiCallInfo: apiCallInfo,
 				})
 			}
-			p.Send(topAPIResult{
-				apiCallInfo: madmin.ServiceTraceInfo{},
-			})
 		}
 	}()
 
@@ -0,0 +1,113 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Names of the flags an alias may carry a default value for.
+var aliasDefaultName = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// Flags read before any alias is resolved, which cannot be defaulted.
+var aliasDefaultExcluded = []string{"config-dir", "help"}
+
+// parseAliasDefaults parses the NAME=VALUE pairs of alias set --default.
+func parseAliasDefaults(values []string) (map[string]string, *probe.Error) {
+	defaults := make(map[string]string)
+	for _, v := range values {
+		kv := strings.SplitN(v, "=", 2)
+		if len(kv) != 2 {
+			return nil, probe.NewError(fmt.Errorf("default `%s` is not of the form NAME=VALUE", v))
+		}
+		name := strings.TrimPrefix(strings.TrimSpace(kv[0]), "--")
+		if !aliasDefaultName.MatchString(name) {
+			return nil, probe.NewError(fmt.Errorf("invalid flag name `%s`", kv[0]))
+		}
+		for _, excluded := range aliasDefaultExcluded {
+			if name == excluded {
+				return nil, probe.NewError(fmt.Errorf("--%s cannot have an alias default", name))
+			}
+		}
+		defaults[name] = kv[1]
+	}
+	return defaults, nil
+}
+
+// formatAliasDefaults returns the defaults of an alias as sorted NAME=VALUE pairs.
+func formatAliasDefaults(defaults map[string]string) string {
+	pairs := make([]string, 0, len(defaults))
+	for name, value := range defaults {
+		pairs = append(pairs, name+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
+// commandFlagNames returns the names of flags, with their short names.
+func commandFlagNames(flags []cli.Flag) map[string]struct{} {
+	names := make(map[string]struct{})
+	for _, flag := range flags {
+		for _, name := range strings.Split(flag.GetName(), ",") {
+			names[strings.TrimSpace(name)] = struct{}{}
+		}
+	}
+	return names
+}
+
+// applyAliasDefaults sets the flags of ctx from the defaults of the alias
+// of its target, the last argument. Flags passed on the command line are
+// left untouched, as are the flags the command does not define, so the
+// precedence is: explicit flag > alias default > flag default.
+func applyAliasDefaults(ctx *cli.Context) {
+	// The application context runs before the config is loaded.
+	if ctx.Command.Name == "" || !ctx.Args().Present() {
+		return
+	}
+	// The defaults of a source alias do not apply to another target.
+	args := ctx.Args()
+	alias, _, hostCfg := mustExpandAlias(args[len(args)-1])
+	if hostCfg == nil || len(hostCfg.Defaults) == 0 {
+		return
+	}
+	defaults := hostCfg.Defaults
+
+	// IsSet caches the flags set on its first call, look them up on
+	// a copy so that ctx still reports the defaults applied below.
+	parsed := *ctx
+	flags := commandFlagNames(ctx.Command.Flags)
+	names := make([]string, 0, len(defaults))
+	for name := range defaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := flags[name]; !ok || parsed.IsSet(name) || parsed.GlobalIsSet(name) {
+			continue
+		}
+		e := ctx.Set(name, defaults[name])
+		fatalIf(probe.NewError(e).Trace(alias, name),
+			"Unable to apply the default `"+name+"="+defaults[name]+"` of alias `"+alias+"`.")
+	}
+}
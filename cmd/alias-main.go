@@ -42,6 +42,7 @@ var aliasSubcommands = []cli.Command{
 	aliasListCmd,
 	aliasRemoveCmd,
 	aliasImportCmd,
+	aliasTestCmd,
 }
 
 var aliasCmd = cli.Command{
@@ -65,13 +66,15 @@ func mainAlias(ctx *cli.Context) error {
 type aliasMessage struct {
 	op          string
 	prettyPrint bool
-	Status      string `json:"status"`
-	Alias       string `json:"alias"`
-	URL         string `json:"URL"`
-	AccessKey   string `json:"accessKey,omitempty"`
-	SecretKey   string `json:"secretKey,omitempty"`
-	API         string `json:"api,omitempty"`
-	Path        string `json:"path,omitempty"`
+	Status      string            `json:"status"`
+	Alias       string            `json:"alias"`
+	URL         string            `json:"URL"`
+	AccessKey   string            `json:"accessKey,omitempty"`
+	SecretKey   string            `json:"secretKey,omitempty"`
+	API         string            `json:"api,omitempty"`
+	Path        string            `json:"path,omitempty"`
+	Region      string            `json:"region,omitempty"`
+	Defaults    map[string]string `json:"defaults,omitempty"`
 	// Deprecated field, replaced by Path
 	Lookup string `json:"lookup,omitempty"`
 }
@@ -82,21 +85,30 @@ type aliasMessage struct {
 func (h aliasMessage) String() string {
 	switch h.op {
 	case "list":
-		// Create a new pretty table with cols configuration
-		t := newPrettyRecord(2,
-			Row{"Alias", "Alias"},
-			Row{"URL", "URL"},
-			Row{"AccessKey", "AccessKey"},
-			Row{"SecretKey", "SecretKey"},
-			Row{"API", "API"},
-			Row{"Path", "Path"},
-		)
 		// Handle deprecated lookup
 		path := h.Path
 		if path == "" {
 			path = h.Lookup
 		}
-		return t.buildRecord(h.Alias, h.URL, h.AccessKey, h.SecretKey, h.API, path)
+		// Create a new pretty table with cols configuration
+		rows := []Row{
+			{"Alias", "Alias"},
+			{"URL", "URL"},
+			{"AccessKey", "AccessKey"},
+			{"SecretKey", "SecretKey"},
+			{"API", "API"},
+			{"Path", "Path"},
+		}
+		contents := []string{h.Alias, h.URL, h.AccessKey, h.SecretKey, h.API, path}
+		if h.Region != "" {
+			rows = append(rows, Row{"Region", "Region"})
+			contents = append(contents, h.Region)
+		}
+		if len(h.Defaults) > 0 {
+			rows = append(rows, Row{"Defaults", "Defaults"})
+			contents = append(contents, formatAliasDefaults(h.Defaults))
+		}
+		return newPrettyRecord(2, rows...).buildRecord(contents...)
 	case "remove":
 		return console.Colorize("AliasMessage", "Removed `"+h.Alias+"` successfully.")
 	case "add": // add is deprecated
@@ -51,6 +51,14 @@ var aliasSetFlags = []cli.Flag{
 		Name:  "api",
 		Usage: "API signature. Valid options are '[S3v4, S3v2]'",
 	},
+	cli.StringFlag{
+		Name:  "region",
+		Usage: "region of the server, sent with the requests instead of looking up the region of each bucket",
+	},
+	cli.StringSliceFlag{
+		Name:  "default",
+		Usage: "default value of a flag for the commands targeting this alias, as NAME=VALUE",
+	},
 }
 
 var aliasSetCmd = cli.Command{
@@ -73,6 +81,12 @@ USAGE:
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+DEFAULTS:
+  Flag values set with --default apply to the commands whose target is the alias. A flag passed on
+  the command line takes precedence over the alias default, which takes precedence over the flag
+  default. A default only applies to the commands having that flag. Setting an alias again without
+  --default keeps its defaults.
+
 EXAMPLES:
   1. Add MinIO service under "myminio" alias. For security reasons turn off bash history momentarily.
      {{.DisableHistory}}
@@ -97,6 +111,17 @@ EXAMPLES:
      {{.Prompt}} echo -e "BKIKJAA5BMMU2RHO6IBB\nV8f1CwQqAcwo80UEIJEjc5gVQUSSx5ohQ9GSrr12" | \
                  {{.HelpName}} mys3 https://s3.amazonaws.com --api "s3v4" --path "off"
      {{.EnableHistory}}
+  6. Add a MinIO service with a self-signed certificate under "mylab" alias, skipping certificate
+     verification by default and sending the requests for the "us-west-2" region. For security reasons
+     turn off bash history momentarily.
+     {{.DisableHistory}}
+     {{.Prompt}} {{.HelpName}} --default insecure=true --region us-west-2 mylab https://lab:9000 minio minio123
+     {{.EnableHistory}}
+  7. Add a non-AWS S3 service only supporting the v2 signature and path style requests, in the "eu-central" region.
+     For security reasons turn off bash history momentarily.
+     {{.DisableHistory}}
+     {{.Prompt}} {{.HelpName}} --api S3v2 --path on --region eu-central legacy https://storage.example.com minio minio123
+     {{.EnableHistory}}
 `,
 }
 
@@ -116,6 +141,8 @@ func checkAliasSetSyntax(ctx *cli.Context, accessKey string, secretKey string, d
 
 	alias := cleanAlias(args.Get(0))
 	url := args.Get(1)
+	_, err := parseAliasDefaults(ctx.StringSlice("default"))
+	fatalIf(err.Trace(ctx.StringSlice("default")...), "Invalid alias defaults.")
 	api := ctx.String("api")
 	path := ctx.String("path")
 	bucketLookup := ctx.String("lookup")
@@ -143,6 +170,11 @@ func checkAliasSetSyntax(ctx *cli.Context, accessKey string, secretKey string, d
 			"Unrecognized API signature. Valid options are `[S3v4, S3v2]`.")
 	}
 
+	if region := ctx.String("region"); region != "" && !isValidRegion(region) {
+		fatalIf(errInvalidArgument().Trace(region),
+			"Invalid region `"+region+"`.")
+	}
+
 	if deprecated {
 		if !isValidLookup(bucketLookup) {
 			fatalIf(errInvalidArgument().Trace(bucketLookup),
@@ -161,6 +193,11 @@ func setAlias(alias string, aliasCfgV10 aliasConfigV10) aliasMessage {
 	mcCfgV10, err := loadMcConfig()
 	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config `"+mustGetMcConfigPath()+"`.")
 
+	// Keep the defaults of an existing alias unless new ones are passed.
+	if aliasCfgV10.Defaults == nil {
+		aliasCfgV10.Defaults = mcCfgV10.Aliases[alias].Defaults
+	}
+
 	// Add new host.
 	mcCfgV10.Aliases[alias] = aliasCfgV10
 
@@ -174,12 +211,14 @@ func setAlias(alias string, aliasCfgV10 aliasConfigV10) aliasMessage {
 		SecretKey: aliasCfgV10.SecretKey,
 		API:       aliasCfgV10.API,
 		Path:      aliasCfgV10.Path,
+		Region:    aliasCfgV10.Region,
+		Defaults:  aliasCfgV10.Defaults,
 	}
 }
 
 // probeS3Signature - auto probe S3 server signature: issue a Stat call
 // using v4 signature then v2 in case of failure.
-func probeS3Signature(ctx context.Context, accessKey, secretKey, url string, peerCert *x509.Certificate) (string, *probe.Error) {
+func probeS3Signature(ctx context.Context, accessKey, secretKey, url, region string, peerCert *x509.Certificate) (string, *probe.Error) {
 	probeBucketName := randString(60, rand.NewSource(time.Now().UnixNano()), "probe-bucket-sign-")
 	// Test s3 connection for API auto probe
 	s3Config := &Config{
@@ -188,6 +227,7 @@ func probeS3Signature(ctx context.Context, accessKey, secretKey, url string, pee
 		AccessKey: accessKey,
 		SecretKey: secretKey,
 		HostURL:   urlJoinPath(url, probeBucketName),
+		Region:    region,
 		Debug:     globalDebug,
 	}
 	if peerCert != nil {
@@ -230,14 +270,28 @@ func probeS3Signature(ctx context.Context, accessKey, secretKey, url string, pee
 	return stype, nil
 }
 
+// testAliasConnection - issue a lightweight ListBuckets call to verify
+// that the endpoint is reachable and the credentials are valid.
+func testAliasConnection(ctx context.Context, s3Config *Config) *probe.Error {
+	clnt, err := S3New(s3Config)
+	if err != nil {
+		return err.Trace(s3Config.HostURL)
+	}
+	if _, e := clnt.(*S3Client).api.ListBuckets(ctx); e != nil {
+		return probe.NewError(e).Trace(s3Config.HostURL)
+	}
+	return nil
+}
+
 // BuildS3Config constructs an S3 Config and does
 // signature auto-probe when needed.
-func BuildS3Config(ctx context.Context, url, alias, accessKey, secretKey, api, path string, peerCert *x509.Certificate) (*Config, *probe.Error) {
+func BuildS3Config(ctx context.Context, url, alias, accessKey, secretKey, api, path, region string, peerCert *x509.Certificate) (*Config, *probe.Error) {
 	s3Config := NewS3Config(url, &aliasConfigV10{
 		AccessKey: accessKey,
 		SecretKey: secretKey,
 		URL:       url,
 		Path:      path,
+		Region:    region,
 	})
 
 	if peerCert != nil {
@@ -251,7 +305,7 @@ func BuildS3Config(ctx context.Context, url, alias, accessKey, secretKey, api, p
 		return s3Config, nil
 	}
 	// Probe S3 signature version
-	api, err := probeS3Signature(ctx, accessKey, secretKey, url, peerCert)
+	api, err := probeS3Signature(ctx, accessKey, secretKey, url, region, peerCert)
 	if err != nil {
 		return nil, err.Trace(url, accessKey, secretKey, api, path)
 	}
@@ -301,11 +355,12 @@ func fetchAliasKeys(args cli.Args) (string, string) {
 func mainAliasSet(cli *cli.Context, deprecated bool) error {
 	console.SetColor("AliasMessage", color.New(color.FgGreen))
 	var (
-		args  = cli.Args()
-		alias = cleanAlias(args.Get(0))
-		url   = trimTrailingSeparator(args.Get(1))
-		api   = cli.String("api")
-		path  = cli.String("path")
+		args   = cli.Args()
+		alias  = cleanAlias(args.Get(0))
+		url    = trimTrailingSeparator(args.Get(1))
+		api    = cli.String("api")
+		path   = cli.String("path")
+		region = cli.String("region")
 
 		peerCert *x509.Certificate
 		err      *probe.Error
@@ -336,20 +391,37 @@ func mainAliasSet(cli *cli.Context, deprecated bool) error {
 		fatalIf(err.Trace(cli.Args()...), "Unable to initialize new alias from the provided credentials.")
 	}
 
-	s3Config, err := BuildS3Config(ctx, url, alias, accessKey, secretKey, api, path, peerCert)
+	s3Config, err := BuildS3Config(ctx, url, alias, accessKey, secretKey, api, path, region, peerCert)
 	fatalIf(err.Trace(cli.Args()...), "Unable to initialize new alias from the provided credentials.")
 
+	defaults, err := parseAliasDefaults(cli.StringSlice("default"))
+	fatalIf(err.Trace(cli.StringSlice("default")...), "Invalid alias defaults.")
+	if len(defaults) == 0 {
+		defaults = nil
+	}
+
 	msg := setAlias(alias, aliasConfigV10{
 		URL:       s3Config.HostURL,
 		AccessKey: s3Config.AccessKey,
 		SecretKey: s3Config.SecretKey,
 		API:       s3Config.Signature,
 		Path:      path,
+		Region:    region,
+		Defaults:  defaults,
 	}) // Add an alias with specified credentials.
 
 	msg.op = "set"
 	if deprecated {
 		msg.op = "add"
+		if !cli.Bool("skip-test") {
+			if err = testAliasConnection(ctx, s3Config); err != nil {
+				hint := "S3v2"
+				if strings.EqualFold(s3Config.Signature, "s3v2") {
+					hint = "S3v4"
+				}
+				errorIf(err.Trace(alias, url), "Unable to connect to `"+url+"` with the provided credentials, please verify them or try `--api "+hint+"`.")
+			}
+		}
 	}
 
 	printMsg(msg)
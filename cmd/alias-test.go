@@ -0,0 +1,266 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/pkg/console"
+)
+
+var aliasTestFlags = []cli.Flag{
+	cli.DurationFlag{
+		Name:  "connect-timeout",
+		Value: 10 * time.Second,
+		Usage: "timeout of each stage of the test",
+	},
+}
+
+var aliasTestCmd = cli.Command{
+	Name:            "test",
+	Usage:           "test the connection and the credentials of an alias",
+	Action:          mainAliasTest,
+	Before:          setGlobalsFromContext,
+	Flags:           append(aliasTestFlags, globalFlags...),
+	HideHelpCommand: true,
+	OnUsageError:    onUsageError,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] ALIAS
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+STAGES:
+  The test stops at the first failing stage, which is reported along with its error:
+    dns   resolving the host name of the alias URL
+    tcp   connecting to the resolved address
+    tls   handshaking with the server, for https URLs
+    auth  authenticating a request with the credentials of the alias
+    api   answering the request, when failing for another reason than the credentials
+
+EXAMPLES:
+  1. Test the "myminio" alias.
+     {{.Prompt}} {{.HelpName}} myminio
+
+  2. Test the "s3" alias, giving up each stage after 3 seconds.
+     {{.Prompt}} {{.HelpName}} --connect-timeout 3s s3
+`,
+}
+
+// Stages of an alias test, in order.
+const (
+	aliasTestStageDNS  = "dns"
+	aliasTestStageTCP  = "tcp"
+	aliasTestStageTLS  = "tls"
+	aliasTestStageAuth = "auth"
+	aliasTestStageAPI  = "api"
+)
+
+// aliasTestStage is the outcome of a stage of an alias test.
+type aliasTestStage struct {
+	Name    string        `json:"name"`
+	Elapsed time.Duration `json:"elapsed"`
+	Error   string        `json:"error,omitempty"`
+	Timeout bool          `json:"timeout,omitempty"`
+}
+
+// aliasTestMessage is the health record of an alias.
+type aliasTestMessage struct {
+	Status      string           `json:"status"`
+	Alias       string           `json:"alias"`
+	URL         string           `json:"url"`
+	FailedStage string           `json:"failedStage,omitempty"`
+	Error       string           `json:"error,omitempty"`
+	Stages      []aliasTestStage `json:"stages"`
+}
+
+func (a aliasTestMessage) String() string {
+	var b strings.Builder
+	for _, stage := range a.Stages {
+		if stage.Error != "" {
+			fmt.Fprintf(&b, "%s %-4s %s\n", console.Colorize("AliasTestFail", "✗"), stage.Name, console.Colorize("AliasTestFail", stage.Error))
+			continue
+		}
+		fmt.Fprintf(&b, "%s %-4s %s\n", console.Colorize("AliasTestOK", check), stage.Name, stage.Elapsed.Round(time.Millisecond))
+	}
+	if a.FailedStage != "" {
+		b.WriteString(console.Colorize("AliasTestFail", fmt.Sprintf("Alias `%s` (%s) is unhealthy, %s failed.", a.Alias, a.URL, a.FailedStage)))
+	} else {
+		b.WriteString(console.Colorize("AliasTestOK", fmt.Sprintf("Alias `%s` (%s) is healthy.", a.Alias, a.URL)))
+	}
+	return b.String()
+}
+
+func (a aliasTestMessage) JSON() string {
+	msgBytes, e := json.MarshalIndent(a, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// isTimeout tells if e is a timeout, of a network operation or a context.
+func isTimeout(e error) bool {
+	var netErr net.Error
+	return errors.Is(e, context.DeadlineExceeded) || (errors.As(e, &netErr) && netErr.Timeout())
+}
+
+// aliasTestAuthCodes are the error codes of requests denied because of
+// their credentials.
+var aliasTestAuthCodes = []string{
+	"AccessDenied", "InvalidAccessKeyId", "SignatureDoesNotMatch", "InvalidToken",
+	"ExpiredToken", "AuthorizationHeaderMalformed",
+}
+
+// aliasTestRequestStage returns the stage a failed request fails at.
+func aliasTestRequestStage(e error) string {
+	code := minio.ToErrorResponse(e).Code
+	for _, authCode := range aliasTestAuthCodes {
+		if code == authCode {
+			return aliasTestStageAuth
+		}
+	}
+	return aliasTestStageAPI
+}
+
+// testAlias runs the stages of the test of the alias, each of them
+// bounded by timeout, stopping at the first failing one.
+func testAlias(ctx context.Context, alias string, hostCfg *aliasConfigV10, timeout time.Duration) aliasTestMessage {
+	msg := aliasTestMessage{Status: "success", Alias: alias, URL: hostCfg.URL}
+
+	// run runs a stage and records its outcome, it returns false on failure.
+	run := func(name string, fn func(ctx context.Context) error) bool {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		start := time.Now()
+		e := fn(ctx)
+		stage := aliasTestStage{Name: name, Elapsed: time.Since(start)}
+		if e != nil {
+			stage.Error, stage.Timeout = e.Error(), isTimeout(e)
+			msg.Status, msg.FailedStage, msg.Error = "error", name, stage.Error
+			if name == aliasTestStageAuth {
+				msg.FailedStage = aliasTestRequestStage(e)
+				stage.Name = msg.FailedStage
+			}
+		}
+		msg.Stages = append(msg.Stages, stage)
+		return e == nil
+	}
+
+	u, e := url.Parse(hostCfg.URL)
+	if e != nil || u.Hostname() == "" {
+		msg.Status, msg.FailedStage, msg.Error = "error", aliasTestStageDNS, fmt.Sprintf("invalid URL `%s`", hostCfg.URL)
+		return msg
+	}
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+
+	var addrs []string
+	if !run(aliasTestStageDNS, func(ctx context.Context) (e error) {
+		if net.ParseIP(u.Hostname()) != nil {
+			addrs = []string{u.Hostname()}
+			return nil
+		}
+		addrs, e = net.DefaultResolver.LookupHost(ctx, u.Hostname())
+		return e
+	}) {
+		return msg
+	}
+
+	var conn net.Conn
+	if !run(aliasTestStageTCP, func(ctx context.Context) (e error) {
+		// Try the resolved addresses in turn, like the client would.
+		for _, addr := range addrs {
+			var dialer net.Dialer
+			if conn, e = dialer.DialContext(ctx, "tcp", net.JoinHostPort(addr, port)); e == nil {
+				return nil
+			}
+		}
+		return e
+	}) {
+		return msg
+	}
+	defer conn.Close()
+
+	if u.Scheme == "https" {
+		if !run(aliasTestStageTLS, func(ctx context.Context) error {
+			tlsConn := tls.Client(conn, &tls.Config{
+				ServerName:         u.Hostname(),
+				RootCAs:            globalRootCAs,
+				InsecureSkipVerify: globalInsecure,
+			})
+			return tlsConn.HandshakeContext(ctx)
+		}) {
+			return msg
+		}
+	}
+
+	run(aliasTestStageAuth, func(ctx context.Context) error {
+		err := testAliasConnection(ctx, NewS3Config(hostCfg.URL, hostCfg))
+		if err != nil {
+			return err.ToGoError()
+		}
+		return nil
+	})
+	return msg
+}
+
+// mainAliasTest is the handle for "mc alias test" command.
+func mainAliasTest(ctx *cli.Context) error {
+	args := ctx.Args()
+	if len(args) != 1 {
+		fatalIf(errInvalidArgument().Trace(args...), "Incorrect number of arguments for alias test command.")
+	}
+	if ctx.Duration("connect-timeout") <= 0 {
+		fatalIf(errInvalidArgument().Trace(args...), "--connect-timeout should be positive.")
+	}
+
+	console.SetColor("AliasTestOK", color.New(color.FgGreen))
+	console.SetColor("AliasTestFail", color.New(color.FgRed))
+
+	alias := cleanAlias(args.Get(0))
+	hostCfg := mustGetHostConfig(alias)
+	if hostCfg == nil {
+		fatalIf(errInvalidAliasedURL(alias), "No such alias `"+alias+"` found.")
+	}
+
+	msg := testAlias(globalContext, alias, hostCfg, ctx.Duration("connect-timeout"))
+	printMsg(msg)
+	if msg.FailedStage != "" {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
@@ -35,8 +35,17 @@ import (
 var anonymousFlags = []cli.Flag{
 	cli.BoolFlag{
 		Name:  "recursive, r",
-		Usage: "list recursively",
+		Usage: "list recursively, or get the permission of every sub-prefix",
 	},
+	cli.StringFlag{
+		Name:  "action",
+		Usage: "S3 action to test the access for with 'test', e.g. s3:GetObject",
+	},
+	cli.StringFlag{
+		Name:  "principal",
+		Usage: "principal to test the access for with 'test' (default: anonymous)",
+	},
+	policyAuditLogFlag,
 }
 
 // Manage anonymous access to buckets and objects.
@@ -56,6 +65,7 @@ USAGE:
   {{.HelpName}} [FLAGS] get TARGET
   {{.HelpName}} [FLAGS] get-json TARGET
   {{.HelpName}} [FLAGS] list TARGET
+  {{.HelpName}} [FLAGS] test TARGET --action ACTION
 {{if .VisibleFlags}}
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -93,6 +103,15 @@ EXAMPLES:
 
   9. List public object URLs recursively.
      {{.Prompt}} {{.HelpName}} --recursive links s3/shared/
+
+  10. Test if an anonymous user can download an object, showing the statement allowing or denying it.
+     {{.Prompt}} {{.HelpName}} test s3/shared/reports/2022.csv --action s3:GetObject
+
+  11. Get the effective permission of a bucket and of all its prefixes.
+     {{.Prompt}} {{.HelpName}} --recursive get s3/shared
+
+  12. Set bucket to "download", recording the previous and new access in an audit log.
+     {{.Prompt}} {{.HelpName}} --audit-log /var/log/mc-anonymous.log set download s3/shared
 `,
 }
 
@@ -214,6 +233,9 @@ func checkAnonymousSyntax(ctx *cli.Context) {
 		if argsLength != 2 {
 			cli.ShowCommandHelpAndExit(ctx, "anonymous", 1)
 		}
+		if ctx.Bool("recursive") && firstArg == "get-json" {
+			fatalIf(errInvalidArgument().Trace(), "--recursive can only be used with get, not get-json.")
+		}
 	case "list":
 		// Always expect an argument after list cmd
 		if argsLength != 2 {
@@ -224,6 +246,12 @@ func checkAnonymousSyntax(ctx *cli.Context) {
 		if argsLength != 2 {
 			cli.ShowCommandHelpAndExit(ctx, "anonymous", 1)
 		}
+	case "test":
+		// Always expect an argument after test cmd
+		if argsLength != 2 {
+			cli.ShowCommandHelpAndExit(ctx, "anonymous", 1)
+		}
+		checkPolicyTestAction(ctx.String("action"))
 	default:
 		cli.ShowCommandHelpAndExit(ctx, "anonymous", 1)
 	}
@@ -421,7 +449,7 @@ func runAnonymousLinksCmd(args cli.Args, recursive bool) {
 }
 
 // Run anonymous cmd to fetch set permission
-func runAnonymousCmd(args cli.Args) {
+func runAnonymousCmd(args cli.Args, auditLog string) {
 	ctx, cancelAnonymous := context.WithCancel(globalContext)
 	defer cancelAnonymous()
 
@@ -431,13 +459,19 @@ func runAnonymousCmd(args cli.Args) {
 	targetURL := args.Get(2)
 	if perms.isValidAccessPERM() {
 		operation = "set"
+		audit := newPolicyAudit(ctx, auditLog, operation, targetURL)
 		probeErr = doSetAccess(ctx, targetURL, perms)
 		if probeErr == nil {
+			audit.record(ctx)
 			perms, _, probeErr = doGetAccess(ctx, targetURL)
 		}
 	} else if perms.isValidAccessFile() {
-		probeErr = doSetAccessJSON(ctx, targetURL, perms)
 		operation = "set-json"
+		audit := newPolicyAudit(ctx, auditLog, operation, targetURL)
+		probeErr = doSetAccessJSON(ctx, targetURL, perms)
+		if probeErr == nil {
+			audit.record(ctx)
+		}
 	} else {
 		targetURL = args.Get(1)
 		operation = "get"
@@ -477,20 +511,35 @@ func mainAnonymous(ctx *cli.Context) error {
 
 	// Additional command speific theme customization.
 	console.SetColor("Anonymous", color.New(color.FgGreen, color.Bold))
+	console.SetColor("PolicyTestAllow", color.New(color.FgGreen, color.Bold))
+	console.SetColor("PolicyTestDeny", color.New(color.FgRed, color.Bold))
+	console.SetColor("PolicyPrefix", color.New(color.FgCyan))
+	console.SetColor("PolicyPerm", color.New(color.FgGreen, color.Bold))
 
 	switch ctx.Args().First() {
-	case "set", "set-json", "get", "get-json":
+	case "set", "set-json":
 		// anonymous set [private|public|download|upload] alias/bucket/prefix
 		// anonymous set-json path-to-anonymous-json-file alias/bucket/prefix
+		fatalIfReadOnly("anonymous " + ctx.Args().First())
+		runAnonymousCmd(ctx.Args(), ctx.String("audit-log"))
+	case "get", "get-json":
+		if ctx.Args().First() == "get" && ctx.Bool("recursive") {
+			// anonymous get --recursive alias/bucket/prefix
+			runPolicyRecursiveGetCmd(ctx.Args().Get(1), "anonymous")
+			return nil
+		}
 		// anonymous get alias/bucket/prefix
 		// anonymous get-json alias/bucket/prefix
-		runAnonymousCmd(ctx.Args())
+		runAnonymousCmd(ctx.Args(), "")
 	case "list":
 		// anonymous list alias/bucket/prefix
 		runAnonymousListCmd(ctx.Args().Tail())
 	case "links":
 		// anonymous links alias/bucket/prefix
 		runAnonymousLinksCmd(ctx.Args().Tail(), ctx.Bool("recursive"))
+	case "test":
+		// anonymous test alias/bucket/object --action s3:GetObject
+		runPolicyTestCmd(ctx.Args().Get(1), ctx.String("action"), ctx.String("principal"))
 	default:
 		// Shows command example and exit
 		cli.ShowCommandHelpAndExit(ctx, "anonymous", 1)
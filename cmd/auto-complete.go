@@ -246,6 +246,9 @@ var completeCmds = map[string]complete.Predictor{
 	"/anonymous": complete.PredictOr(s3Completer, fsCompleter),
 	"/tree":      complete.PredictOr(s3Complete{deepLevel: 2}, fsCompleter),
 	"/du":        complete.PredictOr(s3Complete{deepLevel: 2}, fsCompleter),
+	"/verify":    complete.PredictOr(s3Completer, fsCompleter),
+	"/ping":      aliasCompleter,
+	"/od":        complete.PredictOr(s3Completer, fsCompleter),
 
 	"/retention/set":   s3Completer,
 	"/retention/clear": s3Completer,
@@ -323,9 +326,17 @@ var completeCmds = map[string]complete.Predictor{
 	"/admin/decommission/status": aliasCompleter,
 	"/admin/decommission/cancel": aliasCompleter,
 
+	"/admin/rebalance/start":  aliasCompleter,
+	"/admin/rebalance/status": aliasCompleter,
+	"/admin/rebalance/stop":   aliasCompleter,
+
+	"/admin/scanner/status": aliasCompleter,
+	"/admin/scanner/speed":  aliasCompleter,
+
 	"/admin/trace":     aliasCompleter,
 	"/admin/speedtest": aliasCompleter,
 	"/admin/console":   aliasCompleter,
+	"/admin/logs":      aliasCompleter,
 	"/admin/update":    aliasCompleter,
 	"/admin/inspect":   s3Completer,
 	"/admin/top/locks": aliasCompleter,
@@ -383,6 +394,7 @@ var completeCmds = map[string]complete.Predictor{
 	"/admin/bucket/quota":            aliasCompleter,
 
 	"/admin/kms/key/create": aliasCompleter,
+	"/admin/kms/key/list":   aliasCompleter,
 	"/admin/kms/key/status": aliasCompleter,
 
 	"/admin/subnet/health":   aliasCompleter,
@@ -408,6 +420,7 @@ var completeCmds = map[string]complete.Predictor{
 	"/alias/list":   aliasCompleter,
 	"/alias/remove": aliasCompleter,
 	"/alias/import": nil,
+	"/alias/test":   aliasCompleter,
 
 	"/support/callhome": aliasCompleter,
 	"/support/logs":     aliasCompleter,
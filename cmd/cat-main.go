@@ -24,6 +24,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"syscall"
 	"time"
@@ -47,6 +49,14 @@ var catFlags = []cli.Flag{
 		Name:  "zip",
 		Usage: "Extract from remote zip file (MinIO server source only)",
 	},
+	cli.BoolFlag{
+		Name:  "recursive, r",
+		Usage: "display all objects under a prefix, in sorted order",
+	},
+	cli.BoolFlag{
+		Name:  "continue-on-error",
+		Usage: "continue with the remaining objects if one of them fails",
+	},
 }
 
 // Display contents of a file.
@@ -91,6 +101,12 @@ EXAMPLES:
 
   7. Display the content of a particular object version
      {{.Prompt}} {{.HelpName}} --vid "3ddac055-89a7-40fa-8cd3-530a5581b6b8" play/my-bucket/my-object
+
+  8. Concatenate all objects under a prefix in sorted order to one file.
+     {{.Prompt}} {{.HelpName}} --recursive play/my-bucket/logs/2022-01-01/ > logs.txt
+
+  9. Concatenate several objects, skipping those which cannot be read.
+     {{.Prompt}} {{.HelpName}} --continue-on-error play/my-bucket/part.1 play/my-bucket/part.2 > complete.img
 `,
 }
 
@@ -154,6 +170,10 @@ func parseCatSyntax(ctx *cli.Context) (args []string, versionID string, timeRef
 		fatalIf(errInvalidArgument().Trace(), "You cannot specify --version-id and --rewind at the same time")
 	}
 
+	if versionID != "" && ctx.Bool("recursive") {
+		fatalIf(errInvalidArgument().Trace(), "You cannot specify --version-id and --recursive at the same time")
+	}
+
 	if versionID != "" && len(args) != 1 {
 		fatalIf(errInvalidArgument().Trace(), "You need to pass at least one argument if --version-id is specified")
 	}
@@ -202,6 +222,35 @@ func catURL(ctx context.Context, sourceURL, sourceVersion string, timeRef time.T
 	return catOut(reader, size).Trace(sourceURL)
 }
 
+// expandCatURL lists all objects under sourceURL and returns their
+// aliased URLs in sorted order, failing when there are none.
+func expandCatURL(ctx context.Context, sourceURL string, timeRef time.Time) ([]string, *probe.Error) {
+	if sourceURL == "-" {
+		return []string{sourceURL}, nil
+	}
+	alias, _ := url2Alias(sourceURL)
+	clnt, err := newClient(sourceURL)
+	if err != nil {
+		return nil, err.Trace(sourceURL)
+	}
+	var urls []string
+	for content := range clnt.List(ctx, ListOptions{Recursive: true, TimeRef: timeRef, ShowDir: DirNone}) {
+		if content.Err != nil {
+			return nil, content.Err.Trace(sourceURL)
+		}
+		if content.Type.IsDir() || content.IsDeleteMarker {
+			continue
+		}
+		urls = append(urls, filepath.ToSlash(filepath.Join(alias, content.URL.Path)))
+	}
+	if len(urls) == 0 {
+		// Nothing to display is an error, like a missing object.
+		return nil, probe.NewError(ObjectMissing{timeRef}).Trace(sourceURL)
+	}
+	sort.Strings(urls)
+	return urls, nil
+}
+
 // catOut reads from reader stream and writes to stdout. Also check the length of the
 // read bytes against size parameter (if not -1) and return the appropriate error
 func catOut(r io.Reader, size int64) *probe.Error {
@@ -283,10 +332,38 @@ func mainCat(cliCtx *cli.Context) error {
 		}
 	}
 
+	recursive := cliCtx.Bool("recursive")
+	continueOnError := cliCtx.Bool("continue-on-error")
+
+	var failed bool
+	handleErr := func(err *probe.Error, url string) {
+		if err == nil {
+			return
+		}
+		if !continueOnError {
+			fatalIf(err, "Unable to read from `"+url+"`.")
+		}
+		errorIf(err, "Unable to read from `"+url+"`.")
+		failed = true
+	}
+
 	// Convert arguments to URLs: expand alias, fix format.
 	for _, url := range args {
-		fatalIf(catURL(ctx, url, versionID, rewind, encKeyDB, isZip).Trace(url), "Unable to read from `"+url+"`.")
+		urls := []string{url}
+		if recursive {
+			var err *probe.Error
+			if urls, err = expandCatURL(ctx, url, rewind); err != nil {
+				handleErr(err, url)
+				continue
+			}
+		}
+		for _, u := range urls {
+			handleErr(catURL(ctx, u, versionID, rewind, encKeyDB, isZip).Trace(u), u)
+		}
 	}
 
+	if failed {
+		return exitStatus(globalErrorExitStatus)
+	}
 	return nil
 }
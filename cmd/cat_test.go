@@ -19,9 +19,14 @@ package cmd
 
 import (
 	"bytes"
+	"flag"
 	"io"
 	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/minio/cli"
 )
 
 func TestPrettyStdout(t *testing.T) {
@@ -63,3 +68,49 @@ func TestPrettyStdout(t *testing.T) {
 		}
 	}
 }
+
+func TestCatRecursiveContinueOnError(t *testing.T) {
+	withTempMcConfig(t)
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{"logs/b.log": "b\n", "logs/a.log": "a\n", "logs/sub/c.log": "c\n", "other.log": "other\n"} {
+		if e := os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0o755); e != nil {
+			t.Fatal(e)
+		}
+		if e := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); e != nil {
+			t.Fatal(e)
+		}
+	}
+
+	stdout, e := os.Create(filepath.Join(t.TempDir(), "stdout"))
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer stdout.Close()
+	defer func(f *os.File) { os.Stdout = f }(os.Stdout)
+	os.Stdout = stdout
+
+	set := flag.NewFlagSet("cat", flag.ContinueOnError)
+	for _, f := range catCmd.Flags {
+		f.Apply(set)
+	}
+	// The missing prefix fails, the objects after it are still displayed.
+	args := []string{"--recursive", "--continue-on-error", filepath.Join(dir, "logs") + "/", filepath.Join(dir, "missing") + "/", filepath.Join(dir, "other.log")}
+	if e = set.Parse(args); e != nil {
+		t.Fatal(e)
+	}
+	ctx := cli.NewContext(nil, set, nil)
+	ctx.Command = catCmd
+
+	if e = mainCat(ctx); e == nil {
+		t.Fatal("expected the missing prefix to set the exit status")
+	}
+	output, e := ioutil.ReadFile(stdout.Name())
+	if e != nil {
+		t.Fatal(e)
+	}
+	// The objects of a prefix are displayed in sorted order.
+	if expected := "a\nb\nc\nother\n"; string(output) != expected {
+		t.Fatalf("expected %q, got %q", expected, output)
+	}
+}
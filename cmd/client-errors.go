@@ -176,6 +176,13 @@ func (e ObjectIsDeleteMarker) Error() string {
 	return "Object is marked as deleted"
 }
 
+// ObjectSSECKeyRequired - object is SSE-C encrypted and no key was provided.
+type ObjectSSECKeyRequired struct{}
+
+func (e ObjectSSECKeyRequired) Error() string {
+	return "Object is encrypted with SSE-C, key required"
+}
+
 // UnexpectedShortWrite - write wrote less bytes than expected.
 type UnexpectedShortWrite struct {
 	InputSize int
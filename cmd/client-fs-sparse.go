@@ -0,0 +1,98 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"runtime"
+)
+
+// sparseFilesSupported tells if seeking past the end of a file leaves
+// a hole, Windows needs the file to be flagged sparse beforehand.
+const sparseFilesSupported = runtime.GOOS != "windows"
+
+// sparseBlockSize is the size of the runs of zeros left as holes.
+const sparseBlockSize = 4096
+
+var zeroBlock [sparseBlockSize]byte
+
+// sparseWriter writes to a file, seeking past the blocks of zeros
+// instead of writing them.
+type sparseWriter struct {
+	file *os.File
+	size int64
+}
+
+func newSparseWriter(file *os.File) *sparseWriter {
+	return &sparseWriter{file: file}
+}
+
+// Write writes the blocks of p which are not all zeros, the data
+// between two runs of zeros is written at once.
+func (w *sparseWriter) Write(p []byte) (int, error) {
+	var n, dataStart int
+	hole := int64(0)
+	for n < len(p) {
+		end := n + sparseBlockSize
+		if end > len(p) {
+			end = len(p)
+		}
+		if !bytes.Equal(p[n:end], zeroBlock[:end-n]) {
+			n = end
+			continue
+		}
+		if dataStart < n {
+			if err := w.writeAt(p[dataStart:n], hole); err != nil {
+				return dataStart, err
+			}
+			hole = 0
+		}
+		hole += int64(end - n)
+		n = end
+		dataStart = n
+	}
+	if dataStart < n {
+		if err := w.writeAt(p[dataStart:n], hole); err != nil {
+			return dataStart, err
+		}
+	} else if hole > 0 {
+		if _, err := w.file.Seek(hole, io.SeekCurrent); err != nil {
+			return dataStart, err
+		}
+	}
+	w.size += int64(n)
+	return n, nil
+}
+
+// writeAt writes data after skipping a hole of the given length.
+func (w *sparseWriter) writeAt(data []byte, hole int64) error {
+	if hole > 0 {
+		if _, err := w.file.Seek(hole, io.SeekCurrent); err != nil {
+			return err
+		}
+	}
+	_, err := w.file.Write(data)
+	return err
+}
+
+// finish sets the size of the file, which a trailing hole does not extend.
+func (w *sparseWriter) finish() error {
+	return w.file.Truncate(w.size)
+}
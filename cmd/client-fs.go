@@ -306,7 +306,13 @@ func (f *fsClient) put(ctx context.Context, reader io.Reader, size int64, progre
 	// should remove any partial download if any.
 	defer os.Remove(objectPartPath)
 
-	tmpFile, e := os.OpenFile(objectPartPath, os.O_CREATE|os.O_WRONLY, 0o666)
+	flags := os.O_CREATE | os.O_WRONLY
+	if opts.sparse {
+		// Holes are skipped rather than written, a stale part
+		// file would otherwise leave its old data in them.
+		flags |= os.O_TRUNC
+	}
+	tmpFile, e := os.OpenFile(objectPartPath, flags, 0o666)
 	if e != nil {
 		err := f.toClientError(e, f.PathURL.Path)
 		return 0, err.Trace(f.PathURL.Path)
@@ -325,7 +331,17 @@ func (f *fsClient) put(ctx context.Context, reader io.Reader, size int64, progre
 		}
 	}
 
-	totalWritten, e := io.Copy(tmpFile, hookreader.NewHook(reader, progress))
+	var writer io.Writer = tmpFile
+	var sparse *sparseWriter
+	if opts.sparse && sparseFilesSupported {
+		sparse = newSparseWriter(tmpFile)
+		writer = sparse
+	}
+
+	totalWritten, e := io.Copy(writer, hookreader.NewHook(reader, progress))
+	if e == nil && sparse != nil {
+		e = sparse.finish()
+	}
 	if e != nil {
 		tmpFile.Close()
 		return 0, probe.NewError(e)
@@ -365,6 +381,12 @@ func (f *fsClient) put(ctx context.Context, reader io.Reader, size int64, progre
 		}
 	}
 
+	if opts.verify != nil {
+		if err := opts.verify(); err != nil {
+			return totalWritten, err.Trace(objectPath)
+		}
+	}
+
 	// Safely completed put. Now commit by renaming to actual filename.
 	if e = os.Rename(objectPartPath, objectPath); e != nil {
 		err := f.toClientError(e, objectPath)
@@ -381,6 +403,11 @@ func (f *fsClient) put(ctx context.Context, reader io.Reader, size int64, progre
 				return totalWritten, probe.NewError(e)
 			}
 		}
+	} else if !opts.modTime.IsZero() {
+		// Only the modification time is requested, leave atime as now.
+		if e := os.Chtimes(objectPath, UTCNow(), opts.modTime); e != nil {
+			return totalWritten, probe.NewError(e)
+		}
 	}
 
 	return totalWritten, nil
@@ -419,6 +446,7 @@ func (f *fsClient) Copy(ctx context.Context, source string, opts CopyOptions, pr
 	putOpts := PutOptions{
 		metadata:   opts.metadata,
 		isPreserve: opts.isPreserve,
+		sparse:     opts.sparse,
 	}
 
 	destination := f.PathURL.Path
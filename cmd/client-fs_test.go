@@ -383,3 +383,52 @@ func (s *TestSuite) TestCopy(c *C) {
 	err = fsClientTarget.Copy(context.Background(), sourcePath, CopyOptions{size: int64(len(data))}, nil)
 	c.Assert(err, IsNil)
 }
+
+// Test put a sparse file, with holes in the middle and at the end.
+func (s *TestSuite) TestPutSparse(c *C) {
+	root, e := ioutil.TempDir(os.TempDir(), "fs-")
+	c.Assert(e, IsNil)
+	defer os.RemoveAll(root)
+
+	objectPath := filepath.Join(root, "object")
+	fsClient, err := fsNew(objectPath)
+	c.Assert(err, IsNil)
+
+	data := make([]byte, 10*sparseBlockSize+100)
+	copy(data, "hello")
+	copy(data[5*sparseBlockSize+10:], "world")
+	n, err := fsClient.Put(context.Background(), bytes.NewReader(data), int64(len(data)), nil, PutOptions{sparse: true})
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, int64(len(data)))
+
+	results, e := ioutil.ReadFile(objectPath)
+	c.Assert(e, IsNil)
+	c.Assert(results, DeepEquals, data)
+}
+
+// Test put a sparse file over a stale part file, whose data must not
+// show through the holes.
+func (s *TestSuite) TestPutSparseStalePart(c *C) {
+	root, e := ioutil.TempDir(os.TempDir(), "fs-")
+	c.Assert(e, IsNil)
+	defer os.RemoveAll(root)
+
+	objectPath := filepath.Join(root, "object")
+	fsClient, err := fsNew(objectPath)
+	c.Assert(err, IsNil)
+
+	stale := bytes.Repeat([]byte{'x'}, 12*sparseBlockSize)
+	e = ioutil.WriteFile(objectPath+partSuffix, stale, 0o644)
+	c.Assert(e, IsNil)
+
+	data := make([]byte, 10*sparseBlockSize+100)
+	copy(data, "hello")
+	copy(data[5*sparseBlockSize+10:], "world")
+	n, err := fsClient.Put(context.Background(), bytes.NewReader(data), int64(len(data)), nil, PutOptions{sparse: true})
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, int64(len(data)))
+
+	results, e := ioutil.ReadFile(objectPath)
+	c.Assert(e, IsNil)
+	c.Assert(results, DeepEquals, data)
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"sync"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/minio/pkg/env"
+)
+
+// maxConcurrentParts bounds --concurrent-multipart, each part being
+// buffered in memory.
+const maxConcurrentParts = 64
+
+// concurrentPartsMemoryWarning is the memory buffered per object above
+// which --concurrent-multipart warns.
+const concurrentPartsMemoryWarning = 512 * humanize.MiByte
+
+// concurrentPartsMemory returns the memory used by the threads part
+// buffers of an object, in parts of MC_UPLOAD_MULTIPART_SIZE or of the
+// smallest part size of the uploads. Larger objects may take larger parts.
+func concurrentPartsMemory(threads int) uint64 {
+	partSize := uint64(16 * humanize.MiByte)
+	if v := env.Get("MC_UPLOAD_MULTIPART_SIZE", ""); v != "" {
+		if size, e := humanize.ParseBytes(v); e == nil && size > partSize {
+			partSize = size
+		}
+	}
+	return uint64(threads) * partSize
+}
+
+// concurrentPart is a part of a multipart upload read from the source
+// and waiting for a worker to upload it.
+type concurrentPart struct {
+	number int
+	data   []byte
+}
+
+// putConcurrentParts uploads the size bytes of reader as a multipart
+// upload, with threads workers uploading the parts in parallel. Unlike
+// the uploads of local files, which are read at the offset of each part,
+// reader is read sequentially into at most threads part buffers, allocated
+// as the parts are read. Objects fitting in a single part are uploaded with
+// a single PUT.
+func (c *S3Client) putConcurrentParts(ctx context.Context, bucket, object string, reader io.Reader, size int64, progress io.Reader, opts minio.PutObjectOptions, threads int) (minio.UploadInfo, error) {
+	totalParts, partSize, lastPartSize, e := minio.OptimalPartInfo(size, opts.PartSize)
+	if e != nil {
+		return minio.UploadInfo{}, e
+	}
+	if totalParts < 2 {
+		return c.api.PutObject(ctx, bucket, object, reader, size, opts)
+	}
+
+	core := minio.Core{Client: c.api}
+	uploadID, e := core.NewMultipartUpload(ctx, bucket, object, opts)
+	if e != nil {
+		return minio.UploadInfo{}, e
+	}
+
+	// Encryption keys of SSE-C are sent with each part.
+	var sse encrypt.ServerSide
+	if opts.ServerSideEncryption != nil && opts.ServerSideEncryption.Type() == encrypt.SSEC {
+		sse = opts.ServerSideEncryption
+	}
+
+	if threads > totalParts {
+		threads = totalParts
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// The part buffers bound the memory used to threads parts.
+	buffers := make(chan []byte, threads)
+	allocated := 0
+
+	var (
+		wg         sync.WaitGroup
+		mu         sync.Mutex
+		uploadErr  error
+		completed  = make([]minio.CompletePart, totalParts)
+		partsCh    = make(chan concurrentPart)
+		setFailure = func(e error) {
+			mu.Lock()
+			if uploadErr == nil {
+				uploadErr = e
+			}
+			mu.Unlock()
+			cancel()
+		}
+	)
+	for i := 0; i < threads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for part := range partsCh {
+				objPart, e := core.PutObjectPart(ctx, bucket, object, uploadID, part.number,
+					bytes.NewReader(part.data), int64(len(part.data)), "", "", sse)
+				if e != nil {
+					setFailure(e)
+				} else {
+					mu.Lock()
+					completed[part.number-1] = minio.CompletePart{PartNumber: part.number, ETag: objPart.ETag}
+					if progress != nil {
+						io.CopyN(ioutil.Discard, progress, int64(len(part.data)))
+					}
+					mu.Unlock()
+				}
+				buffers <- part.data[:cap(part.data)]
+			}
+		}()
+	}
+
+	for number := 1; number <= totalParts && ctx.Err() == nil; number++ {
+		length := partSize
+		if number == totalParts {
+			length = lastPartSize
+		}
+		var data []byte
+		select {
+		case data = <-buffers:
+		default:
+			if allocated < threads {
+				allocated++
+				data = make([]byte, partSize)
+				break
+			}
+			select {
+			case data = <-buffers:
+			case <-ctx.Done():
+			}
+		}
+		if data == nil {
+			break
+		}
+		if _, e = io.ReadFull(reader, data[:length]); e != nil {
+			setFailure(e)
+			break
+		}
+		partsCh <- concurrentPart{number: number, data: data[:length]}
+	}
+	close(partsCh)
+	wg.Wait()
+
+	if uploadErr == nil {
+		uploadErr = ctx.Err()
+	}
+	if uploadErr != nil {
+		core.AbortMultipartUpload(context.Background(), bucket, object, uploadID)
+		return minio.UploadInfo{}, uploadErr
+	}
+
+	etag, e := core.CompleteMultipartUpload(ctx, bucket, object, uploadID, completed, opts)
+	if e != nil {
+		return minio.UploadInfo{}, e
+	}
+	return minio.UploadInfo{Bucket: bucket, Key: object, ETag: etag, Size: size}, nil
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dustin/go-humanize"
+)
+
+// multipartServer is a bucket accepting multipart uploads, recording
+// the uploaded parts and the maximum number of parts uploaded at once.
+type multipartServer struct {
+	mu          sync.Mutex
+	parts       map[int][]byte
+	inFlight    int
+	maxInFlight int
+	completed   []byte
+	failPart    int
+	aborted     bool
+}
+
+func (s *multipartServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	switch {
+	case r.Method == http.MethodPost && r.URL.RawQuery == "uploads=":
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><InitiateMultipartUploadResult><Bucket>bucket</Bucket><Key>object</Key><UploadId>upload</UploadId></InitiateMultipartUploadResult>`))
+	case r.Method == http.MethodPut && query.Get("partNumber") == strconv.Itoa(s.failPart):
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>AccessDenied</Code><Message>Access Denied.</Message></Error>`))
+	case r.Method == http.MethodDelete && query.Get("uploadId") != "":
+		s.mu.Lock()
+		s.aborted = true
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut && query.Get("partNumber") != "":
+		s.mu.Lock()
+		s.inFlight++
+		if s.inFlight > s.maxInFlight {
+			s.maxInFlight = s.inFlight
+		}
+		s.mu.Unlock()
+		data, _ := ioutil.ReadAll(r.Body)
+		// Let the other workers start their part.
+		time.Sleep(50 * time.Millisecond)
+		number, _ := strconv.Atoi(query.Get("partNumber"))
+		s.mu.Lock()
+		s.inFlight--
+		s.parts[number] = data
+		s.mu.Unlock()
+		w.Header().Set("ETag", fmt.Sprintf(`"etag-%d"`, number))
+	case r.Method == http.MethodPost && query.Get("uploadId") != "":
+		body, _ := ioutil.ReadAll(r.Body)
+		s.mu.Lock()
+		for number := 1; number <= len(s.parts); number++ {
+			if !strings.Contains(string(body), fmt.Sprintf("<PartNumber>%d</PartNumber><ETag>etag-%d</ETag>", number, number)) {
+				s.mu.Unlock()
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			s.completed = append(s.completed, s.parts[number]...)
+		}
+		s.mu.Unlock()
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><CompleteMultipartUploadResult><Bucket>bucket</Bucket><Key>object</Key><ETag>"etag-3"</ETag></CompleteMultipartUploadResult>`))
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+func TestPutConcurrentParts(t *testing.T) {
+	withTempMcConfig(t)
+
+	mpServer := &multipartServer{parts: make(map[int][]byte)}
+	newS3StubServer(t, "parts", mpServer)
+
+	clnt, err := newClient("parts/bucket/object")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := make([]byte, 12*humanize.MiByte)
+	for i := range data {
+		data[i] = byte(i / humanize.MiByte)
+	}
+	progress := newAccounter(int64(len(data)))
+	n, err := clnt.Put(context.Background(), bytes.NewReader(data), int64(len(data)), progress, PutOptions{
+		multipartSize:    5 * humanize.MiByte,
+		multipartThreads: 3,
+		concurrentParts:  true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != int64(len(data)) {
+		t.Fatalf("expected %d bytes uploaded, got %d", len(data), n)
+	}
+	if len(mpServer.parts) != 3 {
+		t.Fatalf("expected 3 parts, got %d", len(mpServer.parts))
+	}
+	if !bytes.Equal(mpServer.completed, data) {
+		t.Fatal("the completed upload differs from the source")
+	}
+	if mpServer.maxInFlight < 2 {
+		t.Fatalf("expected parts uploaded in parallel, got at most %d at once", mpServer.maxInFlight)
+	}
+	if progress.Get() != int64(len(data)) {
+		t.Fatalf("expected a progress of %d bytes, got %d", len(data), progress.Get())
+	}
+	if mpServer.aborted {
+		t.Fatal("unexpected abort of the upload")
+	}
+
+	// More threads than parts upload each part once.
+	mpServer = &multipartServer{parts: make(map[int][]byte)}
+	newS3StubServer(t, "parts", mpServer)
+	if clnt, err = newClient("parts/bucket/object"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = clnt.Put(context.Background(), bytes.NewReader(data), int64(len(data)), nil, PutOptions{
+		multipartSize:    5 * humanize.MiByte,
+		multipartThreads: maxConcurrentParts,
+		concurrentParts:  true,
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mpServer.parts) != 3 || !bytes.Equal(mpServer.completed, data) {
+		t.Fatalf("expected the 3 parts of the source, got %d parts", len(mpServer.parts))
+	}
+
+	mpServer = &multipartServer{parts: make(map[int][]byte), failPart: 2}
+	newS3StubServer(t, "parts", mpServer)
+	if clnt, err = newClient("parts/bucket/object"); err != nil {
+		t.Fatal(err)
+	}
+	_, err = clnt.Put(context.Background(), bytes.NewReader(data), int64(len(data)), nil, PutOptions{
+		multipartSize:    5 * humanize.MiByte,
+		multipartThreads: 3,
+		concurrentParts:  true,
+	})
+	if err == nil {
+		t.Fatal("expected an error for the failed part")
+	}
+	if !mpServer.aborted {
+		t.Fatal("expected the failed upload to be aborted")
+	}
+}
+
+func TestConcurrentPartsMemory(t *testing.T) {
+	t.Setenv("MC_UPLOAD_MULTIPART_SIZE", "")
+	if got := concurrentPartsMemory(8); got != 8*16*humanize.MiByte {
+		t.Fatalf("expected %d bytes, got %d", 8*16*humanize.MiByte, got)
+	}
+	t.Setenv("MC_UPLOAD_MULTIPART_SIZE", "64MiB")
+	if got := concurrentPartsMemory(8); got != 8*64*humanize.MiByte {
+		t.Fatalf("expected %d bytes, got %d", 8*64*humanize.MiByte, got)
+	}
+	if concurrentPartsMemory(maxConcurrentParts) < concurrentPartsMemoryWarning {
+		t.Fatal("expected the largest --concurrent-multipart to warn")
+	}
+}
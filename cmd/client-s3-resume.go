@@ -0,0 +1,301 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/minio/mc/pkg/hookreader"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+)
+
+// Maximum number of parts of a multipart upload.
+const maxUploadParts = 10000
+
+// Name of the file in the config folder recording the multipart uploads
+// started with --checksum-resume.
+const resumeUploadsFile = "resume-uploads.json"
+
+// partsResume enables resuming an incomplete multipart upload after
+// verifying its uploaded parts, the parts found corrupted and uploaded
+// again are recorded in reuploaded.
+type partsResume struct {
+	reuploaded []int
+}
+
+// resumeUploadPart is an uploaded part of a recorded upload, with the ETag
+// returned by the server and the MD5 sum of its content. These differ
+// for encrypted parts.
+type resumeUploadPart struct {
+	ETag string `json:"etag"`
+	MD5  string `json:"md5"`
+}
+
+// resumeUpload is a multipart upload started with --checksum-resume.
+type resumeUpload struct {
+	UploadID string                   `json:"uploadId"`
+	Options  string                   `json:"options"`
+	PartSize int64                    `json:"partSize"`
+	Parts    map[int]resumeUploadPart `json:"parts"`
+}
+
+// resumeUploads records the uploads started with --checksum-resume by
+// target, only these uploads are resumed, verifying their parts against
+// the sums recorded as they were uploaded.
+type resumeUploads struct {
+	mutex   sync.Mutex
+	loaded  bool
+	uploads map[string]*resumeUpload
+}
+
+var globalResumeUploads = &resumeUploads{}
+
+// load reads the records once, a missing or invalid file records nothing.
+func (r *resumeUploads) load() {
+	if r.loaded {
+		return
+	}
+	r.loaded = true
+	r.uploads = make(map[string]*resumeUpload)
+	if data, e := ioutil.ReadFile(filepath.Join(mustGetMcConfigDir(), resumeUploadsFile)); e == nil {
+		json.Unmarshal(data, &r.uploads)
+	}
+}
+
+// save writes the records, failing to do so only loses them.
+func (r *resumeUploads) save() {
+	data, e := json.Marshal(r.uploads)
+	if e != nil {
+		return
+	}
+	recordsFile := filepath.Join(mustGetMcConfigDir(), resumeUploadsFile)
+	tmpFile := recordsFile + ".tmp"
+	if e = ioutil.WriteFile(tmpFile, data, 0o600); e == nil {
+		os.Rename(tmpFile, recordsFile)
+	}
+}
+
+// get returns a copy of the upload recorded for target.
+func (r *resumeUploads) get(target string) (resumeUpload, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.load()
+	upload, ok := r.uploads[target]
+	if !ok {
+		return resumeUpload{}, false
+	}
+	recorded := *upload
+	recorded.Parts = make(map[int]resumeUploadPart, len(upload.Parts))
+	for number, part := range upload.Parts {
+		recorded.Parts[number] = part
+	}
+	return recorded, true
+}
+
+// set records a new upload of target.
+func (r *resumeUploads) set(target string, upload resumeUpload) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.load()
+	upload.Parts = make(map[int]resumeUploadPart)
+	r.uploads[target] = &upload
+	r.save()
+}
+
+// setPart records an uploaded part of the upload uploadID of target.
+func (r *resumeUploads) setPart(target, uploadID string, number int, part resumeUploadPart) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.load()
+	if upload, ok := r.uploads[target]; ok && upload.UploadID == uploadID {
+		upload.Parts[number] = part
+		r.save()
+	}
+}
+
+// remove forgets the upload of target.
+func (r *resumeUploads) remove(target string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.load()
+	if _, ok := r.uploads[target]; ok {
+		delete(r.uploads, target)
+		r.save()
+	}
+}
+
+// resumeUploadOptions returns a digest of the options of an upload of
+// size bytes, an upload is only resumed with the options it started with.
+func resumeUploadOptions(size int64, opts minio.PutObjectOptions) string {
+	var sseType encrypt.Type
+	if opts.ServerSideEncryption != nil {
+		sseType = opts.ServerSideEncryption.Type()
+	}
+	data, _ := json.Marshal(struct {
+		Size               int64
+		UserMetadata       map[string]string
+		UserTags           map[string]string
+		ContentType        string
+		ContentEncoding    string
+		ContentDisposition string
+		ContentLanguage    string
+		CacheControl       string
+		StorageClass       string
+		Mode               minio.RetentionMode
+		RetainUntilDate    string
+		LegalHold          minio.LegalHoldStatus
+		SSE                encrypt.Type
+	}{
+		size, opts.UserMetadata, opts.UserTags, opts.ContentType, opts.ContentEncoding,
+		opts.ContentDisposition, opts.ContentLanguage, opts.CacheControl, opts.StorageClass,
+		opts.Mode, opts.RetainUntilDate.String(), opts.LegalHold, sseType,
+	})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// listUploadedParts returns the parts of an incomplete multipart upload
+// by part number.
+func (c *S3Client) listUploadedParts(ctx context.Context, bucket, object, uploadID string) (map[int]minio.ObjectPart, error) {
+	core := minio.Core{Client: c.api}
+	parts := make(map[int]minio.ObjectPart)
+	partNumberMarker := 0
+	for {
+		result, e := core.ListObjectParts(ctx, bucket, object, uploadID, partNumberMarker, 1000)
+		if e != nil {
+			return nil, e
+		}
+		for _, part := range result.ObjectParts {
+			parts[part.PartNumber] = part
+		}
+		if !result.IsTruncated {
+			return parts, nil
+		}
+		partNumberMarker = result.NextPartNumberMarker
+	}
+}
+
+// resumePut uploads the content of reader as a multipart upload recorded
+// in the config folder, or resumes the recorded upload of a previous run.
+// Only the uploads started by resumePut are resumed, and only with the
+// options they were started with. The uploaded parts are trusted when
+// the server still lists them with their recorded ETag and the MD5 sum
+// of the same byte range of reader matches the recorded one, so that
+// encrypted parts, whose ETags are not MD5 sums, are verified too. The
+// other parts are uploaded again. It returns false when the object fits
+// in a single part, it is then to be uploaded with a single PUT.
+func (c *S3Client) resumePut(ctx context.Context, bucket, object string, reader io.ReaderAt, size int64, progress io.Reader, opts minio.PutObjectOptions, resume *partsResume) (bool, error) {
+	core := minio.Core{Client: c.api}
+	target := c.targetURL.Host + "/" + bucket + "/" + object
+	options := resumeUploadOptions(size, opts)
+
+	upload, recorded := globalResumeUploads.get(target)
+	var uploaded map[int]minio.ObjectPart
+	if recorded {
+		if upload.Options != options {
+			return true, fmt.Errorf("the incomplete upload of `%s` was started with other options, remove it with `mc rm --incomplete` to upload it anew", object)
+		}
+		var e error
+		if uploaded, e = c.listUploadedParts(ctx, bucket, object, upload.UploadID); e != nil {
+			if minio.ToErrorResponse(e).Code != "NoSuchUpload" {
+				return true, e
+			}
+			// Aborted or completed since, upload anew.
+			globalResumeUploads.remove(target)
+			recorded = false
+		}
+	}
+	if !recorded {
+		totalParts, partSize, _, e := minio.OptimalPartInfo(size, opts.PartSize)
+		if e != nil || totalParts < 2 {
+			return false, e
+		}
+		uploadID, e := core.NewMultipartUpload(ctx, bucket, object, opts)
+		if e != nil {
+			return true, e
+		}
+		upload = resumeUpload{UploadID: uploadID, Options: options, PartSize: partSize}
+		globalResumeUploads.set(target, upload)
+	}
+	if upload.PartSize <= 0 || (size+upload.PartSize-1)/upload.PartSize > maxUploadParts {
+		return true, fmt.Errorf("the incomplete upload of `%s` has an invalid part size %d", object, upload.PartSize)
+	}
+
+	// Encryption keys of SSE-C are sent with each part.
+	var sse encrypt.ServerSide
+	if opts.ServerSideEncryption != nil && opts.ServerSideEncryption.Type() == encrypt.SSEC {
+		sse = opts.ServerSideEncryption
+	}
+
+	var complete []minio.CompletePart
+	for partNumber, offset := 1, int64(0); offset < size; partNumber, offset = partNumber+1, offset+upload.PartSize {
+		length := upload.PartSize
+		if size-offset < length {
+			length = size - offset
+		}
+		hash := md5.New()
+		if _, e := io.Copy(hash, io.NewSectionReader(reader, offset, length)); e != nil {
+			return true, e
+		}
+		sum := hash.Sum(nil)
+		md5Sum := hex.EncodeToString(sum)
+
+		part, listed := uploaded[partNumber]
+		if listed {
+			if recordedPart, ok := upload.Parts[partNumber]; ok && part.Size == length &&
+				strings.Trim(part.ETag, "\"") == strings.Trim(recordedPart.ETag, "\"") && recordedPart.MD5 == md5Sum {
+				if progress != nil {
+					if _, e := io.CopyN(ioutil.Discard, progress, length); e != nil {
+						return true, e
+					}
+				}
+				complete = append(complete, minio.CompletePart{PartNumber: partNumber, ETag: part.ETag})
+				continue
+			}
+			resume.reuploaded = append(resume.reuploaded, partNumber)
+		}
+
+		data := hookreader.NewHook(io.NewSectionReader(reader, offset, length), progress)
+		part, e := core.PutObjectPart(ctx, bucket, object, upload.UploadID, partNumber, data, length,
+			base64.StdEncoding.EncodeToString(sum), "", sse)
+		if e != nil {
+			return true, e
+		}
+		globalResumeUploads.setPart(target, upload.UploadID, partNumber, resumeUploadPart{ETag: part.ETag, MD5: md5Sum})
+		complete = append(complete, minio.CompletePart{PartNumber: partNumber, ETag: part.ETag})
+	}
+
+	if _, e := core.CompleteMultipartUpload(ctx, bucket, object, upload.UploadID, complete, opts); e != nil {
+		return true, e
+	}
+	globalResumeUploads.remove(target)
+	return true, nil
+}
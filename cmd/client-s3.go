@@ -113,6 +113,11 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 		// Save if target supports virtual host style.
 		hostName := targetURL.Host
 		s3Clnt.virtualStyle = isVirtualHostStyle(hostName, config.Lookup)
+		if s3Clnt.virtualStyle && globalAddressing == "virtual" {
+			if err := checkVirtualHostAddressing(hostName); err != nil {
+				return nil, err.Trace(hostName)
+			}
+		}
 		isS3AcceleratedEndpoint := isAmazonAccelerated(hostName)
 
 		if s3Clnt.virtualStyle {
@@ -121,9 +126,22 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 				hostName = googleHostName
 			}
 		}
+		// Use the region set by MC_REGION or on the alias, or else the
+		// region of the bucket persisted by a previous run, if any.
+		region := os.Getenv("MC_REGION")
+		if region == "" {
+			region = config.Region
+		}
+		persistRegions := globalRegionMaxAge > 0 && !globalNoRegionCache
+		if bucket, _ := s3Clnt.url2BucketAndObject(); region == "" && bucket != "" && persistRegions {
+			if cached, ok := globalRegionCache.Get(hostName, bucket); ok {
+				region = cached
+			}
+		}
+
 		// Generate a hash out of s3Conf.
 		confHash := fnv.New32a()
-		confHash.Write([]byte(hostName + config.AccessKey + config.SecretKey + config.SessionToken))
+		confHash.Write([]byte(hostName + config.AccessKey + config.SecretKey + config.SessionToken + region))
 		confSum := confHash.Sum32()
 
 		// Lookup previous cache by hash.
@@ -196,13 +214,18 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 				}
 			}
 
+			// Persist the regions the client resolves for its requests.
+			if persistRegions {
+				transport = &regionCacheTransport{RoundTripper: transport, host: hostName}
+			}
+
 			// Not found. Instantiate a new MinIO
 			var e error
 
 			options := minio.Options{
 				Creds:        creds,
 				Secure:       useTLS,
-				Region:       os.Getenv("MC_REGION"),
+				Region:       region,
 				BucketLookup: config.Lookup,
 				Transport:    transport,
 			}
@@ -905,9 +928,26 @@ func (c *S3Client) Copy(ctx context.Context, source string, opts CopyOptions, pr
 		delete(metadata, AmzObjectLockLegalHold)
 	}
 
+	if tagsHdr, ok := metadata["X-Amz-Tagging"]; ok {
+		tagsSet, e := tags.Parse(tagsHdr, true)
+		if e != nil {
+			return probe.NewError(e)
+		}
+		destOpts.UserTags = tagsSet.ToMap()
+		destOpts.ReplaceTags = true
+		delete(metadata, "X-Amz-Tagging")
+	}
+
 	// Assign metadata after irrelevant parts are delete above
 	destOpts.UserMetadata = metadata
-	destOpts.ReplaceMetadata = len(metadata) > 0
+	switch opts.metadataDirective {
+	case copyMetadataDirective:
+		destOpts.ReplaceMetadata = false
+	case replaceMetadataDirective:
+		destOpts.ReplaceMetadata = true
+	default:
+		destOpts.ReplaceMetadata = len(metadata) > 0
+	}
 
 	var e error
 	if opts.disableMultipart || opts.size < 64*1024*1024 {
@@ -1043,7 +1083,23 @@ func (c *S3Client) Put(ctx context.Context, reader io.Reader, size int64, progre
 		opts.SendContentMd5 = true
 	}
 
-	ui, e := c.api.PutObject(ctx, bucket, object, reader, size, opts)
+	var ui minio.UploadInfo
+	var e error
+	resumed := false
+	if readerAt, ok := reader.(io.ReaderAt); ok && putOpts.resume != nil && !putOpts.disableMultipart {
+		resumed, e = c.resumePut(ctx, bucket, object, readerAt, size, progress, opts, putOpts.resume)
+		if resumed && e == nil {
+			ui.Size = size
+		}
+	}
+	if !resumed && e == nil {
+		if putOpts.concurrentParts && !isReadAt(reader) && !putOpts.disableMultipart && !putOpts.md5 &&
+			putOpts.multipartThreads > 1 && size > 0 {
+			ui, e = c.putConcurrentParts(ctx, bucket, object, reader, size, progress, opts, int(putOpts.multipartThreads))
+		} else {
+			ui, e = c.api.PutObject(ctx, bucket, object, reader, size, opts)
+		}
+	}
 	if e != nil {
 		errResponse := minio.ToErrorResponse(e)
 		if errResponse.Code == "UnexpectedEOF" || e == io.EOF {
@@ -1094,8 +1150,10 @@ func (c *S3Client) removeIncompleteObjects(ctx context.Context, bucket string, o
 		defer close(removeObjectErrorCh)
 
 		for info := range objectsCh {
-			if err := c.api.RemoveIncompleteUpload(ctx, bucket, info.Key); err != nil {
-				removeObjectErrorCh <- minio.RemoveObjectResult{ObjectName: info.Key, Err: err}
+			// Report aborted uploads as well, callers account for them.
+			removeObjectErrorCh <- minio.RemoveObjectResult{
+				ObjectName: info.Key,
+				Err:        c.api.RemoveIncompleteUpload(ctx, bucket, info.Key),
 			}
 		}
 	}()
@@ -1277,15 +1335,22 @@ func (c *S3Client) Remove(ctx context.Context, isIncomplete, isRemoveBucket, isB
 		if statusCh != nil {
 			for removeStatus := range statusCh {
 				if removeStatus.Err != nil {
-					removeStatus.Err = errors.New(strings.Replace(
+					msg := strings.Replace(
 						removeStatus.Err.Error(), "Object is WORM protected",
 						"Object, '"+removeStatus.ObjectName+" (Version ID="+
-							removeStatus.ObjectVersionID+")' is WORM protected", 1))
+							removeStatus.ObjectVersionID+")' is WORM protected", 1)
 
 					// If the removeStatus error message is:
 					// "Object is WORM protected and cannot be overwritten",
 					// it is too generic. We have the object's name and vid.
-					// Adding the object's name and version id into the error msg
+					// Adding the object's name and version id into the error msg,
+					// keeping the error code of an error response.
+					if errResp, ok := removeStatus.Err.(minio.ErrorResponse); ok {
+						errResp.Message = msg
+						removeStatus.Err = errResp
+					} else {
+						removeStatus.Err = errors.New(msg)
+					}
 					resultCh <- RemoveResult{
 						Err: probe.NewError(removeStatus.Err),
 					}
@@ -1418,18 +1483,28 @@ func (c *S3Client) GetAccess(ctx context.Context) (string, string, *probe.Error)
 	if e != nil {
 		return "", "", probe.NewError(e)
 	}
+	pType, e := bucketPolicyPerm(policyStr, bucket, object)
+	if e != nil {
+		return "", "", probe.NewError(e)
+	}
+	return pType, policyStr, nil
+}
+
+// bucketPolicyPerm returns the canned permission the bucket policy
+// policyStr grants on prefix, "custom" when the policy grants none.
+func bucketPolicyPerm(policyStr, bucket, prefix string) (string, error) {
 	if policyStr == "" {
-		return string(policy.BucketPolicyNone), policyStr, nil
+		return string(policy.BucketPolicyNone), nil
 	}
 	var p policy.BucketAccessPolicy
-	if e = json.Unmarshal([]byte(policyStr), &p); e != nil {
-		return "", "", probe.NewError(e)
+	if e := json.Unmarshal([]byte(policyStr), &p); e != nil {
+		return "", e
 	}
-	pType := string(policy.GetPolicy(p.Statements, bucket, object))
-	if pType == string(policy.BucketPolicyNone) && policyStr != "" {
+	pType := string(policy.GetPolicy(p.Statements, bucket, prefix))
+	if pType == string(policy.BucketPolicyNone) {
 		pType = "custom"
 	}
-	return pType, policyStr, nil
+	return pType, nil
 }
 
 // SetAccess set access policy permissions.
@@ -1472,7 +1547,7 @@ func (c *S3Client) SetAccess(ctx context.Context, bucketPolicy string, isJSON bo
 }
 
 // listObjectWrapper - select ObjectList mode depending on arguments
-func (c *S3Client) listObjectWrapper(ctx context.Context, bucket, object string, isRecursive bool, timeRef time.Time, withVersions, withDeleteMarkers bool, metadata bool, maxKeys int, zip bool) <-chan minio.ObjectInfo {
+func (c *S3Client) listObjectWrapper(ctx context.Context, bucket, object string, isRecursive bool, timeRef time.Time, withVersions, withDeleteMarkers bool, metadata bool, maxKeys int, startAfter string, zip bool) <-chan minio.ObjectInfo {
 	if !timeRef.IsZero() || withVersions {
 		return c.listVersions(ctx, bucket, object, isRecursive, timeRef, withVersions, withDeleteMarkers)
 	}
@@ -1480,9 +1555,9 @@ func (c *S3Client) listObjectWrapper(ctx context.Context, bucket, object string,
 	if isGoogle(c.targetURL.Host) {
 		// Google Cloud S3 layer doesn't implement ListObjectsV2 implementation
 		// https://github.com/minio/mc/issues/3073
-		return c.api.ListObjects(ctx, bucket, minio.ListObjectsOptions{Prefix: object, Recursive: isRecursive, UseV1: true, MaxKeys: maxKeys})
+		return c.api.ListObjects(ctx, bucket, minio.ListObjectsOptions{Prefix: object, Recursive: isRecursive, UseV1: true, MaxKeys: maxKeys, StartAfter: startAfter})
 	}
-	opts := minio.ListObjectsOptions{Prefix: object, Recursive: isRecursive, WithMetadata: metadata, MaxKeys: maxKeys}
+	opts := minio.ListObjectsOptions{Prefix: object, Recursive: isRecursive, WithMetadata: metadata, MaxKeys: maxKeys, StartAfter: startAfter}
 	if zip {
 		// If prefix ends with .zip, add a slash.
 		if strings.HasSuffix(object, ".zip") {
@@ -1576,6 +1651,9 @@ func (c *S3Client) Stat(ctx context.Context, opts StatOptions) (*ClientContent,
 		if opts.isZip {
 			o.Set("x-minio-extract", "true")
 		}
+		if opts.checksum {
+			o.Set("x-amz-checksum-mode", "ENABLED")
+		}
 		ctnt, err := c.getObjectStat(ctx, bucket, path, o)
 		if err == nil {
 			return ctnt, nil
@@ -1595,7 +1673,7 @@ func (c *S3Client) Stat(ctx context.Context, opts StatOptions) (*ClientContent,
 
 	nonRecursive := false
 	maxKeys := 1
-	for objectStat := range c.listObjectWrapper(ctx, bucket, path, nonRecursive, opts.timeRef, false, false, false, maxKeys, opts.isZip) {
+	for objectStat := range c.listObjectWrapper(ctx, bucket, path, nonRecursive, opts.timeRef, false, false, false, maxKeys, "", opts.isZip) {
 		if objectStat.Err != nil {
 			return nil, probe.NewError(objectStat.Err)
 		}
@@ -1637,6 +1715,10 @@ func (c *S3Client) getObjectStat(ctx context.Context, bucket, object string, opt
 			}
 			return nil, probe.NewError(ObjectMissing{})
 		}
+		if errResponse.StatusCode == http.StatusBadRequest && opts.ServerSideEncryption == nil &&
+			c.isSSECKeyRequired(ctx, bucket, object, opts.VersionID, errResponse) {
+			return nil, probe.NewError(ObjectSSECKeyRequired{})
+		}
 		return nil, probe.NewError(e)
 	}
 	// HEAD with a version ID will not return version in the response headers
@@ -1646,6 +1728,33 @@ func (c *S3Client) getObjectStat(ctx context.Context, bucket, object string, opt
 	return objectMetadata, nil
 }
 
+// isSSECKeyRequiredResponse tells if the error of a request says that
+// the object is encrypted with SSE-C and its key was not given.
+func isSSECKeyRequiredResponse(errResponse minio.ErrorResponse) bool {
+	return errResponse.StatusCode == http.StatusBadRequest &&
+		strings.Contains(errResponse.Message, "stored using a form of Server Side Encryption")
+}
+
+// isSSECKeyRequired tells if a failed HEAD of an object is due to the
+// object being encrypted with SSE-C. HEAD responses have no body telling
+// why they failed, so the first byte of the object is read to find out.
+func (c *S3Client) isSSECKeyRequired(ctx context.Context, bucket, object, versionID string, errResponse minio.ErrorResponse) bool {
+	if isSSECKeyRequiredResponse(errResponse) {
+		return true
+	}
+	opts := minio.GetObjectOptions{VersionID: versionID}
+	if e := opts.SetRange(0, 0); e != nil {
+		return false
+	}
+	reader, e := c.api.GetObject(ctx, bucket, object, opts)
+	if e != nil {
+		return false
+	}
+	defer reader.Close()
+	_, e = reader.Read(make([]byte, 1))
+	return e != nil && isSSECKeyRequiredResponse(minio.ToErrorResponse(e))
+}
+
 func isAmazon(host string) bool {
 	return s3utils.IsAmazonEndpoint(url.URL{Host: host})
 }
@@ -1679,6 +1788,29 @@ func isVirtualHostStyle(host string, lookup minio.BucketLookupType) bool {
 	return isAmazon(host) && !isAmazonChina(host) || isGoogle(host) || isAmazonAccelerated(host)
 }
 
+// Hosts already checked to support virtual host style addressing.
+var virtualHostChecked sync.Map
+
+// checkVirtualHostAddressing verifies that bucket subdomains of
+// host resolve, as required by virtual host style addressing.
+func checkVirtualHostAddressing(host string) *probe.Error {
+	hostName := host
+	if h, _, e := net.SplitHostPort(host); e == nil {
+		hostName = h
+	}
+	if _, ok := virtualHostChecked.Load(hostName); ok {
+		return nil
+	}
+	if net.ParseIP(hostName) != nil {
+		return probe.NewError(fmt.Errorf("virtual host addressing cannot be used with the IP address `%s`, use --addressing path", hostName))
+	}
+	if _, e := net.LookupHost("mc-addressing-check." + hostName); e != nil {
+		return probe.NewError(fmt.Errorf("virtual host addressing requires a wildcard DNS record for `*.%s`, use --addressing path: %w", hostName, e))
+	}
+	virtualHostChecked.Store(hostName, true)
+	return nil
+}
+
 func url2BucketAndObject(u *ClientURL) (bucketName, objectName string) {
 	tokens := splitStr(u.Path, string(u.Separator), 3)
 	return tokens[1], tokens[2]
@@ -1874,6 +2006,47 @@ func (c *S3Client) unversionedList(ctx context.Context, contentCh chan *ClientCo
 	}
 }
 
+// incompleteUploadsSize returns the size of the parts uploaded so far by
+// the incomplete uploads of the object at urlPath, which their listing
+// does not report. The size summed until an error is returned with it.
+func (c *S3Client) incompleteUploadsSize(ctx context.Context, urlPath string) (size int64, err *probe.Error) {
+	bucket, object := c.splitPath(urlPath)
+	for upload := range c.api.ListIncompleteUploads(ctx, bucket, object, false) {
+		if upload.Err != nil {
+			return size, probe.NewError(upload.Err)
+		}
+		if upload.Key != object {
+			continue
+		}
+		n, e := c.incompleteUploadSize(ctx, bucket, upload)
+		size += n
+		if e != nil {
+			return size, probe.NewError(e)
+		}
+	}
+	return size, nil
+}
+
+// incompleteUploadSize returns the size of the parts uploaded so far by
+// an incomplete upload.
+func (c *S3Client) incompleteUploadSize(ctx context.Context, bucket string, upload minio.ObjectMultipartInfo) (size int64, e error) {
+	core := minio.Core{Client: c.api}
+	partNumberMarker := 0
+	for {
+		result, e := core.ListObjectParts(ctx, bucket, upload.Key, upload.UploadID, partNumberMarker, 1000)
+		if e != nil {
+			return size, e
+		}
+		for _, part := range result.ObjectParts {
+			size += part.Size
+		}
+		if !result.IsTruncated {
+			return size, nil
+		}
+		partNumberMarker = result.NextPartNumberMarker
+	}
+}
+
 func (c *S3Client) listIncompleteInRoutine(ctx context.Context, contentCh chan *ClientContent, opts ListOptions) {
 	// get bucket and object from URL.
 	b, o := c.url2BucketAndObject()
@@ -2146,7 +2319,7 @@ func (c *S3Client) listInRoutine(ctx context.Context, contentCh chan *ClientCont
 		contentCh <- content
 	default:
 		isRecursive := false
-		for object := range c.listObjectWrapper(ctx, b, o, isRecursive, time.Time{}, false, false, opts.WithMetadata, -1, opts.ListZip) {
+		for object := range c.listObjectWrapper(ctx, b, o, isRecursive, time.Time{}, false, false, opts.WithMetadata, -1, opts.StartAfter, opts.ListZip) {
 			if object.Err != nil {
 				contentCh <- &ClientContent{
 					Err: probe.NewError(object.Err),
@@ -2205,7 +2378,7 @@ func (c *S3Client) listRecursiveInRoutine(ctx context.Context, contentCh chan *C
 			}
 
 			isRecursive := true
-			for object := range c.listObjectWrapper(ctx, bucket.Name, o, isRecursive, time.Time{}, false, false, opts.WithMetadata, -1, opts.ListZip) {
+			for object := range c.listObjectWrapper(ctx, bucket.Name, o, isRecursive, time.Time{}, false, false, opts.WithMetadata, -1, "", opts.ListZip) {
 				if object.Err != nil {
 					contentCh <- &ClientContent{
 						Err: probe.NewError(object.Err),
@@ -2221,7 +2394,7 @@ func (c *S3Client) listRecursiveInRoutine(ctx context.Context, contentCh chan *C
 		}
 	default:
 		isRecursive := true
-		for object := range c.listObjectWrapper(ctx, b, o, isRecursive, time.Time{}, false, false, opts.WithMetadata, -1, opts.ListZip) {
+		for object := range c.listObjectWrapper(ctx, b, o, isRecursive, time.Time{}, false, false, opts.WithMetadata, -1, opts.StartAfter, opts.ListZip) {
 			if object.Err != nil {
 				contentCh <- &ClientContent{
 					Err: probe.NewError(object.Err),
@@ -2248,6 +2421,20 @@ func (c *S3Client) ShareDownload(ctx context.Context, versionID string, expires
 	return presignedURL.String(), nil
 }
 
+// PresignHead - get a presigned URL of a HEAD request on the object.
+func (c *S3Client) PresignHead(ctx context.Context, versionID string, expires time.Duration) (string, *probe.Error) {
+	bucket, object := c.url2BucketAndObject()
+	reqParams := make(url.Values)
+	if versionID != "" {
+		reqParams.Set("versionId", versionID)
+	}
+	presignedURL, e := c.api.PresignedHeadObject(ctx, bucket, object, expires, reqParams)
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	return presignedURL.String(), nil
+}
+
 // ShareUpload - get data for presigned post http form upload.
 func (c *S3Client) ShareUpload(ctx context.Context, isRecursive bool, expires time.Duration, contentType string) (string, map[string]string, *probe.Error) {
 	bucket, object := c.url2BucketAndObject()
@@ -21,11 +21,17 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"sync"
 
+	"github.com/minio/mc/pkg/probe"
 	minio "github.com/minio/minio-go/v7"
 	. "gopkg.in/check.v1"
 )
@@ -264,3 +270,325 @@ func (s *TestSuite) TestSelectCompressionType(c *C) {
 		c.Assert(cType, DeepEquals, test.compressionType)
 	}
 }
+
+// resumeHandler is an http.Handler serving multipart uploads with opaque
+// part ETags, like the ones of encrypted parts, failing the upload of
+// failPart.
+type resumeHandler struct {
+	mu        sync.Mutex
+	resource  string
+	failPart  int
+	initiated int
+	parts     map[int][]byte
+	putParts  []int
+	completed bool
+}
+
+// partETag returns an ETag which is not the MD5 sum of the part.
+func partETag(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func (h *resumeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	query := r.URL.Query()
+	var response string
+	switch {
+	case r.Method == "GET" && query.Has("location"):
+		response = "<LocationConstraint xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"></LocationConstraint>"
+	case r.URL.Path != h.resource:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	case r.Method == "POST" && query.Has("uploads"):
+		// Handler for new multipart upload request.
+		h.initiated++
+		h.parts = make(map[int][]byte)
+		response = "<InitiateMultipartUploadResult xmlns=\"http://s3.amazonaws.com/doc/2006-03-01/\"><Bucket>bucket</Bucket><Key>object</Key><UploadId>upload</UploadId></InitiateMultipartUploadResult>"
+	case r.Method == "GET" && query.Get("uploadId") == "upload":
+		// Handler for list object parts request.
+		response = "<ListPartsResult xmlns=\"http://s3.amazonaws.com/doc/2006-03-01/\"><Bucket>bucket</Bucket><Key>object</Key><UploadId>upload</UploadId><IsTruncated>false</IsTruncated>"
+		for number := 1; number <= len(h.parts)+1; number++ {
+			if data, ok := h.parts[number]; ok {
+				response += "<Part><PartNumber>" + strconv.Itoa(number) + "</PartNumber><ETag>\"" + partETag(data) + "\"</ETag><Size>" + strconv.Itoa(len(data)) + "</Size></Part>"
+			}
+		}
+		response += "</ListPartsResult>"
+	case r.Method == "PUT" && query.Get("uploadId") == "upload":
+		// Handler for put object part request.
+		partNumber, e := strconv.Atoi(query.Get("partNumber"))
+		if e != nil || partNumber == h.failPart {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("<Error><Code>BadRequest</Code><Message>Failed part</Message></Error>"))
+			return
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		h.parts[partNumber] = data
+		h.putParts = append(h.putParts, partNumber)
+		w.Header().Set("ETag", "\""+partETag(data)+"\"")
+		w.WriteHeader(http.StatusOK)
+		return
+	case r.Method == "POST" && query.Get("uploadId") == "upload":
+		// Handler for complete multipart upload request.
+		h.completed = true
+		response = "<CompleteMultipartUploadResult xmlns=\"http://s3.amazonaws.com/doc/2006-03-01/\"><Bucket>bucket</Bucket><Key>object</Key><ETag>\"3858f62230ac3c915f300c664312c11f-3\"</ETag></CompleteMultipartUploadResult>"
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(response)))
+	w.Write([]byte(response))
+}
+
+// Test resuming an interrupted multipart upload with corrupted parts.
+func (s *TestSuite) TestPutChecksumResume(c *C) {
+	defer setMcConfigDir(mcCustomConfigDir)
+	setMcConfigDir(c.MkDir())
+	defer func(uploads *resumeUploads) { globalResumeUploads = uploads }(globalResumeUploads)
+	globalResumeUploads = &resumeUploads{}
+
+	handler := &resumeHandler{resource: "/bucket/object", failPart: 3}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + handler.resource
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	s3c, err := S3New(conf)
+	c.Assert(err, IsNil)
+
+	const partSize = 5 * 1024 * 1024
+	data := make([]byte, 2*partSize+10)
+	put := func(data []byte, metadata map[string]string) (*partsResume, *probe.Error) {
+		resume := &partsResume{}
+		_, err := s3c.Put(context.Background(), bytes.NewReader(data), int64(len(data)), nil, PutOptions{
+			metadata:      metadata,
+			multipartSize: partSize,
+			resume:        resume,
+		})
+		return resume, err
+	}
+
+	// The first run fails to upload the third part.
+	_, err = put(data, nil)
+	c.Assert(err, NotNil)
+	c.Assert(handler.putParts, DeepEquals, []int{1, 2})
+	_, recorded := globalResumeUploads.get(server.Listener.Addr().String() + handler.resource)
+	c.Assert(recorded, Equals, true)
+
+	// The upload is not resumed with other options.
+	handler.putParts, handler.failPart = nil, 0
+	_, err = put(data, map[string]string{"X-Amz-Meta-Color": "red"})
+	c.Assert(err, NotNil)
+	c.Assert(handler.putParts, IsNil)
+
+	// The second part no longer matches the local file and is uploaded
+	// again, the first one is trusted although its ETag is no MD5 sum.
+	data[partSize] = 1
+	resume, err := put(data, nil)
+	c.Assert(err, IsNil)
+	c.Assert(resume.reuploaded, DeepEquals, []int{2})
+	c.Assert(handler.putParts, DeepEquals, []int{2, 3})
+	c.Assert(handler.initiated, Equals, 1)
+	c.Assert(handler.completed, Equals, true)
+	_, recorded = globalResumeUploads.get(server.Listener.Addr().String() + handler.resource)
+	c.Assert(recorded, Equals, false)
+}
+
+// copyHandler is an http.Handler serving server side copies, sending
+// the metadata directive and the metadata of each copy to headers.
+type copyHandler struct {
+	headers chan http.Header
+}
+
+func (h copyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var response string
+	switch {
+	case r.Method == "GET" && r.URL.Query().Has("location"):
+		response = "<LocationConstraint xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"></LocationConstraint>"
+	case r.Method == "PUT" && r.Header.Get("X-Amz-Copy-Source") != "":
+		h.headers <- r.Header
+		response = "<CopyObjectResult xmlns=\"http://s3.amazonaws.com/doc/2006-03-01/\"><ETag>\"9af2f8218b150c351ad802c6f3d66abe\"</ETag><LastModified>2022-01-01T00:00:00.000Z</LastModified></CopyObjectResult>"
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(response)))
+	w.Write([]byte(response))
+}
+
+// Test the metadata directive of server side copies.
+func (s *TestSuite) TestCopyMetadataDirective(c *C) {
+	handler := copyHandler{headers: make(chan http.Header, 1)}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + "/bucket/target"
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	s3c, err := S3New(conf)
+	c.Assert(err, IsNil)
+
+	testCases := []struct {
+		directive         string
+		metadata          map[string]string
+		expectedDirective string
+		expectedMetadata  string
+	}{
+		{"", map[string]string{}, "", ""},
+		{"", map[string]string{"Color": "red"}, "REPLACE", "red"},
+		{copyMetadataDirective, map[string]string{"Color": "red"}, "", ""},
+		{replaceMetadataDirective, map[string]string{}, "REPLACE", ""},
+	}
+	for _, testCase := range testCases {
+		err = s3c.Copy(context.Background(), "/bucket/source", CopyOptions{
+			metadata:          testCase.metadata,
+			metadataDirective: testCase.directive,
+			size:              1,
+		}, nil)
+		c.Assert(err, IsNil)
+		header := <-handler.headers
+		c.Assert(header.Get("X-Amz-Metadata-Directive"), Equals, testCase.expectedDirective)
+		c.Assert(header.Get("X-Amz-Meta-Color"), Equals, testCase.expectedMetadata)
+	}
+}
+
+// ssecHandler is an http.Handler failing HEAD requests with a bare
+// 400 Bad Request, and GET requests with message.
+type ssecHandler struct {
+	message string
+}
+
+func (h ssecHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == "GET" && r.URL.Query().Has("location"):
+		response := "<LocationConstraint xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"></LocationConstraint>"
+		w.Header().Set("Content-Length", strconv.Itoa(len(response)))
+		w.Write([]byte(response))
+	case r.Method == "GET":
+		response := "<Error><Code>InvalidRequest</Code><Message>" + h.message + "</Message></Error>"
+		w.Header().Set("Content-Length", strconv.Itoa(len(response)))
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(response))
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+// Test that only the bad requests due to a missing SSE-C key are reported as such.
+func (s *TestSuite) TestStatSSECKeyRequired(c *C) {
+	testCases := []struct {
+		message     string
+		keyRequired bool
+	}{
+		{"The object was stored using a form of Server Side Encryption. The correct parameters must be provided to retrieve the object.", true},
+		{"The request is not valid with the current state of the bucket.", false},
+	}
+	for _, testCase := range testCases {
+		server := httptest.NewServer(ssecHandler{message: testCase.message})
+
+		conf := new(Config)
+		conf.HostURL = server.URL + "/bucket/object"
+		conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+		conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+		conf.Signature = "S3v4"
+		s3c, err := S3New(conf)
+		c.Assert(err, IsNil)
+
+		_, err = s3c.(*S3Client).getObjectStat(context.Background(), "bucket", "object", minio.StatObjectOptions{})
+		c.Assert(err, NotNil)
+		c.Assert(errors.As(err.ToGoError(), &ObjectSSECKeyRequired{}), Equals, testCase.keyRequired)
+		if !testCase.keyRequired {
+			c.Assert(minio.ToErrorResponse(err.ToGoError()).StatusCode, Equals, http.StatusBadRequest)
+		}
+		server.Close()
+	}
+}
+
+// partsHandler is an http.Handler listing the parts of an incomplete
+// upload, two parts per page.
+type partsHandler struct {
+	sizes     []int
+	failParts bool
+}
+
+func (h partsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if r.Method == "GET" && query.Has("location") {
+		response := "<LocationConstraint xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"></LocationConstraint>"
+		w.Header().Set("Content-Length", strconv.Itoa(len(response)))
+		w.Write([]byte(response))
+		return
+	}
+	if r.Method == "GET" && query.Has("uploads") {
+		response := "<ListMultipartUploadsResult xmlns=\"http://s3.amazonaws.com/doc/2006-03-01/\"><Bucket>bucket</Bucket>" +
+			"<Upload><Key>object</Key><UploadId>upload</UploadId></Upload>" +
+			"<Upload><Key>object-other</Key><UploadId>other</UploadId></Upload>" +
+			"<IsTruncated>false</IsTruncated></ListMultipartUploadsResult>"
+		w.Header().Set("Content-Length", strconv.Itoa(len(response)))
+		w.Write([]byte(response))
+		return
+	}
+	if r.Method != "GET" || query.Get("uploadId") != "upload" || h.failParts {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	marker, _ := strconv.Atoi(query.Get("part-number-marker"))
+	response := "<ListPartsResult xmlns=\"http://s3.amazonaws.com/doc/2006-03-01/\"><Bucket>bucket</Bucket><Key>object</Key><UploadId>upload</UploadId>"
+	next := marker
+	for next < len(h.sizes) && next < marker+2 {
+		next++
+		response += "<Part><PartNumber>" + strconv.Itoa(next) + "</PartNumber><ETag>\"etag\"</ETag><Size>" + strconv.Itoa(h.sizes[next-1]) + "</Size></Part>"
+	}
+	response += "<NextPartNumberMarker>" + strconv.Itoa(next) + "</NextPartNumberMarker><IsTruncated>" + strconv.FormatBool(next < len(h.sizes)) + "</IsTruncated></ListPartsResult>"
+	w.Header().Set("Content-Length", strconv.Itoa(len(response)))
+	w.Write([]byte(response))
+}
+
+// Test summing the parts of an incomplete upload over several pages.
+func (s *TestSuite) TestIncompleteUploadSize(c *C) {
+	server := httptest.NewServer(partsHandler{sizes: []int{5, 7, 11, 13, 17}})
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + "/bucket/object"
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	s3c, err := S3New(conf)
+	c.Assert(err, IsNil)
+
+	size, e := s3c.(*S3Client).incompleteUploadSize(context.Background(), "bucket", minio.ObjectMultipartInfo{Key: "object", UploadID: "upload"})
+	c.Assert(e, IsNil)
+	c.Assert(size, Equals, int64(53))
+}
+
+// Test summing the parts of the incomplete uploads of an object only,
+// and reporting when they cannot be listed.
+func (s *TestSuite) TestIncompleteUploadsSize(c *C) {
+	for _, failParts := range []bool{false, true} {
+		server := httptest.NewServer(partsHandler{sizes: []int{5, 7, 11}, failParts: failParts})
+
+		conf := new(Config)
+		conf.HostURL = server.URL + "/bucket/"
+		conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+		conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+		conf.Signature = "S3v4"
+		s3c, err := S3New(conf)
+		c.Assert(err, IsNil)
+
+		size, err := s3c.(*S3Client).incompleteUploadsSize(context.Background(), "/bucket/object")
+		server.Close()
+		if failParts {
+			c.Assert(err, NotNil)
+			continue
+		}
+		c.Assert(err, IsNil)
+		c.Assert(size, Equals, int64(23))
+	}
+}
@@ -187,14 +187,20 @@ func urlJoinPath(url1, url2 string) string {
 
 // url2Stat returns stat info for URL.
 func url2Stat(ctx context.Context, urlStr, versionID string, fileAttr bool, encKeyDB map[string][]prefixSSEPair, timeRef time.Time, isZip bool) (client Client, content *ClientContent, err *probe.Error) {
+	return url2StatWithOptions(ctx, urlStr, encKeyDB, StatOptions{preserve: fileAttr, timeRef: timeRef, versionID: versionID, isZip: isZip})
+}
+
+// url2StatWithOptions - like url2Stat, with the stat options given
+// as is, except for the encryption key which comes from encKeyDB.
+func url2StatWithOptions(ctx context.Context, urlStr string, encKeyDB map[string][]prefixSSEPair, opts StatOptions) (client Client, content *ClientContent, err *probe.Error) {
 	client, err = newClient(urlStr)
 	if err != nil {
 		return nil, nil, err.Trace(urlStr)
 	}
 	alias, _ := url2Alias(urlStr)
-	sse := getSSE(urlStr, encKeyDB[alias])
+	opts.sse = getSSE(urlStr, encKeyDB[alias])
 
-	content, err = client.Stat(ctx, StatOptions{preserve: fileAttr, sse: sse, timeRef: timeRef, versionID: versionID, isZip: isZip})
+	content, err = client.Stat(ctx, opts)
 	if err != nil {
 		return nil, nil, err.Trace(urlStr)
 	}
@@ -55,10 +55,20 @@ type PutOptions struct {
 	metadata              map[string]string
 	sse                   encrypt.ServerSide
 	md5, disableMultipart bool
-	isPreserve            bool
+	isPreserve, sparse    bool
 	storageClass          string
 	multipartSize         uint64
 	multipartThreads      uint
+	modTime               time.Time
+	// concurrentParts uploads the parts of streams in parallel
+	// with multipartThreads workers, not only those of files.
+	concurrentParts bool
+	// resume is set to resume an incomplete multipart upload
+	// of the object, after verifying its uploaded parts.
+	resume *partsResume
+	// verify is called by local file writes once the content is
+	// written, the file is not committed when it fails.
+	verify func() *probe.Error
 }
 
 // StatOptions holds options of the HEAD operation
@@ -69,6 +79,8 @@ type StatOptions struct {
 	timeRef    time.Time
 	versionID  string
 	isZip      bool
+	// checksum asks for the additional checksums of the object.
+	checksum bool
 }
 
 // ListOptions holds options for listing operation
@@ -82,6 +94,9 @@ type ListOptions struct {
 	TimeRef           time.Time
 	ShowDir           DirOpt
 	Count             int
+	// StartAfter resumes an object storage listing after this
+	// object, listing buckets ignores it.
+	StartAfter string
 }
 
 // CopyOptions holds options for copying operation
@@ -92,9 +107,19 @@ type CopyOptions struct {
 	metadata         map[string]string
 	disableMultipart bool
 	isPreserve       bool
+	sparse           bool
 	storageClass     string
+	// metadataDirective is empty to replace the metadata only
+	// when there is metadata to set.
+	metadataDirective string
 }
 
+// Metadata directives of server side copies.
+const (
+	copyMetadataDirective    = "COPY"
+	replaceMetadataDirective = "REPLACE"
+)
+
 // Client - client interface
 type Client interface {
 	// Common operations
@@ -201,6 +226,10 @@ type ClientContent struct {
 	IsLatest          bool
 	ReplicationStatus string
 
+	// EncryptionKeyRequired is set for SSE-C encrypted objects
+	// whose metadata could not be read without their key.
+	EncryptionKeyRequired bool
+
 	Restore *minio.RestoreInfo
 
 	Err *probe.Error
@@ -218,6 +247,7 @@ type Config struct {
 	Debug        bool
 	Insecure     bool
 	Lookup       minio.BucketLookupType
+	Region       string
 	Transport    *http.Transport
 }
 
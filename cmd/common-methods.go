@@ -19,8 +19,11 @@ package cmd
 
 import (
 	"context"
+	"crypto/md5"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"hash"
 	"io"
 	"net/http"
 	"os"
@@ -406,6 +409,35 @@ func getAllMetadata(ctx context.Context, sourceAlias, sourceURLStr string, srcSS
 	return filterMetadata(metadata), nil
 }
 
+// isSameEndpoint reports whether two aliases point to the same endpoint
+// with the same credentials, so objects can be copied server side.
+func isSameEndpoint(sourceAlias, targetAlias string) bool {
+	if sourceAlias == targetAlias {
+		return true
+	}
+	sourceCfg, targetCfg := mustGetHostConfig(sourceAlias), mustGetHostConfig(targetAlias)
+	if sourceCfg == nil || targetCfg == nil {
+		return false
+	}
+	return strings.TrimSuffix(sourceCfg.URL, "/") == strings.TrimSuffix(targetCfg.URL, "/") &&
+		sourceCfg.AccessKey == targetCfg.AccessKey &&
+		sourceCfg.SecretKey == targetCfg.SecretKey &&
+		sourceCfg.SessionToken == targetCfg.SessionToken
+}
+
+// isServerSideCopy reports whether urls are copied with a server side copy.
+// Objects are copied server side within the same alias, and between aliases
+// of the same endpoint only when asked for.
+func (m URLs) isServerSideCopy(isZip bool) bool {
+	if isZip || m.DisableServerSide {
+		return false
+	}
+	if m.SourceAlias == m.TargetAlias {
+		return true
+	}
+	return m.ServerSideAcrossAliases && isSameEndpoint(m.SourceAlias, m.TargetAlias)
+}
+
 // uploadSourceToTargetURL - uploads to targetURL from source.
 // optionally optimizes copy for object sizes <= 5GiB by using
 // server side copy operation.
@@ -463,15 +495,17 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 		legalHold = urls.TargetContent.LegalHold
 	}
 
-	for k, v := range urls.SourceContent.UserMetadata {
-		metadata[http.CanonicalHeaderKey(k)] = v
-	}
-	for k, v := range urls.SourceContent.Metadata {
-		metadata[http.CanonicalHeaderKey(k)] = v
+	if urls.MetadataDirective != replaceMetadataDirective {
+		for k, v := range urls.SourceContent.UserMetadata {
+			metadata[http.CanonicalHeaderKey(k)] = v
+		}
+		for k, v := range urls.SourceContent.Metadata {
+			metadata[http.CanonicalHeaderKey(k)] = v
+		}
 	}
 
 	// Optimize for server side copy if the host is same.
-	if sourceAlias == targetAlias && !isZip {
+	if urls.isServerSideCopy(isZip) {
 		// preserve new metadata and save existing ones.
 		if preserve {
 			currentMetadata, err := getAllMetadata(ctx, sourceAlias, sourceURL.String(), srcSSE, urls)
@@ -500,12 +534,14 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 		}
 
 		opts := CopyOptions{
-			srcSSE:           srcSSE,
-			tgtSSE:           tgtSSE,
-			metadata:         filterMetadata(metadata),
-			disableMultipart: urls.DisableMultipart,
-			isPreserve:       preserve,
-			storageClass:     urls.TargetContent.StorageClass,
+			srcSSE:            srcSSE,
+			tgtSSE:            tgtSSE,
+			metadata:          filterMetadata(metadata),
+			disableMultipart:  urls.DisableMultipart,
+			isPreserve:        preserve,
+			sparse:            urls.Sparse,
+			storageClass:      urls.TargetContent.StorageClass,
+			metadataDirective: urls.MetadataDirective,
 		}
 
 		err = copySourceToTargetURL(ctx, targetAlias, targetURL.String(), sourcePath, sourceVersion, mode, until,
@@ -545,6 +581,10 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 		}
 		defer reader.Close()
 
+		if urls.MetadataDirective == replaceMetadataDirective {
+			metadata = map[string]string{}
+		}
+
 		// Get metadata from target content as well
 		for k, v := range urls.TargetContent.Metadata {
 			metadata[http.CanonicalHeaderKey(k)] = v
@@ -563,11 +603,25 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 				return urls.WithError(probe.NewError(e))
 			}
 		}
+		if targetURL.Type == objectStorage && !urls.DisableMultipart {
+			var increased bool
+			multipartSize, increased, err = fitPartSize(length, multipartSize, urls.AutoPartSize)
+			if err != nil {
+				return urls.WithError(err.Trace(sourceURL.String()))
+			}
+			if increased {
+				warning("Uploading `%s` in parts of %s, MC_UPLOAD_MULTIPART_SIZE takes more than %d parts.",
+					sourcePath, humanize.IBytes(multipartSize), maxMultipartParts)
+			}
+		}
 
 		multipartThreads, e := strconv.Atoi(env.Get("MC_UPLOAD_MULTIPART_THREADS", "4"))
 		if e != nil {
 			return urls.WithError(probe.NewError(e))
 		}
+		if urls.ConcurrentParts > 1 {
+			multipartThreads = urls.ConcurrentParts
+		}
 
 		putOpts := PutOptions{
 			metadata:         filterMetadata(metadata),
@@ -576,17 +630,53 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 			md5:              urls.MD5,
 			disableMultipart: urls.DisableMultipart,
 			isPreserve:       preserve,
+			sparse:           urls.Sparse,
 			multipartSize:    multipartSize,
 			multipartThreads: uint(multipartThreads),
+			concurrentParts:  urls.ConcurrentParts > 1,
+		}
+
+		if urls.PreserveMtime {
+			putOpts.modTime = urls.SourceContent.Time
+		}
+		if urls.ChecksumResume {
+			putOpts.resume = &partsResume{}
+		}
+
+		putTarget := putTargetStream
+		if urls.Atomic && targetURL.Type == objectStorage {
+			putTarget = putTargetAtomic
 		}
 
-		if isReadAt(reader) {
-			_, err = putTargetStream(ctx, targetAlias, targetURL.String(), mode, until,
+		var md5Hash hash.Hash
+		verifyDownload := verifiesDownloadChecksum(urls)
+		if urls.ContentMD5 || verifyDownload {
+			// The upload is buffered to compute its Content-MD5 before the
+			// PUT, hash the source as it is read to report the same sum.
+			// Downloads are hashed to be compared against the ETag.
+			putOpts.md5 = urls.ContentMD5
+			md5Hash = md5.New()
+			if verifyDownload {
+				// Checked before the download replaces the target.
+				putOpts.verify = func() *probe.Error {
+					return checkDownloadChecksum(ctx, urls, hex.EncodeToString(md5Hash.Sum(nil)), encKeyDB)
+				}
+			}
+			_, err = putTarget(ctx, targetAlias, targetURL.String(), mode, until,
+				legalHold, io.TeeReader(io.LimitReader(reader, length), md5Hash), length, progress, putOpts)
+		} else if isReadAt(reader) {
+			_, err = putTarget(ctx, targetAlias, targetURL.String(), mode, until,
 				legalHold, reader, length, progress, putOpts)
 		} else {
-			_, err = putTargetStream(ctx, targetAlias, targetURL.String(), mode, until,
+			_, err = putTarget(ctx, targetAlias, targetURL.String(), mode, until,
 				legalHold, io.LimitReader(reader, length), length, progress, putOpts)
 		}
+		if err == nil && urls.ContentMD5 {
+			urls.ContentMD5Sum = hex.EncodeToString(md5Hash.Sum(nil))
+		}
+		if err == nil && putOpts.resume != nil {
+			urls.ReuploadedParts = putOpts.resume.reuploaded
+		}
 	}
 	if err != nil {
 		return urls.WithError(err.Trace(sourceURL.String()))
@@ -69,3 +69,32 @@ func TestGetDecodedKey(t *testing.T) {
 		}
 	}
 }
+
+func TestIsServerSideCopy(t *testing.T) {
+	withTempMcConfig(t)
+
+	defer func(src, dst *aliasConfigV10) {
+		aliasToConfigMap["sssrc"], aliasToConfigMap["ssdst"] = src, dst
+	}(aliasToConfigMap["sssrc"], aliasToConfigMap["ssdst"])
+	aliasToConfigMap["sssrc"] = &aliasConfigV10{URL: "https://minio.example.com", AccessKey: "minio", SecretKey: "minio123"}
+	aliasToConfigMap["ssdst"] = &aliasConfigV10{URL: "https://minio.example.com/", AccessKey: "minio", SecretKey: "minio123"}
+
+	testCases := []struct {
+		urls       URLs
+		isZip      bool
+		serverSide bool
+	}{
+		{URLs{SourceAlias: "sssrc", TargetAlias: "sssrc"}, false, true},
+		{URLs{SourceAlias: "sssrc", TargetAlias: "sssrc"}, true, false},
+		{URLs{SourceAlias: "sssrc", TargetAlias: "sssrc", DisableServerSide: true}, false, false},
+		// Aliases of the same endpoint are copied server side only when asked for.
+		{URLs{SourceAlias: "sssrc", TargetAlias: "ssdst"}, false, false},
+		{URLs{SourceAlias: "sssrc", TargetAlias: "ssdst", ServerSideAcrossAliases: true}, false, true},
+		{URLs{SourceAlias: "", TargetAlias: "ssdst", ServerSideAcrossAliases: true}, false, false},
+	}
+	for i, testCase := range testCases {
+		if serverSide := testCase.urls.isServerSideCopy(testCase.isZip); serverSide != testCase.serverSide {
+			t.Errorf("Test %d: expected server side %t, got %t", i+1, testCase.serverSide, serverSide)
+		}
+	}
+}
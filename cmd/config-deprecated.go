@@ -48,6 +48,8 @@ var configHostCmd = cli.Command{
 		configHostAddCmd,
 		configHostRemoveCmd,
 		configHostListCmd,
+		configHostExportCmd,
+		configHostImportCmd,
 	},
 	HideHelpCommand: true,
 }
@@ -62,6 +64,14 @@ var configHostAddFlags = []cli.Flag{
 		Name:  "api",
 		Usage: "API signature. Valid options are '[S3v4, S3v2]'",
 	},
+	cli.StringFlag{
+		Name:  "region",
+		Usage: "region of the server, sent with the requests instead of looking up the region of each bucket",
+	},
+	cli.BoolFlag{
+		Name:  "skip-test",
+		Usage: "skip testing the connection to the new host",
+	},
 }
 
 var configHostAddCmd = cli.Command{
@@ -0,0 +1,338 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+var configHostExportFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "redact-secrets",
+		Usage: "leave out the secret keys, session tokens and API keys",
+	},
+}
+
+var configHostExportCmd = cli.Command{
+	Name:            "export",
+	Usage:           "export all hosts of configuration file as JSON",
+	Action:          mainConfigHostExport,
+	OnUsageError:    onUsageError,
+	Before:          setGlobalsFromContext,
+	Flags:           append(configHostExportFlags, globalFlags...),
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Export all hosts to a file, to be imported on another machine.
+     {{.Prompt}} {{.HelpName}} > hosts.json
+
+  2. Export all hosts without their secrets, e.g. to share the endpoints with a team.
+     {{.Prompt}} {{.HelpName}} --redact-secrets > hosts.json
+`,
+}
+
+var configHostImportFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "overwrite",
+		Usage: "replace existing hosts which differ from the imported ones",
+	},
+}
+
+var configHostImportCmd = cli.Command{
+	Name:            "import",
+	Usage:           "import hosts exported by 'config host export' into configuration file",
+	Action:          mainConfigHostImport,
+	OnUsageError:    onUsageError,
+	Before:          setGlobalsFromContext,
+	Flags:           append(configHostImportFlags, globalFlags...),
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] [FILE]
+
+  FILE is read from standard input when omitted or '-'. Hosts which already
+  exist and differ are skipped unless --overwrite is set. The secret of a host
+  exported with --redact-secrets is kept from the existing host of the same
+  URL and access key, such a host is skipped otherwise.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Import the hosts of hosts.json, keeping the existing ones.
+     {{.Prompt}} {{.HelpName}} hosts.json
+
+  2. Import the hosts from standard input, replacing the existing ones.
+     {{.Prompt}} cat hosts.json | {{.HelpName}} --overwrite
+`,
+}
+
+// Actions taken on an imported host.
+const (
+	hostImportAdded   = "added"
+	hostImportUpdated = "updated"
+	hostImportSkipped = "skipped"
+)
+
+// hostsExport is the document written by 'config host export' and
+// read by 'config host import'.
+type hostsExport struct {
+	Version string                    `json:"version"`
+	Aliases map[string]aliasConfigV10 `json:"aliases"`
+}
+
+// hostsExportMessage container for exported hosts.
+type hostsExportMessage struct {
+	hostsExport
+}
+
+func (h hostsExportMessage) String() string {
+	return h.JSON()
+}
+
+func (h hostsExportMessage) JSON() string {
+	msgBytes, e := json.MarshalIndent(h.hostsExport, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// hostImportMessage container for the outcome of the import of a host.
+type hostImportMessage struct {
+	Status string `json:"status"`
+	Alias  string `json:"alias"`
+	Action string `json:"action"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func (h hostImportMessage) String() string {
+	if h.Action == hostImportSkipped {
+		return console.Colorize("HostImportSkipped", fmt.Sprintf("Skipped `%s`, %s.", h.Alias, h.Reason))
+	}
+	return console.Colorize("AliasMessage", fmt.Sprintf("%s `%s` successfully.", map[string]string{
+		hostImportAdded:   "Added",
+		hostImportUpdated: "Updated",
+	}[h.Action], h.Alias))
+}
+
+func (h hostImportMessage) JSON() string {
+	h.Status = "success"
+	msgBytes, e := json.MarshalIndent(h, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// hostImportSummaryMessage container for the counts of an import.
+type hostImportSummaryMessage struct {
+	Status  string `json:"status"`
+	Added   int    `json:"added"`
+	Updated int    `json:"updated"`
+	Skipped int    `json:"skipped"`
+}
+
+func (h hostImportSummaryMessage) String() string {
+	return console.Colorize("AliasMessage", fmt.Sprintf("Imported hosts: %d added, %d updated, %d skipped.", h.Added, h.Updated, h.Skipped))
+}
+
+func (h hostImportSummaryMessage) JSON() string {
+	h.Status = "success"
+	msgBytes, e := json.MarshalIndent(h, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// exportHosts returns the hosts of aliases, without their secrets when
+// redactSecrets is set.
+func exportHosts(aliases map[string]aliasConfigV10, redactSecrets bool) hostsExport {
+	export := hostsExport{Version: globalMCConfigVersion, Aliases: make(map[string]aliasConfigV10, len(aliases))}
+	for alias, cfg := range aliases {
+		if redactSecrets {
+			cfg.SecretKey, cfg.SessionToken, cfg.APIKey = "", "", ""
+		}
+		export.Aliases[alias] = cfg
+	}
+	return export
+}
+
+// invalidHostReason returns why an imported host cannot be added, empty
+// when it is valid.
+func invalidHostReason(alias string, cfg aliasConfigV10) string {
+	switch {
+	case !isValidAlias(alias):
+		return "invalid alias"
+	case !isValidHostURL(cfg.URL):
+		return fmt.Sprintf("invalid URL `%s`", cfg.URL)
+	case !isValidAccessKey(cfg.AccessKey):
+		return "invalid access key"
+	case !isValidSecretKey(cfg.SecretKey):
+		return "invalid secret key"
+	case !isValidAPI(cfg.API):
+		return fmt.Sprintf("unrecognized API signature `%s`", cfg.API)
+	case !isValidPath(cfg.Path) && !isValidLookup(cfg.Path):
+		return fmt.Sprintf("unrecognized path value `%s`", cfg.Path)
+	}
+	return ""
+}
+
+// importHosts merges the imported hosts into aliases, replacing the
+// existing hosts which differ only when overwrite is set. It returns
+// the outcome of the import of each host, sorted by alias.
+func importHosts(aliases, imported map[string]aliasConfigV10, overwrite bool) []hostImportMessage {
+	names := make([]string, 0, len(imported))
+	for alias := range imported {
+		names = append(names, alias)
+	}
+	sort.Strings(names)
+
+	msgs := make([]hostImportMessage, 0, len(names))
+	for _, alias := range names {
+		cfg := imported[alias]
+		cfg.URL = trimTrailingSeparator(cfg.URL)
+		if cfg.API == "" {
+			cfg.API = "S3v4"
+		}
+		if cfg.Path == "" {
+			cfg.Path = "auto"
+		}
+		existing, exists := aliases[alias]
+
+		msg := hostImportMessage{Alias: alias, Action: hostImportSkipped}
+		if cfg.SecretKey == "" && cfg.AccessKey != "" {
+			// Exported with --redact-secrets, the secret may only
+			// be known from the same host on this machine.
+			if !exists || existing.URL != cfg.URL || existing.AccessKey != cfg.AccessKey {
+				msg.Reason = "secret key redacted"
+				msgs = append(msgs, msg)
+				continue
+			}
+			cfg.SecretKey = existing.SecretKey
+			if cfg.SessionToken == "" {
+				cfg.SessionToken = existing.SessionToken
+			}
+			if cfg.APIKey == "" {
+				cfg.APIKey = existing.APIKey
+			}
+		}
+
+		switch {
+		case invalidHostReason(alias, cfg) != "":
+			msg.Reason = invalidHostReason(alias, cfg)
+		case !exists:
+			msg.Action = hostImportAdded
+			aliases[alias] = cfg
+		case reflect.DeepEqual(existing, cfg):
+			msg.Reason = "unchanged"
+		case !overwrite:
+			msg.Reason = "already exists with another configuration, use --overwrite to replace it"
+		default:
+			msg.Action = hostImportUpdated
+			aliases[alias] = cfg
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
+
+// mainConfigHostExport is the handle for "mc config host export" command.
+func mainConfigHostExport(ctx *cli.Context) error {
+	if ctx.NArg() != 0 {
+		cli.ShowCommandHelpAndExit(ctx, "export", 1) // last argument is exit code.
+	}
+
+	mcCfgV10, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config `"+mustGetMcConfigPath()+"`.")
+
+	printMsg(hostsExportMessage{exportHosts(mcCfgV10.Aliases, ctx.Bool("redact-secrets"))})
+	return nil
+}
+
+// mainConfigHostImport is the handle for "mc config host import" command.
+func mainConfigHostImport(ctx *cli.Context) error {
+	if ctx.NArg() > 1 {
+		cli.ShowCommandHelpAndExit(ctx, "import", 1) // last argument is exit code.
+	}
+	console.SetColor("AliasMessage", color.New(color.FgGreen))
+	console.SetColor("HostImportSkipped", color.New(color.FgYellow))
+
+	var (
+		input []byte
+		e     error
+	)
+	file := ctx.Args().First()
+	if file == "" || file == "-" {
+		file = os.Stdin.Name()
+		input, e = io.ReadAll(os.Stdin)
+	} else {
+		input, e = ioutil.ReadFile(file)
+	}
+	fatalIf(probe.NewError(e).Trace(file), "Unable to read the hosts to import.")
+
+	var imported hostsExport
+	e = json.Unmarshal(input, &imported)
+	fatalIf(probe.NewError(e).Trace(file), "Unable to parse the hosts to import.")
+	if len(imported.Aliases) == 0 {
+		fatalIf(errInvalidArgument().Trace(file), "No hosts found in `"+file+"`.")
+	}
+
+	var msgs []hostImportMessage
+	err := updateMcConfig(func(mcCfgV10 *configV10) bool {
+		msgs = importHosts(mcCfgV10.Aliases, imported.Aliases, ctx.Bool("overwrite"))
+		for _, msg := range msgs {
+			if msg.Action != hostImportSkipped {
+				return true
+			}
+		}
+		return false
+	})
+	fatalIf(err.Trace(file), "Unable to import hosts to `"+mustGetMcConfigPath()+"`.")
+
+	var summary hostImportSummaryMessage
+	for _, msg := range msgs {
+		printMsg(msg)
+		switch msg.Action {
+		case hostImportAdded:
+			summary.Added++
+		case hostImportUpdated:
+			summary.Updated++
+		default:
+			summary.Skipped++
+		}
+	}
+	printMsg(summary)
+	return nil
+}
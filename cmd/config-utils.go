@@ -70,6 +70,11 @@ func isValidAPI(api string) (ok bool) {
 	return ok
 }
 
+// isValidRegion - validates if the region is a single word, e.g. us-east-1
+func isValidRegion(region string) bool {
+	return region != "" && !strings.ContainsAny(region, " \t\r\n/")
+}
+
 // isValidLookup - validates if bucket lookup is of valid type
 func isValidLookup(lookup string) (ok bool) {
 	l := strings.ToLower(strings.TrimSpace(lookup))
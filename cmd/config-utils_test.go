@@ -49,6 +49,14 @@ func TestIsValidAPI(t *testing.T) {
 	equalAssert(isValidAPI("s3"), false, t)
 }
 
+func TestIsValidRegion(t *testing.T) {
+	equalAssert(isValidRegion("us-east-1"), true, t)
+	equalAssert(isValidRegion("eu-central"), true, t)
+	equalAssert(isValidRegion(""), false, t)
+	equalAssert(isValidRegion("us east"), false, t)
+	equalAssert(isValidRegion("us/east"), false, t)
+}
+
 func equalAssert(ok1, ok2 bool, t *testing.T) {
 	if ok1 != ok2 {
 		t.Errorf("Expected %t, got %t", ok2, ok1)
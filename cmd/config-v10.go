@@ -44,14 +44,23 @@ type aliasConfigV10 struct {
 	SessionToken string `json:"sessionToken,omitempty"`
 	API          string `json:"api"`
 	Path         string `json:"path"`
+	Region       string `json:"region,omitempty"`
 	License      string `json:"license,omitempty"`
 	APIKey       string `json:"apiKey,omitempty"`
+
+	// Defaults holds flag values applied to the commands
+	// targeting this alias, unless passed explicitly.
+	Defaults map[string]string `json:"defaults,omitempty"`
 }
 
 // configV10 config version.
 type configV10 struct {
 	Version string                    `json:"version"`
 	Aliases map[string]aliasConfigV10 `json:"aliases"`
+
+	// LsColors maps file extensions to the color `ls` prints them
+	// with, MC_LS_COLORS takes precedence over it.
+	LsColors map[string]string `json:"lsColors,omitempty"`
 }
 
 // newConfigV10 - new config version.
@@ -113,6 +122,11 @@ func loadConfigV10() (*configV10, *probe.Error) {
 	cfgMutex.RLock()
 	defer cfgMutex.RUnlock()
 
+	return readConfigV10()
+}
+
+// readConfigV10 - loads a new config, the caller holds cfgMutex.
+func readConfigV10() (*configV10, *probe.Error) {
 	// If already cached, return the cached value.
 	if cacheCfgV10 != nil {
 		return cacheCfgV10, nil
@@ -148,6 +162,11 @@ func saveConfigV10(cfgV10 *configV10) *probe.Error {
 	cfgMutex.Lock()
 	defer cfgMutex.Unlock()
 
+	return writeConfigV10(cfgV10)
+}
+
+// writeConfigV10 - saves an updated config, the caller holds cfgMutex.
+func writeConfigV10(cfgV10 *configV10) *probe.Error {
 	qs, e := quick.NewConfig(cfgV10, nil)
 	if e != nil {
 		return probe.NewError(e)
@@ -162,3 +181,20 @@ func saveConfigV10(cfgV10 *configV10) *probe.Error {
 	}
 	return nil
 }
+
+// updateConfigV10 - loads the config, applies update and saves it when
+// update returns true, holding cfgMutex all along so that no other
+// change is lost in between.
+func updateConfigV10(update func(*configV10) bool) *probe.Error {
+	cfgMutex.Lock()
+	defer cfgMutex.Unlock()
+
+	cfgV10, err := readConfigV10()
+	if err != nil {
+		return err.Trace(mustGetMcConfigPath())
+	}
+	if !update(cfgV10) {
+		return nil
+	}
+	return writeConfigV10(cfgV10)
+}
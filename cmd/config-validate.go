@@ -48,6 +48,12 @@ func validateConfigFile(config *configV10) (bool, []string) {
 			errors = append(errors, aliasErrors...)
 		}
 	}
+	for ext, name := range config.LsColors {
+		if _, _, ok := parseLsColor(ext, name); !ok {
+			validationSuccessful = false
+			errors = append(errors, fmt.Sprintf("Invalid lsColors entry `%s=%s`, expected a file extension and a color name.", ext, name))
+		}
+	}
 	return validationSuccessful, errors
 }
 
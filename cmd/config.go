@@ -150,6 +150,23 @@ func saveMcConfig(config *configV10) *probe.Error {
 	return nil
 }
 
+// updateMcConfig - applies update to the configuration and saves the
+// configuration file when update returns true, see updateConfigV10.
+func updateMcConfig(update func(*configV10) bool) *probe.Error {
+	err := createMcConfigDir()
+	if err != nil {
+		return err.Trace(mustGetMcConfigDir())
+	}
+
+	if err := updateConfigV10(update); err != nil {
+		return err.Trace(mustGetMcConfigPath())
+	}
+
+	// Refresh the config cache.
+	loadMcConfig = loadMcConfigFactory()
+	return nil
+}
+
 // isMcConfigExists returns err if config doesn't exist.
 func isMcConfigExists() bool {
 	configFile, err := getMcConfigPath()
@@ -261,6 +278,8 @@ func parseEnvURLStr(envURL string) (*url.URL, string, string, string, *probe.Err
 const (
 	mcEnvHostPrefix = "MC_HOST_"
 	mcEnvConfigFile = "MC_CONFIG_ENV_FILE"
+	mcEnvReadOnly   = "MC_READ_ONLY"
+	mcEnvLsColors   = "MC_LS_COLORS"
 )
 
 var aliasToConfigMap = make(map[string]*aliasConfigV10)
@@ -17,7 +17,16 @@
 
 package cmd
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"flag"
+	"reflect"
+	"testing"
+
+	"github.com/minio/cli"
+	"github.com/minio/minio-go/v7"
+)
 
 // Tests valid host URL functionality.
 func TestParseEnvURLStr(t *testing.T) {
@@ -142,3 +151,176 @@ func TestParseEnvURLStrInvalid(t *testing.T) {
 		t.Fatalf("Expected failure")
 	}
 }
+
+func TestParseAliasDefaults(t *testing.T) {
+	testCases := []struct {
+		values   []string
+		expected string
+		success  bool
+	}{
+		{[]string{"insecure=true", "--region=us-west-2"}, "insecure=true, region=us-west-2", true},
+		{[]string{"attr=key=value"}, "attr=key=value", true},
+		{[]string{"insecure"}, "", false},
+		{[]string{"In secure=true"}, "", false},
+		{[]string{"config-dir=/tmp"}, "", false},
+	}
+	for i, testCase := range testCases {
+		defaults, err := parseAliasDefaults(testCase.values)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %t, got error %v", i+1, testCase.success, err)
+		}
+		if got := formatAliasDefaults(defaults); testCase.success && got != testCase.expected {
+			t.Fatalf("Test %d: expected %q, got %q", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestApplyAliasDefaults(t *testing.T) {
+	withTempMcConfig(t)
+
+	defer func(src, dst *aliasConfigV10) {
+		aliasToConfigMap["defsrc"], aliasToConfigMap["defdst"] = src, dst
+	}(aliasToConfigMap["defsrc"], aliasToConfigMap["defdst"])
+	aliasToConfigMap["defsrc"] = &aliasConfigV10{URL: "https://src.example.com", Defaults: map[string]string{"storage-class": "GLACIER"}}
+	aliasToConfigMap["defdst"] = &aliasConfigV10{URL: "https://dst.example.com", Defaults: map[string]string{"attr": "team=ops", "unknown-flag": "x"}}
+
+	newContext := func(args ...string) *cli.Context {
+		cmd := cli.Command{Name: "cp", Flags: []cli.Flag{
+			cli.StringFlag{Name: "storage-class, sc"},
+			cli.StringFlag{Name: "attr"},
+		}}
+		set := flag.NewFlagSet("cp", flag.ContinueOnError)
+		for _, f := range cmd.Flags {
+			f.Apply(set)
+		}
+		if e := set.Parse(args); e != nil {
+			t.Fatal(e)
+		}
+		ctx := cli.NewContext(nil, set, nil)
+		ctx.Command = cmd
+		return ctx
+	}
+
+	testCases := []struct {
+		args         []string
+		storageClass string
+		attr         string
+	}{
+		// The defaults of the target alias apply, not those of the source.
+		{[]string{"defsrc/bucket/object", "defdst/bucket/"}, "", "team=ops"},
+		{[]string{"defdst/bucket/object", "/tmp/object"}, "", ""},
+		{[]string{"defdst/bucket/object", "defsrc/bucket/"}, "GLACIER", ""},
+		// An explicit flag wins over the default.
+		{[]string{"--attr", "team=dev", "defsrc/bucket/object", "defdst/bucket/"}, "", "team=dev"},
+	}
+	for i, testCase := range testCases {
+		ctx := newContext(testCase.args...)
+		applyAliasDefaults(ctx)
+		if got := ctx.String("storage-class"); got != testCase.storageClass {
+			t.Errorf("Test %d: expected storage class %q, got %q", i+1, testCase.storageClass, got)
+		}
+		if got := ctx.String("attr"); got != testCase.attr {
+			t.Errorf("Test %d: expected attr %q, got %q", i+1, testCase.attr, got)
+		}
+	}
+}
+
+func TestApplyAliasDefaultsFalse(t *testing.T) {
+	withTempMcConfig(t)
+
+	defer func(cfg *aliasConfigV10) { aliasToConfigMap["deffalse"] = cfg }(aliasToConfigMap["deffalse"])
+	aliasToConfigMap["deffalse"] = &aliasConfigV10{URL: "https://false.example.com", Defaults: map[string]string{
+		"insecure": "false", "json": "false", "quiet": "false", "debug": "false",
+	}}
+
+	defer func(insecure, jsonFlag, jsonLine, quiet, quietWarnings, debug, noColor bool) {
+		globalInsecure, globalJSON, globalJSONLine, globalQuiet, globalQuietWarnings, globalDebug, globalNoColor = insecure, jsonFlag, jsonLine, quiet, quietWarnings, debug, noColor
+	}(globalInsecure, globalJSON, globalJSONLine, globalQuiet, globalQuietWarnings, globalDebug, globalNoColor)
+	globalInsecure, globalJSON, globalQuiet, globalDebug = false, false, false, false
+
+	cmd := cli.Command{Name: "ls", Flags: globalFlags}
+	set := flag.NewFlagSet("ls", flag.ContinueOnError)
+	for _, f := range cmd.Flags {
+		f.Apply(set)
+	}
+	if e := set.Parse([]string{"deffalse/bucket/"}); e != nil {
+		t.Fatal(e)
+	}
+	ctx := cli.NewContext(nil, set, nil)
+	ctx.Command = cmd
+	if e := setGlobalsFromContext(ctx); e != nil {
+		t.Fatal(e)
+	}
+
+	// A default of false is applied but does not turn the flags on.
+	if !ctx.IsSet("insecure") {
+		t.Fatal("expected the default of insecure to be applied")
+	}
+	if globalInsecure || globalJSON || globalQuiet || globalDebug {
+		t.Fatalf("expected the false defaults to leave the flags off, got insecure=%t json=%t quiet=%t debug=%t",
+			globalInsecure, globalJSON, globalQuiet, globalDebug)
+	}
+}
+
+func TestAliasTestRequestStage(t *testing.T) {
+	testCases := []struct {
+		err   error
+		stage string
+	}{
+		{minio.ErrorResponse{Code: "InvalidAccessKeyId"}, aliasTestStageAuth},
+		{minio.ErrorResponse{Code: "SignatureDoesNotMatch"}, aliasTestStageAuth},
+		{minio.ErrorResponse{Code: "InternalError"}, aliasTestStageAPI},
+		{errors.New("XML syntax error"), aliasTestStageAPI},
+	}
+	for i, testCase := range testCases {
+		if stage := aliasTestRequestStage(testCase.err); stage != testCase.stage {
+			t.Fatalf("Test %d: expected stage %s, got %s", i+1, testCase.stage, stage)
+		}
+	}
+
+	if !isTimeout(context.DeadlineExceeded) || isTimeout(errors.New("connection refused")) {
+		t.Fatal("unexpected timeout classification")
+	}
+}
+
+func TestImportHosts(t *testing.T) {
+	aliases := map[string]aliasConfigV10{
+		"same":     {URL: "http://same:9000", AccessKey: "minio", SecretKey: "minio123", API: "S3v4", Path: "auto"},
+		"differs":  {URL: "http://differs:9000", AccessKey: "minio", SecretKey: "minio123", API: "S3v4", Path: "auto"},
+		"redacted": {URL: "http://redacted:9000", AccessKey: "minio", SecretKey: "minio123", API: "S3v4", Path: "auto"},
+	}
+	exported := exportHosts(aliases, true)
+	if exported.Aliases["same"].SecretKey != "" {
+		t.Fatalf("expected the secret key to be redacted")
+	}
+
+	imported := map[string]aliasConfigV10{
+		"same":     {URL: "http://same:9000/", AccessKey: "minio", SecretKey: "minio123"},
+		"differs":  {URL: "http://differs:9000", AccessKey: "minio", SecretKey: "other1234", API: "S3v4", Path: "auto"},
+		"redacted": exported.Aliases["redacted"],
+		"new":      {URL: "https://new", AccessKey: "minio", SecretKey: "minio123", API: "S3v2", Path: "on"},
+		"unknown":  {URL: "https://unknown", AccessKey: "minio", API: "S3v4", Path: "auto"},
+		"bad":      {URL: "ftp://bad", AccessKey: "minio", SecretKey: "minio123"},
+		"1bad":     {URL: "http://bad", AccessKey: "minio", SecretKey: "minio123"},
+	}
+	expected := []hostImportMessage{
+		{Alias: "1bad", Action: hostImportSkipped, Reason: "invalid alias"},
+		{Alias: "bad", Action: hostImportSkipped, Reason: "invalid URL `ftp://bad`"},
+		{Alias: "differs", Action: hostImportSkipped, Reason: "already exists with another configuration, use --overwrite to replace it"},
+		{Alias: "new", Action: hostImportAdded},
+		{Alias: "redacted", Action: hostImportSkipped, Reason: "unchanged"},
+		{Alias: "same", Action: hostImportSkipped, Reason: "unchanged"},
+		{Alias: "unknown", Action: hostImportSkipped, Reason: "secret key redacted"},
+	}
+	if got := importHosts(aliases, imported, false); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, got)
+	}
+	if aliases["differs"].SecretKey != "minio123" || aliases["new"].URL != "https://new" {
+		t.Fatalf("unexpected aliases after import %+v", aliases)
+	}
+
+	got := importHosts(aliases, map[string]aliasConfigV10{"differs": imported["differs"]}, true)
+	if len(got) != 1 || got[0].Action != hostImportUpdated || aliases["differs"].SecretKey != "other1234" {
+		t.Fatalf("expected `differs` to be updated, got %+v", got)
+	}
+}
@@ -0,0 +1,521 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// archiveWriter adds files to an archive.
+type archiveWriter interface {
+	add(name string, size int64, modTime time.Time, r io.Reader) error
+	Close() error
+}
+
+// tarArchive writes a tar archive, gzip compressed when gz is set.
+type tarArchive struct {
+	tw *tar.Writer
+	gz *gzip.Writer
+}
+
+func (t *tarArchive) add(name string, size int64, modTime time.Time, r io.Reader) error {
+	if e := t.tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     size,
+		Mode:     0o644,
+		ModTime:  modTime,
+	}); e != nil {
+		return e
+	}
+	_, e := io.CopyN(t.tw, r, size)
+	return e
+}
+
+func (t *tarArchive) Close() error {
+	if e := t.tw.Close(); e != nil {
+		return e
+	}
+	if t.gz != nil {
+		return t.gz.Close()
+	}
+	return nil
+}
+
+// zipArchive writes a zip archive.
+type zipArchive struct {
+	zw *zip.Writer
+}
+
+func (z *zipArchive) add(name string, size int64, modTime time.Time, r io.Reader) error {
+	w, e := z.zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: modTime,
+	})
+	if e != nil {
+		return e
+	}
+	_, e = io.CopyN(w, r, size)
+	return e
+}
+
+func (z *zipArchive) Close() error {
+	return z.zw.Close()
+}
+
+// archiveFormat returns the format of an archive from its name,
+// one of "tar", "tar.gz" or "zip".
+func archiveFormat(name string) (string, *probe.Error) {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".tar"):
+		return "tar", nil
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return "tar.gz", nil
+	case strings.HasSuffix(lower, ".zip"):
+		return "zip", nil
+	}
+	return "", probe.NewError(fmt.Errorf("unsupported archive `%s`, the name should end with .tar, .tar.gz, .tgz or .zip", name))
+}
+
+// newArchiveWriter returns an archiveWriter of format writing to w.
+func newArchiveWriter(w io.Writer, format string) archiveWriter {
+	switch format {
+	case "zip":
+		return &zipArchive{zw: zip.NewWriter(w)}
+	case "tar.gz":
+		gz := gzip.NewWriter(w)
+		return &tarArchive{tw: tar.NewWriter(gz), gz: gz}
+	}
+	return &tarArchive{tw: tar.NewWriter(w)}
+}
+
+// archiveNames tracks the names added to an archive, to refuse the ones
+// which would overwrite a file or turn a file into a folder on extraction.
+type archiveNames struct {
+	files map[string]bool
+	dirs  map[string]bool
+}
+
+func newArchiveNames() *archiveNames {
+	return &archiveNames{files: make(map[string]bool), dirs: make(map[string]bool)}
+}
+
+// add records name, it returns false when name collides with a previous one.
+func (a *archiveNames) add(name string) bool {
+	if a.files[name] || a.dirs[name] {
+		return false
+	}
+	for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+		if a.files[dir] {
+			return false
+		}
+	}
+	a.files[name] = true
+	for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+		a.dirs[dir] = true
+	}
+	return true
+}
+
+// archiveEntryName returns the name of an object in the archive, its path
+// relative to the folder of the copy source, as a recursive copy would.
+func archiveEntryName(sourceURL ClientURL, contentURL ClientURL) string {
+	name := filepath.ToSlash(contentURL.Path)
+	if i := strings.LastIndex(sourceURL.Path, string(sourceURL.Separator)); i > 1 {
+		name = strings.TrimPrefix(name, filepath.ToSlash(sourceURL.Path[:i]))
+	}
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
+
+// cpArchiveMessage container for an object added to an archive.
+type cpArchiveMessage struct {
+	Status  string `json:"status"`
+	Source  string `json:"source"`
+	Archive string `json:"archive"`
+	Name    string `json:"name"`
+	Size    int64  `json:"size"`
+}
+
+func (c cpArchiveMessage) String() string {
+	return console.Colorize("Copy", fmt.Sprintf("`%s` -> `%s:%s`", c.Source, c.Archive, c.Name))
+}
+
+func (c cpArchiveMessage) JSON() string {
+	c.Status = "success"
+	msgBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// cpArchiveSummaryMessage container for the counts of an archive copy.
+type cpArchiveSummaryMessage struct {
+	Status  string `json:"status"`
+	Archive string `json:"archive"`
+	Objects int64  `json:"objects"`
+	Size    int64  `json:"size"`
+	Failed  int64  `json:"failed"`
+}
+
+func (c cpArchiveSummaryMessage) String() string {
+	return console.Colorize("Summarize", fmt.Sprintf("Archived %d object(s), %s, into `%s`, %d failed.",
+		c.Objects, humanize.IBytes(uint64(c.Size)), c.Archive, c.Failed))
+}
+
+func (c cpArchiveSummaryMessage) JSON() string {
+	c.Status = "success"
+	msgBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// checkCopyArchiveSyntax validates the arguments of cp --archive and
+// returns the path and format of the archive.
+func checkCopyArchiveSyntax(cliCtx *cli.Context) (string, string) {
+	args := cliCtx.Args()
+	if len(args) < 2 {
+		cli.ShowCommandHelpAndExit(cliCtx, "cp", 1) // last argument is exit code.
+	}
+	if !cliCtx.Bool("recursive") {
+		fatalIf(errInvalidArgument().Trace(args...), "--archive requires --recursive.")
+	}
+	for _, flag := range []string{"zip", "version-id", "continue", "metadata-only", "from-stdin", "extract"} {
+		if cliCtx.IsSet(flag) {
+			fatalIf(errInvalidArgument().Trace(args...), "--archive cannot be used with --"+flag+".")
+		}
+	}
+
+	archive := cliCtx.String("archive")
+	format, err := archiveFormat(archive)
+	fatalIf(err.Trace(archive), "Invalid archive.")
+
+	target := args[len(args)-1]
+	if _, _, hostCfg := mustExpandAlias(target); hostCfg != nil {
+		fatalIf(errInvalidArgument().Trace(target), "--archive requires a local target folder.")
+	}
+	if st, e := os.Stat(target); e != nil || !st.IsDir() {
+		fatalIf(errInvalidArgument().Trace(target), "Target `"+target+"` is not a folder.")
+	}
+	if !filepath.IsAbs(archive) {
+		archive = filepath.Join(target, archive)
+	}
+	if _, e := os.Stat(archive); e == nil {
+		fatalIf(errInvalidArgument().Trace(archive), "Archive `"+archive+"` already exists.")
+	}
+	return archive, format
+}
+
+// copyToArchive streams the objects under the copy sources into a single
+// archive in the target folder, as they are listed.
+func copyToArchive(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string][]prefixSSEPair) error {
+	archive, format := checkCopyArchiveSyntax(cliCtx)
+
+	console.SetColor("Copy", color.New(color.FgGreen, color.Bold))
+	console.SetColor("Summarize", color.New(color.Bold))
+
+	f, e := os.OpenFile(archive, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	fatalIf(probe.NewError(e).Trace(archive), "Unable to create archive.")
+	aw := newArchiveWriter(f, format)
+
+	olderThan := cliCtx.String("older-than")
+	newerThan := cliCtx.String("newer-than")
+	timeRef := parseRewindFlag(cliCtx.String("rewind"))
+
+	names := newArchiveNames()
+	summary := cpArchiveSummaryMessage{Archive: archive}
+	args := cliCtx.Args()
+	for _, source := range args[:len(args)-1] {
+		alias, _, _ := mustExpandAlias(source)
+		clnt, err := newClient(source)
+		if err != nil {
+			errorIf(err.Trace(source), "Unable to initialize source `"+source+"`.")
+			summary.Failed++
+			continue
+		}
+		for content := range clnt.List(ctx, ListOptions{Recursive: true, TimeRef: timeRef, ShowDir: DirNone}) {
+			if content.Err != nil {
+				errorIf(content.Err.Trace(source), "Unable to list `"+source+"`.")
+				summary.Failed++
+				continue
+			}
+			if !content.Type.IsRegular() {
+				continue
+			}
+			if olderThan != "" && isOlder(content.Time, olderThan) {
+				continue
+			}
+			if newerThan != "" && isNewer(content.Time, newerThan) {
+				continue
+			}
+
+			object := alias + content.URL.Path
+			if alias == "" {
+				object = content.URL.Path
+			}
+			name := archiveEntryName(clnt.GetURL(), content.URL)
+			if !names.add(name) {
+				errorIf(errInvalidArgument().Trace(object), "Skipping `"+object+"`, `"+name+"` collides with an object already in the archive.")
+				summary.Failed++
+				continue
+			}
+
+			sse := getSSE(object, encKeyDB[alias])
+			reader, _, err := getSourceStream(ctx, alias, content.URL.String(), content.VersionID, false, sse, false, false)
+			if err != nil {
+				errorIf(err.Trace(object), "Unable to read `"+object+"`.")
+				summary.Failed++
+				continue
+			}
+			e := aw.add(name, content.Size, content.Time, reader)
+			reader.Close()
+			if e != nil {
+				// A partially written entry leaves the archive unusable.
+				aw.Close()
+				f.Close()
+				os.Remove(archive)
+				fatalIf(probe.NewError(e).Trace(object), "Unable to add `"+object+"` to the archive.")
+			}
+			printMsg(cpArchiveMessage{Source: object, Archive: archive, Name: name, Size: content.Size})
+			summary.Objects++
+			summary.Size += content.Size
+		}
+	}
+
+	if e = aw.Close(); e == nil {
+		e = f.Close()
+	}
+	fatalIf(probe.NewError(e).Trace(archive), "Unable to write archive.")
+
+	printMsg(summary)
+	if summary.Failed > 0 {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
+
+// cpExtractMessage container for an archive entry uploaded as an object.
+type cpExtractMessage struct {
+	Status  string `json:"status"`
+	Archive string `json:"archive"`
+	Name    string `json:"name"`
+	Target  string `json:"target"`
+	Size    int64  `json:"size"`
+}
+
+func (c cpExtractMessage) String() string {
+	return console.Colorize("Copy", fmt.Sprintf("`%s:%s` -> `%s`", c.Archive, c.Name, c.Target))
+}
+
+func (c cpExtractMessage) JSON() string {
+	c.Status = "success"
+	msgBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// cpExtractSummaryMessage container for the counts of an archive extraction.
+type cpExtractSummaryMessage struct {
+	Status  string `json:"status"`
+	Archive string `json:"archive"`
+	Objects int64  `json:"objects"`
+	Size    int64  `json:"size"`
+	Skipped int64  `json:"skipped"`
+	Failed  int64  `json:"failed"`
+}
+
+func (c cpExtractSummaryMessage) String() string {
+	return console.Colorize("Summarize", fmt.Sprintf("Created %d object(s), %s, from `%s`, %d skipped, %d failed.",
+		c.Objects, humanize.IBytes(uint64(c.Size)), c.Archive, c.Skipped, c.Failed))
+}
+
+func (c cpExtractSummaryMessage) JSON() string {
+	c.Status = "success"
+	msgBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// archiveMemberName returns the object name of an archive member relative
+// to the copy target, or false when the member would escape the target.
+func archiveMemberName(name string) (string, bool) {
+	name = strings.TrimLeft(filepath.ToSlash(name), "/")
+	if name == "" {
+		return "", false
+	}
+	cleaned := path.Clean(name)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", false
+	}
+	return cleaned, true
+}
+
+// checkCopyExtractSyntax validates the arguments of cp --extract and
+// returns the format of the archive.
+func checkCopyExtractSyntax(cliCtx *cli.Context) string {
+	args := cliCtx.Args()
+	if len(args) != 2 {
+		cli.ShowCommandHelpAndExit(cliCtx, "cp", 1) // last argument is exit code.
+	}
+	for _, flag := range []string{"archive", "zip", "recursive", "continue", "metadata-only", "from-stdin"} {
+		if cliCtx.IsSet(flag) {
+			fatalIf(errInvalidArgument().Trace(args...), "--extract cannot be used with --"+flag+".")
+		}
+	}
+	format, err := archiveFormat(args[0])
+	fatalIf(err.Trace(args[0]), "Invalid archive.")
+	if _, _, hostCfg := mustExpandAlias(args[0]); format == "zip" && hostCfg != nil {
+		fatalIf(errInvalidArgument().Trace(args[0]), "Zip archives are read from the local file system only.")
+	}
+	return format
+}
+
+// archiveMember is a regular file read from an archive.
+type archiveMember struct {
+	name    string
+	size    int64
+	modTime time.Time
+	open    func() (io.ReadCloser, error)
+}
+
+// walkArchive calls fn on the regular files of an archive read from reader,
+// or from the local file archive for zip archives. It returns the number of
+// skipped entries, folders and other non-regular files.
+func walkArchive(reader io.Reader, archive, format string, fn func(archiveMember) error) (skipped int64, e error) {
+	if format == "zip" {
+		zr, e := zip.OpenReader(archive)
+		if e != nil {
+			return 0, e
+		}
+		defer zr.Close()
+		for _, f := range zr.File {
+			if !f.Mode().IsRegular() {
+				skipped++
+				continue
+			}
+			if e = fn(archiveMember{name: f.Name, size: int64(f.UncompressedSize64), modTime: f.Modified, open: f.Open}); e != nil {
+				return skipped, e
+			}
+		}
+		return skipped, nil
+	}
+
+	if format == "tar.gz" {
+		gz, e := gzip.NewReader(reader)
+		if e != nil {
+			return 0, e
+		}
+		defer gz.Close()
+		reader = gz
+	}
+	tr := tar.NewReader(reader)
+	for {
+		hdr, e := tr.Next()
+		if e == io.EOF {
+			return skipped, nil
+		}
+		if e != nil {
+			return skipped, e
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			skipped++
+			continue
+		}
+		member := archiveMember{
+			name:    hdr.Name,
+			size:    hdr.Size,
+			modTime: hdr.ModTime,
+			open:    func() (io.ReadCloser, error) { return io.NopCloser(tr), nil },
+		}
+		if e = fn(member); e != nil {
+			return skipped, e
+		}
+	}
+}
+
+// copyFromArchive uploads each regular file of a tar or zip archive as
+// an object under the copy target, streaming the archive entry by entry.
+func copyFromArchive(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string][]prefixSSEPair) error {
+	format := checkCopyExtractSyntax(cliCtx)
+	archive, target := cliCtx.Args().Get(0), cliCtx.Args().Get(1)
+	fatalIfReadOnlyURL("cp", target)
+
+	console.SetColor("Copy", color.New(color.FgGreen, color.Bold))
+	console.SetColor("Summarize", color.New(color.Bold))
+
+	var reader io.ReadCloser
+	if format != "zip" {
+		var err *probe.Error
+		reader, err = getSourceStreamFromURL(ctx, archive, "", encKeyDB, false)
+		fatalIf(err.Trace(archive), "Unable to read archive.")
+		defer reader.Close()
+	}
+
+	targetAlias, _, _ := mustExpandAlias(target)
+	summary := cpExtractSummaryMessage{Archive: archive}
+	skipped, e := walkArchive(reader, archive, format, func(m archiveMember) error {
+		name, ok := archiveMemberName(m.name)
+		if !ok {
+			errorIf(errInvalidArgument().Trace(m.name), "Skipping `"+m.name+"`, it is outside of the archive root.")
+			summary.Failed++
+			return nil
+		}
+		r, e := m.open()
+		if e != nil {
+			return e
+		}
+		defer r.Close()
+
+		object := urlJoinPath(target, name)
+		opts := PutOptions{sse: getSSE(object, encKeyDB[targetAlias])}
+		if _, err := putTargetStreamWithURL(object, r, m.size, opts); err != nil {
+			errorIf(err.Trace(object), "Unable to upload `"+m.name+"` to `"+object+"`.")
+			summary.Failed++
+			return nil
+		}
+		printMsg(cpExtractMessage{Archive: archive, Name: m.name, Target: object, Size: m.size})
+		summary.Objects++
+		summary.Size += m.size
+		return nil
+	})
+	summary.Skipped = skipped
+	fatalIf(probe.NewError(e).Trace(archive), "Unable to read archive.")
+
+	printMsg(summary)
+	if summary.Failed > 0 {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
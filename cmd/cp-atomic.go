@@ -0,0 +1,102 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// atomicStagingSuffix marks the temporary objects uploaded with --atomic.
+const atomicStagingSuffix = ".mc-atomic-"
+
+// atomicStagingURL returns the temporary object next to target which
+// --atomic uploads to. It stays in the bucket of the target, so that the
+// final copy over the target is a server side copy on the same endpoint.
+func atomicStagingURL(target ClientURL) ClientURL {
+	staging := target
+	sep := string(target.Separator)
+	i := strings.LastIndex(target.Path, sep)
+	staging.Path = target.Path[:i+1] + "." + target.Path[i+1:] + atomicStagingSuffix + uuid.New().String()
+	return staging
+}
+
+// putTargetAtomic is putTargetStream with --atomic: the object is uploaded
+// to a temporary object which, once the upload has completed, is copied
+// server side over the target and removed. Readers of the target thus see
+// either the previous object or the new one, never a partial upload.
+func putTargetAtomic(ctx context.Context, alias, urlStr, mode, until, legalHold string, reader io.Reader, size int64, progress io.Reader, opts PutOptions) (int64, *probe.Error) {
+	targetURL := newClientURL(urlStr)
+	stagingURL := atomicStagingURL(*targetURL)
+
+	// Object lock settings only apply to the final object,
+	// the temporary object could not be removed otherwise.
+	n, err := putTargetStream(ctx, alias, stagingURL.String(), "", "", "", reader, size, progress, opts)
+	if err != nil {
+		return n, err
+	}
+
+	stagingClnt, err := newClientFromAlias(alias, stagingURL.String())
+	if err != nil {
+		return n, err.Trace(alias, stagingURL.String())
+	}
+	staged, err := stagingClnt.Stat(ctx, StatOptions{sse: opts.sse})
+	if err != nil {
+		removeAtomicStaging(stagingClnt, stagingURL, "")
+		return n, err.Trace(stagingURL.String())
+	}
+
+	// Copy with the metadata of the upload, the storage class is
+	// only applied by a copy replacing the metadata.
+	metadata := make(map[string]string, len(opts.metadata))
+	for k, v := range opts.metadata {
+		metadata[k] = v
+	}
+	copyOpts := CopyOptions{
+		srcSSE:           opts.sse,
+		tgtSSE:           opts.sse,
+		metadata:         metadata,
+		disableMultipart: opts.disableMultipart,
+		storageClass:     opts.storageClass,
+	}
+	err = copySourceToTargetURL(ctx, alias, urlStr, stagingURL.Path, staged.VersionID, mode, until,
+		legalHold, staged.Size, nil, copyOpts)
+	removeAtomicStaging(stagingClnt, stagingURL, staged.VersionID)
+	if err != nil {
+		return n, err.Trace(stagingURL.String())
+	}
+	return n, nil
+}
+
+// removeAtomicStaging removes the temporary object of an --atomic upload,
+// by version so that no delete marker is left in versioned buckets. It is
+// removed even when the copy is canceled, hence the background context.
+func removeAtomicStaging(clnt Client, stagingURL ClientURL, versionID string) {
+	contentCh := make(chan *ClientContent, 1)
+	contentCh <- &ClientContent{URL: stagingURL, VersionID: versionID}
+	close(contentCh)
+	for result := range clnt.Remove(context.Background(), false, false, false, false, contentCh) {
+		if result.Err != nil {
+			errorIf(result.Err.Trace(stagingURL.String()), "Unable to remove the temporary object `"+stagingURL.String()+"` of an atomic copy.")
+		}
+	}
+}
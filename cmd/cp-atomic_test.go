@@ -0,0 +1,49 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAtomicStagingURL(t *testing.T) {
+	testCases := []struct {
+		target string
+		prefix string
+	}{
+		{"https://play.min.io/mybucket/report.csv", "/mybucket/.report.csv" + atomicStagingSuffix},
+		{"https://play.min.io/mybucket/reports/2022/report.csv", "/mybucket/reports/2022/.report.csv" + atomicStagingSuffix},
+	}
+	for i, testCase := range testCases {
+		target := newClientURL(testCase.target)
+		staging := atomicStagingURL(*target)
+		if staging.Host != target.Host || staging.Scheme != target.Scheme {
+			t.Errorf("Test %d: expecting the endpoint of %s, got %s", i+1, testCase.target, staging.String())
+		}
+		if !strings.HasPrefix(staging.Path, testCase.prefix) || len(staging.Path) == len(testCase.prefix) {
+			t.Errorf("Test %d: expecting a path starting with %s, got %s", i+1, testCase.prefix, staging.Path)
+		}
+		if other := atomicStagingURL(*target); other.Path == staging.Path {
+			t.Errorf("Test %d: expecting a new temporary object for each upload, got %s twice", i+1, staging.Path)
+		}
+		if target.Path == staging.Path {
+			t.Errorf("Test %d: expecting the target to be left unchanged", i+1)
+		}
+	}
+}
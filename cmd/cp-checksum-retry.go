@@ -0,0 +1,72 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// ChecksumMismatch - the md5sum of a downloaded object differs from its ETag.
+type ChecksumMismatch struct {
+	Expected string
+	Computed string
+}
+
+func (e ChecksumMismatch) Error() string {
+	return fmt.Sprintf("md5sum `%s` of the download does not match ETag `%s`.", e.Computed, e.Expected)
+}
+
+// isChecksumMismatch returns true if the copy failed with a ChecksumMismatch.
+func isChecksumMismatch(err *probe.Error) bool {
+	if err == nil {
+		return false
+	}
+	var mismatch ChecksumMismatch
+	return errors.As(err.ToGoError(), &mismatch)
+}
+
+// verifiesDownloadChecksum returns true if the copy is a download
+// whose content is checked against the ETag of the source object.
+func verifiesDownloadChecksum(urls URLs) bool {
+	return urls.ChecksumRetries > 0 &&
+		urls.SourceContent.URL.Type == objectStorage &&
+		urls.TargetContent.URL.Type == fileSystem
+}
+
+// checkDownloadChecksum compares the md5sum of the downloaded source
+// against its ETag. ETags of multipart uploads and of encrypted
+// objects are not md5sums of the content and are not compared.
+func checkDownloadChecksum(ctx context.Context, urls URLs, md5sum string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+	sourcePath := filepath.ToSlash(filepath.Join(urls.SourceAlias, urls.SourceContent.URL.Path))
+	etag := strings.ToLower(strings.Trim(urls.SourceContent.ETag, "\""))
+	if getSSE(sourcePath, encKeyDB[urls.SourceAlias]) != nil || !md5ETag.MatchString(etag) || etag == md5sum {
+		return nil
+	}
+	_, st, err := url2Stat(ctx, sourcePath, urls.SourceContent.VersionID, false, encKeyDB, time.Time{}, false)
+	if err == nil && encryptionType(st.Metadata) != "" {
+		return nil
+	}
+	return probe.NewError(ChecksumMismatch{Expected: etag, Computed: md5sum})
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newCorruptingHandler serves bucket/object, corrupting the content of
+// the first corrupted downloads.
+func newCorruptingHandler(data []byte, corrupted int32) (http.Handler, *int32) {
+	sum := md5.Sum(data)
+	etag := hex.EncodeToString(sum[:])
+	var downloads int32
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bucket/object" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", `"`+etag+`"`)
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		if r.Method == http.MethodHead {
+			return
+		}
+		body := data
+		if atomic.AddInt32(&downloads, 1) <= corrupted {
+			body = []byte(strings.ToUpper(string(data)))
+		}
+		w.Write(body)
+	}), &downloads
+}
+
+func TestCopyRetryOnChecksumMismatch(t *testing.T) {
+	withTempMcConfig(t)
+
+	data := []byte("some object content")
+	sum := md5.Sum(data)
+
+	testCases := []struct {
+		corrupted int32
+		retries   int
+		retried   int
+		mismatch  bool
+	}{
+		{0, 3, 0, false},
+		{2, 3, 2, false},
+		{3, 3, 3, false},
+		{4, 3, 3, true},
+		{1, 0, 0, false},
+	}
+	for i, testCase := range testCases {
+		handler, downloads := newCorruptingHandler(data, testCase.corrupted)
+		server := newS3StubServer(t, "flaky", handler)
+
+		target := filepath.Join(t.TempDir(), "object")
+		previous := []byte("previous content")
+		if e := os.WriteFile(target, previous, 0o644); e != nil {
+			t.Fatal(e)
+		}
+		urls := URLs{
+			SourceAlias: "flaky",
+			SourceContent: &ClientContent{
+				URL:  *newClientURL(server.URL + "/bucket/object"),
+				Size: int64(len(data)),
+				ETag: hex.EncodeToString(sum[:]),
+			},
+			TargetContent:   &ClientContent{URL: *newClientURL(target)},
+			ChecksumRetries: testCase.retries,
+		}
+		urls = doCopy(context.Background(), urls, newAccounter(urls.SourceContent.Size), nil, false, false, false, nil, nil)
+		if isChecksumMismatch(urls.Error) != testCase.mismatch {
+			t.Fatalf("Test %d: expected mismatch %v, got %v", i+1, testCase.mismatch, urls.Error)
+		}
+		if !testCase.mismatch && urls.Error != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, urls.Error)
+		}
+		if urls.ChecksumRetried != testCase.retried {
+			t.Fatalf("Test %d: expected %d retries, got %d", i+1, testCase.retried, urls.ChecksumRetried)
+		}
+		if expected := int32(testCase.retried + 1); atomic.LoadInt32(downloads) != expected {
+			t.Fatalf("Test %d: expected %d downloads, got %d", i+1, expected, *downloads)
+		}
+		if testCase.mismatch {
+			// A corrupted download never replaces the target.
+			if content, e := os.ReadFile(target); e != nil || string(content) != string(previous) {
+				t.Fatalf("Test %d: expected the previous content %q, got %q (%v)", i+1, previous, content, e)
+			}
+			if _, e := os.Stat(target + partSuffix); !os.IsNotExist(e) {
+				t.Fatalf("Test %d: expected no partial download left, got %v", i+1, e)
+			}
+			continue
+		}
+		if testCase.retries == 0 {
+			continue
+		}
+		if content, e := os.ReadFile(target); e != nil || string(content) != string(data) {
+			t.Fatalf("Test %d: expected the downloaded content %q, got %q (%v)", i+1, data, content, e)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/wildcard"
+)
+
+// Extensions and content types MinIO never compresses, whatever
+// its compression configuration.
+var (
+	compressExcludeExtensions   = []string{".gz", ".bz2", ".rar", ".zip", ".7z", ".xz", ".mp4", ".mkv", ".mov", ".jpg", ".png", ".gif"}
+	compressExcludeContentTypes = []string{"video/*", "audio/*", "application/zip", "application/x-gzip", "application/x-zip-compressed", "application/x-compress", "application/x-spoon"}
+)
+
+// compressConfig is the transparent compression configuration of a
+// MinIO server, which decides of the compression of each object.
+type compressConfig struct {
+	enabled         bool
+	allowEncryption bool
+	extensions      []string
+	mimeTypes       []string
+}
+
+func splitCompressList(value string) []string {
+	var list []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// parseCompressConfig parses the keys of the compression sub-system.
+func parseCompressConfig(kvs madmin.KVS) compressConfig {
+	enable, _ := kvs.Lookup("enable")
+	allowEncryption, _ := kvs.Lookup("allow_encryption")
+	extensions, _ := kvs.Lookup("extensions")
+	mimeTypes, _ := kvs.Lookup("mime_types")
+	return compressConfig{
+		enabled:         enable == "on",
+		allowEncryption: allowEncryption == "on",
+		extensions:      splitCompressList(extensions),
+		mimeTypes:       splitCompressList(mimeTypes),
+	}
+}
+
+func hasCompressPattern(patterns []string, contentType string) bool {
+	for _, pattern := range patterns {
+		if wildcard.MatchSimple(pattern, contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasCompressSuffix(suffixes []string, object string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(object, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// compresses returns true if the server compresses object, uploaded
+// with contentType and encrypted or not.
+func (c compressConfig) compresses(object, contentType string, encrypted bool) bool {
+	if !c.enabled || (encrypted && !c.allowEncryption) {
+		return false
+	}
+	if hasCompressSuffix(compressExcludeExtensions, object) || hasCompressPattern(compressExcludeContentTypes, contentType) {
+		return false
+	}
+	if len(c.extensions) == 0 && len(c.mimeTypes) == 0 {
+		return true
+	}
+	return hasCompressSuffix(c.extensions, object) || hasCompressPattern(c.mimeTypes, contentType)
+}
+
+// getCopyCompression returns the compression configuration of the
+// server of targetURL, warning if it does not compress objects. MinIO
+// takes no per object compression request, the configuration alone
+// decides, so --predict-compression only reports what it predicts.
+func getCopyCompression(targetURL string) *compressConfig {
+	alias, _ := url2Alias(targetURL)
+	var kvs madmin.KVS
+	client, err := newAdminClient(alias)
+	if err == nil {
+		var e error
+		if kvs, e = getSubSysKeyFromMinIOConfig(client, "compression"); e != nil {
+			err = probe.NewError(e)
+		}
+	}
+	if err != nil {
+		warningIf(err, "Unable to read the compression configuration of `"+alias+"`, the compression of the copies is not reported.")
+		return nil
+	}
+	cfg := parseCompressConfig(kvs)
+	if !cfg.enabled {
+		warning("Compression is disabled on `" + alias + "`, the copies are stored uncompressed. Enable it with `mc admin config set " + alias + " compression enable=on`.")
+	}
+	return &cfg
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestCompressConfig(t *testing.T) {
+	cfg := parseCompressConfig(madmin.KVS{
+		{Key: "enable", Value: "on"},
+		{Key: "allow_encryption", Value: "off"},
+		{Key: "extensions", Value: ".txt,.log"},
+		{Key: "mime_types", Value: "text/*, application/json"},
+	})
+	if !cfg.enabled || cfg.allowEncryption || len(cfg.extensions) != 2 || len(cfg.mimeTypes) != 2 {
+		t.Fatalf("unexpected configuration %+v", cfg)
+	}
+
+	testCases := []struct {
+		cfg         compressConfig
+		object      string
+		contentType string
+		encrypted   bool
+		compressed  bool
+	}{
+		{cfg, "logs/app.log", "application/octet-stream", false, true},
+		{cfg, "data/report", "application/json", false, true},
+		{cfg, "data/report.bin", "application/octet-stream", false, false},
+		{cfg, "logs/app.log", "application/octet-stream", true, false},
+		{cfg, "logs/app.log.gz", "text/plain", false, false},
+		{cfg, "videos/clip.txt", "video/mp4", false, false},
+		{compressConfig{enabled: true}, "data/report.bin", "application/octet-stream", false, true},
+		{compressConfig{enabled: true}, "photos/a.jpg", "image/jpeg", false, false},
+		{compressConfig{}, "logs/app.log", "text/plain", false, false},
+	}
+	for i, testCase := range testCases {
+		if compressed := testCase.cfg.compresses(testCase.object, testCase.contentType, testCase.encrypted); compressed != testCase.compressed {
+			t.Fatalf("Test %d: expected compressed %v for `%s`, got %v", i+1, testCase.compressed, testCase.object, compressed)
+		}
+	}
+}
+
+func TestCopyMessageCompressionPredicted(t *testing.T) {
+	expected, notExpected := true, false
+	if msg := (copyMessage{Source: "a", Target: "b", CompressionPredicted: &expected}).String(); !strings.Contains(msg, "(compression predicted)") {
+		t.Fatalf("expected the compression to be reported as a prediction, got %s", msg)
+	}
+	if msg := (copyMessage{Source: "a", Target: "b", CompressionPredicted: &notExpected}).String(); !strings.Contains(msg, "(no compression predicted)") {
+		t.Fatalf("expected no compression to be reported as a prediction, got %s", msg)
+	}
+	if msg := (copyMessage{Source: "a", Target: "b"}).JSON(); strings.Contains(msg, "compression") {
+		t.Fatalf("expected no compression field without --predict-compression, got %s", msg)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// Structured message summarizing the objects which failed to copy
+// with --skip-errors.
+type copyErrorSummaryMessage struct {
+	Status   string `json:"status"`
+	Failed   int64  `json:"failed"`
+	Manifest string `json:"manifest,omitempty"`
+}
+
+// Colorized message for console printing.
+func (c copyErrorSummaryMessage) String() string {
+	msg := fmt.Sprintf("Failed to copy %d object(s)", c.Failed)
+	if c.Manifest != "" {
+		msg += fmt.Sprintf(", listed in `%s`", c.Manifest)
+	}
+	return console.Colorize("Summarize", msg+".")
+}
+
+// JSON'ified message for scripting.
+func (c copyErrorSummaryMessage) JSON() string {
+	c.Status = "success"
+	msgBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// copyErrorManifest counts the objects which failed to copy with
+// --skip-errors and records them to the --error-manifest file, one
+// `SOURCE<TAB>TARGET<TAB>REASON` line each. Such lines can be fed back
+// to cp --from-stdin to retry only the failed objects.
+type copyErrorManifest struct {
+	mu     sync.Mutex
+	path   string
+	w      io.WriteCloser
+	failed int64
+}
+
+func newCopyErrorManifest(path string) (*copyErrorManifest, *probe.Error) {
+	m := &copyErrorManifest{path: path}
+	if path == "" {
+		return m, nil
+	}
+	f, e := os.Create(path)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(path)
+	}
+	m.w = f
+	return m, nil
+}
+
+// copyManifestURL returns the alias qualified URL of a copy source or target.
+func copyManifestURL(alias string, content *ClientContent) string {
+	if content == nil {
+		return ""
+	}
+	return filepath.ToSlash(filepath.Join(alias, content.URL.Path))
+}
+
+// copyManifestFieldReplacer keeps the fields of a manifest line on one line.
+var copyManifestFieldReplacer = strings.NewReplacer("\t", " ", "\r", " ", "\n", " ")
+
+// record counts a failed copy and adds it to the manifest.
+func (m *copyErrorManifest) record(cpURLs URLs) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failed++
+	source := copyManifestURL(cpURLs.SourceAlias, cpURLs.SourceContent)
+	if m.w == nil || source == "" {
+		return
+	}
+	reason := "unknown error"
+	if cpURLs.Error != nil {
+		reason = cpURLs.Error.ToGoError().Error()
+	}
+	line := strings.Join([]string{
+		copyManifestFieldReplacer.Replace(source),
+		copyManifestFieldReplacer.Replace(copyManifestURL(cpURLs.TargetAlias, cpURLs.TargetContent)),
+		copyManifestFieldReplacer.Replace(reason),
+	}, "\t")
+	_, e := fmt.Fprintln(m.w, line)
+	errorIf(probe.NewError(e).Trace(m.path), "Unable to write to the error manifest.")
+}
+
+// close closes the manifest and returns the summary of the failures.
+func (m *copyErrorManifest) close() copyErrorSummaryMessage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.w != nil {
+		e := m.w.Close()
+		errorIf(probe.NewError(e).Trace(m.path), "Unable to close the error manifest.")
+		m.w = nil
+	}
+	return copyErrorSummaryMessage{Failed: m.failed, Manifest: m.path}
+}
+
+// parseCopyManifestLine splits a line read by --from-stdin into its
+// source and, for lines of an error manifest, its target.
+func parseCopyManifestLine(line string) (source, target string) {
+	fields := strings.Split(line, "\t")
+	source = strings.TrimSpace(fields[0])
+	if len(fields) > 1 {
+		target = strings.TrimSpace(fields[1])
+	}
+	return source, target
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestCopyErrorManifest(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "failures.txt")
+	m, err := newCopyErrorManifest(manifestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.record(URLs{
+		SourceAlias:   "src",
+		SourceContent: &ClientContent{URL: *newClientURL("/bucket/dir/a.txt")},
+		TargetAlias:   "dst",
+		TargetContent: &ClientContent{URL: *newClientURL("/bucket/dir/a.txt")},
+		Error:         probe.NewError(errors.New("access denied\nretry later")),
+	})
+	// Unreadable sources without a known object are only counted.
+	m.record(URLs{Error: probe.NewError(errors.New("listing failed"))})
+
+	summary := m.close()
+	if summary.Failed != 2 || summary.Manifest != manifestPath {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+	data, e := ioutil.ReadFile(manifestPath)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if got, want := string(data), "src/bucket/dir/a.txt\tdst/bucket/dir/a.txt\taccess denied retry later\n"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	source, target := parseCopyManifestLine(strings.TrimSuffix(string(data), "\n"))
+	if source != "src/bucket/dir/a.txt" || target != "dst/bucket/dir/a.txt" {
+		t.Fatalf("unexpected source `%s` and target `%s`", source, target)
+	}
+	if source, target = parseCopyManifestLine("  play/bucket/b.txt "); source != "play/bucket/b.txt" || target != "" {
+		t.Fatalf("unexpected source `%s` and target `%s`", source, target)
+	}
+}
+
+func TestPrepareCopyURLsFromManifest(t *testing.T) {
+	withTempMcConfig(t)
+
+	dir := t.TempDir()
+	source := filepath.Join(dir, "src", "nested", "a.txt")
+	if e := os.MkdirAll(filepath.Dir(source), 0o755); e != nil {
+		t.Fatal(e)
+	}
+	if e := ioutil.WriteFile(source, []byte("data"), 0o644); e != nil {
+		t.Fatal(e)
+	}
+	target := filepath.Join(dir, "dst", "nested", "a.txt")
+
+	// A manifest line keeps the target of the failed object instead of
+	// copying it flat under the target argument.
+	r := strings.NewReader(source + "\t" + target + "\tconnection reset\n")
+	var urls []URLs
+	for u := range prepareCopyURLsFromReader(context.Background(), r, filepath.Join(dir, "other")+"/", false, time.Time{}, nil) {
+		urls = append(urls, u)
+	}
+	if len(urls) != 1 || urls[0].Error != nil {
+		t.Fatalf("unexpected copy URLs %+v", urls)
+	}
+	if got := urls[0].TargetContent.URL.Path; got != target {
+		t.Fatalf("expected target `%s`, got `%s`", target, got)
+	}
+}
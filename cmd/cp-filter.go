@@ -0,0 +1,107 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// copyFilter selects the objects copied by their name relative to the
+// copy target, with the patterns of --include-from and --exclude-from.
+type copyFilter struct {
+	include []string
+	exclude []string
+}
+
+// readFilterPatterns reads the glob patterns of a file, one per line.
+// Blank lines and lines starting with '#' are ignored and a pattern
+// ending with '/' matches all the objects below that folder.
+func readFilterPatterns(file string) ([]string, *probe.Error) {
+	f, e := os.Open(file)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(file)
+	}
+	defer f.Close()
+
+	patterns := []string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		pattern := strings.TrimSpace(scanner.Text())
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+		if strings.HasSuffix(pattern, "/") {
+			pattern += "*"
+		}
+		patterns = append(patterns, pattern)
+	}
+	if e = scanner.Err(); e != nil {
+		return nil, probe.NewError(e).Trace(file)
+	}
+	return patterns, nil
+}
+
+// newCopyFilter reads the patterns of includeFrom and excludeFrom, it
+// returns nil when neither is set.
+func newCopyFilter(includeFrom, excludeFrom string) (*copyFilter, *probe.Error) {
+	if includeFrom == "" && excludeFrom == "" {
+		return nil, nil
+	}
+	f := &copyFilter{}
+	var err *probe.Error
+	if includeFrom != "" {
+		if f.include, err = readFilterPatterns(includeFrom); err != nil {
+			return nil, err.Trace(includeFrom)
+		}
+	}
+	if excludeFrom != "" {
+		if f.exclude, err = readFilterPatterns(excludeFrom); err != nil {
+			return nil, err.Trace(excludeFrom)
+		}
+	}
+	return f, nil
+}
+
+// match returns true if name matches one of the include patterns, if
+// any, and none of the exclude patterns.
+func (f *copyFilter) match(name string) bool {
+	if f == nil {
+		return true
+	}
+	if f.include != nil && !matchExcludeOptions(f.include, name) {
+		return false
+	}
+	return !matchExcludeOptions(f.exclude, name)
+}
+
+// copyFilterName returns the name a copy is filtered by, the path of
+// its target relative to targetRoot or the source name for a copy to a file.
+func copyFilterName(targetRoot string, cpURLs URLs) string {
+	targetURL := cpURLs.TargetContent.URL
+	separator := string(targetURL.Separator)
+	targetRoot = strings.TrimSuffix(newClientURL(targetRoot).Path, separator) + separator
+	if strings.HasPrefix(targetURL.Path, targetRoot) {
+		return filepath.ToSlash(strings.TrimPrefix(targetURL.Path, targetRoot))
+	}
+	return filepath.ToSlash(filepath.Base(cpURLs.SourceContent.URL.Path))
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestReadFilterPatterns(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "patterns")
+	content := "# build outputs\n*.o\n\n  tmp/  \n#*.go\n*.log\n"
+	if e := os.WriteFile(file, []byte(content), 0o600); e != nil {
+		t.Fatal(e)
+	}
+	patterns, err := readFilterPatterns(file)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"*.o", "tmp/*", "*.log"}; !reflect.DeepEqual(patterns, expected) {
+		t.Fatalf("expected %v, got %v", expected, patterns)
+	}
+	if _, err = readFilterPatterns(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Fatal("expected an error for a missing file")
+	}
+}
+
+func TestCopyFilterMatch(t *testing.T) {
+	testCases := []struct {
+		filter  *copyFilter
+		name    string
+		matched bool
+	}{
+		{nil, "a.o", true},
+		{&copyFilter{exclude: []string{"*.o"}}, "src/a.o", false},
+		{&copyFilter{exclude: []string{"*.o"}}, "src/a.c", true},
+		{&copyFilter{include: []string{"src/*"}}, "src/a.c", true},
+		{&copyFilter{include: []string{"src/*"}}, "doc/a.md", false},
+		{&copyFilter{include: []string{"src/*"}, exclude: []string{"*.o"}}, "src/a.o", false},
+		{&copyFilter{include: []string{}}, "a.c", false},
+	}
+	for i, testCase := range testCases {
+		if matched := testCase.filter.match(testCase.name); matched != testCase.matched {
+			t.Fatalf("Test %d: expected %v for %s, got %v", i+1, testCase.matched, testCase.name, matched)
+		}
+	}
+}
+
+func TestPrepareCopyURLsFilter(t *testing.T) {
+	withTempMcConfig(t)
+
+	source, target := t.TempDir(), t.TempDir()
+	for _, name := range []string{"a.c", "a.o", "tmp/b.c", "src/c.c", "src/c.o"} {
+		path := filepath.Join(source, name)
+		if e := os.MkdirAll(filepath.Dir(path), 0o700); e != nil {
+			t.Fatal(e)
+		}
+		if e := os.WriteFile(path, []byte(name), 0o600); e != nil {
+			t.Fatal(e)
+		}
+	}
+
+	opts := prepareCopyURLsOpts{
+		sourceURLs:  []string{source + string(os.PathSeparator)},
+		targetURL:   target,
+		isRecursive: true,
+		filter:      &copyFilter{exclude: []string{"*.o", "tmp/*"}},
+	}
+	var copied []string
+	for cpURLs := range prepareCopyURLs(context.Background(), opts) {
+		if cpURLs.Error != nil {
+			t.Fatalf("unexpected error: %v", cpURLs.Error)
+		}
+		copied = append(copied, copyFilterName(target, cpURLs))
+	}
+	sort.Strings(copied)
+	if expected := []string{"a.c", "src/c.c"}; !reflect.DeepEqual(copied, expected) {
+		t.Fatalf("expected %v, got %v", expected, copied)
+	}
+}
@@ -25,13 +25,18 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/fatih/color"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
 	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
 	"github.com/minio/pkg/console"
 )
 
@@ -68,24 +73,85 @@ var (
 		},
 		cli.StringFlag{
 			Name:  "attr",
-			Usage: "add custom metadata for the object",
+			Usage: "add custom metadata for the object, may contain {date:LAYOUT} and {env:VAR} tokens",
+		},
+		cli.StringFlag{
+			Name:  "metadata-directive",
+			Usage: "COPY or REPLACE the metadata of the source object(s), REPLACE sets only the metadata of --attr",
+		},
+		cli.BoolFlag{
+			Name:  "predict-compression",
+			Usage: "report whether the compression configuration of MinIO predicts it compresses the object(s), no compression is requested",
 		},
 		cli.BoolFlag{
 			Name:  "continue, c",
 			Usage: "create or resume copy session",
 		},
+		cli.StringFlag{
+			Name:  "continue-token",
+			Usage: "resume an interrupted recursive copy from the token it printed, using the same config folder",
+		},
 		cli.BoolFlag{
 			Name:  "preserve, a",
 			Usage: "preserve filesystem attributes (mode, ownership, timestamps)",
 		},
+		cli.BoolFlag{
+			Name:  "preserve-mtime",
+			Usage: "set modification time of downloaded file(s) to the object's last modified time",
+		},
 		cli.BoolFlag{
 			Name:  "disable-multipart",
 			Usage: "disable multipart upload feature",
 		},
+		cli.BoolFlag{
+			Name:  "auto-part-size",
+			Usage: "increase the part size set by MC_UPLOAD_MULTIPART_SIZE for objects which would take more than 10000 parts, instead of failing",
+		},
+		cli.IntFlag{
+			Name:  "concurrent-multipart",
+			Usage: "upload N parts of each object in parallel, up to 64, including objects streamed from another alias, using up to N parts of memory",
+		},
 		cli.BoolFlag{
 			Name:  "md5",
 			Usage: "force all upload(s) to calculate md5sum checksum",
 		},
+		cli.BoolFlag{
+			Name:  "metadata-only",
+			Usage: "update the metadata of objects in place, SOURCE and TARGET must be the same",
+		},
+		cli.StringFlag{
+			Name:  "cache-control",
+			Usage: "set the Cache-Control header of objects, with --metadata-only",
+		},
+		cli.StringFlag{
+			Name:  "expires",
+			Usage: "set the Expires header of objects to an HTTP date, with --metadata-only",
+		},
+		cli.BoolFlag{
+			Name:  "verify",
+			Usage: "read back the metadata of objects and report the values the server did not apply, with --metadata-only",
+		},
+		cli.IntFlag{
+			Name:  "workers",
+			Value: 4,
+			Usage: "number of objects to update concurrently, with --metadata-only or --restore",
+		},
+		cli.BoolFlag{
+			Name:  "force",
+			Usage: "confirm updating the metadata of every object under a prefix with --metadata-only --recursive, or restoring them with --restore",
+		},
+		cli.BoolFlag{
+			Name:  "restore",
+			Usage: "restore the objects under a prefix to their versions as of --rewind and remove the objects which did not exist then, SOURCE and TARGET must be the same",
+		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "only show the objects --restore would restore or remove, requires --restore",
+		},
+		cli.BoolFlag{
+			Name:  "content-md5",
+			Usage: "send the Content-MD5 header on single PUT uploads and report it, requires --disable-multipart",
+		},
 		cli.StringFlag{
 			Name:  "tags",
 			Usage: "apply one or more tags to the uploaded objects",
@@ -106,6 +172,78 @@ var (
 			Name:  "zip",
 			Usage: "Extract from remote zip file (MinIO server source only)",
 		},
+		cli.BoolFlag{
+			Name:  "show-rate",
+			Usage: "report the transfer rate of each object and the aggregate throughput",
+		},
+		cli.BoolFlag{
+			Name:  "progress-json",
+			Usage: "write the progress of the transfer as a JSON line per second to stderr",
+		},
+		cli.BoolFlag{
+			Name:  "update, if-newer",
+			Usage: "copy only when the source is newer than the target or the target is missing",
+		},
+		cli.BoolFlag{
+			Name:  "atomic",
+			Usage: "upload to a temporary object copied over the target once complete, so that readers never see a partial object",
+		},
+		cli.BoolFlag{
+			Name:  "if-size-differs",
+			Usage: "copy only when the source and target sizes differ or the target is missing",
+		},
+		cli.StringFlag{
+			Name:  "archive",
+			Usage: "write the objects of a recursive copy into a single .tar, .tar.gz, .tgz or .zip archive in the target folder",
+		},
+		cli.BoolFlag{
+			Name:  "extract",
+			Usage: "upload each file of a .tar, .tar.gz, .tgz or .zip archive source as an object under the target",
+		},
+		cli.BoolFlag{
+			Name:  "from-stdin",
+			Usage: "read source URLs from STDIN, one per line, in place of the `-` source argument",
+		},
+		cli.BoolFlag{
+			Name:  "continue-on-error",
+			Usage: "skip sources which cannot be read instead of stopping the copy",
+		},
+		cli.BoolFlag{
+			Name:  "skip-errors",
+			Usage: "skip objects which fail to copy and summarize them at the end, implies --continue-on-error",
+		},
+		cli.StringFlag{
+			Name:  "error-manifest",
+			Usage: "with --skip-errors, list the objects which failed to copy in this file, to retry them with --from-stdin",
+		},
+		cli.IntFlag{
+			Name:  "shard-depth",
+			Usage: "spread the files downloaded to a local folder over this many levels of subfolders named after a hash of the object name",
+		},
+		cli.BoolFlag{
+			Name:  "sparse",
+			Usage: "leave runs of zeros as holes when writing local files, e.g. disk images",
+		},
+		cli.BoolFlag{
+			Name:  "checksum-resume",
+			Usage: "resume the multipart uploads interrupted in a previous --checksum-resume run, uploading again the parts whose checksum differs from the local file",
+		},
+		cli.IntFlag{
+			Name:  "retry-on-checksum-mismatch",
+			Usage: "download again up to N times the objects whose md5sum differs from their ETag",
+		},
+		cli.StringFlag{
+			Name:  "newer-than-ref",
+			Usage: "copy only the objects modified after the reference file or object, e.g. a marker left by the last backup",
+		},
+		cli.StringFlag{
+			Name:  "include-from",
+			Usage: "copy only the objects matching one of the glob patterns of a file, one per line",
+		},
+		cli.StringFlag{
+			Name:  "exclude-from",
+			Usage: "skip the objects matching one of the glob patterns of a file, one per line",
+		},
 	}
 )
 
@@ -125,7 +263,7 @@ var cpCmd = cli.Command{
 	Action:       mainCopy,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(cpFlags, ioFlags...), globalFlags...),
+	Flags:        append(append(append(cpFlags, opsRateFlag), ioFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -136,8 +274,16 @@ FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
 ENVIRONMENT VARIABLES:
-  MC_ENCRYPT:      list of comma delimited prefixes
-  MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
+  MC_ENCRYPT:                list of comma delimited prefixes
+  MC_ENCRYPT_KEY:            list of comma delimited prefix=secret values
+  MC_UPLOAD_MULTIPART_SIZE:  size of the parts of multipart uploads, e.g. 64MiB
+
+TOKENS:
+  The target and --attr may contain the following tokens, expanded once before copying.
+  {date:LAYOUT}  current UTC date formatted with a Go time layout, e.g. {date:2006-01-02}
+  {env:VAR}      value of the environment variable VAR, which must be set and cannot
+                 contain '/', '\', ';', '=' or '..'
+  Braces around any other name are copied as is.
 
 EXAMPLES:
   01. Copy a list of objects from local file system to Amazon S3 cloud storage.
@@ -201,6 +347,99 @@ EXAMPLES:
   20. Set tags to the uploaded objects
       {{.Prompt}} {{.HelpName}} -r --tags "category=prod&type=backup" ./data/ play/another-bucket/
 
+  21. Download a folder recursively and set the modification time of local files to the objects' last modified time.
+      {{.Prompt}} {{.HelpName}} -r --preserve-mtime play/mybucket/photos/ ~/photos/
+
+  22. Copy a folder recursively and report the transfer rate of each object along with the slowest and fastest objects.
+      {{.Prompt}} {{.HelpName}} -r --show-rate --quiet ~/photos/ play/mybucket/photos/
+
+  23. Copy a folder recursively, skipping objects whose copy on the target is as recent as the source.
+      {{.Prompt}} {{.HelpName}} -r --update ~/photos/ play/mybucket/photos/
+
+  24. Copy a folder recursively, skipping objects which have the same size on the target.
+      {{.Prompt}} {{.HelpName}} -r --if-size-differs ~/photos/ play/mybucket/photos/
+
+  25. Copy a file with a single PUT and send its Content-MD5, letting the server reject a corrupted transfer.
+      {{.Prompt}} {{.HelpName}} --disable-multipart --content-md5 backup.tar play/mybucket/
+
+  26. Copy a backup under a folder named after the current date, recording the host it was taken on.
+      {{.Prompt}} {{.HelpName}} --attr "host={env:HOSTNAME}" backup.tar 'play/mybucket/{date:2006-01-02}/backup.tar'
+
+  27. Set the Cache-Control header of all objects under a prefix in place, 16 objects at a time.
+      {{.Prompt}} {{.HelpName}} --recursive --metadata-only --force --workers 16 --cache-control 'max-age=86400' play/mybucket/assets/ play/mybucket/assets/
+
+  28. Copy the objects listed by another command into a folder, skipping the ones which cannot be read.
+      {{.Prompt}} {{.HelpName}} --from-stdin --continue-on-error - play/mybucket/backup/ < objects.txt
+
+  29. Snapshot a prefix into a single tar archive in the current folder.
+      {{.Prompt}} {{.HelpName}} --recursive --archive snapshot.tar play/mybucket/prefix/ .
+
+  30. Seed a prefix with the files of a local tar archive, keeping their paths in the archive.
+      {{.Prompt}} {{.HelpName}} --extract seed.tar play/mybucket/prefix/
+
+  31. Copy a folder, reporting the progress to a wrapping program as JSON lines on stderr.
+      {{.Prompt}} {{.HelpName}} --recursive --json --progress-json backup/ play/mybucket/backup/ 2> progress.json
+
+  32. Change the Content-Type of an object in place and check that the server applied it.
+      {{.Prompt}} {{.HelpName}} --metadata-only --verify --attr "Content-Type=application/json" play/mybucket/data play/mybucket/data
+
+  33. Download a virtual machine image as a sparse file, saving the local disk space of its unused blocks.
+      {{.Prompt}} {{.HelpName}} --sparse play/mybucket/images/disk.img /var/lib/images/
+
+  34. Download millions of small objects, spread over 2 levels of 256 subfolders each. The object
+      'logs/a.txt' is saved e.g. as '/data/logs/3f/a2/logs/a.txt', its name is the path below the
+      first 2 subfolders.
+      {{.Prompt}} {{.HelpName}} --recursive --shard-depth 2 play/mybucket/logs /data/logs/
+
+  35. Resume the interrupted upload of a large file, checking the parts uploaded by the previous run
+      against the local file and uploading again the corrupted ones.
+      {{.Prompt}} {{.HelpName}} --checksum-resume backup.tar play/mybucket/
+
+  36. Ingest a file into a WORM bucket in a single step, with a compliance retention and a legal hold.
+      {{.Prompt}} {{.HelpName}} --retention-mode compliance --retention-duration 7y --legal-hold on invoice.pdf play/locked-bucket/
+
+  37. Resume in a later run a recursive copy interrupted by a time limit, from the resume token it printed.
+      {{.Prompt}} {{.HelpName}} --recursive --continue-token TOKEN play/mybucket/ /mnt/backup/
+
+  38. Copy objects setting only the given metadata, instead of the metadata of the source objects.
+      {{.Prompt}} {{.HelpName}} --recursive --metadata-directive REPLACE --attr "Cache-Control=max-age=90000" play/mybucket/ play/otherbucket/
+
+  39. Upload logs to a MinIO server, reporting which objects its compression configuration should compress.
+      {{.Prompt}} {{.HelpName}} --recursive --predict-compression --json logs/ myminio/logs/
+
+  40. Download a bucket over an unreliable link, downloading again up to 3 times the objects found corrupted.
+      {{.Prompt}} {{.HelpName}} --recursive --retry-on-checksum-mismatch 3 play/mybucket/ /mnt/backup/
+
+  41. Preview, then roll back the objects under a prefix of a versioned bucket to their state of January 1st 2024.
+      The objects created or deleted since then are removed, a delete marker hides them.
+      {{.Prompt}} {{.HelpName}} --recursive --restore --rewind 2024.01.01 --dry-run play/mybucket/prefix/ play/mybucket/prefix/
+      {{.Prompt}} {{.HelpName}} --recursive --restore --rewind 2024.01.01 --force play/mybucket/prefix/ play/mybucket/prefix/
+
+  42. Upload disk images in parts of 16MiB, increasing the part size of the images which would take more than 10000 parts.
+      {{.Prompt}} MC_UPLOAD_MULTIPART_SIZE=16MiB {{.HelpName}} --recursive --auto-part-size images/ play/mybucket/images/
+
+  43. Copy a folder recursively, skipping the objects matching the glob patterns of an ignore file.
+      {{.Prompt}} {{.HelpName}} --recursive --exclude-from .mcignore project/ play/mybucket/project/
+
+  44. Copy the objects modified since the last backup, whose marker object is updated once the backup completes.
+      {{.Prompt}} {{.HelpName}} --recursive --newer-than-ref backup/mybucket/.last-backup play/mybucket/ backup/mybucket/
+
+  45. Move the objects of a folder to the STANDARD_IA storage class, skipping the objects already in it.
+      {{.Prompt}} {{.HelpName}} --recursive --storage-class STANDARD_IA play/mybucket/archive/ play/mybucket/archive/
+
+  46. Copy a large object between two deployments, uploading 8 of its parts in parallel.
+      {{.Prompt}} {{.HelpName}} --concurrent-multipart 8 s3/backups/disk.img play/backups/
+
+  47. Migrate a bucket skipping the objects which fail to copy, then retry only those objects.
+      {{.Prompt}} {{.HelpName}} --recursive --skip-errors --error-manifest failures.txt s3/mybucket/ play/mybucket/
+      {{.Prompt}} {{.HelpName}} --from-stdin --skip-errors --error-manifest retry.txt - play/mybucket/ < failures.txt
+
+  48. Copy a folder of many small files, at most 100 objects per second.
+      {{.Prompt}} {{.HelpName}} --recursive --ops-rate 100 /var/log/archive/ play/logs/
+
+  49. Replace a report read by other applications only when the local copy is newer, without them ever reading a partial upload.
+      {{.Prompt}} {{.HelpName}} --update --atomic report.csv play/reports/
+
 `,
 }
 
@@ -212,11 +451,68 @@ type copyMessage struct {
 	Size       int64  `json:"size"`
 	TotalCount int64  `json:"totalCount"`
 	TotalSize  int64  `json:"totalSize"`
+
+	// Set only with --show-rate, once the transfer has completed.
+	Elapsed float64 `json:"elapsed,omitempty"`
+	Rate    float64 `json:"rate,omitempty"`
+
+	// Set only by mv, either "server-side" or "stream".
+	Mode string `json:"mode,omitempty"`
+
+	// Set only with --content-md5, once the upload has completed.
+	ContentMD5 string `json:"contentMD5,omitempty"`
+
+	// Set only with --legal-hold, the legal hold applied to the target.
+	LegalHold string `json:"legalHold,omitempty"`
+
+	// Set only with --checksum-resume, the parts of a resumed upload
+	// which were corrupted and uploaded again.
+	ReuploadedParts []int `json:"reuploadedParts,omitempty"`
+
+	// Set only with --predict-compression, whether the compression configuration
+	// of the server predicts that it compresses the target. The server
+	// does not report the compression of an object, so it is not read back.
+	CompressionPredicted *bool `json:"compressionPredicted,omitempty"`
+
+	// Set only with --retry-on-checksum-mismatch, the number of times
+	// the source was downloaded again before its md5sum matched.
+	ChecksumRetries int `json:"checksumRetries,omitempty"`
 }
 
 // String colorized copy message
 func (c copyMessage) String() string {
-	return console.Colorize("Copy", fmt.Sprintf("`%s` -> `%s`", c.Source, c.Target))
+	msg := fmt.Sprintf("`%s` -> `%s`", c.Source, c.Target)
+	if c.Elapsed > 0 {
+		msg += fmt.Sprintf(" (%s in %s)", humanizedRate(c.Rate),
+			time.Duration(c.Elapsed*float64(time.Second)).Round(time.Microsecond))
+	}
+	if c.Mode != "" {
+		msg += " [" + c.Mode + "]"
+	}
+	if c.ContentMD5 != "" {
+		msg += " (md5: " + c.ContentMD5 + ")"
+	}
+	if c.LegalHold != "" {
+		msg += " (legal hold: " + c.LegalHold + ")"
+	}
+	if len(c.ReuploadedParts) > 0 {
+		parts := make([]string, 0, len(c.ReuploadedParts))
+		for _, part := range c.ReuploadedParts {
+			parts = append(parts, strconv.Itoa(part))
+		}
+		msg += " (re-uploaded parts: " + strings.Join(parts, ", ") + ")"
+	}
+	if c.CompressionPredicted != nil {
+		if *c.CompressionPredicted {
+			msg += " (compression predicted)"
+		} else {
+			msg += " (no compression predicted)"
+		}
+	}
+	if c.ChecksumRetries > 0 {
+		msg += fmt.Sprintf(" (checksum retries: %d)", c.ChecksumRetries)
+	}
+	return console.Colorize("Copy", msg)
 }
 
 // JSON jsonified copy message
@@ -228,6 +524,58 @@ func (c copyMessage) JSON() string {
 	return string(copyMessageBytes)
 }
 
+// copySkipMessage container for the number of objects skipped by cp predicates.
+type copySkipMessage struct {
+	Status  string `json:"status"`
+	Skipped int64  `json:"skipped"`
+}
+
+// String colorized copy skip message
+func (c copySkipMessage) String() string {
+	return console.Colorize("Copy", fmt.Sprintf("Skipped %d object(s) already up to date on the target.", c.Skipped))
+}
+
+// JSON jsonified copy skip message
+func (c copySkipMessage) JSON() string {
+	c.Status = "success"
+	copyMessageBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(copyMessageBytes)
+}
+
+// copyPredicates are the conditions a source must meet against
+// its target for the copy to happen, set by --update and --if-size-differs.
+type copyPredicates struct {
+	ifNewer       bool
+	ifSizeDiffers bool
+}
+
+func (p copyPredicates) isSet() bool {
+	return p.ifNewer || p.ifSizeDiffers
+}
+
+// skipCopy returns true when the target already exists and
+// does not meet one of the copy predicates.
+func skipCopy(ctx context.Context, cpURLs URLs, encKeyDB map[string][]prefixSSEPair, p copyPredicates) bool {
+	if !p.isSet() || cpURLs.Error != nil {
+		return false
+	}
+	targetPath := filepath.ToSlash(filepath.Join(cpURLs.TargetAlias, cpURLs.TargetContent.URL.Path))
+	_, targetContent, err := url2Stat(ctx, targetPath, "", false, encKeyDB, time.Time{}, false)
+	if err != nil {
+		// Missing target, or let the copy report the error.
+		return false
+	}
+	if p.ifNewer && !cpURLs.SourceContent.Time.After(targetContent.Time) {
+		return true
+	}
+	if p.ifSizeDiffers && cpURLs.SourceContent.Size == targetContent.Size {
+		return true
+	}
+	return false
+}
+
 // Progress - an interface which describes current amount
 // of data written.
 type Progress interface {
@@ -243,7 +591,7 @@ type ProgressReader interface {
 }
 
 // doCopy - Copy a single file from source to destination
-func doCopy(ctx context.Context, cpURLs URLs, pg ProgressReader, encKeyDB map[string][]prefixSSEPair, isMvCmd bool, preserve, isZip bool) URLs {
+func doCopy(ctx context.Context, cpURLs URLs, pg ProgressReader, encKeyDB map[string][]prefixSSEPair, isMvCmd bool, preserve, isZip bool, rates *transferRates, progress *progressJSON) URLs {
 	if cpURLs.Error != nil {
 		cpURLs.Error = cpURLs.Error.Trace()
 		return cpURLs
@@ -256,27 +604,92 @@ func doCopy(ctx context.Context, cpURLs URLs, pg ProgressReader, encKeyDB map[st
 	length := cpURLs.SourceContent.Size
 	sourcePath := filepath.ToSlash(filepath.Join(sourceAlias, sourceURL.Path))
 
-	if progressReader, ok := pg.(*progressBar); ok {
+	progressReader, isProgressBar := pg.(*progressBar)
+	msg := copyMessage{
+		Source:     sourcePath,
+		Target:     filepath.ToSlash(filepath.Join(targetAlias, targetURL.Path)),
+		Size:       length,
+		TotalCount: cpURLs.TotalCount,
+		TotalSize:  cpURLs.TotalSize,
+	}
+	msg.CompressionPredicted = cpURLs.CompressionPredicted
+	if isMvCmd {
+		msg.Mode = "stream"
+		if cpURLs.isServerSideCopy(isZip) {
+			msg.Mode = "server-side"
+		}
+	}
+	// With --show-rate, --content-md5, --checksum-resume,
+	// --retry-on-checksum-mismatch or --legal-hold the copy message
+	// is only printed once the upload has completed.
+	printAfterCopy := rates != nil || cpURLs.ContentMD5 || cpURLs.ChecksumResume || cpURLs.ChecksumRetries > 0 ||
+		cpURLs.TargetContent.LegalHoldEnabled
+	if isProgressBar {
 		progressReader.SetCaption(cpURLs.SourceContent.URL.String() + ": ")
-	} else {
-		targetPath := filepath.ToSlash(filepath.Join(targetAlias, targetURL.Path))
-		printMsg(copyMessage{
-			Source:     sourcePath,
-			Target:     targetPath,
-			Size:       length,
-			TotalCount: cpURLs.TotalCount,
-			TotalSize:  cpURLs.TotalSize,
-		})
+	} else if !printAfterCopy {
+		printMsg(msg)
 	}
 
+	progress.SetObject(sourcePath)
+	start := time.Now()
 	urls := uploadSourceToTargetURL(ctx, cpURLs, pg, encKeyDB, preserve, isZip)
+	for retried := 1; retried <= cpURLs.ChecksumRetries && isChecksumMismatch(urls.Error); retried++ {
+		// The progress of the source was already reported by the
+		// corrupted download.
+		urls = uploadSourceToTargetURL(ctx, cpURLs, nil, encKeyDB, preserve, isZip)
+		urls.ChecksumRetried = retried
+	}
+	if isChecksumMismatch(urls.Error) && cpURLs.ChecksumRetries > 0 {
+		urls.Error = urls.Error.Trace(fmt.Sprintf("after %d retries", cpURLs.ChecksumRetries))
+	}
+	if printAfterCopy && urls.Error == nil {
+		if rates != nil {
+			rate := rates.record(sourcePath, length, time.Since(start))
+			msg.Elapsed, msg.Rate = rate.Elapsed, rate.Rate
+		}
+		msg.ContentMD5 = urls.ContentMD5Sum
+		msg.ReuploadedParts = urls.ReuploadedParts
+		msg.ChecksumRetries = urls.ChecksumRetried
+		if cpURLs.TargetContent.LegalHoldEnabled {
+			// Report the legal hold the server applied.
+			hold, err := readLegalHold(ctx, targetAlias, targetURL.String())
+			switch {
+			case err != nil:
+				errorIf(err.Trace(msg.Target), "Unable to read back the legal hold of `"+msg.Target+"`.")
+			case string(hold) != cpURLs.TargetContent.LegalHold:
+				urls = urls.WithError(probe.NewError(fmt.Errorf("legal hold %s requested, the server applied %q", cpURLs.TargetContent.LegalHold, hold)).Trace(msg.Target))
+			default:
+				msg.LegalHold = string(hold)
+			}
+		}
+		if !isProgressBar && urls.Error == nil {
+			printMsg(msg)
+		}
+	}
 	if isMvCmd && urls.Error == nil {
-		rmManager.add(ctx, sourceAlias, sourceURL.String())
+		if rmManager.verify {
+			if err := rmManager.verifyTarget(ctx, urls, msg.Target, encKeyDB); err != nil {
+				errorIf(err, "Unable to verify `"+msg.Target+"`, keeping `"+sourcePath+"`.")
+				rmManager.keep(sourcePath, msg.Target, err.ToGoError().Error())
+				return urls
+			}
+		}
+		rmManager.add(ctx, sourceAlias, sourceURL.String(), sourcePath, msg.Target)
 	}
 
 	return urls
 }
 
+// readLegalHold reads back the legal hold of the latest version of an
+// uploaded object.
+func readLegalHold(ctx context.Context, targetAlias, targetURL string) (minio.LegalHoldStatus, *probe.Error) {
+	clnt, err := newClientFromAlias(targetAlias, targetURL)
+	if err != nil {
+		return "", err.Trace(targetURL)
+	}
+	return clnt.GetObjectLegalHold(ctx, "")
+}
+
 // doCopyFake - Perform a fake copy to update the progress bar appropriately.
 func doCopyFake(ctx context.Context, cpURLs URLs, pg Progress) URLs {
 	if progressReader, ok := pg.(*progressBar); ok {
@@ -299,10 +712,15 @@ func doPrepareCopyURLs(ctx context.Context, session *sessionV8, cancelCopy conte
 	versionID := session.Header.CommandStringFlags["version-id"]
 	olderThan := session.Header.CommandStringFlags["older-than"]
 	newerThan := session.Header.CommandStringFlags["newer-than"]
+	shardDepth := session.Header.CommandIntFlags["shard-depth"]
 	encryptKeys := session.Header.CommandStringFlags["encrypt-key"]
 	encrypt := session.Header.CommandStringFlags["encrypt"]
 	encKeyDB, err := parseAndValidateEncryptionKeys(encryptKeys, encrypt)
 	fatalIf(err, "Unable to parse encryption keys.")
+	filter, err := newCopyFilter(session.Header.CommandStringFlags["include-from"], session.Header.CommandStringFlags["exclude-from"])
+	fatalIf(err, "Unable to read the filter patterns.")
+	newerThanRef, err := referenceModTime(ctx, session.Header.CommandStringFlags["newer-than-ref"], encKeyDB)
+	fatalIf(err, "Unable to stat the --newer-than-ref reference.")
 
 	// Create a session data file to store the processed URLs.
 	dataFP := session.NewDataWriter()
@@ -313,14 +731,17 @@ func doPrepareCopyURLs(ctx context.Context, session *sessionV8, cancelCopy conte
 	}
 
 	opts := prepareCopyURLsOpts{
-		sourceURLs:  sourceURLs,
-		targetURL:   targetURL,
-		isRecursive: isRecursive,
-		encKeyDB:    encKeyDB,
-		olderThan:   olderThan,
-		newerThan:   newerThan,
-		timeRef:     parseRewindFlag(rewind),
-		versionID:   versionID,
+		sourceURLs:   sourceURLs,
+		targetURL:    targetURL,
+		isRecursive:  isRecursive,
+		encKeyDB:     encKeyDB,
+		olderThan:    olderThan,
+		newerThan:    newerThan,
+		timeRef:      parseRewindFlag(rewind),
+		versionID:    versionID,
+		shardDepth:   shardDepth,
+		filter:       filter,
+		newerThanRef: newerThanRef,
 	}
 
 	URLsCh := prepareCopyURLs(ctx, opts)
@@ -380,24 +801,84 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 	var isCopied func(string) bool
 	var totalObjects, totalBytes int64
 
+	var rates *transferRates
+	if cli.Bool("show-rate") {
+		rates = newTransferRates()
+	}
+
+	predicates := copyPredicates{
+		ifNewer:       cli.Bool("update"),
+		ifSizeDiffers: cli.Bool("if-size-differs"),
+	}
+	var skipped int64
+
+	// Objects copied over themselves to change their storage class,
+	// and the ones skipped being in that storage class already.
+	var classChanged, classUnchanged int64
+
+	// Sources which could not be read, skipped with --continue-on-error.
+	var unreadable int64
+
+	// Paces the copies with --ops-rate.
+	ops := newOpsLimiterFromContext(cli)
+
+	// Objects which failed to copy, recorded with --skip-errors.
+	var failures *copyErrorManifest
+	if cli.Bool("skip-errors") {
+		var err *probe.Error
+		failures, err = newCopyErrorManifest(cli.String("error-manifest"))
+		fatalIf(err, "Unable to create the error manifest.")
+	}
+
 	cpURLsCh := make(chan URLs, 10000)
 
 	// Store a progress bar or an accounter
 	var pg ProgressReader
 
 	// Enable progress bar reader only during default mode.
-	if !globalQuiet && !globalJSON { // set up progress bar
+	if !globalQuiet && !globalJSON && !cli.Bool("progress-json") { // set up progress bar
 		pg = newProgressBar(totalBytes)
 	} else {
 		pg = newAccounter(totalBytes)
 	}
 
+	// Progress events on stderr, keeping stdout for the results.
+	var progress *progressJSON
+	if cli.Bool("progress-json") {
+		progress = newProgressJSON(os.Stderr, pg.Get)
+	}
+
 	sourceURLs := cli.Args()[:len(cli.Args())-1]
 	targetURL := cli.Args()[len(cli.Args())-1] // Last one is target
 
 	// Check if the target path has object locking enabled
 	withLock, _ := isBucketLockEnabled(ctx, targetURL)
 
+	var compression *compressConfig
+	if cli.Bool("predict-compression") {
+		compression = getCopyCompression(targetURL)
+	}
+
+	// Without a session, recursive copies print a token resuming
+	// them when interrupted.
+	var resumeTracker *copyResumeTracker
+	var resumeFilter *copyResumeFilter
+	if session == nil && !isMvCmd && cli.Bool("recursive") {
+		resumeTracker = newCopyResumeTracker(cli.Args())
+		if token := cli.String("continue-token"); token != "" {
+			t, err := decodeCopyResumeToken(token, cli.Args())
+			fatalIf(err, "Invalid --continue-token, it must be issued by a copy of the same sources and target, using the same config folder.")
+			resumeFilter = newCopyResumeFilter(t)
+		}
+		setInterruptHook(func() {
+			if !globalQuiet && !globalJSON {
+				console.Eraseline()
+			}
+			printMsg(resumeTracker.message())
+		})
+		defer setInterruptHook(nil)
+	}
+
 	if session != nil {
 		// isCopied returns true if an object has been already copied
 		// or not. This is useful when we resume from a session.
@@ -410,6 +891,7 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 		}
 
 		pg.SetTotal(totalBytes)
+		progress.SetTotal(totalBytes)
 
 		go func() {
 			jsoniter := jsoniter.ConfigCompatibleWithStandardLibrary
@@ -437,19 +919,30 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 		newerThan := cli.String("newer-than")
 		rewind := cli.String("rewind")
 		versionID := cli.String("version-id")
+		continueOnError := cli.Bool("continue-on-error") || cli.Bool("skip-errors")
+		filter, err := newCopyFilter(cli.String("include-from"), cli.String("exclude-from"))
+		fatalIf(err, "Unable to read the filter patterns.")
+		newerThanRef, err := referenceModTime(ctx, cli.String("newer-than-ref"), encKeyDB)
+		fatalIf(err, "Unable to stat the --newer-than-ref reference.")
 
 		go func() {
 			totalBytes := int64(0)
 			opts := prepareCopyURLsOpts{
-				sourceURLs:  sourceURLs,
-				targetURL:   targetURL,
-				isRecursive: isRecursive,
-				encKeyDB:    encKeyDB,
-				olderThan:   olderThan,
-				newerThan:   newerThan,
-				timeRef:     parseRewindFlag(rewind),
-				versionID:   versionID,
-				isZip:       cli.Bool("zip"),
+				sourceURLs:   sourceURLs,
+				targetURL:    targetURL,
+				isRecursive:  isRecursive,
+				encKeyDB:     encKeyDB,
+				olderThan:    olderThan,
+				newerThan:    newerThan,
+				timeRef:      parseRewindFlag(rewind),
+				versionID:    versionID,
+				isZip:        cli.Bool("zip"),
+				shardDepth:   cli.Int("shard-depth"),
+				filter:       filter,
+				newerThanRef: newerThanRef,
+			}
+			if cli.Bool("from-stdin") {
+				opts.sourcesReader = os.Stdin
 			}
 			for cpURLs := range prepareCopyURLs(ctx, opts) {
 				if cpURLs.Error != nil {
@@ -466,10 +959,18 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 						errorIf(cpURLs.Error.Trace(),
 							"Unable to start copying.")
 					}
+					atomic.AddInt64(&unreadable, 1)
+					if failures != nil {
+						failures.record(cpURLs)
+					}
+					if continueOnError {
+						continue
+					}
 					break
 				} else {
 					totalBytes += cpURLs.SourceContent.Size
 					pg.SetTotal(totalBytes)
+					progress.SetTotal(totalBytes)
 					totalObjects++
 				}
 				cpURLsCh <- cpURLs
@@ -544,15 +1045,58 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 
 				cpURLs.MD5 = cli.Bool("md5") || withLock
 				cpURLs.DisableMultipart = cli.Bool("disable-multipart")
+				cpURLs.AutoPartSize = cli.Bool("auto-part-size")
+				cpURLs.ConcurrentParts = cli.Int("concurrent-multipart")
+				cpURLs.ContentMD5 = cli.Bool("content-md5")
+				cpURLs.PreserveMtime = cli.Bool("preserve-mtime")
+				cpURLs.Sparse = cli.Bool("sparse")
+				cpURLs.ChecksumResume = cli.Bool("checksum-resume")
+				cpURLs.Atomic = cli.Bool("atomic")
+				cpURLs.ChecksumRetries = cli.Int("retry-on-checksum-mismatch")
+				if isMvCmd && cli.IsSet("server-side") {
+					cpURLs.DisableServerSide = !cli.Bool("server-side")
+					cpURLs.ServerSideAcrossAliases = cli.Bool("server-side")
+				}
+				cpURLs.MetadataDirective = strings.ToUpper(cli.String("metadata-directive"))
+				if compression != nil {
+					contentType := cpURLs.SourceContent.Metadata["Content-Type"]
+					if contentType == "" {
+						contentType = guessURLContentType(cpURLs.TargetContent.URL.String())
+					}
+					targetPath := filepath.ToSlash(filepath.Join(cpURLs.TargetAlias, cpURLs.TargetContent.URL.Path))
+					encrypted := getSSE(targetPath, encKeyDB[cpURLs.TargetAlias]) != nil
+					compressed := compression.compresses(cpURLs.TargetContent.URL.Path, contentType, encrypted)
+					cpURLs.CompressionPredicted = &compressed
+				}
+
+				source := cpURLs.SourceContent.URL.String()
+				if resumeTracker != nil {
+					resumeTracker.listed(source)
+				}
 
 				// Verify if previously copied, notify progress bar.
-				if isCopied != nil && isCopied(cpURLs.SourceContent.URL.String()) {
+				if (isCopied != nil && isCopied(source)) ||
+					(resumeFilter != nil && resumeFilter.isCopied(source, cpURLs.SourceContent.Time)) {
 					parallel.queueTask(func() URLs {
 						return doCopyFake(ctx, cpURLs, pg)
 					}, 0)
 				} else {
 					parallel.queueTask(func() URLs {
-						return doCopy(ctx, cpURLs, pg, encKeyDB, isMvCmd, preserve, isZip)
+						if hasStorageClass(cpURLs) {
+							atomic.AddInt64(&classUnchanged, 1)
+							return doCopyFake(ctx, cpURLs, pg)
+						}
+						if skipCopy(ctx, cpURLs, encKeyDB, predicates) {
+							atomic.AddInt64(&skipped, 1)
+							return doCopyFake(ctx, cpURLs, pg)
+						}
+						ops.wait(ctx)
+						urls := doCopy(ctx, cpURLs, pg, encKeyDB, isMvCmd, preserve, isZip, rates, progress)
+						if urls.Error == nil && cpURLs.TargetContent.StorageClass != "" && isSameObjectCopy(cpURLs) &&
+							normalizeStorageClass(cpURLs.SourceContent.StorageClass) != normalizeStorageClass(cpURLs.TargetContent.StorageClass) {
+							atomic.AddInt64(&classChanged, 1)
+						}
+						return urls
 					}, cpURLs.SourceContent.Size)
 				}
 			}
@@ -587,6 +1131,9 @@ loop:
 					session.Header.LastCopied = cpURLs.SourceContent.URL.String()
 					session.Save()
 				}
+				if resumeTracker != nil {
+					resumeTracker.done(cpURLs.SourceContent.URL.String())
+				}
 				cpAllFilesErr = false
 			} else {
 
@@ -600,6 +1147,9 @@ loop:
 				}
 				errorIf(cpURLs.Error.Trace(cpURLs.SourceContent.URL.String()),
 					fmt.Sprintf("Failed to copy `%s`.", cpURLs.SourceContent.URL.String()))
+				if failures != nil {
+					failures.record(cpURLs)
+				}
 				if isErrIgnored(cpURLs.Error) {
 					cpAllFilesErr = false
 					continue loop
@@ -618,7 +1168,7 @@ loop:
 					}
 				}
 
-				if session != nil {
+				if session != nil && failures == nil {
 					// For critical errors we should exit. Session
 					// can be resumed after the user figures out
 					// the  problem.
@@ -628,6 +1178,12 @@ loop:
 		}
 	}
 
+	progress.Finish()
+
+	if resumeFilter != nil && globalContext.Err() == nil {
+		resumeFilter.finish()
+	}
+
 	if progressReader, ok := pg.(*progressBar); ok {
 		if (errSeen && totalObjects == 1) || (cpAllFilesErr && totalObjects > 1) {
 			console.Eraseline()
@@ -640,6 +1196,30 @@ loop:
 		}
 	}
 
+	if rates != nil {
+		printMsg(rates.summary())
+	}
+
+	if predicates.isSet() {
+		printMsg(copySkipMessage{Skipped: atomic.LoadInt64(&skipped)})
+	}
+
+	if changed, unchanged := atomic.LoadInt64(&classChanged), atomic.LoadInt64(&classUnchanged); changed+unchanged > 0 {
+		printMsg(storageClassSummaryMessage{
+			StorageClass: cli.String("storage-class"),
+			Changed:      changed,
+			Unchanged:    unchanged,
+		})
+	}
+
+	if atomic.LoadInt64(&unreadable) > 0 {
+		retErr = exitStatus(globalErrorExitStatus)
+	}
+
+	if failures != nil {
+		printMsg(failures.close())
+	}
+
 	return retErr
 }
 
@@ -652,6 +1232,31 @@ func mainCopy(cliCtx *cli.Context) error {
 	encKeyDB, err := getEncKeys(cliCtx)
 	fatalIf(err, "Unable to parse encryption keys.")
 
+	// Expand {date:LAYOUT} and {env:VAR} tokens.
+	cliCtx = expandCopyTokensFromContext(cliCtx)
+
+	// --metadata-only, --archive and --extract do not go through
+	// checkCopySyntax, which would not reject --dry-run for them.
+	if cliCtx.Bool("dry-run") && !cliCtx.Bool("restore") {
+		fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "--dry-run can only be used with --restore.")
+	}
+
+	if cliCtx.Bool("restore") {
+		return copyRestore(ctx, cliCtx, encKeyDB)
+	}
+
+	if cliCtx.Bool("metadata-only") {
+		return copyMetadataOnly(ctx, cliCtx, encKeyDB)
+	}
+
+	if cliCtx.String("archive") != "" {
+		return copyToArchive(ctx, cliCtx, encKeyDB)
+	}
+
+	if cliCtx.Bool("extract") {
+		return copyFromArchive(ctx, cliCtx, encKeyDB)
+	}
+
 	// Parse metadata.
 	userMetaMap := make(map[string]string)
 	if cliCtx.String("attr") != "" {
@@ -659,10 +1264,17 @@ func mainCopy(cliCtx *cli.Context) error {
 		fatalIf(err, "Unable to parse attribute %v", cliCtx.String("attr"))
 	}
 
-	// check 'copy' cli arguments.
-	checkCopySyntax(ctx, cliCtx, encKeyDB, false)
 	// Additional command specific theme customization.
 	console.SetColor("Copy", color.New(color.FgGreen, color.Bold))
+	console.SetColor("Summarize", color.New(color.Bold))
+
+	// check 'copy' cli arguments.
+	checkCopySyntax(ctx, cliCtx, encKeyDB, false)
+	fatalIfReadOnlyURL("cp", cliCtx.Args().Get(cliCtx.NArg()-1))
+
+	if cliCtx.Bool("sparse") && !sparseFilesSupported {
+		warning("Sparse files are not supported on " + runtime.GOOS + ", writing local files in full.")
+	}
 
 	recursive := cliCtx.Bool("recursive")
 	rewind := cliCtx.String("rewind")
@@ -700,6 +1312,7 @@ func mainCopy(cliCtx *cli.Context) error {
 			session.Header.CommandStringFlags["version-id"] = versionID
 			session.Header.CommandStringFlags["older-than"] = olderThan
 			session.Header.CommandStringFlags["newer-than"] = newerThan
+			session.Header.CommandIntFlags["shard-depth"] = cliCtx.Int("shard-depth")
 			session.Header.CommandStringFlags["storage-class"] = storageClass
 			session.Header.CommandStringFlags["tags"] = tags
 			session.Header.CommandStringFlags[rmFlag] = retentionMode
@@ -707,6 +1320,9 @@ func mainCopy(cliCtx *cli.Context) error {
 			session.Header.CommandStringFlags[lhFlag] = legalHold
 			session.Header.CommandStringFlags["encrypt-key"] = sseKeys
 			session.Header.CommandStringFlags["encrypt"] = sse
+			session.Header.CommandStringFlags["newer-than-ref"] = cliCtx.String("newer-than-ref")
+			session.Header.CommandStringFlags["include-from"] = cliCtx.String("include-from")
+			session.Header.CommandStringFlags["exclude-from"] = cliCtx.String("exclude-from")
 			session.Header.CommandBoolFlags["session"] = cliCtx.Bool("continue")
 
 			if cliCtx.Bool("preserve") {
@@ -715,6 +1331,8 @@ func mainCopy(cliCtx *cli.Context) error {
 			session.Header.UserMetaData = userMetaMap
 			session.Header.CommandBoolFlags["md5"] = cliCtx.Bool("md5")
 			session.Header.CommandBoolFlags["disable-multipart"] = cliCtx.Bool("disable-multipart")
+			session.Header.CommandBoolFlags["content-md5"] = cliCtx.Bool("content-md5")
+			session.Header.CommandBoolFlags["checksum-resume"] = cliCtx.Bool("checksum-resume")
 
 			var e error
 			if session.Header.RootPath, e = os.Getwd(); e != nil {
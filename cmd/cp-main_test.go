@@ -18,8 +18,24 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/minio/cli"
 )
 
 func TestParseMetaData(t *testing.T) {
@@ -74,3 +90,385 @@ func TestParseMetaData(t *testing.T) {
 		}
 	}
 }
+
+func TestTransferRates(t *testing.T) {
+	rates := newTransferRates()
+	rates.record("slow", 1<<20, 2*time.Second)
+	rates.record("fast", 1<<20, time.Second/2)
+	rates.record("empty", 0, time.Second)
+	rates.record("instant", 1<<10, 0)
+
+	summary := rates.summary()
+	if summary.TotalObjects != 4 || summary.TotalSize != 2<<20+1<<10 {
+		t.Fatalf("unexpected totals: %d objects, %d bytes", summary.TotalObjects, summary.TotalSize)
+	}
+	if summary.Slowest == nil || summary.Slowest.Object != "slow" || summary.Slowest.Rate != 1<<19 {
+		t.Fatalf("expected `slow` at 512KiB/s to be the slowest, got %v", summary.Slowest)
+	}
+	if summary.Fastest == nil || summary.Fastest.Object != "fast" || summary.Fastest.Rate != 2<<20 {
+		t.Fatalf("expected `fast` at 2MiB/s to be the fastest, got %v", summary.Fastest)
+	}
+}
+
+func TestExpandCopyTokens(t *testing.T) {
+	os.Setenv("MC_TEST_TOKEN_HOST", "db-1")
+	os.Setenv("MC_TEST_TOKEN_PATH", "../etc")
+	defer os.Unsetenv("MC_TEST_TOKEN_HOST")
+	defer os.Unsetenv("MC_TEST_TOKEN_PATH")
+
+	now := time.Date(2021, 6, 30, 23, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		input       string
+		expected    string
+		shouldError bool
+	}{
+		{"play/backups/backup.tar", "play/backups/backup.tar", false},
+		{"play/backups/{date:2006-01-02}/backup.tar", "play/backups/2021-06-30/backup.tar", false},
+		{"play/backups/{date:2006/01}/{env:MC_TEST_TOKEN_HOST}.tar", "play/backups/2021/06/db-1.tar", false},
+		{"play/backups/{literal}.tar", "play/backups/{literal}.tar", false},
+		{"play/backups/{env:MC_TEST_TOKEN_PATH}.tar", "", true},
+		{"play/backups/{env:MC_TEST_TOKEN_UNSET}.tar", "", true},
+		{"play/backups/{env:NOT-A-NAME}.tar", "", true},
+		{"play/backups/{time:15}.tar", "play/backups/{time:15}.tar", false},
+		{"play/backups/{x:y}/{date:2006}.tar", "play/backups/{x:y}/2021.tar", false},
+		{"play/backups/{date:2006-01-02/backup.tar", "", true},
+		{"play/backups/{date:}/backup.tar", "", true},
+	}
+	for i, testCase := range testCases {
+		expanded, err := expandCopyTokens(testCase.input, now)
+		if testCase.shouldError {
+			if err == nil {
+				t.Fatalf("Test %d: expected an error for %s", i+1, testCase.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %s", i+1, err)
+		}
+		if expanded != testCase.expected {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.expected, expanded)
+		}
+	}
+}
+
+func TestExpandCopyTokensFromContext(t *testing.T) {
+	os.Setenv("MC_TEST_TOKEN_HOST", "db-1")
+	defer os.Unsetenv("MC_TEST_TOKEN_HOST")
+
+	cmd := cli.Command{Name: "cp", Flags: []cli.Flag{
+		cli.StringFlag{Name: "attr"},
+		cli.BoolFlag{Name: "recursive, r"},
+	}}
+	set := flag.NewFlagSet("cp", flag.ContinueOnError)
+	for _, f := range cmd.Flags {
+		f.Apply(set)
+	}
+	if e := set.Parse([]string{"-r", "--attr", "host={env:MC_TEST_TOKEN_HOST}", "backup.tar", "play/{env:MC_TEST_TOKEN_HOST}/"}); e != nil {
+		t.Fatal(e)
+	}
+	cliCtx := cli.NewContext(nil, set, nil)
+	cliCtx.Command = cmd
+
+	expanded := expandCopyTokensFromContext(cliCtx)
+	if args := expanded.Args(); len(args) != 2 || args[0] != "backup.tar" || args[1] != "play/db-1/" {
+		t.Fatalf("unexpected expanded arguments %v", args)
+	}
+	if attr := expanded.String("attr"); attr != "host=db-1" {
+		t.Fatalf("unexpected expanded --attr %s", attr)
+	}
+	if !expanded.Bool("recursive") || !expanded.IsSet("r") {
+		t.Fatal("expected the flags to be kept")
+	}
+	// The arguments of the command line are not changed.
+	if target := cliCtx.Args().Get(1); target != "play/{env:MC_TEST_TOKEN_HOST}/" {
+		t.Fatalf("expected the target to be left unchanged, got %s", target)
+	}
+	if attr := cliCtx.String("attr"); attr != "host={env:MC_TEST_TOKEN_HOST}" {
+		t.Fatalf("expected --attr to be left unchanged, got %s", attr)
+	}
+}
+
+func TestReplacedMetadata(t *testing.T) {
+	current := map[string]string{
+		"Content-Type":     "image/png",
+		"Cache-Control":    "no-cache",
+		"Content-Length":   "1024",
+		"Etag":             "\"abc\"",
+		"X-Amz-Meta-Owner": "web",
+		"x-amz-request-id": "1234",
+	}
+	updates := map[string]string{"Cache-Control": "max-age=86400", "team": "cdn"}
+	expected := map[string]string{
+		"Content-Type":     "image/png",
+		"Cache-Control":    "max-age=86400",
+		"X-Amz-Meta-Owner": "web",
+		"Team":             "cdn",
+	}
+	if got := replacedMetadata(current, updates); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestArchiveNames(t *testing.T) {
+	names := newArchiveNames()
+	testCases := []struct {
+		name  string
+		added bool
+	}{
+		{"a/b", true},
+		{"a/c", true},
+		{"a/b", false},
+		{"a", false},
+		{"a/b/c", false},
+		{"b", true},
+	}
+	for i, testCase := range testCases {
+		if added := names.add(testCase.name); added != testCase.added {
+			t.Fatalf("Test %d: expected %t adding %q, got %t", i+1, testCase.added, testCase.name, added)
+		}
+	}
+
+	for name, format := range map[string]string{"a.tar": "tar", "a.TGZ": "tar.gz", "a.tar.gz": "tar.gz", "a.zip": "zip", "a.rar": ""} {
+		if got, err := archiveFormat(name); got != format || (err == nil) != (format != "") {
+			t.Fatalf("expected format %q for %q, got %q (%v)", format, name, got, err)
+		}
+	}
+}
+
+func TestArchiveMemberName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected string
+		ok       bool
+	}{
+		{"p/a", "p/a", true},
+		{"/p/./d/b", "p/d/b", true},
+		{"p/../a", "a", true},
+		{"../evil", "", false},
+		{"p/../../evil", "", false},
+		{"/", "", false},
+	}
+	for i, testCase := range testCases {
+		got, ok := archiveMemberName(testCase.name)
+		if got != testCase.expected || ok != testCase.ok {
+			t.Fatalf("Test %d: expected (%q, %t), got (%q, %t)", i+1, testCase.expected, testCase.ok, got, ok)
+		}
+	}
+}
+
+func TestProgressJSON(t *testing.T) {
+	var buf bytes.Buffer
+	var transferred int64 = 42
+	progress := newProgressJSON(&buf, func() int64 { return transferred })
+	progress.SetTotal(100)
+	progress.AddTotal(28)
+	progress.SetObject("play/bucket/object")
+	progress.Finish()
+	progress.Finish()
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 1 {
+		t.Fatalf("expected a single progress event, got %d", len(lines))
+	}
+	var msg progressJSONMessage
+	if e := json.Unmarshal(lines[0], &msg); e != nil {
+		t.Fatal(e)
+	}
+	if msg.Status != "progress" || msg.Total != 128 || msg.Transferred != 42 || msg.Object != "play/bucket/object" {
+		t.Fatalf("unexpected progress event %+v", msg)
+	}
+
+	// A nil progress is a no-op.
+	var none *progressJSON
+	none.SetTotal(1)
+	none.SetObject("object")
+	none.Finish()
+}
+
+func TestVerifyMetadata(t *testing.T) {
+	updates := map[string]string{
+		"content-type":    "application/json",
+		"Cache-Control":   "max-age=90",
+		"key1":            "value1",
+		"X-Amz-Meta-Key2": "value2",
+		"Expires":         "Wed, 21 Oct 2015 07:28:00 GMT",
+	}
+	applied := map[string]string{
+		"Content-Type":    "application/json",
+		"Cache-Control":   "no-cache",
+		"X-Amz-Meta-Key1": "value1",
+		"Expires":         "Wednesday, 21-Oct-15 07:28:00 GMT",
+	}
+	expected := []cpMetadataAttr{
+		{Key: "Cache-Control", Requested: "max-age=90", Applied: "no-cache"},
+		{Key: "Content-Type", Requested: "application/json", Applied: "application/json", Verified: true},
+		{Key: "Expires", Requested: "Wed, 21 Oct 2015 07:28:00 GMT", Applied: "Wednesday, 21-Oct-15 07:28:00 GMT", Verified: true},
+		{Key: "Key1", Requested: "value1", Applied: "value1", Verified: true},
+		{Key: "X-Amz-Meta-Key2", Requested: "value2"},
+	}
+	if got := verifyMetadata(updates, applied); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestShardTargetURL(t *testing.T) {
+	key := "photos/2022/a.jpg"
+	sharded := shardKey(key, 3)
+	parts := strings.SplitN(sharded, "/", 4)
+	if len(parts) != 4 || parts[3] != key {
+		t.Fatalf("expected %q below 3 folders, got %q", key, sharded)
+	}
+	for _, part := range parts[:3] {
+		if len(part) != 2 {
+			t.Fatalf("unexpected shard folder %q in %q", part, sharded)
+		}
+	}
+	if shardKey(key, 3) != sharded {
+		t.Fatalf("expected the same sharded path for the same key")
+	}
+
+	targetURL := *newClientURL("/data/" + key)
+	if got := shardTargetURL("/data/", targetURL, 3); got.Path != "/data/"+sharded {
+		t.Fatalf("expected %q, got %q", "/data/"+sharded, got.Path)
+	}
+	if got := shardTargetURL("/other", targetURL, 3); got.Path != targetURL.Path {
+		t.Fatalf("expected a target outside of the root to be left as is, got %q", got.Path)
+	}
+}
+
+func TestCopyContentMD5(t *testing.T) {
+	withTempMcConfig(t)
+
+	data := []byte("some object content")
+	sum := md5.Sum(data)
+	source := filepath.Join(t.TempDir(), "object")
+	if e := ioutil.WriteFile(source, data, 0o644); e != nil {
+		t.Fatal(e)
+	}
+
+	for _, contentMD5 := range []bool{true, false} {
+		var (
+			mu         sync.Mutex
+			sentMD5    string
+			sentObject []byte
+		)
+		server := newS3StubServer(t, "md5", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPut || r.URL.Path != "/bucket/object" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			body, _ := ioutil.ReadAll(r.Body)
+			mu.Lock()
+			sentMD5, sentObject = r.Header.Get("Content-Md5"), body
+			mu.Unlock()
+			w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:])+`"`)
+		}))
+
+		urls := URLs{
+			SourceContent:    &ClientContent{URL: *newClientURL(source), Size: int64(len(data))},
+			TargetAlias:      "md5",
+			TargetContent:    &ClientContent{URL: *newClientURL(server.URL + "/bucket/object")},
+			DisableMultipart: true,
+			ContentMD5:       contentMD5,
+		}
+		urls = doCopy(context.Background(), urls, newAccounter(urls.SourceContent.Size), nil, false, false, false, nil, nil)
+		server.Close()
+		if urls.Error != nil {
+			t.Fatalf("Content-MD5 %v: unexpected error: %v", contentMD5, urls.Error)
+		}
+
+		mu.Lock()
+		// The body may be chunk signed over plain HTTP.
+		if !bytes.Contains(sentObject, data) {
+			t.Fatalf("Content-MD5 %v: expected the object %q to be uploaded, got %q", contentMD5, data, sentObject)
+		}
+		if !contentMD5 {
+			if sentMD5 != "" || urls.ContentMD5Sum != "" {
+				t.Fatalf("expected no Content-MD5, got %q and %q", sentMD5, urls.ContentMD5Sum)
+			}
+			mu.Unlock()
+			continue
+		}
+		if expected := base64.StdEncoding.EncodeToString(sum[:]); sentMD5 != expected {
+			t.Fatalf("expected the Content-MD5 header %q, got %q", expected, sentMD5)
+		}
+		mu.Unlock()
+		// The reported sum is the one sent.
+		if expected := hex.EncodeToString(sum[:]); urls.ContentMD5Sum != expected {
+			t.Fatalf("expected the reported sum %q, got %q", expected, urls.ContentMD5Sum)
+		}
+	}
+}
+
+func TestCopyLegalHoldReadBack(t *testing.T) {
+	withTempMcConfig(t)
+
+	data := []byte("some object content")
+	sum := md5.Sum(data)
+	source := filepath.Join(t.TempDir(), "object")
+	if e := ioutil.WriteFile(source, data, 0o644); e != nil {
+		t.Fatal(e)
+	}
+
+	// The second server ignores the requested legal hold.
+	for _, applied := range []bool{true, false} {
+		var (
+			mu   sync.Mutex
+			hold string
+		)
+		server := newS3StubServer(t, "hold", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			if r.URL.Path != "/bucket/object" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if _, ok := query["legal-hold"]; ok && r.Method == http.MethodGet {
+				if hold == "" {
+					w.WriteHeader(http.StatusNotFound)
+					w.Write([]byte(`<Error><Code>NoSuchObjectLockConfiguration</Code><Message>The specified object does not have a ObjectLock configuration</Message></Error>`))
+					return
+				}
+				w.Write([]byte(`<LegalHold><Status>` + hold + `</Status></LegalHold>`))
+				return
+			}
+			if r.Method != http.MethodPut {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			ioutil.ReadAll(r.Body)
+			if applied {
+				hold = r.Header.Get("X-Amz-Object-Lock-Legal-Hold")
+			}
+			w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:])+`"`)
+		}))
+
+		buf := captureJSONOutput(t)
+		urls := URLs{
+			SourceContent:    &ClientContent{URL: *newClientURL(source), Size: int64(len(data))},
+			TargetAlias:      "hold",
+			TargetContent:    &ClientContent{URL: *newClientURL(server.URL + "/bucket/object"), LegalHoldEnabled: true, LegalHold: "ON"},
+			DisableMultipart: true,
+		}
+		urls = doCopy(context.Background(), urls, newAccounter(urls.SourceContent.Size), nil, false, false, false, nil, nil)
+		if applied != (urls.Error == nil) {
+			t.Fatalf("legal hold applied %v: unexpected error %v", applied, urls.Error)
+		}
+		server.Close()
+
+		if !applied {
+			if buf.Len() != 0 {
+				t.Fatalf("expected no copy message, got %s", buf.String())
+			}
+			continue
+		}
+		var msg copyMessage
+		if e := json.Unmarshal(buf.Bytes(), &msg); e != nil {
+			t.Fatalf("unable to parse %q: %v", buf.String(), e)
+		}
+		if msg.LegalHold != "ON" {
+			t.Fatalf("expected the legal hold ON to be reported, got %q", msg.LegalHold)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// Largest object which can be copied onto itself with a single CopyObject.
+const maxCopyObjectSize = 5 * 1024 * 1024 * 1024
+
+// Object headers kept by an in-place metadata update, along with the
+// user metadata, any other header is either computed or not replaceable.
+var replaceableHeaders = []string{
+	"Cache-Control",
+	"Content-Disposition",
+	"Content-Encoding",
+	"Content-Language",
+	"Content-Type",
+	"Expires",
+	"X-Amz-Website-Redirect-Location",
+}
+
+// cpMetadataAttr is a metadata value requested on an object, along with
+// the value read back from the object with --verify.
+type cpMetadataAttr struct {
+	Key       string `json:"key"`
+	Requested string `json:"requested"`
+	Applied   string `json:"applied"`
+	Verified  bool   `json:"verified"`
+}
+
+// cpMetadataMessage container for an object whose metadata was updated.
+type cpMetadataMessage struct {
+	Status string           `json:"status"`
+	Object string           `json:"object"`
+	Attrs  []cpMetadataAttr `json:"attrs,omitempty"`
+}
+
+func (c cpMetadataMessage) String() string {
+	msg := console.Colorize("Copy", fmt.Sprintf("Updated metadata of `%s`", c.Object))
+	for _, attr := range c.Attrs {
+		if attr.Verified {
+			msg += fmt.Sprintf("\n   %s %s: %s", check, attr.Key, attr.Applied)
+			continue
+		}
+		msg += console.Colorize("CopyMetadataMismatch", fmt.Sprintf("\n   ! %s: requested `%s`, applied `%s`", attr.Key, attr.Requested, attr.Applied))
+	}
+	return msg
+}
+
+func (c cpMetadataMessage) JSON() string {
+	c.Status = "success"
+	msgBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// cpMetadataSummaryMessage container for the counts of a metadata update.
+type cpMetadataSummaryMessage struct {
+	Status     string `json:"status"`
+	Updated    int64  `json:"updated"`
+	Failed     int64  `json:"failed"`
+	Unverified int64  `json:"unverified,omitempty"`
+}
+
+func (c cpMetadataSummaryMessage) String() string {
+	msg := console.Colorize("Summarize", fmt.Sprintf("Updated the metadata of %d object(s), %d failed.", c.Updated, c.Failed))
+	if c.Unverified > 0 {
+		msg += console.Colorize("CopyMetadataMismatch", fmt.Sprintf(" The server did not apply the requested metadata of %d object(s).", c.Unverified))
+	}
+	return msg
+}
+
+func (c cpMetadataSummaryMessage) JSON() string {
+	c.Status = "success"
+	msgBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// replacedMetadata returns the metadata to set on an object in place of
+// current, keeping its replaceable headers and user metadata.
+func replacedMetadata(current, updates map[string]string) map[string]string {
+	metadata := make(map[string]string)
+	for k, v := range current {
+		k = http.CanonicalHeaderKey(k)
+		if strings.HasPrefix(k, "X-Amz-Meta-") {
+			metadata[k] = v
+			continue
+		}
+		for _, header := range replaceableHeaders {
+			if k == header {
+				metadata[k] = v
+			}
+		}
+	}
+	for k, v := range updates {
+		metadata[http.CanonicalHeaderKey(k)] = v
+	}
+	return filterMetadata(metadata)
+}
+
+// verifyMetadata compares the requested metadata updates with the
+// metadata read back from the object. User metadata may be requested
+// with or without its X-Amz-Meta- prefix, and dates of an Expires
+// header are compared as times as servers may reformat them.
+func verifyMetadata(updates, applied map[string]string) []cpMetadataAttr {
+	canonical := make(map[string]string, len(applied))
+	for k, v := range applied {
+		canonical[http.CanonicalHeaderKey(k)] = v
+	}
+
+	attrs := make([]cpMetadataAttr, 0, len(updates))
+	for k, v := range updates {
+		key := http.CanonicalHeaderKey(k)
+		value, ok := canonical[key]
+		if !ok && !strings.HasPrefix(key, "X-Amz-Meta-") {
+			value, ok = canonical["X-Amz-Meta-"+key]
+		}
+		verified := ok && value == v
+		if ok && !verified && key == "Expires" {
+			requested, e1 := http.ParseTime(v)
+			got, e2 := http.ParseTime(value)
+			verified = e1 == nil && e2 == nil && requested.Equal(got)
+		}
+		attrs = append(attrs, cpMetadataAttr{Key: key, Requested: v, Applied: value, Verified: verified})
+	}
+	sort.Slice(attrs, func(i, j int) bool { return attrs[i].Key < attrs[j].Key })
+	return attrs
+}
+
+// checkCopyMetadataOnlySyntax validates the arguments of cp --metadata-only.
+func checkCopyMetadataOnlySyntax(cliCtx *cli.Context) {
+	args := cliCtx.Args()
+	if len(args) != 2 {
+		cli.ShowCommandHelpAndExit(cliCtx, "cp", 1) // last argument is exit code.
+	}
+	if strings.TrimSuffix(args[0], "/") != strings.TrimSuffix(args[1], "/") {
+		fatalIf(errInvalidArgument().Trace(args...), "--metadata-only requires the same source and target.")
+	}
+	if !cliCtx.IsSet("cache-control") && !cliCtx.IsSet("expires") && cliCtx.String("attr") == "" {
+		fatalIf(errInvalidArgument().Trace(args...), "--metadata-only requires --cache-control, --expires or --attr.")
+	}
+	if expires := cliCtx.String("expires"); expires != "" {
+		_, e := http.ParseTime(expires)
+		fatalIf(probe.NewError(e).Trace(expires), "Unable to parse --expires, an HTTP date such as 'Wed, 21 Oct 2015 07:28:00 GMT' is expected.")
+	}
+	if cliCtx.Bool("recursive") && !cliCtx.Bool("force") {
+		fatalIf(errInvalidArgument().Trace(args...), "Updating the metadata of every object under `"+args[0]+"` requires --force.")
+	}
+	if cliCtx.Int("workers") < 1 {
+		fatalIf(errInvalidArgument().Trace(args...), "--workers should be at least 1.")
+	}
+}
+
+// readObjectMetadata returns the metadata of an object, as read back
+// after its update.
+func readObjectMetadata(ctx context.Context, alias, urlStr string, encKeyDB map[string][]prefixSSEPair) (map[string]string, *probe.Error) {
+	clnt, err := newClientFromAlias(alias, urlStr)
+	if err != nil {
+		return nil, err.Trace(alias, urlStr)
+	}
+	sse := getSSE(alias+clnt.GetURL().Path, encKeyDB[alias])
+	st, err := clnt.Stat(ctx, StatOptions{sse: sse})
+	if err != nil {
+		return nil, err.Trace(urlStr)
+	}
+	return st.Metadata, nil
+}
+
+// updateObjectMetadata replaces the metadata of an object with a copy
+// of the object onto itself.
+func updateObjectMetadata(ctx context.Context, alias, urlStr string, updates map[string]string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+	clnt, err := newClientFromAlias(alias, urlStr)
+	if err != nil {
+		return err.Trace(alias, urlStr)
+	}
+	sse := getSSE(alias+clnt.GetURL().Path, encKeyDB[alias])
+	st, err := clnt.Stat(ctx, StatOptions{sse: sse})
+	if err != nil {
+		return err.Trace(urlStr)
+	}
+	return clnt.Copy(ctx, clnt.GetURL().Path, CopyOptions{
+		size:             st.Size,
+		srcSSE:           sse,
+		tgtSSE:           sse,
+		metadata:         replacedMetadata(st.Metadata, updates),
+		disableMultipart: st.Size <= maxCopyObjectSize,
+		storageClass:     st.StorageClass,
+	}, nil)
+}
+
+// copyMetadataOnly updates the metadata of the objects at the copy
+// source in place, with --workers objects updated concurrently.
+func copyMetadataOnly(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string][]prefixSSEPair) error {
+	checkCopyMetadataOnlySyntax(cliCtx)
+	console.SetColor("CopyMetadataMismatch", color.New(color.FgYellow))
+
+	target := cliCtx.Args().Get(1)
+	fatalIfReadOnlyURL("cp", target)
+	alias, urlStr, hostCfg := mustExpandAlias(target)
+	if hostCfg == nil {
+		fatalIf(errInvalidArgument().Trace(target), "--metadata-only is only supported on object storage.")
+	}
+
+	updates := make(map[string]string)
+	if attr := cliCtx.String("attr"); attr != "" {
+		userMetaMap, err := getMetaDataEntry(attr)
+		fatalIf(err, "Unable to parse attribute %v", attr)
+		for k, v := range userMetaMap {
+			updates[k] = v
+		}
+	}
+	if cliCtx.IsSet("cache-control") {
+		updates["Cache-Control"] = cliCtx.String("cache-control")
+	}
+	if cliCtx.IsSet("expires") {
+		updates["Expires"] = cliCtx.String("expires")
+	}
+
+	var listErr bool
+	objectCh := make(chan string)
+	go func() {
+		defer close(objectCh)
+		if !cliCtx.Bool("recursive") {
+			objectCh <- urlStr
+			return
+		}
+		clnt, err := newClientFromAlias(alias, urlStr)
+		if err != nil {
+			errorIf(err.Trace(target), "Unable to initialize target `"+target+"`.")
+			listErr = true
+			return
+		}
+		for content := range clnt.List(ctx, ListOptions{Recursive: true, ShowDir: DirNone}) {
+			if content.Err != nil {
+				errorIf(content.Err.Trace(target), "Unable to list `"+target+"`.")
+				listErr = true
+				continue
+			}
+			objectCh <- content.URL.String()
+		}
+	}()
+
+	var (
+		mutex   sync.Mutex
+		summary cpMetadataSummaryMessage
+		wg      sync.WaitGroup
+	)
+	for i := 0; i < cliCtx.Int("workers"); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for objectURL := range objectCh {
+				object := alias + newClientURL(objectURL).Path
+				err := updateObjectMetadata(ctx, alias, objectURL, updates, encKeyDB)
+				msg := cpMetadataMessage{Object: object}
+				unverified := false
+				if err == nil && cliCtx.Bool("verify") {
+					var applied map[string]string
+					if applied, err = readObjectMetadata(ctx, alias, objectURL, encKeyDB); err == nil {
+						msg.Attrs = verifyMetadata(updates, applied)
+						for _, attr := range msg.Attrs {
+							unverified = unverified || !attr.Verified
+						}
+					}
+				}
+
+				mutex.Lock()
+				if err != nil {
+					errorIf(err.Trace(object), "Unable to update the metadata of `"+object+"`.")
+					summary.Failed++
+				} else {
+					printMsg(msg)
+					summary.Updated++
+					if unverified {
+						summary.Unverified++
+					}
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	printMsg(summary)
+	if summary.Failed > 0 || listErr {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
@@ -0,0 +1,320 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// What cp --restore does to an object, from its version as of the
+// --rewind date.
+const (
+	restoreVersion   = "restore"
+	restoreUnchanged = "unchanged"
+	restoreRemove    = "remove"
+)
+
+// restoreAction returns what cp --restore does to an object from all of
+// its versions, along with the version to restore or to remove. Objects
+// which did not exist at timeRef, not yet created or deleted, are removed.
+func restoreAction(versions []*ClientContent, timeRef time.Time) (string, *ClientContent) {
+	var latest, atDate *ClientContent
+	for _, version := range versions {
+		if version.IsLatest || latest == nil || (!latest.IsLatest && version.Time.After(latest.Time)) {
+			latest = version
+		}
+		if !version.Time.After(timeRef) && (atDate == nil || version.Time.After(atDate.Time)) {
+			atDate = version
+		}
+	}
+	switch {
+	case latest == nil:
+		return restoreUnchanged, nil
+	case atDate == nil || atDate.IsDeleteMarker:
+		// Objects without versions would be removed for good.
+		if latest.IsDeleteMarker || latest.VersionID == "" {
+			return restoreUnchanged, latest
+		}
+		return restoreRemove, latest
+	case atDate == latest:
+		return restoreUnchanged, latest
+	default:
+		return restoreVersion, atDate
+	}
+}
+
+// cpRestoreMessage container for an object restored to a past version.
+type cpRestoreMessage struct {
+	Status       string    `json:"status"`
+	Object       string    `json:"object"`
+	VersionID    string    `json:"versionId"`
+	LastModified time.Time `json:"lastModified"`
+	// Removed is set when the object did not exist at the --rewind
+	// date, VersionID is then its current version.
+	Removed bool `json:"removed,omitempty"`
+	DryRun  bool `json:"dryRun,omitempty"`
+}
+
+func (c cpRestoreMessage) String() string {
+	if c.Removed {
+		verb := "Removed"
+		if c.DryRun {
+			verb = "Would remove"
+		}
+		return console.Colorize("Copy", fmt.Sprintf("%s `%s`, which did not exist at that date", verb, c.Object))
+	}
+	verb := "Restored"
+	if c.DryRun {
+		verb = "Would restore"
+	}
+	return console.Colorize("Copy", fmt.Sprintf("%s `%s` to version `%s` of %s", verb, c.Object, c.VersionID, c.LastModified.Format(printDate)))
+}
+
+func (c cpRestoreMessage) JSON() string {
+	c.Status = "success"
+	msgBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// cpRestoreSummaryMessage container for the counts of a restore.
+type cpRestoreSummaryMessage struct {
+	Status    string `json:"status"`
+	Restored  int64  `json:"restored"`
+	Unchanged int64  `json:"unchanged"`
+	Removed   int64  `json:"removed"`
+	Failed    int64  `json:"failed"`
+	DryRun    bool   `json:"dryRun,omitempty"`
+}
+
+func (c cpRestoreSummaryMessage) String() string {
+	verb := "Restored"
+	if c.DryRun {
+		verb = "Would restore"
+	}
+	msg := fmt.Sprintf("%s %d object(s), %d unchanged, %d failed.", verb, c.Restored, c.Unchanged, c.Failed)
+	if c.Removed > 0 {
+		verb = "Removed"
+		if c.DryRun {
+			verb = "Would remove"
+		}
+		msg += fmt.Sprintf(" %s %d object(s) which did not exist at that date.", verb, c.Removed)
+	}
+	return console.Colorize("Summarize", msg)
+}
+
+func (c cpRestoreSummaryMessage) JSON() string {
+	c.Status = "success"
+	msgBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// checkCopyRestoreSyntax validates the arguments of cp --restore.
+func checkCopyRestoreSyntax(cliCtx *cli.Context) {
+	args := cliCtx.Args()
+	if len(args) != 2 {
+		cli.ShowCommandHelpAndExit(cliCtx, "cp", 1) // last argument is exit code.
+	}
+	if strings.TrimSuffix(args[0], "/") != strings.TrimSuffix(args[1], "/") {
+		fatalIf(errInvalidArgument().Trace(args...), "--restore requires the same source and target.")
+	}
+	if cliCtx.String("rewind") == "" {
+		fatalIf(errInvalidArgument().Trace(args...), "--restore requires --rewind, the date to restore the objects to.")
+	}
+	if !cliCtx.Bool("recursive") {
+		fatalIf(errInvalidArgument().Trace(args...), "--restore requires --recursive.")
+	}
+	if cliCtx.Bool("metadata-only") || cliCtx.String("version-id") != "" {
+		fatalIf(errInvalidArgument().Trace(args...), "--restore cannot be used with --metadata-only or --version-id.")
+	}
+	if !cliCtx.Bool("dry-run") && !cliCtx.Bool("force") {
+		fatalIf(errInvalidArgument().Trace(args...), "Restoring every object under `"+args[0]+"` to its version of "+cliCtx.String("rewind")+" requires --force, preview it with --dry-run.")
+	}
+	if cliCtx.Int("workers") < 1 {
+		fatalIf(errInvalidArgument().Trace(args...), "--workers should be at least 1.")
+	}
+}
+
+// restoreObjectVersion makes a version of an object its current
+// version with a copy of the version onto the object.
+func restoreObjectVersion(ctx context.Context, alias string, content *ClientContent, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+	objectURL := content.URL.String()
+	clnt, err := newClientFromAlias(alias, objectURL)
+	if err != nil {
+		return err.Trace(alias, objectURL)
+	}
+	sse := getSSE(alias+clnt.GetURL().Path, encKeyDB[alias])
+	return clnt.Copy(ctx, clnt.GetURL().Path, CopyOptions{
+		versionID:         content.VersionID,
+		size:              content.Size,
+		srcSSE:            sse,
+		tgtSSE:            sse,
+		metadataDirective: copyMetadataDirective,
+		disableMultipart:  content.Size <= maxCopyObjectSize,
+		storageClass:      content.StorageClass,
+	}, nil)
+}
+
+// removeRestoredObject removes an object which did not exist at the
+// --rewind date. Its versions are kept, a delete marker is added.
+func removeRestoredObject(ctx context.Context, alias string, content *ClientContent) *probe.Error {
+	objectURL := content.URL.String()
+	clnt, err := newClientFromAlias(alias, objectURL)
+	if err != nil {
+		return err.Trace(alias, objectURL)
+	}
+	contentCh := make(chan *ClientContent, 1)
+	contentCh <- &ClientContent{URL: content.URL}
+	close(contentCh)
+	for result := range clnt.Remove(ctx, false, false, false, false, contentCh) {
+		if result.Err != nil {
+			return result.Err.Trace(objectURL)
+		}
+	}
+	return nil
+}
+
+// restoreTask is an object to restore to a version, or to remove.
+type restoreTask struct {
+	action  string
+	content *ClientContent
+}
+
+// copyRestore restores the objects under the copy source to their
+// versions as of the --rewind date, with --workers objects restored
+// concurrently. Objects which did not exist at that date are removed.
+func copyRestore(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string][]prefixSSEPair) error {
+	checkCopyRestoreSyntax(cliCtx)
+
+	target := cliCtx.Args().Get(1)
+	dryRun := cliCtx.Bool("dry-run")
+	if !dryRun {
+		fatalIfReadOnlyURL("cp", target)
+	}
+	alias, urlStr, hostCfg := mustExpandAlias(target)
+	if hostCfg == nil {
+		fatalIf(errInvalidArgument().Trace(target), "--restore is only supported on object storage.")
+	}
+	clnt, err := newClientFromAlias(alias, urlStr)
+	fatalIf(err.Trace(target), "Unable to initialize target `"+target+"`.")
+
+	var (
+		listErr bool
+		mutex   sync.Mutex
+		summary = cpRestoreSummaryMessage{DryRun: dryRun}
+		wg      sync.WaitGroup
+	)
+	timeRef := parseRewindFlag(cliCtx.String("rewind"))
+	restoreCh := make(chan restoreTask)
+	go func() {
+		defer close(restoreCh)
+		// The versions of an object are listed one after the other.
+		var versions []*ClientContent
+		dispatch := func() {
+			action, content := restoreAction(versions, timeRef)
+			versions = versions[:0]
+			if action == restoreUnchanged {
+				if content != nil && !content.IsDeleteMarker {
+					mutex.Lock()
+					summary.Unchanged++
+					mutex.Unlock()
+				}
+				return
+			}
+			restoreCh <- restoreTask{action: action, content: content}
+		}
+		for content := range clnt.List(ctx, ListOptions{
+			Recursive:         true,
+			TimeRef:           time.Now().UTC(),
+			WithOlderVersions: true,
+			WithDeleteMarkers: true,
+			ShowDir:           DirNone,
+		}) {
+			if content.Err != nil {
+				errorIf(content.Err.Trace(target), "Unable to list `"+target+"`.")
+				listErr = true
+				continue
+			}
+			if len(versions) > 0 && versions[0].URL.Path != content.URL.Path {
+				dispatch()
+			}
+			versions = append(versions, content)
+		}
+		if len(versions) > 0 {
+			dispatch()
+		}
+	}()
+
+	for i := 0; i < cliCtx.Int("workers"); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range restoreCh {
+				content := task.content
+				object := alias + content.URL.Path
+				removed := task.action == restoreRemove
+				var err *probe.Error
+				if !dryRun {
+					if removed {
+						err = removeRestoredObject(ctx, alias, content)
+					} else {
+						err = restoreObjectVersion(ctx, alias, content, encKeyDB)
+					}
+				}
+
+				mutex.Lock()
+				switch {
+				case err != nil:
+					errorIf(err.Trace(object), "Unable to restore `"+object+"`.")
+					summary.Failed++
+				default:
+					printMsg(cpRestoreMessage{
+						Object:       object,
+						VersionID:    content.VersionID,
+						LastModified: content.Time,
+						Removed:      removed,
+						DryRun:       dryRun,
+					})
+					if removed {
+						summary.Removed++
+					} else {
+						summary.Restored++
+					}
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	printMsg(summary)
+	if summary.Failed > 0 || listErr {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRestoreAction(t *testing.T) {
+	rewind := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	before := rewind.Add(-time.Hour)
+	after := rewind.Add(time.Hour)
+	version := func(id string, mtime time.Time, isLatest, isDeleteMarker bool) *ClientContent {
+		return &ClientContent{VersionID: id, Time: mtime, IsLatest: isLatest, IsDeleteMarker: isDeleteMarker}
+	}
+	testCases := []struct {
+		versions  []*ClientContent
+		action    string
+		versionID string
+	}{
+		// Not modified since the rewind date.
+		{[]*ClientContent{version("v1", before, true, false)}, restoreUnchanged, "v1"},
+		// Overwritten since the rewind date.
+		{[]*ClientContent{version("v2", after, true, false), version("v1", before, false, false)}, restoreVersion, "v1"},
+		// Deleted since the rewind date.
+		{[]*ClientContent{version("d1", after, true, true), version("v1", before, false, false)}, restoreVersion, "v1"},
+		// Created since the rewind date.
+		{[]*ClientContent{version("v1", after, true, false)}, restoreRemove, "v1"},
+		// Deleted at the rewind date and created again since.
+		{[]*ClientContent{version("v2", after, true, false), version("d1", before, false, true), version("v1", before.Add(-time.Hour), false, false)}, restoreRemove, "v2"},
+		// Deleted at the rewind date and still deleted.
+		{[]*ClientContent{version("d1", before, true, true), version("v1", before.Add(-time.Hour), false, false)}, restoreUnchanged, "d1"},
+		// Created and deleted since the rewind date.
+		{[]*ClientContent{version("d1", after.Add(time.Hour), true, true), version("v1", after, false, false)}, restoreUnchanged, "d1"},
+		// Unversioned objects are never removed.
+		{[]*ClientContent{version("", after, false, false)}, restoreUnchanged, ""},
+		{[]*ClientContent{version("", before, false, false)}, restoreUnchanged, ""},
+	}
+	for i, testCase := range testCases {
+		action, content := restoreAction(testCase.versions, rewind)
+		if action != testCase.action {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.action, action)
+		}
+		if content == nil || content.VersionID != testCase.versionID {
+			t.Fatalf("Test %d: expected version %q, got %v", i+1, testCase.versionID, content)
+		}
+	}
+	if action, content := restoreAction(nil, rewind); action != restoreUnchanged || content != nil {
+		t.Fatalf("expected no action without versions, got %s %v", action, content)
+	}
+}
+
+func TestCopyRestoreSummaryMessage(t *testing.T) {
+	msg := cpRestoreSummaryMessage{Restored: 3, Unchanged: 2, Removed: 1, DryRun: true}
+	if s := msg.String(); !strings.Contains(s, "Would restore 3 object(s), 2 unchanged, 0 failed.") || !strings.Contains(s, "Would remove 1 object(s)") {
+		t.Fatalf("unexpected summary %q", s)
+	}
+	if s := msg.JSON(); !strings.Contains(s, `"dryRun":true`) || !strings.Contains(s, `"restored":3`) {
+		t.Fatalf("unexpected summary %s", s)
+	}
+}
@@ -0,0 +1,296 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// copyResumeToken is the position of an interrupted recursive copy,
+// encoded in the resume token printed by cp.
+type copyResumeToken struct {
+	// Args are the source and target arguments of the copy.
+	Args []string `json:"args"`
+	// Cursor is the last source of the listing copied along with all
+	// the sources listed before it, Position is the number of them.
+	Cursor   string `json:"cursor,omitempty"`
+	Position int64  `json:"position"`
+	// Manifest is the SHA-256 sum, and the name in the resume manifests
+	// folder, of the list of the sources copied after the cursor, which
+	// would make the token grow with the number of copied objects.
+	Manifest string `json:"manifest,omitempty"`
+	// IssuedAt is the start of the copy, sources modified after it
+	// are copied again.
+	IssuedAt time.Time `json:"issuedAt"`
+
+	// completed are the sources copied after the cursor.
+	completed []string
+}
+
+var (
+	errCorruptedResumeToken    = errors.New("resume token is corrupted")
+	errCorruptedResumeManifest = errors.New("resume manifest is missing or corrupted")
+)
+
+// Folder of the config folder keeping the manifests of resume tokens,
+// removed once they are older than copyResumeManifestMaxAge.
+const (
+	copyResumeManifestDir    = "resume-manifests"
+	copyResumeManifestMaxAge = 30 * 24 * time.Hour
+)
+
+// writeCopyResumeManifest saves the list of the completed sources and
+// returns its name, pruning the manifests of old tokens.
+func writeCopyResumeManifest(completed []string) (string, *probe.Error) {
+	if len(completed) == 0 {
+		return "", nil
+	}
+	dir := filepath.Join(mustGetMcConfigDir(), copyResumeManifestDir)
+	if e := os.MkdirAll(dir, 0o700); e != nil {
+		return "", probe.NewError(e).Trace(dir)
+	}
+	if entries, e := ioutil.ReadDir(dir); e == nil {
+		for _, entry := range entries {
+			if time.Since(entry.ModTime()) > copyResumeManifestMaxAge {
+				os.Remove(filepath.Join(dir, entry.Name()))
+			}
+		}
+	}
+	data := []byte(strings.Join(completed, "\n"))
+	sum := sha256.Sum256(data)
+	name := hex.EncodeToString(sum[:])
+	if e := ioutil.WriteFile(filepath.Join(dir, name), data, 0o600); e != nil {
+		return "", probe.NewError(e).Trace(dir, name)
+	}
+	return name, nil
+}
+
+// readCopyResumeManifest returns the completed sources listed in the
+// manifest name, after verifying its integrity.
+func readCopyResumeManifest(name string) ([]string, *probe.Error) {
+	if name == "" {
+		return nil, nil
+	}
+	path := filepath.Join(mustGetMcConfigDir(), copyResumeManifestDir, filepath.Base(name))
+	data, e := ioutil.ReadFile(path)
+	if e != nil {
+		return nil, probe.NewError(errCorruptedResumeManifest).Trace(path)
+	}
+	if sum := sha256.Sum256(data); hex.EncodeToString(sum[:]) != name {
+		return nil, probe.NewError(errCorruptedResumeManifest).Trace(path)
+	}
+	return strings.Split(string(data), "\n"), nil
+}
+
+// encodeCopyResumeToken returns the token prefixed with the SHA-256
+// sum of its content, to detect truncated or edited tokens.
+func encodeCopyResumeToken(t copyResumeToken) string {
+	tokenBytes, _ := json.Marshal(t)
+	sum := sha256.Sum256(tokenBytes)
+	return base64.RawURLEncoding.EncodeToString(append(sum[:], tokenBytes...))
+}
+
+// decodeCopyResumeToken verifies the integrity of token and of its
+// manifest, and that it was issued for a copy of the same arguments.
+func decodeCopyResumeToken(token string, args []string) (t copyResumeToken, err *probe.Error) {
+	tokenBytes, e := base64.RawURLEncoding.DecodeString(token)
+	if e != nil {
+		return t, probe.NewError(errCorruptedResumeToken).Trace(token)
+	}
+	if len(tokenBytes) < sha256.Size {
+		return t, probe.NewError(errCorruptedResumeToken).Trace(token)
+	}
+	sum := sha256.Sum256(tokenBytes[sha256.Size:])
+	if !bytes.Equal(sum[:], tokenBytes[:sha256.Size]) {
+		return t, probe.NewError(errCorruptedResumeToken).Trace(token)
+	}
+	if e = json.Unmarshal(tokenBytes[sha256.Size:], &t); e != nil {
+		return t, probe.NewError(e).Trace(token)
+	}
+	if len(t.Args) != len(args) {
+		return t, errInvalidArgument().Trace(args...)
+	}
+	for i := range args {
+		if t.Args[i] != args[i] {
+			return t, errInvalidArgument().Trace(args...)
+		}
+	}
+	t.completed, err = readCopyResumeManifest(t.Manifest)
+	return t, err
+}
+
+// copyResumeFilter tells which sources were already copied according
+// to a resume token, in the order of the listing.
+type copyResumeFilter struct {
+	token     copyResumeToken
+	completed map[string]bool
+	listed    int64
+	passed    bool
+
+	warnChanged, warnModified bool
+}
+
+func newCopyResumeFilter(t copyResumeToken) *copyResumeFilter {
+	completed := make(map[string]bool, len(t.completed))
+	for _, source := range t.completed {
+		completed[source] = true
+	}
+	return &copyResumeFilter{
+		token:     t,
+		completed: completed,
+		passed:    t.Position == 0,
+	}
+}
+
+// isCopied returns true if source was copied by a previous run and was
+// not modified since.
+func (f *copyResumeFilter) isCopied(source string, modTime time.Time) bool {
+	f.listed++
+	copied := f.completed[source]
+	if !f.passed {
+		switch {
+		case source == f.token.Cursor:
+			f.passed = true
+			copied = true
+			if f.listed != f.token.Position {
+				f.warnListingChanged()
+			}
+		case f.listed <= f.token.Position:
+			copied = true
+		default:
+			// The cursor is not where it was listed, objects were
+			// added or removed since the token was issued.
+			f.passed = true
+			f.warnListingChanged()
+		}
+	}
+	if copied && modTime.After(f.token.IssuedAt) {
+		f.warn(&f.warnModified, "Source objects were modified since the resume token was issued, copying them again.")
+		return false
+	}
+	return copied
+}
+
+// finish warns if the listing ended before reaching the cursor.
+func (f *copyResumeFilter) finish() {
+	if !f.passed {
+		f.warnListingChanged()
+	}
+}
+
+func (f *copyResumeFilter) warnListingChanged() {
+	f.warn(&f.warnChanged, "Source listing changed since the resume token was issued, some objects may be copied again or skipped.")
+}
+
+func (f *copyResumeFilter) warn(warned *bool, msg string) {
+	if *warned {
+		return
+	}
+	*warned = true
+	if !globalQuiet && !globalJSON {
+		console.Eraseline()
+	}
+	warning(msg)
+}
+
+// copyResumeTracker follows the sources of a recursive copy as they
+// are listed and copied, to issue a resume token on interruption.
+type copyResumeTracker struct {
+	sync.Mutex
+	token   copyResumeToken
+	pending []string
+	copied  map[string]bool
+}
+
+func newCopyResumeTracker(args []string) *copyResumeTracker {
+	return &copyResumeTracker{
+		token:  copyResumeToken{Args: args, IssuedAt: UTCNow()},
+		copied: make(map[string]bool),
+	}
+}
+
+// listed records the next source of the listing.
+func (r *copyResumeTracker) listed(source string) {
+	r.Lock()
+	r.pending = append(r.pending, source)
+	r.Unlock()
+}
+
+// done records a copied source and moves the cursor after all the
+// sources copied in the order of the listing.
+func (r *copyResumeTracker) done(source string) {
+	r.Lock()
+	defer r.Unlock()
+	r.copied[source] = true
+	for len(r.pending) > 0 && r.copied[r.pending[0]] {
+		delete(r.copied, r.pending[0])
+		r.token.Cursor = r.pending[0]
+		r.token.Position++
+		r.pending = r.pending[1:]
+	}
+}
+
+// message returns the message with the token resuming the copy.
+func (r *copyResumeTracker) message() copyResumeTokenMessage {
+	r.Lock()
+	defer r.Unlock()
+	t := r.token
+	completed := make([]string, 0, len(r.copied))
+	for source := range r.copied {
+		completed = append(completed, source)
+	}
+	sort.Strings(completed)
+	// The sources copied after the cursor are copied again by the
+	// next run when they cannot be saved.
+	var err *probe.Error
+	t.Manifest, err = writeCopyResumeManifest(completed)
+	errorIf(err, "Unable to save the sources copied after the resume token.")
+	return copyResumeTokenMessage{ResumeToken: encodeCopyResumeToken(t)}
+}
+
+// copyResumeTokenMessage container for the token of an interrupted copy.
+type copyResumeTokenMessage struct {
+	Status      string `json:"status"`
+	ResumeToken string `json:"resumeToken"`
+}
+
+func (c copyResumeTokenMessage) String() string {
+	return console.Colorize("Summarize", "Resume token: "+c.ResumeToken)
+}
+
+func (c copyResumeTokenMessage) JSON() string {
+	c.Status = "success"
+	msgBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCopyResumeToken(t *testing.T) {
+	withTempMcConfig(t)
+
+	args := []string{"play/mybucket/", "/mnt/backup/"}
+	r := newCopyResumeTracker(args)
+	for _, source := range []string{"a", "b", "c", "d", "e"} {
+		r.listed(source)
+	}
+	// Copies complete out of order, the cursor only moves after
+	// all the sources listed before it are copied.
+	r.done("b")
+	r.done("d")
+	r.done("a")
+
+	token := r.message().ResumeToken
+	decoded, err := decodeCopyResumeToken(token, args)
+	if err != nil {
+		t.Fatalf("unexpected error decoding the token: %v", err)
+	}
+	if decoded.Cursor != "b" || decoded.Position != 2 {
+		t.Fatalf("expected the cursor after `b` at position 2, got `%s` at %d", decoded.Cursor, decoded.Position)
+	}
+	if len(decoded.completed) != 1 || decoded.completed[0] != "d" {
+		t.Fatalf("expected `d` to be completed after the cursor, got %v", decoded.completed)
+	}
+
+	if _, err = decodeCopyResumeToken(token, []string{"play/mybucket/", "/mnt/other/"}); err == nil {
+		t.Fatal("expected an error decoding a token of other arguments")
+	}
+	if _, err = decodeCopyResumeToken(token[:len(token)-2], args); err == nil {
+		t.Fatal("expected an error decoding a truncated token")
+	}
+
+	f := newCopyResumeFilter(decoded)
+	before := decoded.IssuedAt.Add(-time.Hour)
+	testCases := []struct {
+		source  string
+		modTime time.Time
+		copied  bool
+	}{
+		{"a", before, true},
+		{"b", before, true},
+		{"c", before, false},
+		{"d", before, true},
+		{"e", before, false},
+	}
+	for i, testCase := range testCases {
+		if copied := f.isCopied(testCase.source, testCase.modTime); copied != testCase.copied {
+			t.Fatalf("Test %d: expected copied %v for `%s`, got %v", i+1, testCase.copied, testCase.source, copied)
+		}
+	}
+	if f.warnChanged || f.warnModified {
+		t.Fatal("unexpected warnings resuming an unchanged listing")
+	}
+
+	// A source modified after the token was issued is copied again.
+	f = newCopyResumeFilter(decoded)
+	if f.isCopied("a", decoded.IssuedAt.Add(time.Hour)) || !f.warnModified {
+		t.Fatal("expected a modified source to be copied again")
+	}
+}
+
+func TestCopyResumeTokenManifest(t *testing.T) {
+	withTempMcConfig(t)
+
+	args := []string{"play/mybucket/", "/mnt/backup/"}
+	r := newCopyResumeTracker(args)
+	for i := 0; i < 10000; i++ {
+		r.listed(fmt.Sprintf("play/mybucket/object-%05d", i))
+	}
+	// A stuck first copy keeps all the others after the cursor.
+	for i := 1; i < 10000; i++ {
+		r.done(fmt.Sprintf("play/mybucket/object-%05d", i))
+	}
+	token := r.message().ResumeToken
+	if len(token) > 512 {
+		t.Fatalf("expected a token independent of the copied objects, got %d bytes", len(token))
+	}
+	decoded, err := decodeCopyResumeToken(token, args)
+	if err != nil {
+		t.Fatalf("unexpected error decoding the token: %v", err)
+	}
+	if len(decoded.completed) != 9999 || decoded.completed[0] != "play/mybucket/object-00001" {
+		t.Fatalf("expected the 9999 completed sources from the manifest, got %d", len(decoded.completed))
+	}
+
+	// An edited manifest invalidates the token.
+	manifest := filepath.Join(mustGetMcConfigDir(), copyResumeManifestDir, decoded.Manifest)
+	if e := ioutil.WriteFile(manifest, []byte("play/mybucket/object-00000"), 0o600); e != nil {
+		t.Fatal(e)
+	}
+	if _, err = decodeCopyResumeToken(token, args); err == nil {
+		t.Fatal("expected an error decoding a token with an edited manifest")
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// isSameObjectCopy returns true if the copy writes the latest version
+// of an object over itself, e.g. to change its storage class.
+func isSameObjectCopy(urls URLs) bool {
+	return urls.SourceContent.URL.Type == objectStorage &&
+		urls.SourceContent.VersionID == "" &&
+		urls.SourceAlias == urls.TargetAlias &&
+		urls.SourceContent.URL.Path == urls.TargetContent.URL.Path
+}
+
+// normalizeStorageClass returns the storage class of an object, the
+// servers do not report the STANDARD class of an object.
+func normalizeStorageClass(storageClass string) string {
+	if storageClass == "" {
+		return "STANDARD"
+	}
+	return strings.ToUpper(storageClass)
+}
+
+// changesStorageClassOnly returns true if the copy sets nothing but the
+// storage class, no metadata, tags, retention or legal hold.
+func changesStorageClassOnly(urls URLs) bool {
+	target := urls.TargetContent
+	return len(target.Metadata) == 0 && len(target.UserMetadata) == 0 &&
+		!target.RetentionEnabled && target.RetentionDuration == "" &&
+		!target.LegalHoldEnabled && urls.MetadataDirective != "REPLACE"
+}
+
+// hasStorageClass returns true if the copy of an object over itself
+// only sets the storage class the object is already in.
+func hasStorageClass(urls URLs) bool {
+	return urls.TargetContent.StorageClass != "" && isSameObjectCopy(urls) && changesStorageClassOnly(urls) &&
+		normalizeStorageClass(urls.SourceContent.StorageClass) == normalizeStorageClass(urls.TargetContent.StorageClass)
+}
+
+// storageClassSummaryMessage reports the number of objects whose
+// storage class was changed by copying them over themselves.
+type storageClassSummaryMessage struct {
+	Status       string `json:"status"`
+	StorageClass string `json:"storageClass"`
+	Changed      int64  `json:"changed"`
+	Unchanged    int64  `json:"unchanged"`
+}
+
+// String colorized storage class summary message
+func (s storageClassSummaryMessage) String() string {
+	return console.Colorize("Copy", fmt.Sprintf("Changed the storage class of %d object(s) to %s, skipped %d object(s) already in it.",
+		s.Changed, normalizeStorageClass(s.StorageClass), s.Unchanged))
+}
+
+// JSON jsonified storage class summary message
+func (s storageClassSummaryMessage) JSON() string {
+	s.Status = "success"
+	s.StorageClass = normalizeStorageClass(s.StorageClass)
+	msgBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(msgBytes)
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHasStorageClass(t *testing.T) {
+	newURLs := func(source, sourceClass, target, targetClass, versionID string) URLs {
+		return URLs{
+			SourceAlias: "play",
+			SourceContent: &ClientContent{
+				URL:          *newClientURL("https://play.min.io" + source),
+				StorageClass: sourceClass,
+				VersionID:    versionID,
+			},
+			TargetAlias: "play",
+			TargetContent: &ClientContent{
+				URL:          *newClientURL("https://play.min.io" + target),
+				StorageClass: targetClass,
+			},
+		}
+	}
+	testCases := []struct {
+		urls     URLs
+		sameObj  bool
+		hasClass bool
+	}{
+		{newURLs("/bucket/a", "STANDARD_IA", "/bucket/a", "STANDARD_IA", ""), true, true},
+		{newURLs("/bucket/a", "STANDARD_IA", "/bucket/a", "standard_ia", ""), true, true},
+		{newURLs("/bucket/a", "", "/bucket/a", "STANDARD", ""), true, true},
+		{newURLs("/bucket/a", "STANDARD", "/bucket/a", "STANDARD_IA", ""), true, false},
+		{newURLs("/bucket/a", "STANDARD_IA", "/bucket/a", "", ""), true, false},
+		{newURLs("/bucket/a", "STANDARD_IA", "/bucket/b", "STANDARD_IA", ""), false, false},
+		{newURLs("/bucket/a", "STANDARD_IA", "/bucket/a", "STANDARD_IA", "v1"), false, false},
+	}
+	for i, testCase := range testCases {
+		if sameObj := isSameObjectCopy(testCase.urls); sameObj != testCase.sameObj {
+			t.Fatalf("Test %d: expected same object %v, got %v", i+1, testCase.sameObj, sameObj)
+		}
+		if hasClass := hasStorageClass(testCase.urls); hasClass != testCase.hasClass {
+			t.Fatalf("Test %d: expected storage class match %v, got %v", i+1, testCase.hasClass, hasClass)
+		}
+	}
+}
+
+func TestHasStorageClassOtherChanges(t *testing.T) {
+	newURLs := func() URLs {
+		return URLs{
+			SourceAlias: "play",
+			SourceContent: &ClientContent{
+				URL:          *newClientURL("https://play.min.io/bucket/a"),
+				StorageClass: "STANDARD_IA",
+			},
+			TargetAlias: "play",
+			TargetContent: &ClientContent{
+				URL:          *newClientURL("https://play.min.io/bucket/a"),
+				StorageClass: "STANDARD_IA",
+				Metadata:     map[string]string{},
+				UserMetadata: map[string]string{},
+			},
+		}
+	}
+	testCases := []struct {
+		name     string
+		change   func(urls *URLs)
+		hasClass bool
+	}{
+		{"storage-class", func(urls *URLs) {}, true},
+		{"metadata-directive COPY", func(urls *URLs) { urls.MetadataDirective = "COPY" }, true},
+		{"attr", func(urls *URLs) { urls.TargetContent.UserMetadata["Cache-Control"] = "max-age=60" }, false},
+		{"tags", func(urls *URLs) { urls.TargetContent.Metadata["X-Amz-Tagging"] = "key=value" }, false},
+		{"retention-mode", func(urls *URLs) {
+			urls.TargetContent.RetentionMode = "GOVERNANCE"
+			urls.TargetContent.RetentionEnabled = true
+		}, false},
+		{"retention-duration", func(urls *URLs) { urls.TargetContent.RetentionDuration = "1d" }, false},
+		{"legal-hold", func(urls *URLs) {
+			urls.TargetContent.LegalHold = "ON"
+			urls.TargetContent.LegalHoldEnabled = true
+		}, false},
+		{"metadata-directive REPLACE", func(urls *URLs) { urls.MetadataDirective = "REPLACE" }, false},
+	}
+	for _, testCase := range testCases {
+		urls := newURLs()
+		testCase.change(&urls)
+		if hasClass := hasStorageClass(urls); hasClass != testCase.hasClass {
+			t.Errorf("%s: expected storage class match %v, got %v", testCase.name, testCase.hasClass, hasClass)
+		}
+	}
+}
+
+func TestStorageClassSummaryMessage(t *testing.T) {
+	msg := storageClassSummaryMessage{StorageClass: "standard_ia", Changed: 3, Unchanged: 2}
+	if s := msg.String(); !strings.Contains(s, "3 object(s) to STANDARD_IA") || !strings.Contains(s, "skipped 2") {
+		t.Fatalf("unexpected message %s", s)
+	}
+	if s := msg.JSON(); !strings.Contains(s, `"storageClass":"STANDARD_IA"`) || !strings.Contains(s, `"unchanged":2`) {
+		t.Fatalf("unexpected JSON %s", s)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	// copyToken matches {date:LAYOUT} and {env:VAR} tokens, other
+	// names in braces are literal parts of object names.
+	copyToken = regexp.MustCompile(`\{(date|env):([^{}]*)\}`)
+
+	// copyTokenStart matches the start of a token, to find unterminated ones.
+	copyTokenStart = regexp.MustCompile(`\{(date|env):`)
+
+	// Names of environment variables which may be expanded.
+	copyTokenEnvName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// expandCopyTokens expands the {date:LAYOUT} and {env:VAR} tokens of s,
+// LAYOUT is a Go time layout applied to now in UTC. Braces around any
+// other name are left as is. An unset variable or a variable holding a
+// path separator, '..' or an --attr delimiter is an error, so that the
+// environment cannot change where objects are copied or which metadata
+// is set.
+func expandCopyTokens(s string, now time.Time) (string, *probe.Error) {
+	if len(copyTokenStart.FindAllStringIndex(s, -1)) != len(copyToken.FindAllStringIndex(s, -1)) {
+		return s, probe.NewError(fmt.Errorf("malformed token in `%s`", s))
+	}
+
+	var err *probe.Error
+	expanded := copyToken.ReplaceAllStringFunc(s, func(token string) string {
+		if err != nil {
+			return token
+		}
+		match := copyToken.FindStringSubmatch(token)
+		name, value := match[1], match[2]
+		if name == "date" {
+			if value == "" {
+				err = probe.NewError(fmt.Errorf("empty date layout in `%s`", token))
+				return token
+			}
+			return now.UTC().Format(value)
+		}
+		if !copyTokenEnvName.MatchString(value) {
+			err = probe.NewError(fmt.Errorf("invalid environment variable name in `%s`", token))
+			return token
+		}
+		v, ok := os.LookupEnv(value)
+		if !ok {
+			err = probe.NewError(fmt.Errorf("environment variable `%s` is not set", value))
+			return token
+		}
+		if strings.ContainsAny(v, `/\;=`) || strings.Contains(v, "..") {
+			err = probe.NewError(fmt.Errorf("environment variable `%s` cannot contain '/', '\\', ';', '=' or '..'", value))
+			return token
+		}
+		return v
+	})
+	if err != nil {
+		return s, err
+	}
+	return expanded, nil
+}
+
+// expandCopyTokensFromContext returns a copy of cliCtx with the tokens
+// of the copy target and of --attr expanded, before any of them is
+// parsed. cliCtx itself is left as given on the command line.
+func expandCopyTokensFromContext(cliCtx *cli.Context) *cli.Context {
+	now := time.Now()
+	args := append([]string{}, cliCtx.Args()...)
+	if len(args) > 0 {
+		target, err := expandCopyTokens(args[len(args)-1], now)
+		fatalIf(err.Trace(args[len(args)-1]), "Unable to expand the tokens of the target.")
+		args[len(args)-1] = target
+	}
+
+	set := flag.NewFlagSet(cliCtx.Command.Name, flag.ContinueOnError)
+	for _, f := range cliCtx.Command.Flags {
+		f.Apply(set)
+		names := strings.Split(f.GetName(), ",")
+		for i := range names {
+			names[i] = strings.TrimSpace(names[i])
+		}
+		// The value of the first name set applies to all the names of the flag.
+		var value flag.Value
+		for _, name := range names {
+			if v, ok := cliCtx.Generic(name).(flag.Value); ok && cliCtx.IsSet(name) {
+				value = v
+				break
+			}
+		}
+		if value == nil {
+			continue
+		}
+		v := value.String()
+		if names[0] == "attr" {
+			expanded, err := expandCopyTokens(v, now)
+			fatalIf(err.Trace(v), "Unable to expand the tokens of --attr.")
+			v = expanded
+		}
+		for _, name := range names {
+			fatalIf(probe.NewError(set.Set(name, v)), "Unable to set --"+name+".")
+		}
+	}
+	fatalIf(probe.NewError(set.Parse(append([]string{"--"}, args...))), "Unable to parse the arguments.")
+
+	expandedCtx := cli.NewContext(cliCtx.App, set, cliCtx.Parent())
+	expandedCtx.Command = cliCtx.Command
+	return expandedCtx
+}
@@ -21,13 +21,29 @@ import (
 	"context"
 	"fmt"
 	"runtime"
+	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/minio/cli"
 	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/pkg/console"
 )
 
+// isTargetBucketLockDisabled tells if the bucket of tgtURL is known to have
+// no object lock. The lock status is unknown when its configuration cannot
+// be read, e.g. without the permission to get it, the error is returned
+// and the uploads are left to report any failure.
+func isTargetBucketLockDisabled(ctx context.Context, tgtURL string) (bool, *probe.Error) {
+	enabled, err := isBucketLockEnabled(ctx, tgtURL)
+	if err != nil {
+		return false, err
+	}
+	return !enabled, nil
+}
+
 func checkCopySyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string][]prefixSSEPair, isMvCmd bool) {
 	if len(cliCtx.Args()) < 2 {
 		if isMvCmd {
@@ -57,6 +73,98 @@ func checkCopySyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 		fatalIf(errDummy().Trace(cliCtx.Args()...), "--zip and --rewind cannot be used together")
 	}
 
+	if cliCtx.Bool("update") && (versionID != "" || cliCtx.String("rewind") != "") {
+		fatalIf(errDummy().Trace(cliCtx.Args()...), "--update cannot be used with --version-id or --rewind, an older version of the source is not expected to be newer than the target")
+	}
+
+	if cliCtx.Bool("content-md5") && !cliCtx.Bool("disable-multipart") {
+		fatalIf(errDummy().Trace(cliCtx.Args()...), "--content-md5 requires --disable-multipart, multipart uploads are verified by their part checksums")
+	}
+
+	if cliCtx.Bool("checksum-resume") && cliCtx.Bool("disable-multipart") {
+		fatalIf(errDummy().Trace(cliCtx.Args()...), "--checksum-resume cannot be used with --disable-multipart, only multipart uploads are resumed")
+	}
+
+	if cliCtx.Int("retry-on-checksum-mismatch") < 0 {
+		fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "--retry-on-checksum-mismatch must not be negative")
+	}
+
+	if cliCtx.IsSet("concurrent-multipart") {
+		n := cliCtx.Int("concurrent-multipart")
+		if n < 1 || n > maxConcurrentParts {
+			fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), fmt.Sprintf("--concurrent-multipart must be between 1 and %d", maxConcurrentParts))
+		}
+		if buffered := concurrentPartsMemory(n); buffered >= concurrentPartsMemoryWarning {
+			warning("--concurrent-multipart %d buffers up to %s per object, for each object copied in parallel.", n, humanize.IBytes(buffered))
+		}
+		if cliCtx.Bool("disable-multipart") {
+			fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "--concurrent-multipart cannot be used with --disable-multipart")
+		}
+	}
+
+	switch strings.ToUpper(cliCtx.String("metadata-directive")) {
+	case "":
+	case copyMetadataDirective:
+		if cliCtx.String("attr") != "" {
+			fatalIf(errDummy().Trace(cliCtx.Args()...), "--attr is ignored with --metadata-directive COPY, use REPLACE to set the metadata of the copies")
+		}
+	case replaceMetadataDirective:
+		if cliCtx.String("attr") == "" {
+			warning("--metadata-directive REPLACE without --attr strips the metadata of the copies.")
+		}
+	default:
+		fatalIf(errInvalidArgument().Trace(cliCtx.String("metadata-directive")), "--metadata-directive must be COPY or REPLACE.")
+	}
+
+	if cliCtx.Bool("predict-compression") {
+		tgtClnt, err := newClient(tgtURL)
+		fatalIf(err.Trace(tgtURL), "Unable to initialize target `"+tgtURL+"`.")
+		if tgtClnt.GetURL().Type != objectStorage {
+			fatalIf(errInvalidArgument().Trace(tgtURL), "--predict-compression only applies to a MinIO target.")
+		}
+	}
+
+	if cliCtx.Bool("atomic") {
+		if cliCtx.Bool("checksum-resume") {
+			fatalIf(errDummy().Trace(cliCtx.Args()...), "--atomic cannot be used with --checksum-resume, every upload goes to a new temporary object")
+		}
+		tgtClnt, err := newClient(tgtURL)
+		fatalIf(err.Trace(tgtURL), "Unable to initialize target `"+tgtURL+"`.")
+		if tgtClnt.GetURL().Type != objectStorage {
+			warning("--atomic only applies to object storage targets, local files are written to a temporary file and renamed already.")
+		}
+	}
+
+	if cliCtx.String("continue-token") != "" {
+		if !isRecursive || cliCtx.Bool("continue") {
+			fatalIf(errDummy().Trace(cliCtx.Args()...), "--continue-token requires --recursive and cannot be used with --continue")
+		}
+	}
+
+	if shardDepth := cliCtx.Int("shard-depth"); shardDepth != 0 {
+		if shardDepth < 0 || shardDepth > maxShardDepth {
+			fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), fmt.Sprintf("--shard-depth must be between 1 and %d.", maxShardDepth))
+		}
+		tgtClnt, err := newClient(tgtURL)
+		fatalIf(err.Trace(tgtURL), "Unable to initialize target `"+tgtURL+"`.")
+		if tgtClnt.GetURL().Type != fileSystem {
+			fatalIf(errInvalidArgument().Trace(tgtURL), "--shard-depth only applies to a local target folder.")
+		}
+	}
+
+	if tagStr := cliCtx.String("tags"); tagStr != "" {
+		// Validate keys, values and the number of tags allowed on an object.
+		_, e := tags.Parse(tagStr, true)
+		fatalIf(probe.NewError(e).Trace(tagStr), "Invalid object tags `"+tagStr+"`.")
+	}
+
+	fromStdin := cliCtx.Bool("from-stdin")
+	if fromStdin {
+		checkCopyFromStdinSyntax(cliCtx)
+		// Sources are validated as they are read.
+		srcURLs = nil
+	}
+
 	// Verify if source(s) exists.
 	for _, srcURL := range srcURLs {
 		var err *probe.Error
@@ -91,6 +199,28 @@ func checkCopySyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 		fatalIf(errInvalidArgument().Trace(), fmt.Sprintf("Both object retention flags `--%s` and `--%s` are required.\n", rdFlag, rmFlag))
 	}
 
+	if lh := cliCtx.String(lhFlag); lh != "" {
+		switch minio.LegalHoldStatus(strings.ToUpper(lh)) {
+		case minio.LegalHoldEnabled, minio.LegalHoldDisabled:
+		default:
+			fatalIf(errInvalidArgument().Trace(lh), fmt.Sprintf("Invalid `--%s` value `%s`, expected `on` or `off`.", lhFlag, lh))
+		}
+	}
+
+	if cliCtx.String(rmFlag) != "" || cliCtx.String(lhFlag) != "" {
+		// Fail before transferring any data rather than on the first upload.
+		disabled, err := isTargetBucketLockDisabled(ctx, tgtURL)
+		warningIf(err.Trace(tgtURL), "Unable to get the object lock configuration of `"+tgtURL+"`, copying without checking it.")
+		if disabled {
+			fatalIf(errInvalidArgument().Trace(tgtURL), fmt.Sprintf("Object lock is not enabled on the bucket of `%s`, `--%s`, `--%s` and `--%s` cannot be applied.", tgtURL, rmFlag, rdFlag, lhFlag))
+		}
+	}
+
+	if fromStdin {
+		checkCopySyntaxTypeD(ctx, srcURLs, tgtURL, encKeyDB, isMvCmd, timeRef)
+		return
+	}
+
 	operation := "copy"
 	if isMvCmd {
 		operation = "move"
@@ -134,12 +264,29 @@ func checkCopySyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 		fatalIf(errInvalidArgument().Trace(), "Unable to guess the type of "+operation+" operation.")
 	}
 
+	if cliCtx.IsSet("error-manifest") && !cliCtx.Bool("skip-errors") {
+		fatalIf(errInvalidArgument().Trace(cliCtx.String("error-manifest")), "--error-manifest requires --skip-errors.")
+	}
+
 	// Preserve functionality not supported for windows
 	if cliCtx.Bool("preserve") && runtime.GOOS == "windows" {
 		fatalIf(errInvalidArgument().Trace(), "Permissions are not preserved on windows platform.")
 	}
 }
 
+// checkCopyFromStdinSyntax - validates the arguments of cp --from-stdin.
+func checkCopyFromStdinSyntax(cliCtx *cli.Context) {
+	args := cliCtx.Args()
+	if len(args) != 2 || args[0] != "-" {
+		fatalIf(errInvalidArgument().Trace(args...), "--from-stdin expects `-` as the only source argument.")
+	}
+	for _, flag := range []string{"version-id", "zip", "continue"} {
+		if cliCtx.IsSet(flag) {
+			fatalIf(errInvalidArgument().Trace(args...), "--from-stdin cannot be used with --"+flag+".")
+		}
+	}
+}
+
 // checkCopySyntaxTypeA verifies if the source and target are valid file arguments.
 func checkCopySyntaxTypeA(ctx context.Context, srcURL, versionID string, tgtURL string, keys map[string][]prefixSSEPair, isMvCmd bool, timeRef time.Time) {
 	_, srcContent, err := url2Stat(ctx, srcURL, versionID, false, keys, timeRef, false)
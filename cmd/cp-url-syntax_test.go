@@ -0,0 +1,58 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestIsTargetBucketLockDisabled(t *testing.T) {
+	withTempMcConfig(t)
+
+	testCases := []struct {
+		status   int
+		body     string
+		disabled bool
+		unknown  bool
+	}{
+		{http.StatusOK, `<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled></ObjectLockConfiguration>`, false, false},
+		{http.StatusNotFound, `<Error><Code>ObjectLockConfigurationNotFoundError</Code><Message>Object Lock configuration does not exist for this bucket</Message></Error>`, true, false},
+		{http.StatusNotImplemented, `<Error><Code>NotImplemented</Code><Message>A header you provided implies functionality that is not implemented</Message></Error>`, true, false},
+		{http.StatusForbidden, `<Error><Code>AccessDenied</Code><Message>Access Denied.</Message></Error>`, false, true},
+	}
+	for i, testCase := range testCases {
+		newS3StubServer(t, "target", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := r.URL.Query()["object-lock"]; !ok || r.URL.Path != "/bucket/" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(testCase.status)
+			w.Write([]byte(testCase.body))
+		}))
+
+		disabled, err := isTargetBucketLockDisabled(context.Background(), "target/bucket/prefix/object")
+		if disabled != testCase.disabled {
+			t.Errorf("Test %d: expected disabled %v, got %v", i+1, testCase.disabled, disabled)
+		}
+		if unknown := err != nil; unknown != testCase.unknown {
+			t.Errorf("Test %d: expected unknown %v, got %v", i+1, testCase.unknown, err)
+		}
+	}
+}
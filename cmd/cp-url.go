@@ -18,7 +18,11 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
 	"path/filepath"
 	"strings"
 	"time"
@@ -220,8 +224,52 @@ func prepareCopyURLsTypeD(ctx context.Context, sourceURLs []string, targetURL st
 	return copyURLsCh
 }
 
+// MULTI-SOURCE from a reader - Type D: copy([](f|d...), d) -> []B
+// prepareCopyURLsFromReader - prepares target and source clientURLs for
+// copying sources read one per line from r, blank lines are skipped.
+func prepareCopyURLsFromReader(ctx context.Context, r io.Reader, targetURL string, isRecursive bool, timeRef time.Time, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
+	copyURLsCh := make(chan URLs)
+	go func() {
+		defer close(copyURLsCh)
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			sourceURL, manifestTargetURL := parseCopyManifestLine(scanner.Text())
+			if sourceURL == "" {
+				continue
+			}
+			if sourceURL == "-" {
+				copyURLsCh <- URLs{Error: errInvalidSource(sourceURL).Trace(sourceURL)}
+				continue
+			}
+			// Lines of a --error-manifest name the target of each object.
+			if manifestTargetURL != "" {
+				copyURLsCh <- prepareCopyURLsTypeA(ctx, sourceURL, "", manifestTargetURL, encKeyDB)
+				continue
+			}
+			_, sourceContent, err := url2Stat(ctx, sourceURL, "", false, encKeyDB, timeRef, false)
+			if err != nil {
+				// Source does not exist or insufficient privileges.
+				copyURLsCh <- URLs{Error: err.Trace(sourceURL)}
+				continue
+			}
+			if sourceContent.Type.IsDir() && !isRecursive {
+				copyURLsCh <- URLs{Error: errSourceIsDir(sourceURL).Trace(sourceURL)}
+				continue
+			}
+			for cpURLs := range prepareCopyURLsTypeC(ctx, sourceURL, targetURL, isRecursive, false, timeRef, encKeyDB) {
+				copyURLsCh <- cpURLs
+			}
+		}
+		if e := scanner.Err(); e != nil {
+			copyURLsCh <- URLs{Error: probe.NewError(e)}
+		}
+	}()
+	return copyURLsCh
+}
+
 type prepareCopyURLsOpts struct {
 	sourceURLs           []string
+	sourcesReader        io.Reader
 	targetURL            string
 	isRecursive          bool
 	encKeyDB             map[string][]prefixSSEPair
@@ -229,6 +277,54 @@ type prepareCopyURLsOpts struct {
 	timeRef              time.Time
 	versionID            string
 	isZip                bool
+	shardDepth           int
+	filter               *copyFilter
+	newerThanRef         time.Time
+}
+
+// referenceModTime returns the modification time of the --newer-than-ref
+// reference file or object, the zero time when ref is not set.
+func referenceModTime(ctx context.Context, ref string, encKeyDB map[string][]prefixSSEPair) (time.Time, *probe.Error) {
+	if ref == "" {
+		return time.Time{}, nil
+	}
+	_, content, err := url2Stat(ctx, ref, "", false, encKeyDB, time.Time{}, false)
+	if err != nil {
+		return time.Time{}, err.Trace(ref)
+	}
+	if content.Type.IsDir() {
+		return time.Time{}, errInvalidArgument().Trace(ref)
+	}
+	return content.Time, nil
+}
+
+// maxShardDepth is the maximum number of levels of --shard-depth.
+const maxShardDepth = 8
+
+// shardKey returns key below depth levels of directories named after
+// the leading bytes of the SHA-256 sum of key, e.g. "3f/a2/photos/a.jpg"
+// for "photos/a.jpg" and a depth of 2. The key is recovered by removing
+// the first depth directories.
+func shardKey(key string, depth int) string {
+	sum := sha256.Sum256([]byte(key))
+	shards := make([]string, 0, depth+1)
+	for i := 0; i < depth; i++ {
+		shards = append(shards, hex.EncodeToString(sum[i:i+1]))
+	}
+	return strings.Join(append(shards, key), "/")
+}
+
+// shardTargetURL moves the target of a copy into a folder below
+// targetRoot to its sharded path, a copy to a file is left as is.
+func shardTargetURL(targetRoot string, targetURL ClientURL, depth int) ClientURL {
+	separator := string(targetURL.Separator)
+	targetRoot = strings.TrimSuffix(targetRoot, separator) + separator
+	if !strings.HasPrefix(targetURL.Path, targetRoot) {
+		return targetURL
+	}
+	key := filepath.ToSlash(strings.TrimPrefix(targetURL.Path, targetRoot))
+	targetURL.Path = targetRoot + strings.ReplaceAll(shardKey(key, depth), "/", separator)
+	return targetURL
 }
 
 // prepareCopyURLs - prepares target and source clientURLs for copying.
@@ -236,6 +332,13 @@ func prepareCopyURLs(ctx context.Context, o prepareCopyURLsOpts) chan URLs {
 	copyURLsCh := make(chan URLs)
 	go func(o prepareCopyURLsOpts) {
 		defer close(copyURLsCh)
+		if o.sourcesReader != nil {
+			for cURLs := range prepareCopyURLsFromReader(ctx, o.sourcesReader, o.targetURL, o.isRecursive, o.timeRef, o.encKeyDB) {
+				copyURLsCh <- cURLs
+			}
+			return
+		}
+
 		cpType, cpVersion, err := guessCopyURLType(ctx, o)
 		fatalIf(err.Trace(), "Unable to guess the type of copy operation.")
 
@@ -257,10 +360,16 @@ func prepareCopyURLs(ctx context.Context, o prepareCopyURLsOpts) chan URLs {
 		}
 	}(o)
 
+	_, targetRoot, _ := mustExpandAlias(o.targetURL)
 	finalCopyURLsCh := make(chan URLs)
 	go func() {
 		defer close(finalCopyURLsCh)
 		for cpURLs := range copyURLsCh {
+			if cpURLs.Error != nil {
+				finalCopyURLsCh <- cpURLs
+				continue
+			}
+
 			// Skip objects older than --older-than parameter if specified
 			if o.olderThan != "" && isOlder(cpURLs.SourceContent.Time, o.olderThan) {
 				continue
@@ -271,6 +380,20 @@ func prepareCopyURLs(ctx context.Context, o prepareCopyURLsOpts) chan URLs {
 				continue
 			}
 
+			// Skip objects not newer than the --newer-than-ref reference object
+			if !o.newerThanRef.IsZero() && !cpURLs.SourceContent.Time.After(o.newerThanRef) {
+				continue
+			}
+
+			// Skip objects not selected by --include-from and --exclude-from
+			if !o.filter.match(copyFilterName(targetRoot, cpURLs)) {
+				continue
+			}
+
+			if o.shardDepth > 0 {
+				cpURLs.TargetContent.URL = shardTargetURL(targetRoot, cpURLs.TargetContent.URL, o.shardDepth)
+			}
+
 			finalCopyURLsCh <- cpURLs
 		}
 	}()
@@ -0,0 +1,83 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestPrepareCopyURLsNewerThanRef(t *testing.T) {
+	withTempMcConfig(t)
+
+	source, target := t.TempDir(), t.TempDir()
+	marker := filepath.Join(target, ".marker")
+	watermark := time.Now().Add(-time.Hour)
+	files := map[string]time.Time{
+		marker:                         watermark,
+		filepath.Join(source, "old"):   watermark.Add(-time.Minute),
+		filepath.Join(source, "same"):  watermark,
+		filepath.Join(source, "new"):   watermark.Add(time.Minute),
+		filepath.Join(source, "d/new"): watermark.Add(time.Hour),
+	}
+	for path, modTime := range files {
+		if e := os.MkdirAll(filepath.Dir(path), 0o700); e != nil {
+			t.Fatal(e)
+		}
+		if e := os.WriteFile(path, []byte(path), 0o600); e != nil {
+			t.Fatal(e)
+		}
+		if e := os.Chtimes(path, modTime, modTime); e != nil {
+			t.Fatal(e)
+		}
+	}
+
+	ref, err := referenceModTime(context.Background(), marker, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ref.Equal(watermark) {
+		t.Fatalf("expected the reference time %v, got %v", watermark, ref)
+	}
+	if _, err = referenceModTime(context.Background(), filepath.Join(target, "missing"), nil); err == nil {
+		t.Fatal("expected an error for a missing reference")
+	}
+
+	opts := prepareCopyURLsOpts{
+		sourceURLs:   []string{source + string(os.PathSeparator)},
+		targetURL:    target,
+		isRecursive:  true,
+		newerThanRef: ref,
+	}
+	var copied []string
+	for cpURLs := range prepareCopyURLs(context.Background(), opts) {
+		if cpURLs.Error != nil {
+			t.Fatalf("unexpected error: %v", cpURLs.Error)
+		}
+		copied = append(copied, copyFilterName(target, cpURLs))
+	}
+	sort.Strings(copied)
+	if expected := []string{"d/new", "new"}; !reflect.DeepEqual(copied, expected) {
+		t.Fatalf("expected %v, got %v", expected, copied)
+	}
+}
@@ -32,7 +32,12 @@ import (
 
 // diff specific flags.
 var (
-	diffFlags = []cli.Flag{}
+	diffFlags = []cli.Flag{
+		cli.DurationFlag{
+			Name:  "skew-tolerance",
+			Usage: "consider source object(s) newer only if their modtime exceeds the target by more than this duration (e.g. 2s)",
+		},
+	}
 )
 
 // Compute differences in object name, size, and date between two buckets.
@@ -66,6 +71,9 @@ EXAMPLES:
 
   2. Compare two folders on a local filesystem.
      {{.Prompt}} {{.HelpName}} ~/Photos /Media/Backup/Photos
+
+  3. Compare two buckets, ignoring modtime differences up to 2 seconds caused by clock skew.
+     {{.Prompt}} {{.HelpName}} --skew-tolerance 2s site1/photos site2/photos
 `,
 }
 
@@ -90,7 +98,7 @@ func (d diffMessage) String() string {
 		msg = console.Colorize("DiffOnlyInSecond", "> "+d.SecondURL)
 	case differInType:
 		msg = console.Colorize("DiffType", "! "+d.SecondURL)
-	case differInSize:
+	case differInSize, differInETag:
 		msg = console.Colorize("DiffSize", "! "+d.SecondURL)
 	case differInMetadata:
 		msg = console.Colorize("DiffMetadata", "! "+d.SecondURL)
@@ -156,7 +164,7 @@ func checkDiffSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 }
 
 // doDiffMain runs the diff.
-func doDiffMain(ctx context.Context, firstURL, secondURL string) error {
+func doDiffMain(ctx context.Context, firstURL, secondURL string, skewTolerance time.Duration) error {
 	// Source and targets are always directories
 	sourceSeparator := string(newClientURL(firstURL).Separator)
 	if !strings.HasSuffix(firstURL, sourceSeparator) {
@@ -184,7 +192,7 @@ func doDiffMain(ctx context.Context, firstURL, secondURL string) error {
 	}
 
 	// Diff first and second urls.
-	for diffMsg := range objectDifference(ctx, firstClient, secondClient, true) {
+	for diffMsg := range objectDifference(ctx, firstClient, secondClient, true, skewTolerance, etagIgnore) {
 		if diffMsg.Error != nil {
 			errorIf(diffMsg.Error, "Unable to calculate objects difference.")
 			// Ignore error and proceed to next object.
@@ -221,5 +229,5 @@ func mainDiff(cliCtx *cli.Context) error {
 	firstURL := URLs.Get(0)
 	secondURL := URLs.Get(1)
 
-	return doDiffMain(ctx, firstURL, secondURL)
+	return doDiffMain(ctx, firstURL, secondURL, cliCtx.Duration("skew-tolerance"))
 }
@@ -42,6 +42,7 @@ const (
 	differInFirst                    // only in source (FIRST)
 	differInSecond                   // only in target (SECOND)
 	differInAASourceMTime            // differs in active-active source modtime
+	differInETag                     // differs in etag
 )
 
 func (d differType) String() string {
@@ -54,6 +55,8 @@ func (d differType) String() string {
 		return "metadata"
 	case differInAASourceMTime:
 		return "mm-source-mtime"
+	case differInETag:
+		return "etag"
 	case differInType:
 		return "type"
 	case differInFirst:
@@ -83,8 +86,10 @@ func getSourceModTimeKey(metadata map[string]string) string {
 }
 
 // activeActiveModTimeUpdated tries to calculate if the object copy in the target
-// is older than the one in the source by comparing the modtime of the data.
-func activeActiveModTimeUpdated(src, dst *ClientContent) bool {
+// is older than the one in the source by comparing the modtime of the data. The
+// source is only considered newer when it exceeds the target by more than
+// skewTolerance, to absorb small clock differences between systems.
+func activeActiveModTimeUpdated(src, dst *ClientContent, skewTolerance time.Duration) bool {
 	if src == nil || dst == nil {
 		return false
 	}
@@ -104,7 +109,7 @@ func activeActiveModTimeUpdated(src, dst *ClientContent) bool {
 	if srcModTime == "" && dstModTime == "" {
 		// No active-active mirror context found, fallback to modTimes presented
 		// by the client content
-		return srcActualModTime.After(dstActualModTime)
+		return srcActualModTime.After(dstActualModTime.Add(skewTolerance))
 	}
 
 	var srcOriginLastModified, dstOriginLastModified time.Time
@@ -132,7 +137,55 @@ func activeActiveModTimeUpdated(src, dst *ClientContent) bool {
 		dstActualModTime = dstOriginLastModified
 	}
 
-	return srcActualModTime.After(dstActualModTime)
+	return srcActualModTime.After(dstActualModTime.Add(skewTolerance))
+}
+
+// ETag comparison strategies of the difference of objects.
+const (
+	// Objects differ when both have an ETag and these differ.
+	etagStrict = "strict"
+	// Objects differ when both ETags are plain MD5 sums and these differ.
+	etagLenient = "lenient"
+	// ETags are not compared, objects differ in size or time only.
+	etagIgnore = "ignore"
+)
+
+// isValidETagStrategy tells if strategy is a known ETag strategy.
+func isValidETagStrategy(strategy string) bool {
+	switch strategy {
+	case etagStrict, etagLenient, etagIgnore:
+		return true
+	}
+	return false
+}
+
+// isPlainMD5ETag tells if etag looks like the MD5 sum of the content,
+// unlike the ETags of multipart uploads, encrypted objects or of some
+// S3 compatible providers.
+func isPlainMD5ETag(etag string) bool {
+	if len(etag) != 32 {
+		return false
+	}
+	for _, c := range etag {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// etagsDiffer tells if the ETags of src and dst differ, as compared
+// by strategy.
+func etagsDiffer(src, dst *ClientContent, strategy string) bool {
+	srcETag := strings.ToLower(strings.Trim(src.ETag, "\""))
+	dstETag := strings.ToLower(strings.Trim(dst.ETag, "\""))
+	switch strategy {
+	case etagStrict:
+		return srcETag != "" && dstETag != "" && srcETag != dstETag
+	case etagLenient:
+		return isPlainMD5ETag(srcETag) && isPlainMD5ETag(dstETag) && srcETag != dstETag
+	}
+	return false
 }
 
 func metadataEqual(m1, m2 map[string]string) bool {
@@ -161,15 +214,15 @@ func metadataEqual(m1, m2 map[string]string) bool {
 	return true
 }
 
-func objectDifference(ctx context.Context, sourceClnt, targetClnt Client, isMetadata bool) (diffCh chan diffMessage) {
-	return difference(ctx, sourceClnt, targetClnt, isMetadata, true, false, DirNone)
+func objectDifference(ctx context.Context, sourceClnt, targetClnt Client, isMetadata bool, skewTolerance time.Duration, etagStrategy string) (diffCh chan diffMessage) {
+	return difference(ctx, sourceClnt, targetClnt, isMetadata, true, false, DirNone, skewTolerance, etagStrategy)
 }
 
 func dirDifference(ctx context.Context, sourceClnt, targetClnt Client) (diffCh chan diffMessage) {
-	return difference(ctx, sourceClnt, targetClnt, false, false, true, DirFirst)
+	return difference(ctx, sourceClnt, targetClnt, false, false, true, DirFirst, 0, etagIgnore)
 }
 
-func differenceInternal(ctx context.Context, sourceClnt, targetClnt Client, isMetadata bool, isRecursive, returnSimilar bool, dirOpt DirOpt, diffCh chan<- diffMessage) *probe.Error {
+func differenceInternal(ctx context.Context, sourceClnt, targetClnt Client, isMetadata bool, isRecursive, returnSimilar bool, dirOpt DirOpt, skewTolerance time.Duration, etagStrategy string, diffCh chan<- diffMessage) *probe.Error {
 	// Set default values for listing.
 	srcCh := sourceClnt.List(ctx, ListOptions{Recursive: isRecursive, WithMetadata: isMetadata, ShowDir: dirOpt})
 	tgtCh := targetClnt.List(ctx, ListOptions{Recursive: isRecursive, WithMetadata: isMetadata, ShowDir: dirOpt})
@@ -276,7 +329,16 @@ func differenceInternal(ctx context.Context, sourceClnt, targetClnt Client, isMe
 					firstContent:  srcCtnt,
 					secondContent: tgtCtnt,
 				}
-			} else if activeActiveModTimeUpdated(srcCtnt, tgtCtnt) {
+			} else if etagsDiffer(srcCtnt, tgtCtnt, etagStrategy) {
+				// Regular files of the same size differing in content.
+				diffCh <- diffMessage{
+					FirstURL:      srcCtnt.URL.String(),
+					SecondURL:     tgtCtnt.URL.String(),
+					Diff:          differInETag,
+					firstContent:  srcCtnt,
+					secondContent: tgtCtnt,
+				}
+			} else if activeActiveModTimeUpdated(srcCtnt, tgtCtnt, skewTolerance) {
 				diffCh <- diffMessage{
 					FirstURL:      srcCtnt.URL.String(),
 					SecondURL:     tgtCtnt.URL.String(),
@@ -327,13 +389,13 @@ func differenceInternal(ctx context.Context, sourceClnt, targetClnt Client, isMe
 
 // objectDifference function finds the difference between all objects
 // recursively in sorted order from source and target.
-func difference(ctx context.Context, sourceClnt, targetClnt Client, isMetadata bool, isRecursive, returnSimilar bool, dirOpt DirOpt) (diffCh chan diffMessage) {
+func difference(ctx context.Context, sourceClnt, targetClnt Client, isMetadata bool, isRecursive, returnSimilar bool, dirOpt DirOpt, skewTolerance time.Duration, etagStrategy string) (diffCh chan diffMessage) {
 	diffCh = make(chan diffMessage, 10000)
 
 	go func() {
 		defer close(diffCh)
 
-		err := differenceInternal(ctx, sourceClnt, targetClnt, isMetadata, isRecursive, returnSimilar, dirOpt, diffCh)
+		err := differenceInternal(ctx, sourceClnt, targetClnt, isMetadata, isRecursive, returnSimilar, dirOpt, skewTolerance, etagStrategy, diffCh)
 		if err != nil {
 			// handle this specifically for filesystem related errors.
 			switch v := err.ToGoError().(type) {
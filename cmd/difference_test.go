@@ -18,7 +18,9 @@
 package cmd
 
 import (
+	"strings"
 	"testing"
+	"time"
 )
 
 var testCases = []struct {
@@ -47,3 +49,57 @@ func TestExcludeOptions(t *testing.T) {
 		}
 	}
 }
+
+func TestActiveActiveModTimeUpdatedSkewTolerance(t *testing.T) {
+	now := time.Now().UTC()
+	testCases := []struct {
+		srcTime, dstTime time.Time
+		skewTolerance    time.Duration
+		updated          bool
+	}{
+		{now.Add(time.Second), now, 0, true},
+		{now.Add(time.Second), now, 2 * time.Second, false},
+		{now.Add(3 * time.Second), now, 2 * time.Second, true},
+		{now, now.Add(time.Second), 0, false},
+	}
+	for i, test := range testCases {
+		src := &ClientContent{Time: test.srcTime}
+		dst := &ClientContent{Time: test.dstTime}
+		if updated := activeActiveModTimeUpdated(src, dst, test.skewTolerance); updated != test.updated {
+			t.Fatalf("Test %d: expected %t, got %t", i+1, test.updated, updated)
+		}
+	}
+}
+
+func TestETagsDiffer(t *testing.T) {
+	const (
+		md5A      = "5d41402abc4b2a76b9719d911017c592"
+		md5B      = "7d793037a0760186574b0282f2f435e7"
+		multipart = "9b2cf535f27731c974343645a3985328-3"
+	)
+	testCases := []struct {
+		src, dst string
+		strict   bool
+		lenient  bool
+	}{
+		{md5A, md5A, false, false},
+		{md5A, md5B, true, true},
+		{`"` + md5A + `"`, strings.ToUpper(md5A), false, false},
+		{md5A, multipart, true, false},
+		{multipart, multipart + "x", true, false},
+		{md5A, "", false, false},
+		{"", "", false, false},
+	}
+	for i, testCase := range testCases {
+		src, dst := &ClientContent{ETag: testCase.src}, &ClientContent{ETag: testCase.dst}
+		if got := etagsDiffer(src, dst, etagStrict); got != testCase.strict {
+			t.Errorf("Test %d: strict expected %v, got %v", i+1, testCase.strict, got)
+		}
+		if got := etagsDiffer(src, dst, etagLenient); got != testCase.lenient {
+			t.Errorf("Test %d: lenient expected %v, got %v", i+1, testCase.lenient, got)
+		}
+		if etagsDiffer(src, dst, etagIgnore) {
+			t.Errorf("Test %d: ignore expected no difference", i+1)
+		}
+	}
+}
@@ -21,6 +21,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 	"unicode"
 
@@ -45,6 +47,30 @@ type errorMessage struct {
 	SysInfo   map[string]string  `json:"sysinfo"`
 }
 
+// warningMessage container for warning messages
+type warningMessage struct {
+	Message string        `json:"message"`
+	Cause   *causeMessage `json:"cause,omitempty"`
+}
+
+// JSON jsonified warning message, with a "warning" status.
+func (w warningMessage) JSON() string {
+	json, e := json.MarshalIndent(struct {
+		Status  string         `json:"status"`
+		Warning warningMessage `json:"warning"`
+	}{
+		Status:  "warning",
+		Warning: w,
+	}, "", " ")
+	if e != nil {
+		console.Fatalln(probe.NewError(e))
+	}
+	return string(json)
+}
+
+// warningOutput is where warnings are printed without --json.
+var warningOutput io.Writer = os.Stderr
+
 // fatalIf wrapper function which takes error and selectively prints stack frames if available on debug
 func fatalIf(err *probe.Error, msg string, data ...interface{}) {
 	if err == nil {
@@ -170,3 +196,45 @@ func errorIf(err *probe.Error, msg string, data ...interface{}) {
 	}
 	console.Errorln(fmt.Sprintf("%s %s", msg, err))
 }
+
+// warningIf prints a non-fatal condition, such as a skipped object or a
+// degraded mode, as a warning. Unlike errors, warnings are printed with
+// a "warning" status with --json and are suppressed by --quiet.
+func warningIf(err *probe.Error, msg string, data ...interface{}) {
+	if err == nil {
+		return
+	}
+	printWarning(err, fmt.Sprintf(msg, data...))
+}
+
+// warning prints msg as a warning, see warningIf.
+func warning(msg string, data ...interface{}) {
+	printWarning(nil, fmt.Sprintf(msg, data...))
+}
+
+func printWarning(err *probe.Error, msg string) {
+	if globalQuietWarnings {
+		return
+	}
+	if globalJSON {
+		warningMsg := warningMessage{Message: msg}
+		if err != nil {
+			warningMsg.Cause = &causeMessage{
+				Message: err.ToGoError().Error(),
+				Error:   err.ToGoError(),
+			}
+		}
+		console.Println(warningMsg.JSON())
+		return
+	}
+	if err != nil {
+		if globalDebug {
+			msg = fmt.Sprintf("%s %s", msg, err)
+		} else {
+			msg = fmt.Sprintf("%s %s", msg, err.ToGoError())
+		}
+	}
+	console.Lock()
+	defer console.Unlock()
+	fmt.Fprintln(warningOutput, console.Colorize("Warning", console.ProgramName()+": <WARNING> "+msg))
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestWarningIf(t *testing.T) {
+	defer func(quiet, jsonFlag bool) { globalQuietWarnings, globalJSON = quiet, jsonFlag }(globalQuietWarnings, globalJSON)
+	defer func(w io.Writer) { warningOutput = w }(warningOutput)
+	var output bytes.Buffer
+	warningOutput = &output
+	globalQuietWarnings, globalJSON = false, false
+
+	warningIf(nil, "Unable to list `%s`.", "link")
+	if output.Len() != 0 {
+		t.Fatalf("expected no warning without an error, got %q", output.String())
+	}
+
+	warningIf(probe.NewError(errors.New("broken link")), "Unable to list `%s`.", "link")
+	if got := output.String(); !strings.Contains(got, "<WARNING> Unable to list `link`. broken link") {
+		t.Fatalf("unexpected warning %q", got)
+	}
+
+	output.Reset()
+	globalQuietWarnings = true
+	warning("Skipping `%s`.", "object")
+	if output.Len() != 0 {
+		t.Fatalf("expected --quiet to suppress warnings, got %q", output.String())
+	}
+}
+
+func TestWarningMessageJSON(t *testing.T) {
+	msg := warningMessage{
+		Message: "Unable to list `link`.",
+		Cause:   &causeMessage{Message: "broken link", Error: errors.New("broken link")},
+	}
+	got := msg.JSON()
+	for _, expected := range []string{`"status":"warning"`, `"message":"Unable to list`, `"cause":{"message":"broken link"`} {
+		if !strings.Contains(got, expected) {
+			t.Fatalf("expected %s in %s", expected, got)
+		}
+	}
+	if got = (warningMessage{Message: "Skipping."}).JSON(); strings.Contains(got, "cause") {
+		t.Fatalf("expected no cause in %s", got)
+	}
+}
@@ -80,6 +80,22 @@ var (
 			Name:  "watch",
 			Usage: "monitor a specified path for newly created object(s)",
 		},
+		cli.BoolFlag{
+			Name:  "prune-date-prefixes",
+			Usage: "skip date partitioned prefixes entirely outside the --older-than, --newer-than window (see DATE PREFIXES)",
+		},
+		cli.StringFlag{
+			Name:  "sort",
+			Usage: "sort matching objects by 'size' or 'time', largest or newest first",
+		},
+		cli.IntFlag{
+			Name:  "limit",
+			Usage: "find only the first N sorted objects, requires --sort",
+		},
+		cli.BoolFlag{
+			Name:  "reverse",
+			Usage: "reverse the order of --sort",
+		},
 	}
 )
 
@@ -109,6 +125,13 @@ UNITS
   --older-than, --newer-than flags accept the string for days, hours and minutes 
   i.e. 1d2h30m states 1 day, 2 hours and 30 minutes.
 
+DATE PREFIXES
+  --prune-date-prefixes walks the target prefix by prefix and does not list the
+  prefixes named after a date (e.g. 2021/05/03/, 2021-05-03/, year=2021/month=05/
+  or dt=2021-05-03/) whose whole period falls outside the --older-than, --newer-than
+  window. This assumes objects are modified within the period of their prefix, prefixes
+  without a date are always listed.
+
 FORMAT
   Support string substitutions with special interpretations for following keywords.
   Keywords supported if target is filesystem or object storage:
@@ -154,6 +177,15 @@ EXAMPLES:
 
   10. List all objects up to 3 levels sub-directory deep under "s3/bucket".
       {{.Prompt}} {{.HelpName}} s3/bucket --maxdepth 3
+
+  11. Find the logs of the last 2 days under "s3/logs", laid out as "s3/logs/YYYY/MM/DD/", without listing older days.
+      {{.Prompt}} {{.HelpName}} s3/logs --newer-than 2d --prune-date-prefixes
+
+  12. Find the 10 largest ".iso" images under "s3/bucket".
+      {{.Prompt}} {{.HelpName}} s3/bucket --name "*.iso" --sort size --limit 10
+
+  13. Find the 5 oldest objects under "s3/bucket" and remove them.
+      {{.Prompt}} {{.HelpName}} s3/bucket --sort time --reverse --limit 5 --exec "mc rm {}"
 `,
 }
 
@@ -172,6 +204,26 @@ func checkFindSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 		}
 	}
 
+	if cliCtx.Bool("prune-date-prefixes") && cliCtx.String("older-than") == "" && cliCtx.String("newer-than") == "" {
+		fatalIf(errInvalidArgument().Trace(args...), "--prune-date-prefixes requires --older-than or --newer-than.")
+	}
+
+	sortBy := cliCtx.String("sort")
+	switch sortBy {
+	case "", "size", "time":
+	default:
+		fatalIf(errInvalidArgument().Trace(sortBy), "Unrecognized --sort value `"+sortBy+"`. Allowed values are [size, time].")
+	}
+	if sortBy == "" && (cliCtx.Int("limit") != 0 || cliCtx.Bool("reverse")) {
+		fatalIf(errInvalidArgument().Trace(args...), "--limit and --reverse can only be used with --sort.")
+	}
+	if cliCtx.Int("limit") < 0 {
+		fatalIf(errInvalidArgument().Trace(args...), "--limit cannot be negative.")
+	}
+	if sortBy != "" && (cliCtx.Bool("watch") || cliCtx.Uint("maxdepth") > 0) {
+		fatalIf(errInvalidArgument().Trace(args...), "--sort cannot be used with --watch or --maxdepth.")
+	}
+
 	// Extract input URLs and validate.
 	for _, url := range args {
 		_, _, err := url2Stat(ctx, url, "", false, encKeyDB, time.Time{}, false)
@@ -202,6 +254,10 @@ type findContext struct {
 	largerSize    uint64
 	smallerSize   uint64
 	watch         bool
+	prunePrefixes bool
+	sortBy        string
+	limit         int
+	isReverse     bool
 
 	// Internal values
 	targetAlias   string
@@ -281,6 +337,10 @@ func mainFind(cliCtx *cli.Context) error {
 		largerSize:    largerSize,
 		smallerSize:   smallerSize,
 		watch:         cliCtx.Bool("watch"),
+		prunePrefixes: cliCtx.Bool("prune-date-prefixes"),
+		sortBy:        cliCtx.String("sort"),
+		limit:         cliCtx.Int("limit"),
+		isReverse:     cliCtx.Bool("reverse"),
 		targetAlias:   targetAlias,
 		targetURL:     args[0],
 		targetFullURL: targetFullURL,
@@ -262,16 +262,27 @@ func doFind(ctxCtx context.Context, ctx *findContext) error {
 
 	var prevKeyName string
 
+	// With --sort only the top --limit matching objects are kept while listing.
+	var ranked *listRankHeap
+	if ctx.sortBy != "" {
+		ranked = &listRankHeap{before: listRankFunc(ctx.sortBy, ctx.isReverse)}
+	}
+
+	contentCh := ctx.clnt.List(globalContext, ListOptions{Recursive: true, ShowDir: DirFirst})
+	if ctx.prunePrefixes {
+		contentCh = listPruningDatePrefixes(globalContext, ctx.targetAlias, ctx.clnt, newAgeWindow(ctx.olderThan, ctx.newerThan))
+	}
+
 	// iterate over all content which is within the given directory
-	for content := range ctx.clnt.List(globalContext, ListOptions{Recursive: true, ShowDir: DirFirst}) {
+	for content := range contentCh {
 		if content.Err != nil {
 			switch content.Err.ToGoError().(type) {
 			// handle this specifically for filesystem related errors.
 			case BrokenSymlink:
-				errorIf(content.Err.Trace(ctx.clnt.GetURL().String()), "Unable to list broken link.")
+				warningIf(content.Err.Trace(ctx.clnt.GetURL().String()), "Unable to list broken link.")
 				continue
 			case TooManyLevelsSymlink:
-				errorIf(content.Err.Trace(ctx.clnt.GetURL().String()), "Unable to list too many levels link.")
+				warningIf(content.Err.Trace(ctx.clnt.GetURL().String()), "Unable to list too many levels link.")
 				continue
 			case PathNotFound:
 				errorIf(content.Err.Trace(ctx.clnt.GetURL().String()), "Unable to list folder.")
@@ -287,12 +298,8 @@ func doFind(ctxCtx context.Context, ctx *findContext) error {
 			continue
 		}
 
-		fileKeyName := getAliasedPath(ctx, content.URL.String())
-		fileContent := contentMessage{
-			Key:  fileKeyName,
-			Time: content.Time.Local(),
-			Size: content.Size,
-		}
+		fileContent := findContentMessage(ctx, content)
+		fileKeyName := fileContent.Key
 
 		// Match the incoming content, didn't match return.
 		if !matchFind(ctx, fileContent) || prevKeyName == fileKeyName {
@@ -301,6 +308,14 @@ func doFind(ctxCtx context.Context, ctx *findContext) error {
 
 		prevKeyName = fileKeyName
 
+		if ranked != nil {
+			// Prefixes have neither a size nor a modification time.
+			if !content.Type.IsDir() {
+				ranked.add(content, ctx.limit)
+			}
+			continue
+		}
+
 		// proceed to either exec, format the output string.
 		if ctx.execCmd != "" {
 			execFind(ctxCtx, ctx.execCmd, fileContent)
@@ -313,11 +328,49 @@ func doFind(ctxCtx context.Context, ctx *findContext) error {
 		printMsg(findMessage{fileContent})
 	}
 
+	if ranked != nil {
+		printFindSorted(ctxCtx, ctx, ranked.sorted())
+	}
+
 	// Success, notice watch will execute in defer only if enabled and this call
 	// will return after watch is canceled.
 	return nil
 }
 
+// findContentMessage returns the message of a listed content, with
+// its aliased path as the key.
+func findContentMessage(ctx *findContext, content *ClientContent) contentMessage {
+	return contentMessage{
+		Key:  getAliasedPath(ctx, content.URL.String()),
+		Time: content.Time.Local(),
+		Size: content.Size,
+	}
+}
+
+// printFindSorted executes or prints the sorted matching contents, as
+// a single array with --json like `ls --sort`.
+func printFindSorted(ctxCtx context.Context, ctx *findContext, contents []*ClientContent) {
+	msgs := make(contentMessages, 0, len(contents))
+	for _, content := range contents {
+		fileContent := findContentMessage(ctx, content)
+		if ctx.execCmd != "" {
+			execFind(ctxCtx, ctx.execCmd, fileContent)
+			continue
+		}
+		if ctx.printFmt != "" {
+			fileContent.Key = stringsReplace(ctxCtx, ctx.printFmt, fileContent)
+		}
+		if !globalJSON {
+			printMsg(findMessage{fileContent})
+			continue
+		}
+		msgs = append(msgs, fileContent)
+	}
+	if globalJSON && ctx.execCmd == "" {
+		printMsg(msgs)
+	}
+}
+
 // stringsReplace - formats the string to remove {} and replace each
 // with the appropriate argument
 func stringsReplace(ctx context.Context, args string, fileContent contentMessage) string {
@@ -454,3 +507,144 @@ func getShareURL(ctx context.Context, path string) string {
 
 	return shareURL
 }
+
+// ageWindow is the range of modification times matched by the
+// --older-than and --newer-than flags, a zero bound is unbounded.
+type ageWindow struct {
+	after, before time.Time
+}
+
+func newAgeWindow(olderThan, newerThan string) ageWindow {
+	var w ageWindow
+	now := UTCNow()
+	if olderThan != "" {
+		d, e := ParseDuration(olderThan)
+		fatalIf(probe.NewError(e), "Unable to parse olderThan=`"+olderThan+"`.")
+		w.before = now.Add(-time.Duration(d))
+	}
+	if newerThan != "" {
+		d, e := ParseDuration(newerThan)
+		fatalIf(probe.NewError(e), "Unable to parse newerThan=`"+newerThan+"`.")
+		w.after = now.Add(-time.Duration(d))
+	}
+	return w
+}
+
+// overlaps reports whether the period [start, end) intersects the window.
+func (w ageWindow) overlaps(start, end time.Time) bool {
+	if !w.after.IsZero() && !end.After(w.after) {
+		return false
+	}
+	if !w.before.IsZero() && start.After(w.before) {
+		return false
+	}
+	return true
+}
+
+// Keys of hive style partitions, e.g. year=2021/month=05 or dt=2021-05-03.
+var datePartitionKeys = map[string]bool{
+	"year": true, "month": true, "day": true, "hour": true,
+	"y": true, "m": true, "d": true, "h": true,
+	"dt": true, "date": true,
+}
+
+// datePrefixPeriod returns the period covered by the date found in the
+// segments of path, e.g. the month of May 2021 for "logs/2021/05/".
+func datePrefixPeriod(path string) (start, end time.Time, ok bool) {
+	var year, month, day, hour int
+	level := 0
+	for _, segment := range strings.Split(filepath.ToSlash(path), "/") {
+		if kv := strings.SplitN(segment, "=", 2); len(kv) == 2 && datePartitionKeys[strings.ToLower(kv[0])] {
+			segment = kv[1]
+		}
+		if level == 0 {
+			for _, layout := range []struct {
+				format string
+				level  int
+			}{{"2006-01-02", 3}, {"20060102", 3}, {"2006-01", 2}, {"2006", 1}} {
+				if len(segment) != len(layout.format) {
+					continue
+				}
+				if t, e := time.Parse(layout.format, segment); e == nil && t.Year() >= 1970 {
+					year, month, day, level = t.Year(), int(t.Month()), t.Day(), layout.level
+					break
+				}
+			}
+			continue
+		}
+		if level == 4 || len(segment) != 2 {
+			break
+		}
+		n, e := strconv.Atoi(segment)
+		if e != nil {
+			break
+		}
+		if level == 1 && n >= 1 && n <= 12 {
+			month = n
+		} else if level == 2 && n >= 1 && n <= 31 {
+			day = n
+		} else if level == 3 && n >= 0 && n <= 23 {
+			hour = n
+		} else {
+			break
+		}
+		level++
+	}
+	switch level {
+	case 1:
+		start = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		end = start.AddDate(1, 0, 0)
+	case 2:
+		start = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+		end = start.AddDate(0, 1, 0)
+	case 3:
+		start = time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+		end = start.AddDate(0, 0, 1)
+	case 4:
+		start = time.Date(year, time.Month(month), day, hour, 0, 0, 0, time.UTC)
+		end = start.Add(time.Hour)
+	default:
+		return start, end, false
+	}
+	return start, end, true
+}
+
+// listPruningDatePrefixes lists clnt recursively, directories first, one
+// prefix at a time so that date prefixes whose period falls outside the
+// window are skipped without being listed.
+func listPruningDatePrefixes(ctx context.Context, alias string, clnt Client, w ageWindow) <-chan *ClientContent {
+	contentCh := make(chan *ClientContent)
+	go func() {
+		defer close(contentCh)
+		walkPruningDatePrefixes(ctx, alias, clnt.GetURL(), w, contentCh)
+	}()
+	return contentCh
+}
+
+func walkPruningDatePrefixes(ctx context.Context, alias string, dirURL ClientURL, w ageWindow, contentCh chan<- *ClientContent) {
+	separator := string(dirURL.Separator)
+	urlStr := dirURL.String()
+	if !strings.HasSuffix(urlStr, separator) {
+		urlStr += separator
+	}
+	clnt, err := newClientFromAlias(alias, urlStr)
+	if err != nil {
+		contentCh <- &ClientContent{Err: err.Trace(urlStr)}
+		return
+	}
+	for content := range clnt.List(ctx, ListOptions{ShowDir: DirFirst}) {
+		if content.Err != nil || !content.Type.IsDir() {
+			contentCh <- content
+			continue
+		}
+		// Skip a directory marker listing itself.
+		if strings.TrimSuffix(content.URL.Path, separator) == strings.TrimSuffix(dirURL.Path, separator) {
+			continue
+		}
+		if start, end, ok := datePrefixPeriod(content.URL.Path); ok && !w.overlaps(start, end) {
+			continue
+		}
+		contentCh <- content
+		walkPruningDatePrefixes(ctx, alias, content.URL, w, contentCh)
+	}
+}
@@ -18,8 +18,11 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"io/ioutil"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
@@ -461,3 +464,88 @@ func TestGetExitStatus(t *testing.T) {
 		}
 	}
 }
+
+func TestDatePrefixPeriod(t *testing.T) {
+	testCases := []struct {
+		path  string
+		start time.Time
+		end   time.Time
+		ok    bool
+	}{
+		{"/bucket/logs/2021/", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		{"/bucket/logs/2021/05/", time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC), true},
+		{"/bucket/logs/2021/05/03/10/", time.Date(2021, 5, 3, 10, 0, 0, 0, time.UTC), time.Date(2021, 5, 3, 11, 0, 0, 0, time.UTC), true},
+		{"/bucket/year=2021/month=12/", time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		{"/bucket/dt=2021-05-03/", time.Date(2021, 5, 3, 0, 0, 0, 0, time.UTC), time.Date(2021, 5, 4, 0, 0, 0, 0, time.UTC), true},
+		{"/bucket/20210503/", time.Date(2021, 5, 3, 0, 0, 0, 0, time.UTC), time.Date(2021, 5, 4, 0, 0, 0, 0, time.UTC), true},
+		{"/bucket/2021/13/", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		{"/bucket/logs/", time.Time{}, time.Time{}, false},
+		{"/bucket/0042/", time.Time{}, time.Time{}, false},
+	}
+	for i, testCase := range testCases {
+		start, end, ok := datePrefixPeriod(testCase.path)
+		if ok != testCase.ok || !start.Equal(testCase.start) || !end.Equal(testCase.end) {
+			t.Errorf("Test %d: expected %s %s %t, got %s %s %t", i+1, testCase.start, testCase.end, testCase.ok, start, end, ok)
+		}
+	}
+
+	now := time.Now().UTC()
+	w := ageWindow{after: now.Add(-48 * time.Hour)}
+	if w.overlaps(now.AddDate(0, 0, -10), now.AddDate(0, 0, -9)) {
+		t.Error("expected a period older than the window not to overlap")
+	}
+	if !w.overlaps(now.AddDate(0, 0, -3), now.AddDate(0, 0, -1)) {
+		t.Error("expected a period across the window start to overlap")
+	}
+}
+
+func TestFindSorted(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("Skipping on non-linux")
+		return
+	}
+	withTempMcConfig(t)
+
+	dir := t.TempDir()
+	for name, size := range map[string]int{"a.iso": 1, "b.iso": 5, "c.iso": 3, "d.iso": 4, "e.txt": 9} {
+		if e := ioutil.WriteFile(filepath.Join(dir, name), bytes.Repeat([]byte("x"), size), 0o600); e != nil {
+			t.Fatal(e)
+		}
+	}
+	clnt, err := newClient(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		sortBy    string
+		limit     int
+		isReverse bool
+		expected  string
+	}{
+		{"size", 2, false, "b.iso\nd.iso\n"},
+		{"size", 0, true, "a.iso\nc.iso\nd.iso\nb.iso\n"},
+	}
+	for i, testCase := range testCases {
+		out := filepath.Join(t.TempDir(), "out")
+		e := doFind(context.Background(), &findContext{
+			namePattern: "*.iso",
+			execCmd:     "sh -c 'echo {base} >> " + out + "'",
+			sortBy:      testCase.sortBy,
+			limit:       testCase.limit,
+			isReverse:   testCase.isReverse,
+			targetURL:   dir,
+			clnt:        clnt,
+		})
+		if e != nil {
+			t.Fatal(e)
+		}
+		data, e := ioutil.ReadFile(out)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if string(data) != testCase.expected {
+			t.Fatalf("Test %d: expected %q, got %q", i+1, testCase.expected, string(data))
+		}
+	}
+}
@@ -21,6 +21,24 @@ import (
 	"github.com/minio/cli"
 )
 
+// queryFlag filters the JSON messages with a JMESPath expression.
+var queryFlag = cli.StringFlag{
+	Name:  "query",
+	Usage: "print only the result of a JMESPath expression applied to each JSON message, implies --json",
+}
+
+// globalFlagsWithoutQuery are the global flags of the commands with a
+// --query flag of their own.
+func globalFlagsWithoutQuery() []cli.Flag {
+	flags := make([]cli.Flag, 0, len(globalFlags))
+	for _, flag := range globalFlags {
+		if flag != cli.Flag(queryFlag) {
+			flags = append(flags, flag)
+		}
+	}
+	return flags
+}
+
 // Collection of mc flags currently supported
 var globalFlags = []cli.Flag{
 	cli.StringFlag{
@@ -40,6 +58,7 @@ var globalFlags = []cli.Flag{
 		Name:  "json",
 		Usage: "enable JSON lines formatted output",
 	},
+	queryFlag,
 	cli.BoolFlag{
 		Name:  "debug",
 		Usage: "enable debug output",
@@ -48,6 +67,22 @@ var globalFlags = []cli.Flag{
 		Name:  "insecure",
 		Usage: "disable SSL certificate verification",
 	},
+	cli.BoolFlag{
+		Name:  "read-only",
+		Usage: "refuse to run commands which modify data or configuration",
+	},
+	cli.StringFlag{
+		Name:  "addressing",
+		Usage: "bucket addressing style overriding the alias lookup. Valid options are '[path, virtual, auto]'",
+	},
+	cli.StringFlag{
+		Name:  "region-cache-max-age",
+		Usage: "persist the resolved region of buckets across runs for this duration, e.g. '1h'",
+	},
+	cli.BoolFlag{
+		Name:  "no-region-cache",
+		Usage: "neither use nor update the regions persisted with --region-cache-max-age",
+	},
 }
 
 // Flags common across all I/O commands such as cp, mirror, stat, pipe etc.
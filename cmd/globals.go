@@ -22,9 +22,15 @@ import (
 	"context"
 	"crypto/x509"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/jmespath/go-jmespath"
 	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/console"
+	"github.com/minio/pkg/env"
 )
 
 const (
@@ -57,17 +63,27 @@ const (
 
 var (
 	globalQuiet          = false  // Quiet flag set via command line
+	globalQuietWarnings  = false  // Quiet flag explicitly set, also suppresses warnings
 	globalJSON           = false  // Json flag set via command line
 	globalJSONLine       = false  // Print json as single line.
 	globalDebug          = false  // Debug flag set via command line
 	globalNoColor        = false  // No Color flag set via command line
 	globalInsecure       = false  // Insecure flag set via command line
 	globalDevMode        = false  // dev flag set via command line
+	globalReadOnly       = false  // Read-only flag set via command line or MC_READ_ONLY
+	globalAddressing     = ""     // Bucket addressing style set via command line, overrides the alias lookup
+	globalNoRegionCache  = false  // Resolve bucket regions for every client, set via command line
 	globalSubnetProxyURL *url.URL // Proxy to be used for communication with subnet
 
 	globalContext, globalCancel = context.WithCancel(context.Background())
 )
 
+// globalQuery is the JMESPath expression applied to JSON output, set via --query.
+var globalQuery *jmespath.JMESPath
+
+// Time bucket regions are persisted across runs, set via command line.
+var globalRegionMaxAge time.Duration
+
 var (
 	// Terminal width
 	globalTermWidth int
@@ -76,15 +92,27 @@ var (
 	globalRootCAs *x509.CertPool
 )
 
+// hasQueryFlag tells if flags include the global --query flag.
+func hasQueryFlag(flags []cli.Flag) bool {
+	for _, flag := range flags {
+		if flag == cli.Flag(queryFlag) {
+			return true
+		}
+	}
+	return false
+}
+
 // Set global states. NOTE: It is deliberately kept monolithic to ensure we dont miss out any flags.
-func setGlobals(quiet, debug, json, noColor, insecure, devMode bool) {
+func setGlobals(quiet, debug, json, noColor, insecure, devMode, readOnly bool) {
 	globalQuiet = globalQuiet || quiet
+	globalQuietWarnings = globalQuietWarnings || quiet
 	globalDebug = globalDebug || debug
 	globalJSONLine = !isTerminal() && json
 	globalJSON = globalJSON || json
 	globalNoColor = globalNoColor || noColor || globalJSONLine
 	globalInsecure = globalInsecure || insecure
 	globalDevMode = globalDevMode || devMode
+	globalReadOnly = globalReadOnly || readOnly
 
 	// Disable colorified messages if requested.
 	if globalNoColor || globalQuiet {
@@ -94,13 +122,61 @@ func setGlobals(quiet, debug, json, noColor, insecure, devMode bool) {
 
 // Set global states. NOTE: It is deliberately kept monolithic to ensure we dont miss out any flags.
 func setGlobalsFromContext(ctx *cli.Context) error {
-	quiet := ctx.IsSet("quiet") || ctx.GlobalIsSet("quiet")
-	debug := ctx.IsSet("debug") || ctx.GlobalIsSet("debug")
-	json := ctx.IsSet("json") || ctx.GlobalIsSet("json")
-	noColor := ctx.IsSet("no-color") || ctx.GlobalIsSet("no-color")
-	insecure := ctx.IsSet("insecure") || ctx.GlobalIsSet("insecure")
-	devMode := ctx.IsSet("dev") || ctx.GlobalIsSet("dev")
-
-	setGlobals(quiet, debug, json, noColor, insecure, devMode)
+	applyAliasDefaults(ctx)
+
+	// Bool flags are read by value, an alias default of false is set
+	// on ctx but must not turn them on.
+	quiet := ctx.Bool("quiet") || ctx.GlobalBool("quiet")
+	debug := ctx.Bool("debug") || ctx.GlobalBool("debug")
+	query := ctx.String("query")
+	if !ctx.IsSet("query") && ctx.GlobalIsSet("query") {
+		query = ctx.GlobalString("query")
+	}
+	// The --query of a command such as sql is not a JMESPath expression.
+	if ctx.Command.Name != "" && !hasQueryFlag(ctx.Command.Flags) {
+		query = ctx.GlobalString("query")
+	}
+	json := ctx.Bool("json") || ctx.GlobalBool("json") || query != ""
+	noColor := ctx.Bool("no-color") || ctx.GlobalBool("no-color")
+	insecure := ctx.Bool("insecure") || ctx.GlobalBool("insecure")
+	devMode := ctx.Bool("dev") || ctx.GlobalBool("dev")
+	readOnlyEnv, _ := strconv.ParseBool(env.Get(mcEnvReadOnly, "false"))
+	readOnly := ctx.Bool("read-only") || ctx.GlobalBool("read-only") || readOnlyEnv
+
+	setGlobals(quiet, debug, json, noColor, insecure, devMode, readOnly)
+
+	if query != "" {
+		jp, e := jmespath.Compile(query)
+		fatalIf(probe.NewError(e).Trace(query), "Unable to parse --query, a JMESPath expression is expected.")
+		globalQuery = jp
+	}
+
+	addressing := ctx.String("addressing")
+	if !ctx.IsSet("addressing") && ctx.GlobalIsSet("addressing") {
+		addressing = ctx.GlobalString("addressing")
+	}
+	if addressing != "" {
+		if _, ok := addressingLookupTypes[strings.ToLower(addressing)]; !ok {
+			fatalIf(errInvalidArgument().Trace(addressing),
+				"Unrecognized bucket addressing. Valid options are `[path, virtual, auto]`.")
+		}
+		globalAddressing = strings.ToLower(addressing)
+	}
+
+	globalNoRegionCache = globalNoRegionCache || ctx.Bool("no-region-cache") || ctx.GlobalBool("no-region-cache")
+	maxAge := ctx.String("region-cache-max-age")
+	if !ctx.IsSet("region-cache-max-age") && ctx.GlobalIsSet("region-cache-max-age") {
+		maxAge = ctx.GlobalString("region-cache-max-age")
+	}
+	if maxAge != "" {
+		d, e := time.ParseDuration(maxAge)
+		if e != nil || d < 0 {
+			fatalIf(errInvalidArgument().Trace(maxAge), "Unable to parse --region-cache-max-age, a duration such as `1h` is expected.")
+		}
+		globalRegionMaxAge = d
+	}
+
+	// Refuse mutating commands early in read-only mode.
+	checkReadOnly(ctx)
 	return nil
 }
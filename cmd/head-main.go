@@ -29,8 +29,10 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/fatih/color"
 	"github.com/minio/cli"
 	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
 )
 
 var headFlags = []cli.Flag{
@@ -47,6 +49,10 @@ var headFlags = []cli.Flag{
 		Name:  "version-id, vid",
 		Usage: "select an object version to display",
 	},
+	cli.BoolFlag{
+		Name:  "raw",
+		Usage: "send a HEAD request to the object URL and print the HTTP status and response headers as is",
+	},
 }
 
 // Display contents of a file.
@@ -85,6 +91,12 @@ EXAMPLES:
 
   4. Display the first lines of a specific object version.
      {{.Prompt}} {{.HelpName}} --version-id "3ddac055-89a7-40fa-8cd3-530a5581b6b8" s3/json-data/population.json
+
+  5. Display the HTTP status and headers returned for an object, e.g. by a cache in front of the server.
+     {{.Prompt}} {{.HelpName}} --raw s3/json-data/population.json
+
+  6. Display the HTTP status and headers returned for a presigned URL.
+     {{.Prompt}} {{.HelpName}} --raw 'https://cdn.example.com/json-data/population.json?X-Amz-Algorithm=...'
 `,
 }
 
@@ -177,6 +189,15 @@ func parseHeadSyntax(ctx *cli.Context) (args []string, versionID string, timeRef
 		fatalIf(errInvalidArgument().Trace(), "You need to pass at least one argument if --version-id is specified")
 	}
 
+	if ctx.Bool("raw") {
+		if len(args) == 0 {
+			fatalIf(errInvalidArgument().Trace(), "--raw requires at least one target.")
+		}
+		if rewind != "" {
+			fatalIf(errInvalidArgument().Trace(), "--raw cannot be used with --rewind.")
+		}
+	}
+
 	timeRef = parseRewindFlag(rewind)
 	return
 }
@@ -189,6 +210,17 @@ func mainHead(ctx *cli.Context) error {
 
 	args, versionID, timeRef := parseHeadSyntax(ctx)
 
+	if ctx.Bool("raw") {
+		console.SetColor("HeadStatus", color.New(color.FgGreen, color.Bold))
+		console.SetColor("HeadHeader", color.New(color.FgCyan))
+		for _, url := range args {
+			msg, err := headRaw(globalContext, url, versionID)
+			fatalIf(err.Trace(url), "Unable to send a HEAD request to `"+url+"`.")
+			printMsg(msg)
+		}
+		return nil
+	}
+
 	stdinMode := len(args) == 0
 
 	// handle std input data.
@@ -0,0 +1,118 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// Validity of the presigned URL of a raw HEAD request on an alias.
+const headRawExpiry = time.Minute
+
+// headRawMessage container for the response of a raw HEAD request.
+type headRawMessage struct {
+	Status     string              `json:"status"`
+	URL        string              `json:"url"`
+	StatusLine string              `json:"statusLine"`
+	StatusCode int                 `json:"statusCode"`
+	Headers    map[string][]string `json:"headers"`
+}
+
+func (h headRawMessage) String() string {
+	var b strings.Builder
+	b.WriteString(console.Colorize("HeadStatus", h.StatusLine))
+	names := make([]string, 0, len(h.Headers))
+	for name := range h.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range h.Headers[name] {
+			b.WriteString("\n" + console.Colorize("HeadHeader", name+":") + " " + value)
+		}
+	}
+	return b.String()
+}
+
+func (h headRawMessage) JSON() string {
+	h.Status = "success"
+	msgBytes, e := json.MarshalIndent(h, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// headRawURL returns the URL a raw HEAD request is sent to, a presigned
+// URL for an alias or the passed URL as is, e.g. an already presigned one.
+func headRawURL(ctx context.Context, targetURL, versionID string) (string, *probe.Error) {
+	alias, urlStr, hostCfg := mustExpandAlias(targetURL)
+	if hostCfg == nil {
+		if strings.HasPrefix(targetURL, "http://") || strings.HasPrefix(targetURL, "https://") {
+			return targetURL, nil
+		}
+		return "", probe.NewError(fmt.Errorf("`%s` is neither an object storage alias nor an HTTP URL", targetURL))
+	}
+	clnt, err := newClientFromAlias(alias, urlStr)
+	if err != nil {
+		return "", err.Trace(targetURL)
+	}
+	s3Client, ok := clnt.(*S3Client)
+	if !ok {
+		return "", probe.NewError(fmt.Errorf("`%s` is not an object storage URL", targetURL))
+	}
+	return s3Client.PresignHead(ctx, versionID, headRawExpiry)
+}
+
+// headRaw sends a literal HEAD request to the URL of targetURL and
+// returns the response status and headers, redirects are not followed.
+func headRaw(ctx context.Context, targetURL, versionID string) (headRawMessage, *probe.Error) {
+	rawURL, err := headRawURL(ctx, targetURL, versionID)
+	if err != nil {
+		return headRawMessage{}, err.Trace(targetURL)
+	}
+
+	req, e := http.NewRequestWithContext(ctx, http.MethodHead, rawURL, nil)
+	if e != nil {
+		return headRawMessage{}, probe.NewError(e).Trace(targetURL)
+	}
+	clnt := httpClient(30 * time.Second)
+	clnt.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify = globalInsecure
+	clnt.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	resp, e := clnt.Do(req)
+	if e != nil {
+		return headRawMessage{}, probe.NewError(e).Trace(targetURL)
+	}
+	resp.Body.Close()
+
+	return headRawMessage{
+		URL:        targetURL,
+		StatusLine: resp.Proto + " " + resp.Status,
+		StatusCode: resp.StatusCode,
+		Headers:    resp.Header,
+	}, nil
+}
@@ -109,6 +109,7 @@ var ilmAddFlags = []cli.Flag{
 }
 
 type ilmAddMessage struct {
+	messageBase
 	Status string `json:"status"`
 	Target string `json:"target"`
 	ID     string `json:"id"`
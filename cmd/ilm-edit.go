@@ -70,6 +70,7 @@ var ilmEditFlags = append(
 )
 
 type ilmEditMessage struct {
+	messageBase
 	Status string `json:"status"`
 	Target string `json:"target"`
 	ID     string `json:"id"`
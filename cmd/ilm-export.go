@@ -53,6 +53,7 @@ EXAMPLES:
 }
 
 type ilmExportMessage struct {
+	messageBase
 	Status string                   `json:"status"`
 	Target string                   `json:"target"`
 	Config *lifecycle.Configuration `json:"config"`
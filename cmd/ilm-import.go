@@ -54,6 +54,7 @@ EXAMPLES:
 }
 
 type ilmImportMessage struct {
+	messageBase
 	Status string `json:"status"`
 	Target string `json:"target"`
 }
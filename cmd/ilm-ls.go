@@ -78,6 +78,7 @@ EXAMPLES:
 }
 
 type ilmListMessage struct {
+	messageBase
 	Status  string                   `json:"status"`
 	Target  string                   `json:"target"`
 	Context *cli.Context             `json:"-"`
@@ -73,6 +73,7 @@ EXAMPLES:
 }
 
 type ilmRmMessage struct {
+	messageBase
 	Status string `json:"status"`
 	ID     string `json:"id"`
 	Target string `json:"target"`
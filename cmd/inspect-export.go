@@ -18,6 +18,7 @@
 package cmd
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/binary"
 	"encoding/hex"
@@ -35,60 +36,69 @@ import (
 	"github.com/tinylib/msgp/msgp"
 )
 
-func inspectToExportType(downloadPath string, datajson bool) error {
-	decode := func(r io.Reader, file string) ([]byte, error) {
+// inspectToExportType writes the xl.meta files of the downloaded inspect
+// archive to w as a single JSON object keyed by file name. Every file is
+// decoded and written as soon as it is read from the archive, so the
+// output is never held in memory as a whole.
+func inspectToExportType(downloadPath string, datajson bool, w io.Writer) error {
+	decode := func(r io.Reader, w io.Writer) error {
 		b, e := ioutil.ReadAll(r)
 		if e != nil {
-			return nil, e
+			return e
 		}
 		b, _, minor, e := checkXL2V1(b)
 		if e != nil {
-			return nil, e
+			return e
 		}
 
-		buf := bytes.NewBuffer(nil)
 		var data xlMetaInlineData
 		switch minor {
 		case 0:
-			_, e = msgp.CopyToJSON(buf, bytes.NewReader(b))
-			if e != nil {
-				return nil, e
+			if datajson {
+				break
 			}
+			_, e = msgp.CopyToJSON(w, bytes.NewReader(b))
+			return e
 		case 1, 2:
 			v, b, e := msgp.ReadBytesZC(b)
 			if e != nil {
-				return nil, e
+				return e
 			}
 			if _, nbuf, e := msgp.ReadUint32Bytes(b); e == nil {
 				// Read metadata CRC (added in v2, ignore if not found)
 				b = nbuf
 			}
-
-			_, e = msgp.CopyToJSON(buf, bytes.NewReader(v))
-			if e != nil {
-				return nil, e
+			if !datajson {
+				_, e = msgp.CopyToJSON(w, bytes.NewReader(v))
+				return e
 			}
 			data = b
 		case 3:
 			v, b, e := msgp.ReadBytesZC(b)
 			if e != nil {
-				return nil, e
+				return e
 			}
 			if _, nbuf, e := msgp.ReadUint32Bytes(b); e == nil {
 				// Read metadata CRC (added in v2, ignore if not found)
 				b = nbuf
 			}
+			if datajson {
+				data = b
+				break
+			}
 
 			nVers, v, e := decodeXLHeaders(v)
 			if e != nil {
-				return nil, e
+				return e
 			}
 			type version struct {
 				Idx      int
 				Header   json.RawMessage
 				Metadata json.RawMessage
 			}
-			versions := make([]version, nVers)
+			if _, e = io.WriteString(w, `{"Versions":[`); e != nil {
+				return e
+			}
 			e = decodeVersions(v, nVers, func(idx int, hdr, meta []byte) error {
 				var header xlMetaV2VersionHeaderV2
 				if _, e := header.UnmarshalMsg(hdr); e != nil {
@@ -102,82 +112,83 @@ func inspectToExportType(downloadPath string, datajson bool) error {
 				if _, e := msgp.UnmarshalAsJSON(&buf, meta); e != nil {
 					return e
 				}
-				versions[idx] = version{
+				b, e = json.Marshal(version{
 					Idx:      idx,
 					Header:   b,
 					Metadata: buf.Bytes(),
+				})
+				if e != nil {
+					return e
 				}
-				return nil
+				if idx > 0 {
+					if _, e = io.WriteString(w, ","); e != nil {
+						return e
+					}
+				}
+				_, e = w.Write(b)
+				return e
 			})
 			if e != nil {
-				return nil, e
-			}
-			enc := json.NewEncoder(buf)
-			if e := enc.Encode(struct {
-				Versions []version
-			}{Versions: versions}); e != nil {
-				return nil, e
+				return e
 			}
-			data = b
+			_, e = io.WriteString(w, "]}\n")
+			return e
 		default:
-			return nil, fmt.Errorf("unknown metadata version %d", minor)
+			return fmt.Errorf("unknown metadata version %d", minor)
 		}
 
-		if datajson {
-			b, e := data.json()
-			if e != nil {
-				return nil, e
-			}
-			buf = bytes.NewBuffer(b)
+		b, e = data.json()
+		if e != nil {
+			return e
 		}
-
-		return buf.Bytes(), nil
+		_, e = w.Write(b)
+		return e
 	}
 
-	fmt.Println("{")
-
-	hasWritten := false
-	var r io.Reader
-	var sz int64
 	f, e := os.Open(downloadPath)
 	if e != nil {
 		return e
 	}
-	if st, e := f.Stat(); e == nil {
-		sz = st.Size()
-	}
 	defer f.Close()
-	r = f
+	st, e := f.Stat()
+	if e != nil {
+		return e
+	}
 
-	zr, e := zip.NewReader(r.(io.ReaderAt), sz)
+	zr, e := zip.NewReader(f, st.Size())
 	if e != nil {
 		return e
 	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "{")
+	hasWritten := false
 	for _, file := range zr.File {
-		if !file.FileInfo().IsDir() && strings.HasSuffix(file.Name, "xl.meta") {
-			r, e := file.Open()
-			if e != nil {
-				return e
-			}
-			// Quote string...
-			b, _ := json.Marshal(file.Name)
-			if hasWritten {
-				fmt.Print(",\n")
-			}
-			fmt.Printf("\t%s: ", string(b))
+		if file.FileInfo().IsDir() || !strings.HasSuffix(file.Name, "xl.meta") {
+			continue
+		}
+		r, e := file.Open()
+		if e != nil {
+			return e
+		}
+		// Quote string...
+		b, _ := json.Marshal(file.Name)
+		if hasWritten {
+			fmt.Fprint(bw, ",\n")
+		}
+		fmt.Fprintf(bw, "\t%s: ", string(b))
 
-			b, e = decode(r, file.Name)
-			if e != nil {
-				return e
-			}
-			fmt.Print(string(b))
-			hasWritten = true
+		e = decode(r, bw)
+		r.Close()
+		if e != nil {
+			return fmt.Errorf("%s: %w", file.Name, e)
 		}
+		hasWritten = true
 	}
-	fmt.Println("")
-	fmt.Println("}")
+	fmt.Fprintln(bw, "")
+	fmt.Fprintln(bw, "}")
 
-	return nil
+	return bw.Flush()
 }
 
 var (
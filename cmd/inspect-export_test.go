@@ -0,0 +1,78 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	json "github.com/minio/colorjson"
+
+	"github.com/klauspost/compress/zip"
+	"github.com/tinylib/msgp/msgp"
+)
+
+func TestInspectToExportType(t *testing.T) {
+	downloadPath := filepath.Join(t.TempDir(), "inspect.zip")
+	f, e := os.Create(downloadPath)
+	if e != nil {
+		t.Fatal(e)
+	}
+	zw := zip.NewWriter(f)
+	files := map[string][]byte{
+		"server1/disk1/bucket/object/xl.meta": msgp.AppendInt(msgp.AppendString(msgp.AppendMapHeader([]byte("XL2 1   "), 1), "Size"), 1),
+		"server1/disk2/bucket/object/xl.meta": msgp.AppendInt(msgp.AppendString(msgp.AppendMapHeader([]byte("XL2 1   "), 1), "Size"), 2),
+		"server1/disk1/bucket/object/part.1":  []byte("data"),
+	}
+	for name, data := range files {
+		w, e := zw.Create(name)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if _, e = w.Write(data); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if e = zw.Close(); e != nil {
+		t.Fatal(e)
+	}
+	f.Close()
+
+	var buf bytes.Buffer
+	if e = inspectToExportType(downloadPath, false, &buf); e != nil {
+		t.Fatal(e)
+	}
+	var exported map[string]struct{ Size int }
+	if e = json.Unmarshal(buf.Bytes(), &exported); e != nil {
+		t.Fatalf("expected valid JSON, got %v: %s", e, buf.String())
+	}
+	if len(exported) != 2 || exported["server1/disk1/bucket/object/xl.meta"].Size != 1 || exported["server1/disk2/bucket/object/xl.meta"].Size != 2 {
+		t.Fatalf("unexpected export %s", buf.String())
+	}
+
+	buf.Reset()
+	if e = inspectToExportType(downloadPath, true, &buf); e != nil {
+		t.Fatal(e)
+	}
+	var inline map[string]map[string]int
+	if e = json.Unmarshal(buf.Bytes(), &inline); e != nil || len(inline) != 2 {
+		t.Fatalf("expected the inline data of 2 files, got %v: %s", e, buf.String())
+	}
+}
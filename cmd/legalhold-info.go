@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/fatih/color"
@@ -49,6 +50,11 @@ var lhInfoFlags = []cli.Flag{
 		Name:  "versions",
 		Usage: "show legal hold status of multiple versions of object(s)",
 	},
+	cli.IntFlag{
+		Name:  "workers",
+		Value: 8,
+		Usage: "number of objects to get the legal hold status of concurrently",
+	},
 }
 
 var legalHoldInfoCmd = cli.Command{
@@ -80,11 +86,16 @@ EXAMPLES:
 
    4. Show object legal hold recursively for all objects versions older than one year
       $ {{.HelpName}} myminio/mybucket/prefix --recursive --rewind 365d --versions
+
+   5. Audit the legal hold status of all objects of a bucket, streaming a record per object
+      followed by the number of held and unheld objects.
+      $ {{.HelpName}} --json --recursive --workers 32 myminio/mybucket
 `,
 }
 
 // Structured message depending on the type of console.
 type legalHoldInfoMessage struct {
+	messageBase
 	LegalHold minio.LegalHoldStatus `json:"legalhold"`
 	URLPath   string                `json:"urlpath"`
 	Key       string                `json:"key"`
@@ -128,8 +139,40 @@ func (l legalHoldInfoMessage) JSON() string {
 	return string(msgBytes)
 }
 
+// legalHoldSummaryMessage counts the legal hold status of the objects of
+// a prefix, LockEnabled is false for a bucket without object locking.
+type legalHoldSummaryMessage struct {
+	Status      string `json:"status"`
+	URL         string `json:"url"`
+	LockEnabled bool   `json:"lockEnabled"`
+	On          int64  `json:"on"`
+	Off         int64  `json:"off"`
+	NotSet      int64  `json:"notSet"`
+	Errors      int64  `json:"errors,omitempty"`
+}
+
+func (l legalHoldSummaryMessage) String() string {
+	if !l.LockEnabled {
+		return console.Colorize("LegalHoldMessageFailure", "Object locking is not enabled on the bucket of `"+l.URL+"`, none of its objects can be under legal hold.")
+	}
+	msg := fmt.Sprintf("Held: %s, unheld: %s (%s, %s)",
+		console.Colorize("LegalHoldOn", l.On), console.Colorize("LegalHoldOff", l.Off+l.NotSet),
+		console.Colorize("LegalHoldOff", fmt.Sprintf("%d OFF", l.Off)), console.Colorize("LegalHoldNotSet", fmt.Sprintf("%d not set", l.NotSet)))
+	if l.Errors > 0 {
+		msg += console.Colorize("LegalHoldPartialFailure", fmt.Sprintf(", %d errors", l.Errors))
+	}
+	return msg
+}
+
+func (l legalHoldSummaryMessage) JSON() string {
+	l.Status = "success"
+	msgBytes, e := json.MarshalIndent(l, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
 // showLegalHoldInfo - show legalhold for one or many objects within a given prefix, with or without versioning
-func showLegalHoldInfo(ctx context.Context, urlStr, versionID string, timeRef time.Time, withOlderVersions, recursive bool) error {
+func showLegalHoldInfo(ctx context.Context, urlStr, versionID string, timeRef time.Time, withOlderVersions, recursive bool, workers int) error {
 	clnt, err := newClient(urlStr)
 	if err != nil {
 		fatalIf(err.Trace(), "Unable to parse the provided url.")
@@ -163,59 +206,86 @@ func showLegalHoldInfo(ctx context.Context, urlStr, versionID string, timeRef ti
 
 	alias, _, _ := mustExpandAlias(urlStr)
 	var cErr error
-	errorsFound := false
-	objectsFound := false
 	lstOptions := ListOptions{Recursive: recursive, ShowDir: DirNone}
 	if !timeRef.IsZero() {
 		lstOptions.WithOlderVersions = withOlderVersions
 		lstOptions.TimeRef = timeRef
 	}
-	for content := range clnt.List(ctx, lstOptions) {
-		if content.Err != nil {
-			errorIf(content.Err.Trace(clnt.GetURL().String()), "Unable to list folder.")
-			cErr = exitStatus(globalErrorExitStatus) // Set the exit status.
-			continue
-		}
-
-		if !recursive && alias+getKey(content) != getStandardizedURL(urlStr) {
-			break
-		}
-
-		objectsFound = true
-		newClnt, perr := newClientFromAlias(alias, content.URL.String())
-		if perr != nil {
-			errorIf(content.Err.Trace(clnt.GetURL().String()), "Invalid URL")
-			continue
-		}
-		lhold, probeErr := newClnt.GetObjectLegalHold(ctx, content.VersionID)
-		if probeErr != nil {
-			errorsFound = true
-			errorIf(probeErr.Trace(content.URL.Path), "Failed to get legal hold information on `"+content.URL.Path+"`")
-		} else {
-			if !globalJSON {
 
+	summary := legalHoldSummaryMessage{URL: urlStr, LockEnabled: true}
+	contentCh := make(chan *ClientContent)
+	msgCh := make(chan legalHoldInfoMessage)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for content := range contentCh {
+				newClnt, perr := newClientFromAlias(alias, content.URL.String())
+				if perr != nil {
+					errorIf(perr.Trace(content.URL.String()), "Invalid URL")
+					continue
+				}
 				contentURL := filepath.ToSlash(content.URL.Path)
-				key := strings.TrimPrefix(contentURL, prefixPath)
-
-				printMsg(legalHoldInfoMessage{
-					LegalHold: lhold,
+				msg := legalHoldInfoMessage{
 					Status:    "success",
 					URLPath:   content.URL.String(),
-					Key:       key,
+					Key:       strings.TrimPrefix(contentURL, prefixPath),
 					VersionID: content.VersionID,
-				})
+				}
+				var probeErr *probe.Error
+				if msg.LegalHold, probeErr = newClnt.GetObjectLegalHold(ctx, content.VersionID); probeErr != nil {
+					errorIf(probeErr.Trace(content.URL.Path), "Failed to get legal hold information on `"+content.URL.Path+"`")
+					msg.Status, msg.Err = "error", probeErr.ToGoError()
+				}
+				msgCh <- msg
 			}
-		}
+		}()
 	}
 
-	if cErr == nil && !globalJSON {
-		switch {
-		case errorsFound:
-			console.Print(console.Colorize("LegalHoldPartialFailure", fmt.Sprintf("Errors found while getting legal hold status on objects with prefix `%s`. \n", urlStr)))
-		case !objectsFound:
-			console.Print(console.Colorize("LegalHoldMessageFailure", fmt.Sprintf("No objects/versions found while getting legal hold status with prefix `%s`. \n", urlStr)))
+	go func() {
+		defer close(msgCh)
+		for content := range clnt.List(ctx, lstOptions) {
+			if content.Err != nil {
+				errorIf(content.Err.Trace(clnt.GetURL().String()), "Unable to list folder.")
+				cErr = exitStatus(globalErrorExitStatus) // Set the exit status.
+				continue
+			}
+
+			if !recursive && alias+getKey(content) != getStandardizedURL(urlStr) {
+				break
+			}
+			contentCh <- content
+		}
+		close(contentCh)
+		wg.Wait()
+	}()
+
+	for msg := range msgCh {
+		if msg.Err != nil {
+			summary.Errors++
+			continue
+		}
+		switch msg.LegalHold {
+		case minio.LegalHoldEnabled:
+			summary.On++
+		case minio.LegalHoldDisabled:
+			summary.Off++
+		default:
+			summary.NotSet++
 		}
+		printMsg(msg)
+	}
+
+	if summary.Errors > 0 {
+		cErr = exitStatus(globalErrorExitStatus)
 	}
+	if !globalJSON && summary.On+summary.Off+summary.NotSet+summary.Errors == 0 {
+		console.Print(console.Colorize("LegalHoldMessageFailure", fmt.Sprintf("No objects/versions found while getting legal hold status with prefix `%s`. \n", urlStr)))
+		return cErr
+	}
+	printMsg(summary)
 	return cErr
 }
 
@@ -237,13 +307,20 @@ func mainLegalHoldInfo(cliCtx *cli.Context) error {
 	ctx, cancelLegalHold := context.WithCancel(globalContext)
 	defer cancelLegalHold()
 
+	workers := cliCtx.Int("workers")
+	if workers < 1 {
+		fatalIf(errInvalidArgument().Trace(targetURL), "--workers should be at least 1.")
+	}
+
 	enabled, err := isBucketLockEnabled(ctx, targetURL)
 	if err != nil {
 		fatalIf(err, "Unable to get legalhold info of `%s`", targetURL)
 	}
 	if !enabled {
-		fatalIf(errDummy().Trace(), "Bucket lock needs to be enabled in order to use this feature.")
+		// No object of the bucket can be under legal hold.
+		printMsg(legalHoldSummaryMessage{URL: targetURL})
+		return nil
 	}
 
-	return showLegalHoldInfo(ctx, targetURL, versionID, timeRef, withVersions, recursive)
+	return showLegalHoldInfo(ctx, targetURL, versionID, timeRef, withVersions, recursive, workers)
 }
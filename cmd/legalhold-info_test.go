@@ -0,0 +1,107 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// legalHoldHandler lists the objects of bucket and serves their legal
+// hold status, an empty status is not set and "denied" an error.
+func legalHoldHandler(holds map[string]string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path == "/bucket/" {
+			var contents strings.Builder
+			for _, key := range []string{"a", "b", "c", "d"} {
+				contents.WriteString("<Contents><Key>" + key + "</Key><LastModified>2021-01-01T00:00:00.000Z</LastModified><ETag>&quot;259d04a13802ae09c7e41be50ccc6baa&quot;</ETag><Size>1</Size><StorageClass>STANDARD</StorageClass></Contents>")
+			}
+			w.Write([]byte(`<ListBucketResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Name>bucket</Name><Prefix></Prefix><KeyCount>4</KeyCount><MaxKeys>1000</MaxKeys><IsTruncated>false</IsTruncated>` + contents.String() + `</ListBucketResult>`))
+			return
+		}
+		if _, ok := query["legal-hold"]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch hold := holds[strings.TrimPrefix(r.URL.Path, "/bucket/")]; hold {
+		case "":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`<Error><Code>NoSuchObjectLockConfiguration</Code><Message>The specified object does not have a ObjectLock configuration</Message></Error>`))
+		case "denied":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`<Error><Code>AccessDenied</Code><Message>Access Denied.</Message></Error>`))
+		default:
+			w.Write([]byte(`<LegalHold><Status>` + hold + `</Status></LegalHold>`))
+		}
+	})
+}
+
+func TestShowLegalHoldInfoRecursive(t *testing.T) {
+	withTempMcConfig(t)
+
+	out := captureJSONOutput(t)
+
+	newS3StubServer(t, "hold", legalHoldHandler(map[string]string{"a": "ON", "b": "OFF", "d": "denied"}))
+
+	if e := showLegalHoldInfo(context.Background(), "hold/bucket/", "", time.Time{}, false, true, 3); e == nil {
+		t.Fatal("expected the error of d to set the exit status")
+	}
+
+	var summary legalHoldSummaryMessage
+	holds := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var record struct {
+			Status    string `json:"status"`
+			Key       string `json:"key"`
+			LegalHold string `json:"legalhold"`
+			On        *int64 `json:"on"`
+		}
+		if e := json.Unmarshal([]byte(line), &record); e != nil {
+			t.Fatalf("unexpected record %s: %v", line, e)
+		}
+		if record.Status == "error" {
+			// The error of d.
+			continue
+		}
+		if record.On != nil {
+			if e := json.Unmarshal([]byte(line), &summary); e != nil {
+				t.Fatal(e)
+			}
+			continue
+		}
+		holds[record.Key] = record.LegalHold
+	}
+
+	expected := map[string]string{"a": "ON", "b": "OFF", "c": ""}
+	if len(holds) != len(expected) {
+		t.Fatalf("expected the status of %v, got %v", expected, holds)
+	}
+	for key, hold := range expected {
+		if got, ok := holds[key]; !ok || got != hold {
+			t.Fatalf("expected %s to be %q, got %q", key, hold, got)
+		}
+	}
+	if summary.On != 1 || summary.Off != 1 || summary.NotSet != 1 || summary.Errors != 1 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+}
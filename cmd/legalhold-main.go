@@ -48,6 +48,7 @@ var legalHoldCmd = cli.Command{
 
 // Structured message depending on the type of console.
 type legalHoldCmdMessage struct {
+	messageBase
 	LegalHold minio.LegalHoldStatus `json:"legalhold"`
 	URLPath   string                `json:"urlpath"`
 	Key       string                `json:"key"`
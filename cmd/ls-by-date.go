@@ -0,0 +1,200 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// lsDatePeriods are the granularities of --summarize-by-date.
+var lsDatePeriods = []string{"day", "week", "month", "year"}
+
+func isValidDatePeriod(period string) bool {
+	for _, p := range lsDatePeriods {
+		if p == period {
+			return true
+		}
+	}
+	return false
+}
+
+// truncateToPeriod returns the start of the day, week, month or year t
+// falls in, weeks start on Monday as ISO weeks do.
+func truncateToPeriod(t time.Time, period string) time.Time {
+	year, month, day := t.Date()
+	switch period {
+	case "week":
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, t.Location())
+	case "month":
+		return time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+	case "year":
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, t.Location())
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// datePeriodLabel names the period starting at start.
+func datePeriodLabel(start time.Time, period string) string {
+	switch period {
+	case "week":
+		year, week := start.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case "month":
+		return start.Format("2006-01")
+	case "year":
+		return start.Format("2006")
+	}
+	return start.Format("2006-01-02")
+}
+
+// dateHistogramEntry is the number and size of the objects last
+// modified in one period.
+type dateHistogramEntry struct {
+	Period string `json:"period"`
+	Count  int64  `json:"count"`
+	Size   int64  `json:"size"`
+}
+
+// dateHistogramMessage container for the --summarize-by-date histogram,
+// oldest period first.
+type dateHistogramMessage []dateHistogramEntry
+
+// dateHistogramWidth is the width of the largest bar of the histogram.
+const dateHistogramWidth = 40
+
+// String colorized histogram, the bars are proportional to the size.
+func (h dateHistogramMessage) String() string {
+	var maxSize int64
+	for _, e := range h {
+		if e.Size > maxSize {
+			maxSize = e.Size
+		}
+	}
+	var b strings.Builder
+	for _, e := range h {
+		bar := 0
+		if maxSize > 0 {
+			bar = int(float64(e.Size) / float64(maxSize) * dateHistogramWidth)
+		}
+		if bar == 0 && e.Size > 0 {
+			bar = 1
+		}
+		b.WriteString(console.Colorize("Time", fmt.Sprintf("%-10s ", e.Period)))
+		b.WriteString(fmt.Sprintf("%10d objects ", e.Count))
+		b.WriteString(console.Colorize("Size", fmt.Sprintf("%10s ", humanize.IBytes(uint64(e.Size)))))
+		b.WriteString(console.Colorize("Summarize", strings.Repeat("#", bar)))
+		b.WriteString("\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// JSON jsonified histogram.
+func (h dateHistogramMessage) JSON() string {
+	if h == nil {
+		h = dateHistogramMessage{}
+	}
+	jsonMessageBytes, e := json.MarshalIndent(h, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// dateHistogram accumulates the objects of a listing by period.
+type dateHistogram struct {
+	period  string
+	entries map[time.Time]*dateHistogramEntry
+}
+
+func newDateHistogram(period string) *dateHistogram {
+	return &dateHistogram{period: period, entries: map[time.Time]*dateHistogramEntry{}}
+}
+
+// add counts an object last modified at t.
+func (h *dateHistogram) add(t time.Time, size int64) {
+	start := truncateToPeriod(t, h.period)
+	entry, ok := h.entries[start]
+	if !ok {
+		entry = &dateHistogramEntry{Period: datePeriodLabel(start, h.period)}
+		h.entries[start] = entry
+	}
+	entry.Count++
+	entry.Size += size
+}
+
+// message returns the histogram, oldest period first.
+func (h *dateHistogram) message() dateHistogramMessage {
+	starts := make([]time.Time, 0, len(h.entries))
+	for start := range h.entries {
+		starts = append(starts, start)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+	msg := make(dateHistogramMessage, 0, len(starts))
+	for _, start := range starts {
+		msg = append(msg, *h.entries[start])
+	}
+	return msg
+}
+
+// doListByDate - list objects and print their number and size per
+// period of their last modification time instead of the objects.
+func doListByDate(ctx context.Context, clnt Client, o doListOptions) error {
+	var cErr error
+
+	histogram := newDateHistogram(o.summarizeByDate)
+	for content := range clnt.List(ctx, ListOptions{
+		Recursive:         o.isRecursive,
+		Incomplete:        o.isIncomplete,
+		TimeRef:           o.timeRef,
+		WithOlderVersions: o.withOlderVersions || !o.timeRef.IsZero(),
+		ShowDir:           DirNone,
+		ListZip:           o.listZip,
+	}) {
+		if content.Err != nil {
+			errorIf(content.Err.Trace(clnt.GetURL().String()), "Unable to list folder.")
+			cErr = exitStatus(globalErrorExitStatus) // Set the exit status.
+			continue
+		}
+
+		if content.StorageClass != "" && o.filter != "" && o.filter != "*" && content.StorageClass != o.filter {
+			continue
+		}
+
+		// Prefixes and delete markers are not ingested objects.
+		if content.Type.IsDir() || content.IsDeleteMarker || content.Time.IsZero() {
+			continue
+		}
+
+		if o.latestOnly && o.withOlderVersions && !content.IsLatest {
+			continue
+		}
+
+		histogram.add(content.Time.Local(), content.Size)
+	}
+
+	printMsg(histogram.message())
+	return cErr
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"path"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+	"github.com/minio/pkg/env"
+)
+
+// Default extension categories used to colorize `ls` output.
+var lsExtensionCategories = map[string][]string{
+	"Image":   {"bmp", "gif", "heic", "ico", "jpeg", "jpg", "png", "svg", "tif", "tiff", "webp"},
+	"Archive": {"7z", "bz2", "gz", "lz4", "rar", "tar", "tgz", "xz", "zip", "zst"},
+	"Text":    {"conf", "csv", "htm", "html", "ini", "json", "log", "md", "toml", "txt", "xml", "yaml", "yml"},
+	"Binary":  {"bin", "deb", "dll", "dmg", "exe", "img", "iso", "msi", "rpm", "so"},
+}
+
+// Default colors of the extension categories.
+var lsCategoryColors = map[string]*color.Color{
+	"Image":   color.New(color.FgMagenta, color.Bold),
+	"Archive": color.New(color.FgRed, color.Bold),
+	"Text":    color.New(color.FgWhite),
+	"Binary":  color.New(color.FgGreen, color.Bold),
+}
+
+// Colors accepted in MC_LS_COLORS and the lsColors config entry.
+var lsColorNames = map[string]color.Attribute{
+	"black":   color.FgBlack,
+	"red":     color.FgRed,
+	"green":   color.FgGreen,
+	"yellow":  color.FgYellow,
+	"blue":    color.FgBlue,
+	"magenta": color.FgMagenta,
+	"cyan":    color.FgCyan,
+	"white":   color.FgWhite,
+}
+
+// lsExtensionTags maps a lowercase file extension to its console theme
+// tag, it stays empty unless setLsColors is called.
+var lsExtensionTags = map[string]string{}
+
+// setLsColors registers the theme colors of file extensions, applying
+// the overrides of the lsColors config entry and MC_LS_COLORS.
+func setLsColors() {
+	for category, extensions := range lsExtensionCategories {
+		tag := "File" + category
+		console.SetColor(tag, lsCategoryColors[category])
+		for _, ext := range extensions {
+			lsExtensionTags[ext] = tag
+		}
+	}
+
+	for ext, attr := range lsColorOverrides() {
+		tag := "File." + ext
+		console.SetColor(tag, color.New(attr))
+		lsExtensionTags[ext] = tag
+	}
+}
+
+// lsColorOverrides returns the extension colors of the lsColors config
+// entry, overridden by those found in MC_LS_COLORS, e.g. "mp4=cyan:log=yellow".
+func lsColorOverrides() map[string]color.Attribute {
+	config, err := loadMcConfig()
+	fatalIf(err.Trace(mustGetMcConfigPath()), "Unable to access configuration file.")
+	overrides, err := configLsColors(config.LsColors)
+	fatalIf(err.Trace(mustGetMcConfigPath()), "Unable to parse the lsColors of the configuration file.")
+
+	envOverrides, err := parseLsColors(env.Get(mcEnvLsColors, ""))
+	fatalIf(err.Trace(mcEnvLsColors), "Unable to parse "+mcEnvLsColors+".")
+	for ext, attr := range envOverrides {
+		overrides[ext] = attr
+	}
+	return overrides
+}
+
+// parseLsColors parses colon separated ext=color pairs.
+func parseLsColors(s string) (map[string]color.Attribute, *probe.Error) {
+	overrides := make(map[string]color.Attribute)
+	for _, entry := range strings.Split(s, ":") {
+		if entry == "" {
+			continue
+		}
+		kv := strings.SplitN(entry, "=", 2)
+		if len(kv) != 2 {
+			return nil, errInvalidArgument().Trace(entry)
+		}
+		ext, attr, ok := parseLsColor(kv[0], kv[1])
+		if !ok {
+			return nil, errInvalidArgument().Trace(entry)
+		}
+		overrides[ext] = attr
+	}
+	return overrides, nil
+}
+
+// configLsColors parses the extension to color map of the config.
+func configLsColors(colors map[string]string) (map[string]color.Attribute, *probe.Error) {
+	overrides := make(map[string]color.Attribute, len(colors))
+	for ext, name := range colors {
+		ext, attr, ok := parseLsColor(ext, name)
+		if !ok {
+			return nil, errInvalidArgument().Trace(ext + "=" + name)
+		}
+		overrides[ext] = attr
+	}
+	return overrides, nil
+}
+
+// parseLsColor returns the lowercase extension and the color of an
+// extension and color name pair, false if either is invalid.
+func parseLsColor(ext, name string) (string, color.Attribute, bool) {
+	ext = strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(ext), "*"), "."))
+	attr, ok := lsColorNames[strings.ToLower(strings.TrimSpace(name))]
+	return ext, attr, ext != "" && ok
+}
+
+// lsFileColorTag returns the console theme tag used to print a file.
+func lsFileColorTag(key string) string {
+	ext := strings.ToLower(strings.TrimPrefix(path.Ext(key), "."))
+	if tag, ok := lsExtensionTags[ext]; ok {
+		return tag
+	}
+	return "File"
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/base64"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// listToken is the position of a paged listing, encoded in the
+// continuation token printed by ls.
+type listToken struct {
+	Bucket     string `json:"bucket"`
+	StartAfter string `json:"startAfter"`
+}
+
+// encodeListToken returns the continuation token resuming a listing of
+// bucket after the object or prefix key.
+func encodeListToken(bucket, key string, isDir bool) string {
+	if isDir {
+		// A prefix is listed again when resuming right after it, so
+		// resume after the last possible key below it.
+		key += string(utf8.MaxRune)
+	}
+	tokenBytes, _ := json.Marshal(listToken{Bucket: bucket, StartAfter: key})
+	return base64.RawURLEncoding.EncodeToString(tokenBytes)
+}
+
+// decodeListToken returns the key after which the listing of the bucket
+// and prefix of targetURL is resumed by token.
+func decodeListToken(token string, targetURL ClientURL) (string, *probe.Error) {
+	tokenBytes, e := base64.RawURLEncoding.DecodeString(token)
+	if e != nil {
+		return "", probe.NewError(e).Trace(token)
+	}
+	var t listToken
+	if e = json.Unmarshal(tokenBytes, &t); e != nil {
+		return "", probe.NewError(e).Trace(token)
+	}
+	bucket, prefix := url2BucketAndObject(&targetURL)
+	if t.Bucket != bucket || !strings.HasPrefix(t.StartAfter, prefix) {
+		return "", errInvalidArgument().Trace(token, targetURL.String())
+	}
+	return t.StartAfter, nil
+}
+
+// listTokenMessage container for the continuation token ending a page.
+type listTokenMessage struct {
+	Status            string `json:"status"`
+	URL               string `json:"url"`
+	ContinuationToken string `json:"continuationToken,omitempty"`
+	IsTruncated       bool   `json:"isTruncated"`
+}
+
+func (l listTokenMessage) String() string {
+	if !l.IsTruncated {
+		return console.Colorize("Summarize", "End of listing.")
+	}
+	return console.Colorize("Summarize", "Continuation token: "+l.ContinuationToken)
+}
+
+func (l listTokenMessage) JSON() string {
+	l.Status = "success"
+	msgBytes, e := json.MarshalIndent(l, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// listCursor records the last printed entry of a paged listing, it is
+// read when the listing is interrupted.
+type listCursor struct {
+	sync.Mutex
+	url   string
+	token string
+}
+
+// set moves the cursor after the printed entries of a same path.
+func (c *listCursor) set(contents []*ClientContent) {
+	if len(contents) == 0 {
+		return
+	}
+	bucket, key := url2BucketAndObject(&contents[0].URL)
+	token := encodeListToken(bucket, key, contents[0].Type.IsDir())
+	c.Lock()
+	c.token = token
+	c.Unlock()
+}
+
+// message returns the message ending the page at the cursor.
+func (c *listCursor) message(isTruncated bool) listTokenMessage {
+	c.Lock()
+	defer c.Unlock()
+	msg := listTokenMessage{URL: c.url, IsTruncated: isTruncated}
+	if isTruncated {
+		msg.ContinuationToken = c.token
+	}
+	return msg
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"reflect"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// lsFormatEscapes expands the escapes commonly typed in a quoted --format.
+var lsFormatEscapes = strings.NewReplacer(`\\`, `\`, `\t`, "\t", `\n`, "\n")
+
+var lsFormatBadField = regexp.MustCompile(`can't evaluate field (\w+)`)
+
+// lsFormatFields returns the fields of a listing record usable in --format.
+func lsFormatFields() []string {
+	var fields []string
+	typ := reflect.TypeOf(contentMessage{})
+	for i := 0; i < typ.NumField(); i++ {
+		if f := typ.Field(i); f.PkgPath == "" && f.Name != "Status" {
+			fields = append(fields, f.Name)
+		}
+	}
+	return fields
+}
+
+// parseListFormat compiles the --format template once, and checks it
+// against an empty listing record so that unknown fields are reported
+// before listing starts.
+func parseListFormat(format string) (*template.Template, *probe.Error) {
+	tmpl, e := template.New("format").Parse(lsFormatEscapes.Replace(format))
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	if e = tmpl.Execute(ioutil.Discard, contentMessage{}); e != nil {
+		if m := lsFormatBadField.FindStringSubmatch(e.Error()); m != nil {
+			return nil, probe.NewError(errors.New("unknown field `" + m[1] + "`, available fields are " +
+				strings.Join(lsFormatFields(), ", ")))
+		}
+	}
+	return tmpl, nil
+}
+
+// formatContentMessage renders a listing record with the --format template.
+func formatContentMessage(tmpl *template.Template, c contentMessage) string {
+	var buf bytes.Buffer
+	e := tmpl.Execute(&buf, c)
+	fatalIf(probe.NewError(e).Trace(c.Key), "Unable to apply --format.")
+	return buf.String()
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/fatih/color"
@@ -40,6 +41,14 @@ var (
 			Name:  "versions",
 			Usage: "list all versions",
 		},
+		cli.BoolTFlag{
+			Name:  "include-delete-markers",
+			Usage: "list delete markers with --versions, use --include-delete-markers=false to hide them",
+		},
+		cli.BoolFlag{
+			Name:  "latest-only",
+			Usage: "list only the current version of objects, along with its version fields with --versions",
+		},
 		cli.BoolFlag{
 			Name:  "recursive, r",
 			Usage: "list recursively",
@@ -52,6 +61,10 @@ var (
 			Name:  "summarize",
 			Usage: "display summary information (number of objects, total size)",
 		},
+		cli.StringFlag{
+			Name:  "summarize-by-date",
+			Usage: "display the number and size of the objects per 'day', 'week', 'month' or 'year' of their last modification instead of the objects",
+		},
 		cli.StringFlag{
 			Name:  "storage-class, sc",
 			Usage: "filter to specified storage class",
@@ -60,6 +73,56 @@ var (
 			Name:  "zip",
 			Usage: "list files inside zip archive (MinIO servers only)",
 		},
+		cli.StringFlag{
+			Name:  "sort",
+			Usage: "sort objects by 'size' or 'time', largest or newest first",
+		},
+		cli.IntFlag{
+			Name:  "limit",
+			Usage: "list only the first N sorted objects, requires --sort",
+		},
+		cli.BoolFlag{
+			Name:  "reverse",
+			Usage: "reverse the order of --sort",
+		},
+		cli.StringSliceFlag{
+			Name:  "metadata-filter, meta",
+			Usage: "list only objects whose metadata matches KEY=VALUE, VALUE may be a wildcard pattern (stats every object)",
+		},
+		cli.StringSliceFlag{
+			Name:  "metadata",
+			Usage: "show the value of metadata KEY next to each object, '*' shows all user metadata (stats every object)",
+		},
+		cli.IntFlag{
+			Name:  "metadata-max",
+			Value: 1000,
+			Usage: "abort --metadata once more than N objects are listed, 0 for no limit",
+		},
+		cli.IntFlag{
+			Name:  "workers",
+			Value: 8,
+			Usage: "number of objects to stat concurrently with --metadata-filter, --metadata or --full-checksum",
+		},
+		cli.BoolFlag{
+			Name:  "checksum",
+			Usage: "show the ETag of each object",
+		},
+		cli.BoolFlag{
+			Name:  "full-checksum",
+			Usage: "show the ETag and the additional checksums (CRC32, CRC32C, SHA1, SHA256) of each object (stats every object)",
+		},
+		cli.IntFlag{
+			Name:  "page-size",
+			Usage: "list at most N objects, then print the token resuming the listing",
+		},
+		cli.StringFlag{
+			Name:  "format",
+			Usage: "print each object with a Go template, e.g. '{{.Key}}\\t{{.Size}}\\t{{.ETag}}'",
+		},
+		cli.StringFlag{
+			Name:  "continuation-token",
+			Usage: "resume a listing after the objects listed before printing this token",
+		},
 	}
 )
 
@@ -80,6 +143,9 @@ USAGE:
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+ENVIRONMENT VARIABLES:
+  MC_LS_COLORS:  list of colon delimited extension=color values overriding the file colors, including the "lsColors" of the config
+
 EXAMPLES:
   1. List buckets on Amazon S3 cloud storage.
      {{.Prompt}} {{.HelpName}} s3
@@ -112,6 +178,40 @@ EXAMPLES:
   
   10. List all objects on mybucket, for the GLACIER storage class
      {{.Prompt}} {{.HelpName}} --storage-class 'GLACIER' s3/mybucket 
+
+  11. List the 10 largest objects on mybucket.
+     {{.Prompt}} {{.HelpName}} --recursive --sort size --limit 10 s3/mybucket
+
+  12. List the 5 oldest objects on mybucket.
+     {{.Prompt}} {{.HelpName}} --recursive --sort time --reverse --limit 5 s3/mybucket
+
+  13. List all objects on mybucket classified as secret, whose owner starts with 'finance-'.
+     {{.Prompt}} {{.HelpName}} --recursive --meta "x-amz-meta-classification=secret" --meta "x-amz-meta-owner=finance-*" s3/mybucket
+
+  14. List all objects on mybucket, showing videos in cyan and logs in yellow.
+     {{.Prompt}} MC_LS_COLORS="mp4=cyan:log=yellow" {{.HelpName}} s3/mybucket
+
+  15. List all objects on mybucket along with their owner and Content-Type.
+     {{.Prompt}} {{.HelpName}} --recursive --metadata owner --metadata content-type s3/mybucket
+
+  16. List all versions of the objects on mybucket as JSON, without the delete markers.
+     {{.Prompt}} {{.HelpName}} --json --recursive --versions --include-delete-markers=false s3/mybucket
+
+  17. List the version IDs of the current versions of the objects on mybucket.
+     {{.Prompt}} {{.HelpName}} --recursive --versions --latest-only s3/mybucket
+
+  18. List the objects of a very large bucket 10000 at a time, resuming each page with the token ending the previous one.
+     {{.Prompt}} {{.HelpName}} --json --recursive --page-size 10000 s3/mybucket
+     {{.Prompt}} {{.HelpName}} --json --recursive --page-size 10000 --continuation-token TOKEN s3/mybucket
+
+  19. Build a manifest of the objects on mybucket with their ETag and additional checksums.
+     {{.Prompt}} {{.HelpName}} --json --recursive --full-checksum s3/mybucket
+
+  20. List the key, size and ETag of the objects on mybucket separated by tabs.
+     {{.Prompt}} {{.HelpName}} --recursive --format '{{"{{"}}.Key{{"}}"}}\t{{"{{"}}.Size{{"}}"}}\t{{"{{"}}.ETag{{"}}"}}' s3/mybucket
+
+  21. Show how many objects were added to mybucket each day, along with their size.
+     {{.Prompt}} {{.HelpName}} --recursive --summarize-by-date day s3/mybucket
 `,
 }
 
@@ -173,8 +273,13 @@ func checkListSyntax(ctx context.Context, cliCtx *cli.Context) ([]string, doList
 	withOlderVersions := cliCtx.Bool("versions")
 	isSummary := cliCtx.Bool("summarize")
 	listZip := cliCtx.Bool("zip")
+	withDeleteMarkers := cliCtx.BoolT("include-delete-markers")
+	latestOnly := cliCtx.Bool("latest-only")
 
 	timeRef := parseRewindFlag(cliCtx.String("rewind"))
+	if latestOnly && !timeRef.IsZero() {
+		fatalIf(errInvalidArgument().Trace(args...), "--latest-only cannot be used with --rewind.")
+	}
 	if timeRef.IsZero() && withOlderVersions {
 		timeRef = time.Now().UTC()
 	}
@@ -182,6 +287,76 @@ func checkListSyntax(ctx context.Context, cliCtx *cli.Context) ([]string, doList
 	if listZip && (withOlderVersions || !timeRef.IsZero()) {
 		fatalIf(errInvalidArgument().Trace(args...), "Zip file listing can only be performed on the latest version")
 	}
+	sortBy := cliCtx.String("sort")
+	limit := cliCtx.Int("limit")
+	isReverse := cliCtx.Bool("reverse")
+	switch sortBy {
+	case "", "size", "time":
+	default:
+		fatalIf(errInvalidArgument().Trace(sortBy), "Unrecognized --sort value `"+sortBy+"`. Allowed values are [size, time].")
+	}
+	if sortBy == "" && (limit != 0 || isReverse) {
+		fatalIf(errInvalidArgument().Trace(args...), "--limit and --reverse can only be used with --sort.")
+	}
+	if limit < 0 {
+		fatalIf(errInvalidArgument().Trace(args...), "--limit cannot be negative.")
+	}
+	if sortBy != "" && (withOlderVersions || !timeRef.IsZero()) {
+		fatalIf(errInvalidArgument().Trace(args...), "--sort can only be performed on the latest version")
+	}
+
+	metadataFilters, err := parseMetadataFilters(cliCtx.StringSlice("metadata-filter"))
+	fatalIf(err.Trace(args...), "Unable to parse --metadata-filter.")
+	metadataKeys := parseMetadataKeys(cliCtx.StringSlice("metadata"))
+	metadataMax := cliCtx.Int("metadata-max")
+	workers := cliCtx.Int("workers")
+	fullChecksum := cliCtx.Bool("full-checksum")
+	withChecksum := cliCtx.Bool("checksum") || fullChecksum
+	if len(metadataFilters) > 0 || len(metadataKeys) > 0 || fullChecksum {
+		if isIncomplete || withOlderVersions || !timeRef.IsZero() || listZip || sortBy != "" {
+			fatalIf(errInvalidArgument().Trace(args...), "--metadata-filter, --metadata and --full-checksum cannot be used with --incomplete, --versions, --rewind, --zip or --sort.")
+		}
+		if workers < 1 {
+			fatalIf(errInvalidArgument().Trace(args...), "--workers should be at least 1.")
+		}
+	}
+	if metadataMax < 0 {
+		fatalIf(errInvalidArgument().Trace(args...), "--metadata-max cannot be negative.")
+	}
+
+	pageSize := cliCtx.Int("page-size")
+	continuationToken := cliCtx.String("continuation-token")
+	if pageSize < 0 {
+		fatalIf(errInvalidArgument().Trace(args...), "--page-size cannot be negative.")
+	}
+	if pageSize > 0 || continuationToken != "" {
+		if len(args) != 1 {
+			fatalIf(errInvalidArgument().Trace(args...), "--page-size and --continuation-token can only be used with a single target.")
+		}
+		if isIncomplete || withOlderVersions || !timeRef.IsZero() || listZip || sortBy != "" || len(metadataFilters) > 0 || len(metadataKeys) > 0 || fullChecksum {
+			fatalIf(errInvalidArgument().Trace(args...), "--page-size and --continuation-token cannot be used with --incomplete, --versions, --rewind, --zip, --sort, --metadata-filter, --metadata or --full-checksum.")
+		}
+	}
+
+	var format *template.Template
+	if cliCtx.IsSet("format") {
+		if globalJSON {
+			fatalIf(errInvalidArgument().Trace(args...), "--format cannot be used with --json.")
+		}
+		format, err = parseListFormat(cliCtx.String("format"))
+		fatalIf(err.Trace(cliCtx.String("format")), "Invalid --format template.")
+	}
+
+	summarizeByDate := cliCtx.String("summarize-by-date")
+	if summarizeByDate != "" {
+		if !isValidDatePeriod(summarizeByDate) {
+			fatalIf(errInvalidArgument().Trace(summarizeByDate), "Unrecognized --summarize-by-date value `"+summarizeByDate+"`. Allowed values are ["+strings.Join(lsDatePeriods, ", ")+"].")
+		}
+		if sortBy != "" || format != nil || pageSize > 0 || continuationToken != "" || len(metadataFilters) > 0 || len(metadataKeys) > 0 || fullChecksum {
+			fatalIf(errInvalidArgument().Trace(args...), "--summarize-by-date cannot be used with --sort, --format, --page-size, --continuation-token, --metadata-filter, --metadata or --full-checksum.")
+		}
+	}
+
 	storageClasss := cliCtx.String("storage-class")
 	opts := doListOptions{
 		timeRef:           timeRef,
@@ -189,8 +364,23 @@ func checkListSyntax(ctx context.Context, cliCtx *cli.Context) ([]string, doList
 		isIncomplete:      isIncomplete,
 		isSummary:         isSummary,
 		withOlderVersions: withOlderVersions,
+		withDeleteMarkers: withDeleteMarkers,
+		latestOnly:        latestOnly,
 		listZip:           listZip,
+		withChecksum:      withChecksum,
+		fullChecksum:      fullChecksum,
 		filter:            storageClasss,
+		sortBy:            sortBy,
+		limit:             limit,
+		isReverse:         isReverse,
+		metadataFilters:   metadataFilters,
+		metadataKeys:      metadataKeys,
+		metadataMax:       metadataMax,
+		workers:           workers,
+		pageSize:          pageSize,
+		continuationToken: continuationToken,
+		format:            format,
+		summarizeByDate:   summarizeByDate,
 	}
 	return args, opts
 }
@@ -207,10 +397,15 @@ func mainList(cliCtx *cli.Context) error {
 	console.SetColor("VersionID", color.New(color.FgHiBlue))
 	console.SetColor("VersionOrd", color.New(color.FgHiMagenta))
 	console.SetColor("Dir", color.New(color.FgCyan, color.Bold))
+	console.SetColor("Metadata", color.New(color.FgYellow))
+	console.SetColor("Checksum", color.New(color.FgHiBlack))
 	console.SetColor("Size", color.New(color.FgYellow))
 	console.SetColor("Time", color.New(color.FgGreen))
 	console.SetColor("Summarize", color.New(color.Bold))
 	console.SetColor("SC", color.New(color.FgBlue))
+	if !globalJSON && !globalNoColor && !globalQuiet {
+		setLsColors()
+	}
 
 	// check 'ls' cliCtx arguments.
 	args, opts := checkListSyntax(ctx, cliCtx)
@@ -228,6 +423,17 @@ func mainList(cliCtx *cli.Context) error {
 				fatalIf(err.Trace(targetURL), "Unable to initialize target `"+targetURL+"`.")
 			}
 		}
+		opts.alias, _, _, _ = expandAlias(targetURL)
+		if opts.pageSize > 0 || opts.continuationToken != "" {
+			clntURL := clnt.GetURL()
+			if bucket, _ := url2BucketAndObject(&clntURL); clntURL.Type != objectStorage || bucket == "" {
+				fatalIf(errInvalidArgument().Trace(targetURL), "--page-size and --continuation-token can only list the objects of a bucket.")
+			}
+		}
+		if opts.continuationToken != "" {
+			opts.startAfter, err = decodeListToken(opts.continuationToken, clnt.GetURL())
+			fatalIf(err, "Invalid --continuation-token for `"+targetURL+"`.")
+		}
 		if e := doList(ctx, clnt, opts); e != nil {
 			cErr = e
 		}
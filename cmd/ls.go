@@ -18,17 +18,22 @@
 package cmd
 
 import (
+	"container/heap"
 	"context"
 	"fmt"
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"text/template"
 	"time"
 
 	"github.com/dustin/go-humanize"
 	json "github.com/minio/colorjson"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/console"
+	"github.com/minio/pkg/wildcard"
 )
 
 // printDate - human friendly formatted date.
@@ -50,11 +55,27 @@ type contentMessage struct {
 	VersionOrd     int    `json:"versionOrdinal,omitempty"`
 	VersionIndex   int    `json:"versionIndex,omitempty"`
 	IsDeleteMarker bool   `json:"isDeleteMarker,omitempty"`
+	IsLatest       bool   `json:"isLatest,omitempty"`
 	StorageClass   string `json:"storageClass,omitempty"`
+
+	// Set only with --metadata.
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Set only with --full-checksum, the additional checksums by algorithm.
+	Checksums map[string]string `json:"checksums,omitempty"`
+
+	// withETag prints the ETag with --checksum.
+	withETag bool
+
+	// format replaces the default rendering with --format.
+	format *template.Template
 }
 
 // String colorized string message.
 func (c contentMessage) String() string {
+	if c.format != nil {
+		return formatContentMessage(c.format, c)
+	}
 	message := console.Colorize("Time", fmt.Sprintf("[%s]", c.Time.Format(printDate)))
 	message += console.Colorize("Size", fmt.Sprintf("%7s", strings.Join(strings.Fields(humanize.IBytes(uint64(c.Size))), "")))
 	fileDesc := ""
@@ -72,12 +93,34 @@ func (c contentMessage) String() string {
 		}
 	}
 
+	if c.withETag && c.ETag != "" {
+		message += " " + console.Colorize("Checksum", c.ETag)
+	}
+
 	fileDesc += " " + c.Key
 
 	if c.Filetype == "folder" {
 		message += console.Colorize("Dir", fileDesc)
 	} else {
-		message += console.Colorize("File", fileDesc)
+		message += console.Colorize(lsFileColorTag(c.Key), fileDesc)
+	}
+
+	keys := make([]string, 0, len(c.Metadata))
+	for k := range c.Metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		message += " " + console.Colorize("Metadata", k+"="+c.Metadata[k])
+	}
+
+	algorithms := make([]string, 0, len(c.Checksums))
+	for algorithm := range c.Checksums {
+		algorithms = append(algorithms, algorithm)
+	}
+	sort.Strings(algorithms)
+	for _, algorithm := range algorithms {
+		message += " " + console.Colorize("Checksum", algorithm+"="+c.Checksums[algorithm])
 	}
 	return message
 }
@@ -144,6 +187,7 @@ func generateContentMessages(clntURL ClientURL, ctnts []*ClientContent, printAll
 		contentMsg.Key = getKey(c)
 		contentMsg.VersionID = c.VersionID
 		contentMsg.IsDeleteMarker = c.IsDeleteMarker
+		contentMsg.IsLatest = c.IsLatest
 		contentMsg.VersionOrd = nrVersions - i
 		// URL is empty by default
 		// Set it to either relative dir (host) or public url (remote)
@@ -192,10 +236,12 @@ func (s summaryMessage) JSON() string {
 }
 
 // Pretty print the list of versions belonging to one object
-func printObjectVersions(clntURL ClientURL, ctntVersions []*ClientContent, printAllVersions, isSummary bool) {
+func printObjectVersions(clntURL ClientURL, ctntVersions []*ClientContent, printAllVersions, isSummary, withETag bool, format *template.Template) {
 	sortObjectVersions(ctntVersions)
 	msgs := generateContentMessages(clntURL, ctntVersions, printAllVersions)
 	for _, msg := range msgs {
+		msg.withETag = withETag
+		msg.format = format
 		printMsg(msg)
 	}
 }
@@ -206,37 +252,162 @@ type doListOptions struct {
 	isIncomplete      bool
 	isSummary         bool
 	withOlderVersions bool
+	withDeleteMarkers bool
+	latestOnly        bool
 	listZip           bool
+	withChecksum      bool
+	fullChecksum      bool
 	filter            string
+	sortBy            string
+	limit             int
+	isReverse         bool
+	metadataFilters   []metadataFilter
+	metadataKeys      []string
+	metadataMax       int
+	workers           int
+	alias             string
+	pageSize          int
+	continuationToken string
+	startAfter        string
+	format            *template.Template
+	summarizeByDate   string
+}
+
+// contentMessages container for a sorted list of content messages.
+type contentMessages []contentMessage
+
+// String colorized sorted content messages.
+func (c contentMessages) String() string {
+	lines := make([]string, 0, len(c))
+	for _, msg := range c {
+		lines = append(lines, msg.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// JSON jsonified sorted content messages, as a single array.
+func (c contentMessages) JSON() string {
+	for i := range c {
+		c[i].Status = "success"
+	}
+	jsonMessageBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// listRankFunc returns whether a ranks before b for the given sort
+// criteria, largest or newest first unless reversed.
+func listRankFunc(sortBy string, reverse bool) func(a, b *ClientContent) bool {
+	before := func(a, b *ClientContent) bool {
+		return a.Size > b.Size
+	}
+	if sortBy == "time" {
+		before = func(a, b *ClientContent) bool {
+			return a.Time.After(b.Time)
+		}
+	}
+	if reverse {
+		return func(a, b *ClientContent) bool {
+			return before(b, a)
+		}
+	}
+	return before
+}
+
+// listRankHeap keeps the lowest ranked content at its root, so that
+// the top N contents can be kept while streaming a listing.
+type listRankHeap struct {
+	contents []*ClientContent
+	before   func(a, b *ClientContent) bool
+}
+
+func (h listRankHeap) Len() int           { return len(h.contents) }
+func (h listRankHeap) Less(i, j int) bool { return h.before(h.contents[j], h.contents[i]) }
+func (h listRankHeap) Swap(i, j int)      { h.contents[i], h.contents[j] = h.contents[j], h.contents[i] }
+
+func (h *listRankHeap) Push(x interface{}) {
+	h.contents = append(h.contents, x.(*ClientContent))
+}
+
+func (h *listRankHeap) Pop() interface{} {
+	n := len(h.contents)
+	content := h.contents[n-1]
+	h.contents = h.contents[:n-1]
+	return content
+}
+
+// add ranks content, keeping at most limit contents when limit is positive.
+func (h *listRankHeap) add(content *ClientContent, limit int) {
+	if limit > 0 && h.Len() == limit {
+		if h.before(content, h.contents[0]) {
+			h.contents[0] = content
+			heap.Fix(h, 0)
+		}
+		return
+	}
+	heap.Push(h, content)
+}
+
+// sorted returns the ranked contents, best ranked first.
+func (h *listRankHeap) sorted() []*ClientContent {
+	sort.SliceStable(h.contents, func(i, j int) bool {
+		return h.before(h.contents[i], h.contents[j])
+	})
+	return h.contents
 }
 
 // doList - list all entities inside a folder.
 func doList(ctx context.Context, clnt Client, o doListOptions) error {
+	if o.summarizeByDate != "" {
+		return doListByDate(ctx, clnt, o)
+	}
+	if o.sortBy != "" {
+		return doListSorted(ctx, clnt, o)
+	}
+	if len(o.metadataFilters) > 0 || len(o.metadataKeys) > 0 || o.fullChecksum {
+		return doListMetadata(ctx, clnt, o)
+	}
+
 	var (
 		lastPath          string
 		perObjectVersions []*ClientContent
 		cErr              error
 		totalSize         int64
 		totalObjects      int64
+		isTruncated       bool
 	)
 
+	// A paged listing ends with the token resuming it, which is also
+	// printed when the listing is interrupted.
+	isPaged := o.pageSize > 0 || o.continuationToken != ""
+	cursor := &listCursor{url: clnt.GetURL().String(), token: o.continuationToken}
+	if isPaged {
+		setInterruptHook(func() { printMsg(cursor.message(true)) })
+		defer setInterruptHook(nil)
+	}
+
+	ctx, cancelList := context.WithCancel(ctx)
+	defer cancelList()
+
 	for content := range clnt.List(ctx, ListOptions{
 		Recursive:         o.isRecursive,
 		Incomplete:        o.isIncomplete,
 		TimeRef:           o.timeRef,
 		WithOlderVersions: o.withOlderVersions || !o.timeRef.IsZero(),
-		WithDeleteMarkers: true,
+		WithDeleteMarkers: o.withDeleteMarkers,
 		ShowDir:           DirNone,
 		ListZip:           o.listZip,
+		StartAfter:        o.startAfter,
 	}) {
 		if content.Err != nil {
 			switch content.Err.ToGoError().(type) {
 			// handle this specifically for filesystem related errors.
 			case BrokenSymlink:
-				errorIf(content.Err.Trace(clnt.GetURL().String()), "Unable to list broken link.")
+				warningIf(content.Err.Trace(clnt.GetURL().String()), "Unable to list broken link.")
 				continue
 			case TooManyLevelsSymlink:
-				errorIf(content.Err.Trace(clnt.GetURL().String()), "Unable to list too many levels link.")
+				warningIf(content.Err.Trace(clnt.GetURL().String()), "Unable to list too many levels link.")
 				continue
 			case PathNotFound:
 				errorIf(content.Err.Trace(clnt.GetURL().String()), "Unable to list folder.")
@@ -254,9 +425,20 @@ func doList(ctx context.Context, clnt Client, o doListOptions) error {
 			continue
 		}
 
+		// Versioned listings flag the current version of each object.
+		if o.latestOnly && o.withOlderVersions && !content.IsLatest {
+			continue
+		}
+
+		if o.pageSize > 0 && totalObjects == int64(o.pageSize) {
+			isTruncated = true
+			break
+		}
+
 		if lastPath != content.URL.Path {
 			// Print any object in the current list before reinitializing it
-			printObjectVersions(clnt.GetURL(), perObjectVersions, o.withOlderVersions, o.isSummary)
+			printObjectVersions(clnt.GetURL(), perObjectVersions, o.withOlderVersions, o.isSummary, o.withChecksum, o.format)
+			cursor.set(perObjectVersions)
 			lastPath = content.URL.Path
 			perObjectVersions = []*ClientContent{}
 		}
@@ -266,7 +448,8 @@ func doList(ctx context.Context, clnt Client, o doListOptions) error {
 		totalObjects++
 	}
 
-	printObjectVersions(clnt.GetURL(), perObjectVersions, o.withOlderVersions, o.isSummary)
+	printObjectVersions(clnt.GetURL(), perObjectVersions, o.withOlderVersions, o.isSummary, o.withChecksum, o.format)
+	cursor.set(perObjectVersions)
 
 	if o.isSummary {
 		printMsg(summaryMessage{
@@ -275,5 +458,279 @@ func doList(ctx context.Context, clnt Client, o doListOptions) error {
 		})
 	}
 
+	if isPaged {
+		printMsg(cursor.message(isTruncated))
+	}
+
+	return cErr
+}
+
+// doListSorted - list objects sorted by size or time, only keeping
+// the top --limit objects in memory while listing.
+func doListSorted(ctx context.Context, clnt Client, o doListOptions) error {
+	var (
+		cErr         error
+		totalSize    int64
+		totalObjects int64
+	)
+
+	ranked := &listRankHeap{before: listRankFunc(o.sortBy, o.isReverse)}
+	for content := range clnt.List(ctx, ListOptions{
+		Recursive:  o.isRecursive,
+		Incomplete: o.isIncomplete,
+		ShowDir:    DirNone,
+		ListZip:    o.listZip,
+	}) {
+		if content.Err != nil {
+			errorIf(content.Err.Trace(clnt.GetURL().String()), "Unable to list folder.")
+			cErr = exitStatus(globalErrorExitStatus) // Set the exit status.
+			continue
+		}
+
+		if content.StorageClass != "" && o.filter != "" && o.filter != "*" && content.StorageClass != o.filter {
+			continue
+		}
+
+		// Prefixes have neither a size nor a modification time.
+		if content.Type.IsDir() {
+			continue
+		}
+
+		ranked.add(content, o.limit)
+		totalSize += content.Size
+		totalObjects++
+	}
+
+	msgs := contentMessages{}
+	for _, content := range ranked.sorted() {
+		msgs = append(msgs, generateContentMessages(clnt.GetURL(), []*ClientContent{content}, false)...)
+	}
+	for i := range msgs {
+		msgs[i].withETag = o.withChecksum
+		msgs[i].format = o.format
+	}
+	if len(msgs) > 0 || globalJSON {
+		printMsg(msgs)
+	}
+
+	if o.isSummary {
+		printMsg(summaryMessage{
+			TotalObjects: totalObjects,
+			TotalSize:    totalSize,
+		})
+	}
+
+	return cErr
+}
+
+// metadataFilter matches objects having a metadata key whose value
+// matches a wildcard pattern.
+type metadataFilter struct {
+	key     string
+	pattern string
+}
+
+// parseMetadataFilters parses KEY=VALUE metadata filters.
+func parseMetadataFilters(filters []string) ([]metadataFilter, *probe.Error) {
+	var metadataFilters []metadataFilter
+	for _, filter := range filters {
+		kv := strings.SplitN(filter, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, errInvalidArgument().Trace(filter)
+		}
+		metadataFilters = append(metadataFilters, metadataFilter{key: strings.TrimSpace(kv[0]), pattern: kv[1]})
+	}
+	return metadataFilters, nil
+}
+
+// match reports whether the metadata of content matches the filter, user
+// metadata keys may be given with or without their X-Amz-Meta- prefix.
+func (f metadataFilter) match(content *ClientContent) bool {
+	for k, v := range content.Metadata {
+		if strings.EqualFold(k, f.key) && wildcard.Match(f.pattern, v) {
+			return true
+		}
+	}
+	for k, v := range content.UserMetadata {
+		if (strings.EqualFold(k, f.key) || strings.EqualFold("X-Amz-Meta-"+k, f.key)) && wildcard.Match(f.pattern, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseMetadataKeys splits comma separated metadata keys.
+func parseMetadataKeys(values []string) (keys []string) {
+	for _, value := range values {
+		for _, key := range strings.Split(value, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// selectMetadata returns the metadata of content named by keys, user
+// metadata keys may be given with or without their X-Amz-Meta- prefix
+// and '*' selects all of them.
+func selectMetadata(content *ClientContent, keys []string) map[string]string {
+	selected := make(map[string]string)
+	for _, key := range keys {
+		for k, v := range content.Metadata {
+			if strings.EqualFold(k, key) {
+				selected[k] = v
+			}
+		}
+		for k, v := range content.UserMetadata {
+			if key == "*" || strings.EqualFold(k, key) || strings.EqualFold("X-Amz-Meta-"+k, key) {
+				selected[k] = v
+			}
+		}
+	}
+	return selected
+}
+
+// selectChecksums returns the additional checksums of content by
+// algorithm, from its X-Amz-Checksum-* headers.
+func selectChecksums(content *ClientContent) map[string]string {
+	const prefix = "X-Amz-Checksum-"
+	checksums := make(map[string]string)
+	for k, v := range content.Metadata {
+		if len(k) > len(prefix) && strings.EqualFold(k[:len(prefix)], prefix) && !strings.EqualFold(k, prefix+"Mode") {
+			checksums[strings.ToUpper(k[len(prefix):])] = v
+		}
+	}
+	return checksums
+}
+
+// statListedObject stats a listed object, asking for its additional
+// checksums with --full-checksum.
+func statListedObject(ctx context.Context, objectURL string, withChecksums bool) (*ClientContent, *probe.Error) {
+	clnt, err := newClient(objectURL)
+	if err != nil {
+		return nil, err.Trace(objectURL)
+	}
+	st, err := clnt.Stat(ctx, StatOptions{checksum: withChecksums})
+	if err != nil {
+		return nil, err.Trace(objectURL)
+	}
+	return st, nil
+}
+
+// listedContent is a listed object along with its selected metadata.
+type listedContent struct {
+	content   *ClientContent
+	metadata  map[string]string
+	checksums map[string]string
+}
+
+// doListMetadata - list objects whose metadata match all the filters,
+// along with their selected metadata keys or additional checksums,
+// which requires a stat of every listed object.
+func doListMetadata(ctx context.Context, clnt Client, o doListOptions) error {
+	var (
+		cErr         error
+		totalSize    int64
+		totalObjects int64
+		statFailures int64
+	)
+
+	contentCh := make(chan *ClientContent)
+	matchCh := make(chan listedContent)
+
+	var wg sync.WaitGroup
+	for i := 0; i < o.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for content := range contentCh {
+				objectURL := filepath.ToSlash(filepath.Join(o.alias, content.URL.Path))
+				st, err := statListedObject(ctx, objectURL, o.fullChecksum)
+				if err != nil {
+					errorIf(err.Trace(objectURL), "Unable to stat `"+objectURL+"`.")
+					atomic.AddInt64(&statFailures, 1)
+					continue
+				}
+				matched := true
+				for _, filter := range o.metadataFilters {
+					if !filter.match(st) {
+						matched = false
+						break
+					}
+				}
+				if matched {
+					listed := listedContent{content: content}
+					if len(o.metadataKeys) > 0 {
+						listed.metadata = selectMetadata(st, o.metadataKeys)
+					}
+					if o.fullChecksum {
+						listed.checksums = selectChecksums(st)
+					}
+					matchCh <- listed
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(matchCh)
+		var listed int
+		for content := range clnt.List(ctx, ListOptions{
+			Recursive: o.isRecursive,
+			ShowDir:   DirNone,
+		}) {
+			if content.Err != nil {
+				errorIf(content.Err.Trace(clnt.GetURL().String()), "Unable to list folder.")
+				cErr = exitStatus(globalErrorExitStatus) // Set the exit status.
+				continue
+			}
+			if content.StorageClass != "" && o.filter != "" && o.filter != "*" && content.StorageClass != o.filter {
+				continue
+			}
+			if content.Type.IsDir() {
+				continue
+			}
+			if listed++; len(o.metadataKeys) > 0 && o.metadataMax > 0 && listed > o.metadataMax {
+				errorIf(errDummy().Trace(clnt.GetURL().String()),
+					fmt.Sprintf("Stopped listing after %d objects, pass a higher --metadata-max to stat more objects.", o.metadataMax))
+				cErr = exitStatus(globalErrorExitStatus)
+				break
+			}
+			contentCh <- content
+		}
+		close(contentCh)
+		wg.Wait()
+	}()
+
+	for listed := range matchCh {
+		msgs := generateContentMessages(clnt.GetURL(), []*ClientContent{listed.content}, false)
+		for _, msg := range msgs {
+			msg.Metadata = listed.metadata
+			msg.Checksums = listed.checksums
+			msg.withETag = o.withChecksum
+			msg.format = o.format
+			printMsg(msg)
+		}
+		totalSize += listed.content.Size
+		totalObjects++
+	}
+
+	// The number of matching objects is always of interest when filtering.
+	if o.isSummary || len(o.metadataFilters) > 0 {
+		printMsg(summaryMessage{
+			TotalObjects: totalObjects,
+			TotalSize:    totalSize,
+		})
+	}
+
+	// Objects which could not be checked may have been left out.
+	if statFailures > 0 {
+		cErr = exitStatus(globalErrorExitStatus)
+	}
+	// Objects which could not be checked may have been left out.
+	if statFailures > 0 {
+		cErr = exitStatus(globalErrorExitStatus)
+	}
 	return cErr
 }
@@ -16,3 +16,305 @@
 // along with this program.  If not, see <http://www.gnu.org/licenses/>.
 
 package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+func TestParseLsColors(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected map[string]color.Attribute
+		success  bool
+	}{
+		{"", map[string]color.Attribute{}, true},
+		{"mp4=cyan:LOG=Yellow", map[string]color.Attribute{"mp4": color.FgCyan, "log": color.FgYellow}, true},
+		{"*.tar=red:", map[string]color.Attribute{"tar": color.FgRed}, true},
+		{"mp4", nil, false},
+		{"mp4=purple", nil, false},
+		{"=red", nil, false},
+	}
+	for i, testCase := range testCases {
+		overrides, err := parseLsColors(testCase.input)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %v, got error %v", i+1, testCase.success, err)
+		}
+		if !testCase.success {
+			continue
+		}
+		if len(overrides) != len(testCase.expected) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, overrides)
+		}
+		for ext, attr := range testCase.expected {
+			if overrides[ext] != attr {
+				t.Fatalf("Test %d: expected %v for %s, got %v", i+1, attr, ext, overrides[ext])
+			}
+		}
+	}
+}
+
+func TestListRankHeap(t *testing.T) {
+	now := time.Now()
+	var contents []*ClientContent
+	for i, size := range []int64{4, 9, 1, 7, 3, 8} {
+		contents = append(contents, &ClientContent{Size: size, Time: now.Add(time.Duration(i) * time.Minute)})
+	}
+
+	testCases := []struct {
+		sortBy   string
+		reverse  bool
+		limit    int
+		expected []int64
+	}{
+		{"size", false, 3, []int64{9, 8, 7}},
+		{"size", true, 2, []int64{1, 3}},
+		{"size", false, 0, []int64{9, 8, 7, 4, 3, 1}},
+		{"time", false, 2, []int64{8, 3}},
+		{"time", true, 1, []int64{4}},
+		{"size", false, 10, []int64{9, 8, 7, 4, 3, 1}},
+	}
+	for i, testCase := range testCases {
+		ranked := &listRankHeap{before: listRankFunc(testCase.sortBy, testCase.reverse)}
+		for _, content := range contents {
+			ranked.add(content, testCase.limit)
+		}
+		sorted := ranked.sorted()
+		if len(sorted) != len(testCase.expected) {
+			t.Fatalf("Test %d: expected %d contents, got %d", i+1, len(testCase.expected), len(sorted))
+		}
+		for j, content := range sorted {
+			if content.Size != testCase.expected[j] {
+				t.Fatalf("Test %d: expected size %d at %d, got %d", i+1, testCase.expected[j], j, content.Size)
+			}
+		}
+	}
+}
+
+func TestMetadataFilterMatch(t *testing.T) {
+	content := &ClientContent{
+		Metadata:     map[string]string{"Content-Type": "text/plain"},
+		UserMetadata: map[string]string{"Classification": "secret", "Owner": "finance-team"},
+	}
+	testCases := []struct {
+		filter   string
+		expected bool
+	}{
+		{"x-amz-meta-classification=secret", true},
+		{"classification=secret", true},
+		{"X-Amz-Meta-Owner=finance-*", true},
+		{"x-amz-meta-owner=hr-*", false},
+		{"content-type=text/*", true},
+		{"x-amz-meta-missing=*", false},
+	}
+	for i, testCase := range testCases {
+		filters, err := parseMetadataFilters([]string{testCase.filter})
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %s", i+1, err)
+		}
+		if got := filters[0].match(content); got != testCase.expected {
+			t.Fatalf("Test %d: expected %t, got %t", i+1, testCase.expected, got)
+		}
+	}
+	if _, err := parseMetadataFilters([]string{"noequals"}); err == nil {
+		t.Fatal("expected an error for a filter without a value")
+	}
+}
+
+func TestSelectMetadata(t *testing.T) {
+	content := &ClientContent{
+		Metadata:     map[string]string{"Content-Type": "text/plain"},
+		UserMetadata: map[string]string{"Classification": "secret", "Owner": "finance-team"},
+	}
+	testCases := []struct {
+		keys     []string
+		expected map[string]string
+	}{
+		{[]string{"owner"}, map[string]string{"Owner": "finance-team"}},
+		{[]string{"x-amz-meta-owner, content-type"}, map[string]string{"Owner": "finance-team", "Content-Type": "text/plain"}},
+		{[]string{"*"}, map[string]string{"Owner": "finance-team", "Classification": "secret"}},
+		{[]string{"missing"}, map[string]string{}},
+	}
+	for i, testCase := range testCases {
+		got := selectMetadata(content, parseMetadataKeys(testCase.keys))
+		if !reflect.DeepEqual(got, testCase.expected) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestSelectChecksums(t *testing.T) {
+	content := &ClientContent{
+		Metadata: map[string]string{
+			"Content-Type":           "text/plain",
+			"X-Amz-Checksum-Crc32":   "i9aeUg==",
+			"X-Amz-Checksum-Sha256":  "n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg=",
+			"X-Amz-Checksum-Mode":    "ENABLED",
+			"X-Amz-Meta-Checksum-Id": "42",
+		},
+	}
+	expected := map[string]string{
+		"CRC32":  "i9aeUg==",
+		"SHA256": "n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg=",
+	}
+	if got := selectChecksums(content); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestGenerateContentMessagesVersions(t *testing.T) {
+	now := time.Now()
+	clntURL := newClientURL("s3/bucket/")
+	versions := []*ClientContent{
+		{URL: *newClientURL("s3/bucket/obj"), VersionID: "v1", Time: now.Add(-time.Hour), Size: 4},
+		{URL: *newClientURL("s3/bucket/obj"), VersionID: "v3", Time: now, IsLatest: true, IsDeleteMarker: true},
+		{URL: *newClientURL("s3/bucket/obj"), VersionID: "v2", Time: now.Add(-time.Minute), Size: 2},
+	}
+	sortObjectVersions(versions)
+	msgs := generateContentMessages(*clntURL, versions, true)
+	if len(msgs) != 3 {
+		t.Fatalf("expected 3 versions, got %d", len(msgs))
+	}
+	expected := []struct {
+		versionID      string
+		isLatest       bool
+		isDeleteMarker bool
+	}{
+		{"v3", true, true},
+		{"v2", false, false},
+		{"v1", false, false},
+	}
+	for i, e := range expected {
+		if msgs[i].VersionID != e.versionID || msgs[i].IsLatest != e.isLatest || msgs[i].IsDeleteMarker != e.isDeleteMarker {
+			t.Fatalf("unexpected version %d: %+v", i, msgs[i])
+		}
+	}
+}
+
+func TestListContinuationToken(t *testing.T) {
+	targetURL := *newClientURL("https://play.min.io/mybucket/photos/")
+
+	token := encodeListToken("mybucket", "photos/2021/a.jpg", false)
+	startAfter, err := decodeListToken(token, targetURL)
+	if err != nil || startAfter != "photos/2021/a.jpg" {
+		t.Fatalf("expected `photos/2021/a.jpg`, got `%s` (%v)", startAfter, err)
+	}
+
+	// Resuming after a prefix skips every object below it.
+	token = encodeListToken("mybucket", "photos/2021/", true)
+	if startAfter, err = decodeListToken(token, targetURL); err != nil || startAfter <= "photos/2021/zzz" || startAfter >= "photos/2022" {
+		t.Fatalf("unexpected start after `%s` (%v)", startAfter, err)
+	}
+
+	for _, token := range []string{
+		"not a token",
+		encodeListToken("otherbucket", "photos/2021/a.jpg", false),
+		encodeListToken("mybucket", "videos/a.mp4", false),
+	} {
+		if _, err = decodeListToken(token, targetURL); err == nil {
+			t.Errorf("expected token `%s` to be rejected", token)
+		}
+	}
+
+	cursor := &listCursor{url: targetURL.String(), token: "start"}
+	if msg := cursor.message(true); msg.ContinuationToken != "start" {
+		t.Fatalf("expected the initial token, got `%s`", msg.ContinuationToken)
+	}
+	cursor.set([]*ClientContent{{URL: *newClientURL("https://play.min.io/mybucket/photos/b.jpg")}})
+	if msg := cursor.message(true); msg.ContinuationToken != encodeListToken("mybucket", "photos/b.jpg", false) {
+		t.Fatalf("unexpected token `%s`", msg.ContinuationToken)
+	}
+	if msg := cursor.message(false); msg.ContinuationToken != "" || msg.IsTruncated {
+		t.Fatalf("expected no token at the end of the listing, got %+v", msg)
+	}
+}
+
+func TestParseListFormat(t *testing.T) {
+	tmpl, err := parseListFormat(`{{.Key}}\t{{.Size}}\t{{.ETag}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := contentMessage{Key: "photos/a.jpg", Size: 1024, ETag: "d41d8cd98f00b204e9800998ecf8427e", format: tmpl}
+	if got, want := msg.String(), "photos/a.jpg\t1024\td41d8cd98f00b204e9800998ecf8427e"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	if _, err = parseListFormat(`{{.Key`); err == nil {
+		t.Fatal("expected a malformed template to be rejected")
+	}
+	_, err = parseListFormat(`{{.Key}} {{.Sise}}`)
+	if err == nil || !strings.Contains(err.ToGoError().Error(), "`Sise`") {
+		t.Fatalf("expected the unknown field to be named, got %v", err)
+	}
+	if _, err = parseListFormat(`{{index .Metadata "owner"}}`); err != nil {
+		t.Fatalf("expected metadata lookups to be accepted, got %v", err)
+	}
+}
+
+func TestDateHistogram(t *testing.T) {
+	// 2022-03-02 is a Wednesday of ISO week 9.
+	day := func(d, h int) time.Time { return time.Date(2022, time.March, d, h, 30, 0, 0, time.UTC) }
+	testCases := []struct {
+		period   string
+		expected dateHistogramMessage
+	}{
+		{"day", dateHistogramMessage{
+			{"2022-02-27", 1, 1},
+			{"2022-03-02", 2, 6},
+			{"2022-03-07", 1, 8},
+		}},
+		{"week", dateHistogramMessage{
+			{"2022-W08", 1, 1},
+			{"2022-W09", 2, 6},
+			{"2022-W10", 1, 8},
+		}},
+		{"month", dateHistogramMessage{
+			{"2022-02", 1, 1},
+			{"2022-03", 3, 14},
+		}},
+		{"year", dateHistogramMessage{
+			{"2022", 4, 15},
+		}},
+	}
+	for _, testCase := range testCases {
+		if !isValidDatePeriod(testCase.period) {
+			t.Fatalf("%s: expecting a valid period", testCase.period)
+		}
+		h := newDateHistogram(testCase.period)
+		h.add(day(7, 0), 8)
+		h.add(day(2, 23), 4)
+		h.add(time.Date(2022, time.February, 27, 12, 0, 0, 0, time.UTC), 1)
+		h.add(day(2, 1), 2)
+		if got := h.message(); !reflect.DeepEqual(got, testCase.expected) {
+			t.Errorf("%s: expecting %v, got %v", testCase.period, testCase.expected, got)
+		}
+	}
+	if isValidDatePeriod("hour") {
+		t.Errorf("expecting hour to be an invalid period")
+	}
+	if got := (dateHistogramMessage(nil)).JSON(); got != "[]" {
+		t.Errorf("expecting an empty JSON array, got %s", got)
+	}
+}
+
+func TestLsColorOverrides(t *testing.T) {
+	withTempMcConfig(t)
+
+	config := newConfigV10()
+	config.LsColors = map[string]string{"mp4": "cyan", ".LOG": "blue"}
+	if err := saveMcConfig(config); err != nil {
+		t.Fatal(err)
+	}
+	loadMcConfig = loadMcConfigFactory()
+	t.Setenv(mcEnvLsColors, "log=yellow:tar=green")
+
+	// MC_LS_COLORS takes precedence over the config.
+	expected := map[string]color.Attribute{"mp4": color.FgCyan, "log": color.FgYellow, "tar": color.FgGreen}
+	if overrides := lsColorOverrides(); !reflect.DeepEqual(overrides, expected) {
+		t.Fatalf("expected %v, got %v", expected, overrides)
+	}
+}
@@ -34,6 +34,7 @@ import (
 	"time"
 
 	"github.com/cheggaaa/pb"
+	"github.com/fatih/color"
 	"github.com/inconshreveable/mousetrap"
 	"github.com/minio/cli"
 	"github.com/minio/mc/pkg/probe"
@@ -115,6 +116,9 @@ func Main(args []string) {
 	probe.SetAppInfo("Release-Tag", ReleaseTag)
 	probe.SetAppInfo("Commit", ShortCommitID)
 
+	// Warnings may be printed by any command.
+	console.SetColor("Warning", color.New(color.FgYellow))
+
 	// Fetch terminal size, if not available, automatically
 	// set globalQuiet to true.
 	if w, e := pb.GetTerminalWidth(); e != nil {
@@ -468,7 +472,10 @@ var appCmds = []cli.Command{
 	policyCmd,
 	tagCmd,
 	diffCmd,
+	verifyCmd,
 	replicateCmd,
+	pingCmd,
+	odCmd,
 	adminCmd,
 	configCmd,
 	updateCmd,
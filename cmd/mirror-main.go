@@ -22,11 +22,13 @@ import (
 	"fmt"
 	"math/rand"
 	"net/http"
+	"os"
 	"path"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/fatih/color"
@@ -60,8 +62,8 @@ var (
 			Hidden: true, // deprecated 2022
 		},
 		cli.BoolFlag{
-			Name:  "dry-run",
-			Usage: "perform a fake mirror operation",
+			Name:  "dry-run, diff",
+			Usage: "print what would be copied, updated and deleted without transferring anything",
 		},
 		cli.BoolFlag{
 			Name:  "watch, w",
@@ -69,7 +71,16 @@ var (
 		},
 		cli.BoolFlag{
 			Name:  "remove",
-			Usage: "remove extraneous object(s) on target",
+			Usage: "remove extraneous object(s) on target, and object(s) removed from source while watching",
+		},
+		cli.BoolFlag{
+			Name:  "keep-deleted",
+			Usage: "never remove object(s) from target, not even those removed from source while watching",
+		},
+		cli.BoolFlag{
+			Name:   "ignore-delete",
+			Usage:  "never remove object(s) from target",
+			Hidden: true, // alias of --keep-deleted
 		},
 		cli.StringFlag{
 			Name:  "region",
@@ -109,6 +120,31 @@ var (
 			Name:  "newer-than",
 			Usage: "filter object(s) newer than value in duration string (e.g. 7d10h31s)",
 		},
+		cli.DurationFlag{
+			Name:  "skew-tolerance",
+			Usage: "consider source object(s) newer only if their modtime exceeds the target by more than this duration (e.g. 2s)",
+		},
+		cli.StringFlag{
+			Name:  "etag-strategy",
+			Value: etagStrict,
+			Usage: "compare the ETags of object(s) of the same size, valid options are '[strict, lenient, ignore]'",
+		},
+		cli.BoolFlag{
+			Name:  "show-rate",
+			Usage: "report the transfer rate of each object and the aggregate throughput",
+		},
+		cli.BoolFlag{
+			Name:  "summary",
+			Usage: "report the number of object(s) copied, updated, deleted, skipped and failed once mirroring ends",
+		},
+		cli.BoolFlag{
+			Name:  "continue-on-error",
+			Usage: "keep mirroring the remaining object(s) after a failure and exit successfully",
+		},
+		cli.BoolFlag{
+			Name:  "progress-json",
+			Usage: "write the progress of the transfer as a JSON line per second to stderr",
+		},
 		cli.StringFlag{
 			Name:  "storage-class, sc",
 			Usage: "specify storage class for new object(s) on target",
@@ -135,7 +171,7 @@ var mirrorCmd = cli.Command{
 	Action:       mainMirror,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(mirrorFlags, ioFlags...), globalFlags...),
+	Flags:        append(append(append(mirrorFlags, opsRateFlag), ioFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -149,6 +185,18 @@ ENVIRONMENT VARIABLES:
    MC_ENCRYPT:      list of comma delimited prefixes
    MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
 
+ETAG STRATEGIES:
+   strict:          default, object(s) of the same size whose ETags differ are copied again
+   lenient:         same as strict, but only when both ETags are plain MD5 sums, ETags of multipart uploads,
+                    encrypted objects and of some S3 compatible providers are not comparable
+   ignore:          ETags are not compared, object(s) of the same size are copied again only when newer on source
+   ETags are never compared by --watch or --active-active, which copy the newer object(s).
+
+DELETIONS:
+   default:         object(s) found only on target are kept, object(s) removed from source while watching are removed from target
+   --remove:        object(s) found only on target are removed, and so are object(s) removed from source while watching
+   --keep-deleted:  nothing is ever removed from target, object(s) removed from source while watching are kept as well
+
 EXAMPLES:
   01. Mirror a bucket recursively from MinIO cloud storage to a bucket on Amazon S3 cloud storage.
       {{.Prompt}} {{.HelpName}} play/photos/2014 s3/backup-photos
@@ -202,6 +250,30 @@ EXAMPLES:
   16. Cross mirror between sites in a active-active deployment.
       Site-A: {{.Prompt}} {{.HelpName}} --active-active siteA siteB
       Site-B: {{.Prompt}} {{.HelpName}} --active-active siteB siteA
+
+  17. Continuously mirror between two sites with slightly unsynchronized clocks, ignoring modtime differences up to 2 seconds.
+      {{.Prompt}} {{.HelpName}} --watch --skew-tolerance 2s site1-alias/photos site2-alias/photos
+
+  18. Mirror a local folder and report the transfer rate of each object along with the slowest and fastest objects.
+      {{.Prompt}} {{.HelpName}} --show-rate --quiet backup/ play/archive
+
+  19. Mirror a local folder, reporting the progress to a wrapping program as JSON lines on stderr.
+      {{.Prompt}} {{.HelpName}} --json --progress-json backup/ play/archive 2> progress.json
+
+  20. Continuously mirror a local folder to MinIO cloud storage, keeping the objects of the files removed locally.
+      {{.Prompt}} {{.HelpName}} --watch --keep-deleted /var/lib/backups play/backups
+
+  21. Mirror a bucket to an S3 compatible provider computing ETags differently, overwriting the objects which differ in size only.
+      {{.Prompt}} {{.HelpName}} --overwrite --etag-strategy ignore play/photos other/photos
+
+  22. Mirror a local folder from a CI job, reporting the outcome as JSON and failing the job if any object failed.
+      {{.Prompt}} {{.HelpName}} --json --quiet --summary build/ play/artifacts
+
+  23. Preview the objects a mirror with --remove would copy, update and delete on a production bucket.
+      {{.Prompt}} {{.HelpName}} --dry-run --overwrite --remove backup/ prod/archive
+
+  24. Mirror a bucket of many small objects to a rate limited backend, at most 50 objects per second.
+      {{.Prompt}} {{.HelpName}} --ops-rate 50 play/thumbnails s3/thumbnails
 `,
 }
 
@@ -252,6 +324,15 @@ type mirrorJob struct {
 	TotalObjects int64
 	TotalBytes   int64
 
+	// Per object transfer rates, only with --show-rate
+	rates *transferRates
+
+	// Progress events, only with --progress-json
+	progress *progressJSON
+
+	// Outcome of the objects, only with --summary
+	summary *mirrorSummary
+
 	sourceURL string
 	targetURL string
 
@@ -266,11 +347,20 @@ type mirrorMessage struct {
 	Size       int64  `json:"size"`
 	TotalCount int64  `json:"totalCount"`
 	TotalSize  int64  `json:"totalSize"`
+
+	// Set only with --show-rate, once the transfer has completed.
+	Elapsed float64 `json:"elapsed,omitempty"`
+	Rate    float64 `json:"rate,omitempty"`
 }
 
 // String colorized mirror message
 func (m mirrorMessage) String() string {
-	return console.Colorize("Mirror", fmt.Sprintf("`%s` -> `%s`", m.Source, m.Target))
+	msg := fmt.Sprintf("`%s` -> `%s`", m.Source, m.Target)
+	if m.Elapsed > 0 {
+		msg += fmt.Sprintf(" (%s in %s)", humanizedRate(m.Rate),
+			time.Duration(m.Elapsed*float64(time.Second)).Round(time.Microsecond))
+	}
+	return console.Colorize("Mirror", msg)
 }
 
 // JSON jsonified mirror message
@@ -332,6 +422,7 @@ func (mj *mirrorJob) doRemove(ctx context.Context, sURLs URLs) URLs {
 	if mj.opts.isFake {
 		return sURLs.WithError(nil)
 	}
+	mj.opts.ops.wait(ctx)
 
 	// Construct proper path with alias.
 	targetWithAlias := filepath.Join(sURLs.TargetAlias, sURLs.TargetContent.URL.Path)
@@ -384,6 +475,7 @@ func (mj *mirrorJob) doMirrorWatch(ctx context.Context, targetPath string, tgtSS
 		// adjust total, because we want to show progress of
 		// the item still queued to be copied.
 		mj.status.Add(sURLs.SourceContent.Size)
+		mj.progress.AddTotal(sURLs.SourceContent.Size)
 		mj.status.SetTotal(mj.status.Get()).Update()
 		mj.status.AddCounts(1)
 		sURLs.TotalSize = mj.status.Get()
@@ -423,8 +515,10 @@ func (mj *mirrorJob) doMirror(ctx context.Context, sURLs URLs) URLs {
 			mj.status.Add(sURLs.SourceContent.Size)
 		}
 		mj.status.Update()
+		mj.status.PrintMsg(newMirrorPlanMessage(sURLs))
 		return sURLs.WithError(nil)
 	}
+	mj.opts.ops.wait(ctx)
 
 	sourceAlias := sURLs.SourceAlias
 	sourceURL := sURLs.SourceContent.URL
@@ -433,6 +527,7 @@ func (mj *mirrorJob) doMirror(ctx context.Context, sURLs URLs) URLs {
 	length := sURLs.SourceContent.Size
 
 	mj.status.SetCaption(sourceURL.String() + ": ")
+	mj.progress.SetObject(filepath.ToSlash(filepath.Join(sourceAlias, sourceURL.Path)))
 
 	// Initialize target metadata.
 	sURLs.TargetContent.Metadata = make(map[string]string)
@@ -457,21 +552,30 @@ func (mj *mirrorJob) doMirror(ctx context.Context, sURLs URLs) URLs {
 
 	sourcePath := filepath.ToSlash(filepath.Join(sourceAlias, sourceURL.Path))
 	targetPath := filepath.ToSlash(filepath.Join(targetAlias, targetURL.Path))
-	mj.status.PrintMsg(mirrorMessage{
+	msg := mirrorMessage{
 		Source:     sourcePath,
 		Target:     targetPath,
 		Size:       length,
 		TotalCount: sURLs.TotalCount,
 		TotalSize:  sURLs.TotalSize,
-	})
+	}
+	if mj.rates == nil {
+		mj.status.PrintMsg(msg)
+	}
 	sURLs.MD5 = mj.opts.md5
 	sURLs.DisableMultipart = mj.opts.disableMultipart
 
 	now := time.Now()
 	ret := uploadSourceToTargetURL(ctx, sURLs, mj.status, mj.opts.encKeyDB, mj.opts.isMetadata, false)
 	if ret.Error == nil {
-		durationMs := time.Since(now) / time.Millisecond
-		mirrorReplicationDurations.With(prometheus.Labels{"object_size": convertSizeToTag(sURLs.SourceContent.Size)}).Observe(float64(durationMs))
+		elapsed := time.Since(now)
+		mirrorReplicationDurations.With(prometheus.Labels{"object_size": convertSizeToTag(sURLs.SourceContent.Size)}).Observe(float64(elapsed / time.Millisecond))
+		if mj.rates != nil {
+			// With --show-rate the mirror message is only printed once the rate is known.
+			rate := mj.rates.record(sourcePath, length, elapsed)
+			msg.Elapsed, msg.Rate = rate.Elapsed, rate.Rate
+			mj.status.PrintMsg(msg)
+		}
 	}
 	return ret
 }
@@ -481,6 +585,7 @@ func (mj *mirrorJob) monitorMirrorStatus(cancel context.CancelFunc) (errDuringMi
 	// now we want to start the progress bar
 	mj.status.Start()
 	defer mj.status.Finish()
+	defer mj.progress.Finish()
 
 	var cancelInProgress bool
 
@@ -495,6 +600,10 @@ func (mj *mirrorJob) monitorMirrorStatus(cancel context.CancelFunc) (errDuringMi
 		// Update prometheus fields
 		mirrorTotalOps.Inc()
 
+		if mj.summary != nil {
+			mj.summary.record(sURLs)
+		}
+
 		if sURLs.Error != nil {
 			mirrorFailedOps.Inc()
 			switch {
@@ -519,8 +628,8 @@ func (mj *mirrorJob) monitorMirrorStatus(cancel context.CancelFunc) (errDuringMi
 				errDuringMirror = true
 			}
 
-			// Do not quit mirroring if we are in --watch or --active-active mode
-			if !mj.opts.activeActive && !mj.opts.isWatch {
+			// Do not quit mirroring if we are in --watch, --active-active or --continue-on-error mode
+			if !mj.opts.activeActive && !mj.opts.isWatch && !mj.opts.continueOnError {
 				cancel()
 				cancelInProgress = true
 			}
@@ -530,6 +639,8 @@ func (mj *mirrorJob) monitorMirrorStatus(cancel context.CancelFunc) (errDuringMi
 
 		if sURLs.SourceContent != nil {
 			mirrorTotalUploadedBytes.Add(float64(sURLs.SourceContent.Size))
+		} else if sURLs.TargetContent != nil && mj.opts.isFake {
+			mj.status.PrintMsg(newMirrorPlanMessage(sURLs))
 		} else if sURLs.TargetContent != nil {
 			// Construct user facing message and path.
 			targetPath := filepath.ToSlash(filepath.Join(sURLs.TargetAlias, sURLs.TargetContent.URL.Path))
@@ -626,7 +737,7 @@ func (mj *mirrorJob) watchMirrorEvents(ctx context.Context, events []EventInfo)
 			}
 			mirrorURL.TotalCount = mj.status.GetCounts()
 			mirrorURL.TotalSize = mj.status.Get()
-			if mirrorURL.TargetContent != nil && (mj.opts.isRemove || mj.opts.activeActive) {
+			if mirrorURL.TargetContent != nil && mj.opts.propagatesDeletes() {
 				mj.parallel.queueTask(func() URLs {
 					return mj.doRemove(ctx, mirrorURL)
 				}, 0)
@@ -706,16 +817,18 @@ func (mj *mirrorJob) startMirror(ctx context.Context) {
 			}
 
 			if sURLs.SourceContent != nil {
-				if isOlder(sURLs.SourceContent.Time, mj.opts.olderThan) {
-					continue
-				}
-				if isNewer(sURLs.SourceContent.Time, mj.opts.newerThan) {
+				if isOlder(sURLs.SourceContent.Time, mj.opts.olderThan) ||
+					isNewer(sURLs.SourceContent.Time, mj.opts.newerThan) {
+					if mj.summary != nil {
+						mj.summary.skip()
+					}
 					continue
 				}
 			}
 
 			if sURLs.SourceContent != nil {
 				mj.status.Add(sURLs.SourceContent.Size)
+				mj.progress.AddTotal(sURLs.SourceContent.Size)
 			}
 
 			mj.status.SetTotal(mj.status.Get()).Update()
@@ -730,7 +843,7 @@ func (mj *mirrorJob) startMirror(ctx context.Context) {
 				mj.parallel.queueTask(func() URLs {
 					return mj.doMirror(ctx, sURLs)
 				}, sURLs.SourceContent.Size)
-			} else if sURLs.TargetContent != nil && mj.opts.isRemove {
+			} else if sURLs.TargetContent != nil && mj.opts.removesExtraneous() {
 				mj.parallel.queueTask(func() URLs {
 					return mj.doRemove(ctx, sURLs)
 				}, 0)
@@ -772,7 +885,14 @@ func (mj *mirrorJob) mirror(ctx context.Context) bool {
 		close(mj.statusCh)
 	}()
 
-	return mj.monitorMirrorStatus(cancel)
+	errDuringMirror := mj.monitorMirrorStatus(cancel)
+	if mj.rates != nil {
+		printMsg(mj.rates.summary())
+	}
+	if mj.summary != nil {
+		printMsg(mj.summary.summary())
+	}
+	return errDuringMirror
 }
 
 func newMirrorJob(srcURL, dstURL string, opts mirrorOptions) *mirrorJob {
@@ -788,9 +908,18 @@ func newMirrorJob(srcURL, dstURL string, opts mirrorOptions) *mirrorJob {
 
 	mj.parallel = newParallelManager(mj.statusCh)
 
+	if opts.showRate {
+		mj.rates = newTransferRates()
+	}
+
+	if opts.summary || opts.isFake {
+		mj.summary = newMirrorSummary()
+		mj.summary.dryRun = opts.isFake
+	}
+
 	// we'll define the status to use here,
 	// do we want the quiet status? or the progressbar
-	if globalQuiet {
+	if globalQuiet || opts.progressJSON {
 		mj.status = NewQuietStatus(mj.parallel)
 	} else if globalJSON {
 		mj.status = NewQuietStatus(mj.parallel)
@@ -798,6 +927,12 @@ func newMirrorJob(srcURL, dstURL string, opts mirrorOptions) *mirrorJob {
 		mj.status = NewProgressStatus(mj.parallel)
 	}
 
+	if opts.progressJSON {
+		mj.progress = newProgressJSON(os.Stderr, func() int64 {
+			return atomic.LoadInt64(&mj.parallel.sentBytes)
+		})
+	}
+
 	return &mj
 }
 
@@ -874,17 +1009,20 @@ func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dst
 		isOverwrite = cli.Bool("overwrite")
 	}
 
-	isWatch := cli.Bool("watch") || cli.Bool("multi-master") || cli.Bool("active-active")
+	isFake := cli.Bool("fake") || cli.Bool("dry-run")
+	// A dry run prints the plan of the current differences and exits.
+	isWatch := !isFake && (cli.Bool("watch") || cli.Bool("multi-master") || cli.Bool("active-active"))
 	isRemove := cli.Bool("remove")
+	keepDeleted := cli.Bool("keep-deleted") || cli.Bool("ignore-delete")
 
 	// preserve is also expected to be overwritten if necessary
 	isMetadata := cli.Bool("a") || isWatch || len(userMetadata) > 0
 	isOverwrite = isOverwrite || isMetadata
-	isFake := cli.Bool("fake") || cli.Bool("dry-run")
 
 	mopts := mirrorOptions{
 		isFake:           isFake,
 		isRemove:         isRemove,
+		keepDeleted:      keepDeleted,
 		isOverwrite:      isOverwrite,
 		isWatch:          isWatch,
 		isMetadata:       isMetadata,
@@ -893,10 +1031,17 @@ func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dst
 		excludeOptions:   cli.StringSlice("exclude"),
 		olderThan:        cli.String("older-than"),
 		newerThan:        cli.String("newer-than"),
+		skewTolerance:    cli.Duration("skew-tolerance"),
+		showRate:         cli.Bool("show-rate"),
+		progressJSON:     cli.Bool("progress-json"),
+		summary:          cli.Bool("summary"),
+		continueOnError:  cli.Bool("continue-on-error"),
 		storageClass:     cli.String("storage-class"),
+		etagStrategy:     cli.String("etag-strategy"),
 		userMetadata:     userMetadata,
 		encKeyDB:         encKeyDB,
 		activeActive:     isWatch,
+		ops:              newOpsLimiterFromContext(cli),
 	}
 
 	// Create a new mirror job and execute it
@@ -921,7 +1066,12 @@ func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dst
 
 			if d.Diff == differInSecond {
 				diffBucket := strings.TrimPrefix(d.SecondURL, dstClt.GetURL().String())
-				if isRemove {
+				if isRemove && mj.opts.isFake {
+					mj.status.PrintMsg(mirrorPlanMessage{
+						Action: mirrorPlanRemoveBucket,
+						Target: path.Join(dstURL, diffBucket),
+					})
+				} else if isRemove {
 					aliasedDstBucket := path.Join(dstURL, diffBucket)
 					err := deleteBucket(ctx, aliasedDstBucket, false)
 					mj.status.fatalIf(err, "Failed to start mirroring.")
@@ -952,15 +1102,19 @@ func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dst
 					}
 				}
 
+				if mj.opts.isFake {
+					mj.status.PrintMsg(mirrorPlanMessage{
+						Action: mirrorPlanMakeBucket,
+						Target: newTgtURL,
+					})
+					continue
+				}
+
 				mj.status.PrintMsg(mirrorMessage{
 					Source: newSrcURL,
 					Target: newTgtURL,
 				})
 
-				if mj.opts.isFake {
-					continue
-				}
-
 				// Bucket only exists in the source, create the same bucket in the destination
 				if err := newDstClt.MakeBucket(ctx, cli.String("region"), false, withLock); err != nil {
 					errorIf(err, "Unable to create bucket at `"+newTgtURL+"`.")
@@ -1004,6 +1158,10 @@ func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dst
 func mainMirror(cliCtx *cli.Context) error {
 	// Additional command specific theme customization.
 	console.SetColor("Mirror", color.New(color.FgGreen, color.Bold))
+	console.SetColor("Summarize", color.New(color.Bold))
+	console.SetColor("MirrorPlan"+mirrorPlanCopy, color.New(color.FgGreen))
+	console.SetColor("MirrorPlan"+mirrorPlanUpdate, color.New(color.FgYellow))
+	console.SetColor("MirrorPlan"+mirrorPlanDelete, color.New(color.FgRed))
 
 	ctx, cancelMirror := context.WithCancel(globalContext)
 	defer cancelMirror()
@@ -1014,6 +1172,7 @@ func mainMirror(cliCtx *cli.Context) error {
 
 	// check 'mirror' cli arguments.
 	srcURL, tgtURL := checkMirrorSyntax(ctx, cliCtx, encKeyDB)
+	fatalIfReadOnlyURL("mirror", tgtURL)
 
 	if prometheusAddress := cliCtx.String("monitoring-address"); prometheusAddress != "" {
 		http.Handle("/metrics", promhttp.Handler())
@@ -1036,7 +1195,7 @@ func mainMirror(cliCtx *cli.Context) error {
 				time.Sleep(time.Duration(r.Float64() * float64(2*time.Second)))
 				continue
 			}
-			if errorDetected {
+			if errorDetected && !cliCtx.Bool("continue-on-error") {
 				return exitStatus(globalErrorExitStatus)
 			}
 			return nil
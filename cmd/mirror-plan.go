@@ -0,0 +1,87 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/dustin/go-humanize"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// Actions of a mirror planned by --dry-run.
+const (
+	mirrorPlanCopy         = "copy"
+	mirrorPlanUpdate       = "update"
+	mirrorPlanDelete       = "delete"
+	mirrorPlanMakeBucket   = "make-bucket"
+	mirrorPlanRemoveBucket = "remove-bucket"
+)
+
+// mirrorPlanMessage container for an action a mirror would take,
+// printed by --dry-run in place of taking it.
+type mirrorPlanMessage struct {
+	Status string `json:"status"`
+	Action string `json:"action"`
+	Source string `json:"source,omitempty"`
+	Target string `json:"target"`
+	Size   int64  `json:"size,omitempty"`
+}
+
+// newMirrorPlanMessage returns the planned action of sURLs.
+func newMirrorPlanMessage(sURLs URLs) mirrorPlanMessage {
+	msg := mirrorPlanMessage{
+		Target: filepath.ToSlash(filepath.Join(sURLs.TargetAlias, sURLs.TargetContent.URL.Path)),
+	}
+	switch {
+	case sURLs.SourceContent == nil:
+		msg.Action = mirrorPlanDelete
+	case sURLs.Overwrite:
+		msg.Action = mirrorPlanUpdate
+	default:
+		msg.Action = mirrorPlanCopy
+	}
+	if sURLs.SourceContent != nil {
+		msg.Source = filepath.ToSlash(filepath.Join(sURLs.SourceAlias, sURLs.SourceContent.URL.Path))
+		msg.Size = sURLs.SourceContent.Size
+	}
+	return msg
+}
+
+// String colorized mirror plan message.
+func (m mirrorPlanMessage) String() string {
+	switch m.Action {
+	case mirrorPlanCopy, mirrorPlanUpdate:
+		return console.Colorize("MirrorPlan"+m.Action, fmt.Sprintf("%-13s `%s` -> `%s` (%s)", m.Action, m.Source, m.Target, humanize.IBytes(uint64(m.Size))))
+	case mirrorPlanMakeBucket:
+		return console.Colorize("MirrorPlan"+mirrorPlanCopy, fmt.Sprintf("%-13s `%s`", m.Action, m.Target))
+	default:
+		return console.Colorize("MirrorPlan"+mirrorPlanDelete, fmt.Sprintf("%-13s `%s`", m.Action, m.Target))
+	}
+}
+
+// JSON jsonified mirror plan message.
+func (m mirrorPlanMessage) JSON() string {
+	m.Status = "success"
+	msgBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
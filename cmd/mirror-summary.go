@@ -0,0 +1,118 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// mirrorSummary accumulates the outcome of the objects of a mirror
+// run, safe for concurrent use.
+type mirrorSummary struct {
+	mutex  sync.Mutex
+	start  time.Time
+	dryRun bool
+	msg    mirrorSummaryMessage
+}
+
+func newMirrorSummary() *mirrorSummary {
+	return &mirrorSummary{start: time.Now()}
+}
+
+// record accounts the outcome of the mirroring of sURLs.
+func (s *mirrorSummary) record(sURLs URLs) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	switch {
+	case sURLs.Error != nil && isErrIgnored(sURLs.Error):
+		s.msg.Skipped++
+	case sURLs.Error != nil:
+		s.msg.Failed++
+	case sURLs.SourceContent == nil:
+		s.msg.Deleted++
+	case sURLs.Overwrite:
+		s.msg.Updated++
+		s.msg.TotalSize += sURLs.SourceContent.Size
+	default:
+		s.msg.Copied++
+		s.msg.TotalSize += sURLs.SourceContent.Size
+	}
+}
+
+// skip accounts an object left out by the --older-than and --newer-than filters.
+func (s *mirrorSummary) skip() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.msg.Skipped++
+}
+
+// summary returns the message reporting the outcome of the run so far.
+func (s *mirrorSummary) summary() mirrorSummaryMessage {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	msg := s.msg
+	msg.DryRun = s.dryRun
+	msg.Elapsed = time.Since(s.start).Seconds()
+	return msg
+}
+
+// mirrorSummaryMessage container for the outcome of a mirror run.
+type mirrorSummaryMessage struct {
+	Status    string  `json:"status"`
+	Copied    int64   `json:"copied"`
+	Updated   int64   `json:"updated"`
+	Deleted   int64   `json:"deleted"`
+	Skipped   int64   `json:"skipped"`
+	Failed    int64   `json:"failed"`
+	TotalSize int64   `json:"totalSize"`
+	Elapsed   float64 `json:"elapsed"`
+	// Set with --dry-run, the counts are of the planned actions.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// String colorized mirror summary.
+func (m mirrorSummaryMessage) String() string {
+	elapsed := time.Duration(m.Elapsed * float64(time.Second)).Round(time.Millisecond)
+	if m.DryRun {
+		return console.Colorize("Summarize", fmt.Sprintf("Dry run, would mirror %s: %d to copy, %d to update, %d to delete, %d skipped, %d failed.",
+			humanize.IBytes(uint64(m.TotalSize)), m.Copied, m.Updated, m.Deleted, m.Skipped, m.Failed))
+	}
+	return console.Colorize("Summarize", fmt.Sprintf("Mirrored %s in %s: %d copied, %d updated, %d deleted, %d skipped, %d failed.",
+		humanize.IBytes(uint64(m.TotalSize)), elapsed, m.Copied, m.Updated, m.Deleted, m.Skipped, m.Failed))
+}
+
+// JSON jsonified mirror summary.
+func (m mirrorSummaryMessage) JSON() string {
+	m.Status = "success"
+	if m.Failed > 0 {
+		m.Status = "error"
+	}
+	msgBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
@@ -51,6 +51,14 @@ func checkMirrorSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[st
 		errorIf(errInvalidArgument().Trace(URLs...), "`--force` is deprecated, please use `--overwrite` instead for the same functionality.")
 	}
 
+	if strategy := cliCtx.String("etag-strategy"); !isValidETagStrategy(strategy) {
+		fatalIf(errInvalidArgument().Trace(strategy), "Unrecognized --etag-strategy value `"+strategy+"`. Allowed values are [strict, lenient, ignore].")
+	}
+
+	if cliCtx.Bool("remove") && (cliCtx.Bool("keep-deleted") || cliCtx.Bool("ignore-delete")) {
+		fatalIf(errInvalidArgument().Trace(URLs...), "`--remove` and `--keep-deleted` cannot be used together, the former removes object(s) from target while the latter never does.")
+	}
+
 	_, expandedSourcePath, _ := mustExpandAlias(srcURL)
 	srcClient := newClientURL(expandedSourcePath)
 	_, expandedTargetPath, _ := mustExpandAlias(tgtURL)
@@ -127,8 +135,15 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 		return
 	}
 
+	// Active-active mirrors copy the newer side only, comparing ETags
+	// would copy objects back and forth between both sides.
+	etagStrategy := opts.etagStrategy
+	if opts.activeActive {
+		etagStrategy = etagIgnore
+	}
+
 	// List both source and target, compare and return values through channel.
-	for diffMsg := range objectDifference(ctx, sourceClnt, targetClnt, opts.isMetadata) {
+	for diffMsg := range objectDifference(ctx, sourceClnt, targetClnt, opts.isMetadata, opts.skewTolerance, etagStrategy) {
 		if diffMsg.Error != nil {
 			// Send all errors through the channel
 			URLsCh <- URLs{Error: diffMsg.Error, ErrorCond: differInUnknown}
@@ -152,7 +167,7 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 			// No difference, continue.
 		case differInType:
 			URLsCh <- URLs{Error: errInvalidTarget(diffMsg.SecondURL)}
-		case differInSize, differInMetadata, differInAASourceMTime:
+		case differInSize, differInETag, differInMetadata, differInAASourceMTime:
 			if !opts.isOverwrite && !opts.isFake && !opts.activeActive {
 				// Size or time or etag differs but --overwrite not set.
 				URLsCh <- URLs{
@@ -172,6 +187,7 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 				SourceContent: sourceContent,
 				TargetAlias:   targetAlias,
 				TargetContent: targetContent,
+				Overwrite:     true,
 			}
 		case differInFirst:
 			// Only in first, always copy.
@@ -186,7 +202,7 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 				TargetContent: targetContent,
 			}
 		case differInSecond:
-			if !opts.isRemove && !opts.isFake {
+			if !opts.removesExtraneous() && !opts.isFake {
 				continue
 			}
 			URLsCh <- URLs{
@@ -207,10 +223,28 @@ type mirrorOptions struct {
 	isWatch, isRemove, isMetadata     bool
 	excludeOptions                    []string
 	encKeyDB                          map[string][]prefixSSEPair
-	md5, disableMultipart             bool
+	md5, disableMultipart, showRate   bool
+	progressJSON, keepDeleted         bool
+	summary, continueOnError          bool
 	olderThan, newerThan              string
+	skewTolerance                     time.Duration
 	storageClass                      string
+	etagStrategy                      string
 	userMetadata                      map[string]string
+	ops                               *opsLimiter
+}
+
+// removesExtraneous tells if the objects found on the target only are
+// removed, which --remove asks for.
+func (o mirrorOptions) removesExtraneous() bool {
+	return o.isRemove && !o.keepDeleted
+}
+
+// propagatesDeletes tells if the objects removed from the source while
+// watching are removed from the target too. This is the case unless
+// --keep-deleted is set.
+func (o mirrorOptions) propagatesDeletes() bool {
+	return !o.keepDeleted && (o.isRemove || o.activeActive)
 }
 
 // Prepares urls that need to be copied or removed based on requested options.
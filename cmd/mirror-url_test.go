@@ -0,0 +1,96 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestMirrorDeletePropagation(t *testing.T) {
+	testCases := []struct {
+		name              string
+		opts              mirrorOptions
+		removesExtraneous bool
+		propagatesDeletes bool
+	}{
+		{"default", mirrorOptions{}, false, false},
+		{"default watch", mirrorOptions{isWatch: true, activeActive: true}, false, true},
+		{"remove", mirrorOptions{isRemove: true}, true, true},
+		{"remove watch", mirrorOptions{isRemove: true, isWatch: true, activeActive: true}, true, true},
+		{"remove dry-run", mirrorOptions{isRemove: true, isFake: true}, true, true},
+		{"keep-deleted", mirrorOptions{keepDeleted: true}, false, false},
+		{"keep-deleted watch", mirrorOptions{keepDeleted: true, isWatch: true, activeActive: true}, false, false},
+		{"keep-deleted dry-run", mirrorOptions{keepDeleted: true, isFake: true}, false, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if got := testCase.opts.removesExtraneous(); got != testCase.removesExtraneous {
+				t.Errorf("removesExtraneous: expected %v, got %v", testCase.removesExtraneous, got)
+			}
+			if got := testCase.opts.propagatesDeletes(); got != testCase.propagatesDeletes {
+				t.Errorf("propagatesDeletes: expected %v, got %v", testCase.propagatesDeletes, got)
+			}
+		})
+	}
+}
+
+func TestMirrorSummary(t *testing.T) {
+	s := newMirrorSummary()
+	s.record(URLs{SourceContent: &ClientContent{Size: 10}, TargetContent: &ClientContent{}})
+	s.record(URLs{SourceContent: &ClientContent{Size: 5}, TargetContent: &ClientContent{}, Overwrite: true})
+	s.record(URLs{TargetContent: &ClientContent{}})
+	s.record(URLs{SourceContent: &ClientContent{Size: 7}, Error: probe.NewError(PathNotFound{})})
+	s.record(URLs{SourceContent: &ClientContent{Size: 7}, Error: errDummy()})
+	s.skip()
+
+	msg := s.summary()
+	msg.Elapsed = 0
+	expected := mirrorSummaryMessage{Copied: 1, Updated: 1, Deleted: 1, Skipped: 2, Failed: 1, TotalSize: 15}
+	if msg != expected {
+		t.Fatalf("expected %+v, got %+v", expected, msg)
+	}
+}
+
+func TestNewMirrorPlanMessage(t *testing.T) {
+	testCases := []struct {
+		sURLs    URLs
+		expected mirrorPlanMessage
+	}{
+		{
+			URLs{SourceAlias: "src", SourceContent: &ClientContent{URL: *newClientURL("/bucket/a"), Size: 10}, TargetAlias: "dst", TargetContent: &ClientContent{URL: *newClientURL("/bucket/a")}},
+			mirrorPlanMessage{Action: mirrorPlanCopy, Source: "src/bucket/a", Target: "dst/bucket/a", Size: 10},
+		},
+		{
+			URLs{SourceAlias: "src", SourceContent: &ClientContent{URL: *newClientURL("/bucket/b"), Size: 5}, TargetAlias: "dst", TargetContent: &ClientContent{URL: *newClientURL("/bucket/b")}, Overwrite: true},
+			mirrorPlanMessage{Action: mirrorPlanUpdate, Source: "src/bucket/b", Target: "dst/bucket/b", Size: 5},
+		},
+		{
+			URLs{TargetAlias: "dst", TargetContent: &ClientContent{URL: *newClientURL("/bucket/c")}},
+			mirrorPlanMessage{Action: mirrorPlanDelete, Target: "dst/bucket/c"},
+		},
+	}
+
+	for i, testCase := range testCases {
+		if msg := newMirrorPlanMessage(testCase.sURLs); msg != testCase.expected {
+			t.Errorf("Test %d: expected %+v, got %+v", i+1, testCase.expected, msg)
+		}
+	}
+}
@@ -21,10 +21,15 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/console"
 )
@@ -68,6 +73,14 @@ var (
 			Name:  "disable-multipart",
 			Usage: "disable multipart upload feature",
 		},
+		cli.BoolFlag{
+			Name:  "server-side",
+			Usage: "copy objects server side between aliases of the same endpoint too, use --server-side=false to always stream them",
+		},
+		cli.BoolFlag{
+			Name:  "verify-before-delete",
+			Usage: "remove source objects only once their target is verified to exist with the same size and ETag",
+		},
 	}
 )
 
@@ -141,9 +154,44 @@ EXAMPLES:
 
   16. Move a text file to an object storage and disable multipart upload feature.
       {{.Prompt}} {{.HelpName}} --disable-multipart myobject.txt play/mybucket
+
+  17. Move a folder recursively, removing each source object only after its copy is verified on the target.
+      {{.Prompt}} {{.HelpName}} --recursive --verify-before-delete play/mybucket/burningman2011/ s3/mybucket/
+
+  18. Rename a folder, streaming the objects through the client instead of copying them server side.
+      {{.Prompt}} {{.HelpName}} --recursive --server-side=false play/mybucket/2021/ play/mybucket/archive/2021/
+
+  19. Move a folder between two aliases of the same endpoint, copying the objects server side.
+      {{.Prompt}} {{.HelpName}} --recursive --server-side play/mybucket/2021/ play-admin/archive/2021/
 `,
 }
 
+// Structured message reporting an object copied to its target but
+// not removed from its source, to be reconciled by the user.
+type mvNotRemovedMessage struct {
+	Status string `json:"status"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Colorized message for console printing.
+func (m mvNotRemovedMessage) String() string {
+	msg := fmt.Sprintf("Copied but not removed: `%s` -> `%s`", m.Source, m.Target)
+	if m.Reason != "" {
+		msg += " (" + m.Reason + ")"
+	}
+	return console.Colorize("MoveNotRemoved", msg)
+}
+
+// JSON'ified message for scripting.
+func (m mvNotRemovedMessage) JSON() string {
+	m.Status = "error"
+	msgBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
 type removeClientInfo struct {
 	client    Client
 	contentCh chan *ClientContent
@@ -154,35 +202,116 @@ type removeManager struct {
 	removeMap      map[string]*removeClientInfo
 	removeMapMutex sync.RWMutex
 	wg             sync.WaitGroup
+
+	// verify the target of a moved object before removing its source.
+	verify bool
+
+	// Moved objects whose source removal is not confirmed yet, keyed
+	// by source alias and object name, and the ones not removed.
+	movedMutex sync.Mutex
+	pending    map[string][]mvNotRemovedMessage
+	notRemoved []mvNotRemovedMessage
 }
 
-func (rm *removeManager) readErrors(resultCh <-chan RemoveResult, targetURL string) {
+func movedObjectKey(alias, objectName string) string {
+	return alias + "/" + objectName
+}
+
+func (rm *removeManager) readErrors(resultCh <-chan RemoveResult, sourceAlias, targetURL string) {
 	rm.wg.Add(1)
 	go func() {
 		defer rm.wg.Done()
 		for result := range resultCh {
 			if result.Err != nil {
 				errorIf(result.Err.Trace(targetURL), "Failed to remove in`"+targetURL+"`.")
+				continue
 			}
+			rm.removed(movedObjectKey(sourceAlias, result.ObjectName))
 		}
 	}()
 }
 
+// removed marks the source of a moved object as removed.
+func (rm *removeManager) removed(key string) {
+	rm.movedMutex.Lock()
+	defer rm.movedMutex.Unlock()
+
+	if moved := rm.pending[key]; len(moved) > 1 {
+		rm.pending[key] = moved[1:]
+	} else {
+		delete(rm.pending, key)
+	}
+}
+
+// keep records a moved object whose source is kept.
+func (rm *removeManager) keep(source, target, reason string) {
+	rm.movedMutex.Lock()
+	defer rm.movedMutex.Unlock()
+
+	rm.notRemoved = append(rm.notRemoved, mvNotRemovedMessage{Source: source, Target: target, Reason: reason})
+}
+
+// verifyTarget verifies the target of a moved object exists with the
+// source size and, when both are md5sums of the content, the source ETag.
+// ETags of multipart uploads and of encrypted objects are not compared.
+func (rm *removeManager) verifyTarget(ctx context.Context, urls URLs, target string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+	_, content, err := url2Stat(ctx, target, "", false, encKeyDB, time.Time{}, false)
+	if err != nil {
+		return err.Trace(target)
+	}
+	if content.Size != urls.SourceContent.Size {
+		return probe.NewError(fmt.Errorf("target size %d differs from source size %d", content.Size, urls.SourceContent.Size)).Trace(target)
+	}
+
+	sourceETag := strings.ToLower(strings.Trim(urls.SourceContent.ETag, "\""))
+	if sourceETag == "" {
+		// Local sources have no ETag, use the md5sum computed while copying.
+		sourceETag = urls.ContentMD5Sum
+	}
+	targetETag := strings.ToLower(strings.Trim(content.ETag, "\""))
+	if !md5ETag.MatchString(sourceETag) || !md5ETag.MatchString(targetETag) || sourceETag == targetETag {
+		return nil
+	}
+	if getSSE(target, encKeyDB[urls.TargetAlias]) != nil || encryptionType(content.Metadata) != "" {
+		return nil
+	}
+	if urls.SourceContent.URL.Type == objectStorage {
+		sourcePath := filepath.ToSlash(filepath.Join(urls.SourceAlias, urls.SourceContent.URL.Path))
+		if getSSE(sourcePath, encKeyDB[urls.SourceAlias]) != nil {
+			return nil
+		}
+		_, st, err := url2Stat(ctx, sourcePath, urls.SourceContent.VersionID, false, encKeyDB, time.Time{}, false)
+		if err == nil && encryptionType(st.Metadata) != "" {
+			return nil
+		}
+	}
+	return probe.NewError(fmt.Errorf("target ETag `%s` differs from source ETag `%s`", targetETag, sourceETag)).Trace(target)
+}
+
 // This function should be parallel-safe because it is executed by ParallelManager
 // If targetAlias is empty, it means we will target local FS contents
-func (rm *removeManager) add(ctx context.Context, targetAlias, targetURL string) {
+func (rm *removeManager) add(ctx context.Context, targetAlias, targetURL, source, target string) {
+	url := newClientURL(targetURL)
+	_, objectName := url2BucketAndObject(url)
+	key := movedObjectKey(targetAlias, objectName)
+
+	rm.movedMutex.Lock()
+	rm.pending[key] = append(rm.pending[key], mvNotRemovedMessage{Source: source, Target: target})
+	rm.movedMutex.Unlock()
+
 	rm.removeMapMutex.Lock()
 	clientInfo := rm.removeMap[targetAlias]
 	if clientInfo == nil {
 		client, pErr := newClientFromAlias(targetAlias, targetURL)
 		if pErr != nil {
+			rm.removeMapMutex.Unlock()
 			errorIf(pErr.Trace(targetURL), "Invalid argument `"+targetURL+"`.")
 			return
 		}
 
 		contentCh := make(chan *ClientContent, 10000)
 		resultCh := client.Remove(ctx, false, false, false, false, contentCh)
-		rm.readErrors(resultCh, targetURL)
+		rm.readErrors(resultCh, targetAlias, targetURL)
 
 		clientInfo = &removeClientInfo{
 			client:    client,
@@ -194,20 +323,45 @@ func (rm *removeManager) add(ctx context.Context, targetAlias, targetURL string)
 	}
 	rm.removeMapMutex.Unlock()
 
-	clientInfo.contentCh <- &ClientContent{URL: *newClientURL(targetURL)}
+	clientInfo.contentCh <- &ClientContent{URL: *url}
 }
 
-func (rm *removeManager) close() {
+// close waits for all removals to finish and reports the moved objects
+// which were copied but not removed from their source, returning their count.
+func (rm *removeManager) close() int {
 	for _, clientInfo := range rm.removeMap {
 		close(clientInfo.contentCh)
 	}
 
 	// Wait until all on-going client.Remove() operations to finish
 	rm.wg.Wait()
+
+	rm.movedMutex.Lock()
+	defer rm.movedMutex.Unlock()
+
+	notRemoved := rm.notRemoved
+	for _, moved := range rm.pending {
+		for _, m := range moved {
+			// A source which no longer exists was removed without a
+			// result, e.g. a local file already deleted, skip it.
+			if _, _, err := url2Stat(context.Background(), m.Source, "", false, nil, time.Time{}, false); err != nil && isStatPending(err) {
+				continue
+			}
+			notRemoved = append(notRemoved, m)
+		}
+	}
+	sort.Slice(notRemoved, func(i, j int) bool {
+		return notRemoved[i].Source < notRemoved[j].Source
+	})
+	for _, moved := range notRemoved {
+		printMsg(moved)
+	}
+	return len(notRemoved)
 }
 
 var rmManager = &removeManager{
 	removeMap: make(map[string]*removeClientInfo),
+	pending:   make(map[string][]mvNotRemovedMessage),
 }
 
 // mainMove is the entry point for mv command.
@@ -229,6 +383,11 @@ func mainMove(cliCtx *cli.Context) error {
 	// check 'copy' cli arguments.
 	checkCopySyntax(ctx, cliCtx, encKeyDB, true)
 
+	// mv removes the source objects as well, refuse any remote url.
+	for _, arg := range cliCtx.Args() {
+		fatalIfReadOnlyURL("mv", arg)
+	}
+
 	if cliCtx.NArg() == 2 {
 		args := cliCtx.Args()
 		srcURL := args.Get(0)
@@ -252,6 +411,9 @@ func mainMove(cliCtx *cli.Context) error {
 
 	// Additional command speific theme customization.
 	console.SetColor("Copy", color.New(color.FgGreen, color.Bold))
+	console.SetColor("MoveNotRemoved", color.New(color.FgYellow, color.Bold))
+
+	rmManager.verify = cliCtx.Bool("verify-before-delete")
 
 	recursive := cliCtx.Bool("recursive")
 	olderThan := cliCtx.String("older-than")
@@ -309,7 +471,12 @@ func mainMove(cliCtx *cli.Context) error {
 	}
 
 	console.Colorize("Copy", "Waiting for move operations to complete")
-	rmManager.close()
+	if notRemoved := rmManager.close(); notRemoved > 0 {
+		errorIf(errDummy().Trace(), fmt.Sprintf("%d object(s) copied but not removed from their source, please reconcile them.", notRemoved))
+		if e == nil {
+			e = exitStatus(globalErrorExitStatus)
+		}
+	}
 
 	return e
 }
@@ -0,0 +1,98 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestMoveVerifyTarget(t *testing.T) {
+	withTempMcConfig(t)
+
+	const sum = "9e107d9d372bb6826bd81d3542a419d6"
+	testCases := []struct {
+		size      int64
+		etag      string
+		encrypted bool
+		sourceSum string
+		verified  bool
+	}{
+		{5, sum, false, sum, true},
+		{6, sum, false, sum, false},
+		{5, "e4d909c290d0fb1ca068ffaddf22cbd0", false, sum, false},
+		{5, "e4d909c290d0fb1ca068ffaddf22cbd0-2", false, sum, true},
+		{5, "e4d909c290d0fb1ca068ffaddf22cbd0", true, sum, true},
+		{5, "e4d909c290d0fb1ca068ffaddf22cbd0", false, "", true},
+	}
+	for i, testCase := range testCases {
+		newS3StubServer(t, "target", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/bucket/object" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("ETag", `"`+testCase.etag+`"`)
+			w.Header().Set("Content-Length", strconv.FormatInt(testCase.size, 10))
+			w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+			if testCase.encrypted {
+				w.Header().Set("X-Amz-Server-Side-Encryption", "aws:kms")
+			}
+		}))
+
+		urls := URLs{
+			SourceContent: &ClientContent{URL: *newClientURL(filepath.Join(t.TempDir(), "object")), Size: 5},
+			TargetAlias:   "target",
+			ContentMD5Sum: testCase.sourceSum,
+		}
+		err := rmManager.verifyTarget(context.Background(), urls, "target/bucket/object", nil)
+		if verified := err == nil; verified != testCase.verified {
+			t.Fatalf("Test %d: expected verified %v, got %v", i+1, testCase.verified, err)
+		}
+	}
+}
+
+func TestMoveNotRemoved(t *testing.T) {
+	withTempMcConfig(t)
+
+	dir := t.TempDir()
+	rm := &removeManager{
+		removeMap: make(map[string]*removeClientInfo),
+		pending:   make(map[string][]mvNotRemovedMessage),
+	}
+	for _, name := range []string{"removed", "gone"} {
+		source := filepath.Join(dir, name)
+		if e := ioutil.WriteFile(source, []byte("data"), 0o600); e != nil {
+			t.Fatal(e)
+		}
+		rm.add(context.Background(), "", source, source, "target/bucket/"+name)
+	}
+	// Removed by someone else before mv got to it.
+	if e := os.Remove(filepath.Join(dir, "gone")); e != nil {
+		t.Fatal(e)
+	}
+	rm.keep(filepath.Join(dir, "kept"), "target/bucket/kept", "target size 0 differs from source size 4")
+
+	if notRemoved := rm.close(); notRemoved != 1 {
+		t.Fatalf("expected only the kept object to be reported, got %d", notRemoved)
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+var odFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "size",
+		Usage: "size of each object",
+		Value: "1MiB",
+	},
+	cli.IntFlag{
+		Name:  "count",
+		Usage: "number of objects to write or read",
+		Value: 100,
+	},
+	cli.IntFlag{
+		Name:  "concurrent",
+		Usage: "number of objects written or read in parallel",
+		Value: 4,
+	},
+}
+
+// Benchmark an endpoint or a local disk.
+var odCmd = cli.Command{
+	Name:         "od",
+	Usage:        "benchmark object writes and reads from the client",
+	Action:       mainOD,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(odFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] write|read TARGET
+
+  write uploads --count objects of --size bytes under TARGET, read
+  downloads the objects written by a previous write with the same
+  --count. The objects are kept, remove them with 'mc rm --recursive'.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Measure the write throughput of 'myminio' with 200 objects of 4MiB, 8 at a time.
+     {{.Prompt}} {{.HelpName}} --size 4MiB --count 200 --concurrent 8 write myminio/bench/od/
+
+  2. Measure the read throughput of the objects written above.
+     {{.Prompt}} {{.HelpName}} --count 200 --concurrent 8 read myminio/bench/od/
+
+  3. Benchmark a local disk and report the results as JSON.
+     {{.Prompt}} {{.HelpName}} --json --size 64MiB --count 10 write /mnt/data/od/
+`,
+}
+
+// odLatencyMessage holds latency percentiles in milliseconds.
+type odLatencyMessage struct {
+	Min float64 `json:"min"`
+	P50 float64 `json:"p50"`
+	P90 float64 `json:"p90"`
+	P99 float64 `json:"p99"`
+	Max float64 `json:"max"`
+}
+
+// odMessage container for the performance report of a benchmark.
+type odMessage struct {
+	Status     string           `json:"status"`
+	Mode       string           `json:"mode"`
+	Target     string           `json:"target"`
+	Size       int64            `json:"size"`
+	Concurrent int              `json:"concurrent"`
+	Objects    int              `json:"objects"`
+	Errors     int              `json:"errors"`
+	Bytes      int64            `json:"bytes"`
+	Duration   float64          `json:"duration"`
+	Throughput float64          `json:"throughput"`
+	ObjectsPS  float64          `json:"objectsPerSec"`
+	Latency    odLatencyMessage `json:"latency"`
+}
+
+func (o odMessage) String() string {
+	msg := fmt.Sprintf("%s %s: %d objects of %s, %d concurrent, %d errors\n",
+		o.Mode, o.Target, o.Objects, humanize.IBytes(uint64(o.Size)), o.Concurrent, o.Errors)
+	msg += fmt.Sprintf("Throughput: %s/s, %.1f objects/s (%s in %s)\n",
+		humanize.IBytes(uint64(o.Throughput)), o.ObjectsPS, humanize.IBytes(uint64(o.Bytes)),
+		time.Duration(o.Duration*float64(time.Second)).Round(time.Millisecond))
+	msg += fmt.Sprintf("Latency min/p50/p90/p99/max = %.3f/%.3f/%.3f/%.3f/%.3f ms",
+		o.Latency.Min, o.Latency.P50, o.Latency.P90, o.Latency.P99, o.Latency.Max)
+	return console.Colorize("Summarize", msg)
+}
+
+func (o odMessage) JSON() string {
+	o.Status = "success"
+	msgBytes, e := json.MarshalIndent(o, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// odPercentile returns the p-th percentile of sorted latencies in
+// milliseconds, with the nearest-rank method.
+func odPercentile(sorted []time.Duration, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return float64(sorted[rank]) / float64(time.Millisecond)
+}
+
+// odLatencies returns the percentiles of latencies.
+func odLatencies(latencies []time.Duration) odLatencyMessage {
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return odLatencyMessage{
+		Min: odPercentile(sorted, 0),
+		P50: odPercentile(sorted, 0.5),
+		P90: odPercentile(sorted, 0.9),
+		P99: odPercentile(sorted, 0.99),
+		Max: odPercentile(sorted, 1),
+	}
+}
+
+// odPayload is the content of the written objects, a random buffer
+// repeated as many times as needed.
+type odPayload []byte
+
+func (p odPayload) ReadAt(b []byte, off int64) (n int, e error) {
+	for n < len(b) {
+		n += copy(b[n:], p[(off+int64(n))%int64(len(p)):])
+	}
+	return n, nil
+}
+
+// odObjectURL returns the URL of the i-th object under target.
+func odObjectURL(target string, i int) string {
+	return urlJoinPath(target, fmt.Sprintf("od-%06d", i))
+}
+
+// odTransfer writes or reads one object, it returns the number of
+// bytes transferred.
+func odTransfer(ctx context.Context, mode, objectURL string, payload odPayload, size int64) (int64, *probe.Error) {
+	clnt, err := newClient(objectURL)
+	if err != nil {
+		return 0, err.Trace(objectURL)
+	}
+	if mode == "write" {
+		reader := io.NewSectionReader(payload, 0, size)
+		n, err := clnt.Put(ctx, reader, size, nil, PutOptions{})
+		if err != nil {
+			return n, err.Trace(objectURL)
+		}
+		return n, nil
+	}
+	reader, err := clnt.Get(ctx, GetOptions{})
+	if err != nil {
+		return 0, err.Trace(objectURL)
+	}
+	defer reader.Close()
+	n, e := io.Copy(ioutil.Discard, reader)
+	if e != nil {
+		return n, probe.NewError(e).Trace(objectURL)
+	}
+	return n, nil
+}
+
+// checkODSyntax - validate all the passed arguments
+func checkODSyntax(cliCtx *cli.Context) (mode, target string, size int64) {
+	if cliCtx.NArg() != 2 {
+		cli.ShowCommandHelpAndExit(cliCtx, "od", 1) // last argument is exit code
+	}
+	mode, target = cliCtx.Args().Get(0), cliCtx.Args().Get(1)
+	if mode != "write" && mode != "read" {
+		fatalIf(errInvalidArgument().Trace(mode), "Unknown mode `"+mode+"`, expected write or read.")
+	}
+	if cliCtx.Int("count") < 1 || cliCtx.Int("concurrent") < 1 {
+		fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "--count and --concurrent must be at least 1.")
+	}
+	bytes, e := humanize.ParseBytes(cliCtx.String("size"))
+	if e != nil || bytes == 0 {
+		fatalIf(errInvalidArgument().Trace(cliCtx.String("size")), "Invalid --size `"+cliCtx.String("size")+"`.")
+	}
+	return mode, target, int64(bytes)
+}
+
+// mainOD is the handle for "mc od" command.
+func mainOD(cliCtx *cli.Context) error {
+	mode, target, size := checkODSyntax(cliCtx)
+	if mode == "write" {
+		fatalIfReadOnlyURL("od write", target)
+	}
+
+	console.SetColor("Summarize", color.New(color.Bold))
+
+	ctx, cancelOD := context.WithCancel(globalContext)
+	defer cancelOD()
+
+	var payload odPayload
+	if mode == "write" {
+		payloadSize := size
+		if payloadSize > humanize.MiByte {
+			payloadSize = humanize.MiByte
+		}
+		payload = make(odPayload, payloadSize)
+		_, e := rand.Read(payload)
+		fatalIf(probe.NewError(e), "Unable to generate the content of the objects.")
+	}
+
+	count := cliCtx.Int("count")
+	concurrent := cliCtx.Int("concurrent")
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		latencies = make([]time.Duration, 0, count)
+		bytes     int64
+		errs      int
+	)
+	objects := make(chan int)
+	start := time.Now()
+	for w := 0; w < concurrent; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range objects {
+				objectURL := odObjectURL(target, i)
+				objectStart := time.Now()
+				n, err := odTransfer(ctx, mode, objectURL, payload, size)
+				latency := time.Since(objectStart)
+
+				mu.Lock()
+				bytes += n
+				if err != nil {
+					if errs == 0 {
+						errorIf(err, "Unable to "+mode+" `"+objectURL+"`.")
+					}
+					errs++
+				} else {
+					latencies = append(latencies, latency)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := 0; i < count && ctx.Err() == nil; i++ {
+		objects <- i
+	}
+	close(objects)
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	msg := odMessage{
+		Mode:       mode,
+		Target:     target,
+		Size:       size,
+		Concurrent: concurrent,
+		Objects:    len(latencies),
+		Errors:     errs,
+		Bytes:      bytes,
+		Duration:   elapsed.Seconds(),
+		Latency:    odLatencies(latencies),
+	}
+	if mode == "read" && len(latencies) > 0 {
+		// The objects are read whatever the --size they were written with.
+		msg.Size = bytes / int64(len(latencies))
+	}
+	if elapsed > 0 {
+		msg.Throughput = float64(bytes) / elapsed.Seconds()
+		msg.ObjectsPS = float64(len(latencies)) / elapsed.Seconds()
+	}
+	printMsg(msg)
+
+	if errs > 0 {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestODLatencies(t *testing.T) {
+	latencies := make([]time.Duration, 0, 100)
+	for i := 100; i > 0; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	msg := odLatencies(latencies)
+	expected := odLatencyMessage{Min: 1, P50: 50, P90: 90, P99: 99, Max: 100}
+	if msg != expected {
+		t.Fatalf("expected %+v, got %+v", expected, msg)
+	}
+	if msg = odLatencies(nil); msg != (odLatencyMessage{}) {
+		t.Fatalf("expected no latencies, got %+v", msg)
+	}
+}
+
+func TestODPayload(t *testing.T) {
+	payload := odPayload("0123456789")
+	data, e := io.ReadAll(io.NewSectionReader(payload, 0, 25))
+	if e != nil {
+		t.Fatal(e)
+	}
+	if !bytes.Equal(data, []byte("0123456789012345678901234")) {
+		t.Fatalf("unexpected content `%s`", data)
+	}
+	b := make([]byte, 4)
+	if _, e = payload.ReadAt(b, 18); e != nil || string(b) != "8901" {
+		t.Fatalf("unexpected content `%s` at offset 18", b)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/minio/cli"
+)
+
+// opsRateFlag limits the object operations of cp, mirror and rm.
+var opsRateFlag = cli.Float64Flag{
+	Name:  "ops-rate",
+	Usage: "limit the number of object operations per second, e.g. for many small objects",
+}
+
+// opsLimiter paces object operations with a token bucket holding up to
+// one second worth of operations. A nil limiter does not limit.
+type opsLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newOpsLimiter(rate float64) *opsLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	burst := rate
+	if burst < 1 {
+		burst = 1
+	}
+	return &opsLimiter{rate: rate, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// newOpsLimiterFromContext validates --ops-rate and returns its limiter.
+func newOpsLimiterFromContext(cliCtx *cli.Context) *opsLimiter {
+	if !cliCtx.IsSet("ops-rate") {
+		return nil
+	}
+	rate := cliCtx.Float64("ops-rate")
+	if rate <= 0 {
+		fatalIf(errInvalidArgument().Trace(strconv.FormatFloat(rate, 'f', -1, 64)),
+			"--ops-rate should be a positive number of operations per second.")
+	}
+	return newOpsLimiter(rate)
+}
+
+// reserve takes a token at now and returns how long to wait before
+// the operation may start.
+func (l *opsLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens += elapsed.Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = now
+	}
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// wait blocks until the next operation may start or ctx is done.
+func (l *opsLimiter) wait(ctx context.Context) {
+	if l == nil {
+		return
+	}
+	delay := l.reserve(time.Now())
+	if delay <= 0 {
+		return
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
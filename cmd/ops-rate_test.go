@@ -0,0 +1,81 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestOpsLimiterReserve(t *testing.T) {
+	if newOpsLimiter(0) != nil || newOpsLimiter(-1) != nil {
+		t.Fatal("expected no limiter without a positive rate")
+	}
+
+	l := newOpsLimiter(10)
+	now := l.last
+	// One second worth of operations starts right away.
+	for i := 0; i < 10; i++ {
+		if delay := l.reserve(now); delay != 0 {
+			t.Fatalf("operation %d: expected no delay, got %v", i, delay)
+		}
+	}
+	// The next ones are spaced by 1/rate.
+	if delay := l.reserve(now); delay != 100*time.Millisecond {
+		t.Fatalf("expected 100ms, got %v", delay)
+	}
+	if delay := l.reserve(now); delay != 200*time.Millisecond {
+		t.Fatalf("expected 200ms, got %v", delay)
+	}
+	// Idle time refills the bucket, but never beyond its burst.
+	now = now.Add(time.Hour)
+	for i := 0; i < 10; i++ {
+		if delay := l.reserve(now); delay != 0 {
+			t.Fatalf("operation %d after idle: expected no delay, got %v", i, delay)
+		}
+	}
+	if delay := l.reserve(now); delay == 0 {
+		t.Fatal("expected the burst to be capped")
+	}
+
+	// Rates below one operation per second still allow one operation.
+	slow := newOpsLimiter(0.5)
+	if delay := slow.reserve(slow.last); delay != 0 {
+		t.Fatalf("expected no delay, got %v", delay)
+	}
+	if delay := slow.reserve(slow.last); delay != 2*time.Second {
+		t.Fatalf("expected 2s, got %v", delay)
+	}
+}
+
+func TestOpsLimiterWait(t *testing.T) {
+	// A nil limiter never waits.
+	var l *opsLimiter
+	l.wait(context.Background())
+
+	l = newOpsLimiter(1)
+	l.wait(context.Background())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	l.wait(ctx)
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("expected a canceled wait to return right away, waited %v", elapsed)
+	}
+}
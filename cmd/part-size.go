@@ -0,0 +1,67 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/mc/pkg/probe"
+)
+
+const (
+	// Maximum number of parts of a multipart upload.
+	maxMultipartParts = 10000
+	// Maximum size of a part of a multipart upload.
+	maxMultipartSize = 5 * humanize.GiByte
+)
+
+// PartCountExceeded - a multipart upload needs more parts than allowed.
+type PartCountExceeded struct {
+	Size     int64
+	PartSize uint64
+}
+
+func (e PartCountExceeded) Error() string {
+	return fmt.Sprintf("Uploading %s in parts of %s takes %d parts, more than the %d allowed. Set a larger MC_UPLOAD_MULTIPART_SIZE or pass --auto-part-size.",
+		humanize.IBytes(uint64(e.Size)), humanize.IBytes(e.PartSize), partCount(e.Size, e.PartSize), maxMultipartParts)
+}
+
+// partCount returns the number of parts of size bytes in parts of partSize.
+func partCount(size int64, partSize uint64) uint64 {
+	return (uint64(size) + partSize - 1) / partSize
+}
+
+// fitPartSize checks that a multipart upload of size bytes in parts of
+// partSize takes at most maxMultipartParts parts, before the upload
+// fails on its last parts. With auto the part size is increased to the
+// smallest multiple of a MiB within the limit instead of failing, and
+// returned along with whether it was increased.
+func fitPartSize(size int64, partSize uint64, auto bool) (uint64, bool, *probe.Error) {
+	if size <= 0 || partSize == 0 || partCount(size, partSize) <= maxMultipartParts {
+		return partSize, false, nil
+	}
+	if !auto {
+		return 0, false, probe.NewError(PartCountExceeded{Size: size, PartSize: partSize})
+	}
+	fitted := partCount(size, maxMultipartParts*humanize.MiByte) * humanize.MiByte
+	if fitted > maxMultipartSize {
+		return 0, false, probe.NewError(PartCountExceeded{Size: size, PartSize: maxMultipartSize})
+	}
+	return fitted, true, nil
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dustin/go-humanize"
+)
+
+func TestFitPartSize(t *testing.T) {
+	testCases := []struct {
+		size      int64
+		partSize  uint64
+		auto      bool
+		expected  uint64
+		increased bool
+		exceeded  bool
+	}{
+		// Part size chosen by the uploader.
+		{humanize.TiByte, 0, false, 0, false, false},
+		// Unknown size.
+		{-1, 16 * humanize.MiByte, false, 16 * humanize.MiByte, false, false},
+		// Exactly 10000 parts.
+		{10000 * 16 * humanize.MiByte, 16 * humanize.MiByte, false, 16 * humanize.MiByte, false, false},
+		{10000*16*humanize.MiByte + 1, 16 * humanize.MiByte, false, 0, false, true},
+		{10000*16*humanize.MiByte + 1, 16 * humanize.MiByte, true, 17 * humanize.MiByte, true, false},
+		{humanize.TiByte, 16 * humanize.MiByte, true, 105 * humanize.MiByte, true, false},
+		// More than 10000 parts of the largest part size.
+		{10000*5*humanize.GiByte + 1, 16 * humanize.MiByte, true, 0, false, true},
+	}
+	for i, testCase := range testCases {
+		partSize, increased, err := fitPartSize(testCase.size, testCase.partSize, testCase.auto)
+		if testCase.exceeded {
+			var exceeded PartCountExceeded
+			if err == nil || !errors.As(err.ToGoError(), &exceeded) {
+				t.Fatalf("Test %d: expected PartCountExceeded, got %v", i+1, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if partSize != testCase.expected || increased != testCase.increased {
+			t.Fatalf("Test %d: expected %d (increased %v), got %d (increased %v)", i+1, testCase.expected, testCase.increased, partSize, increased)
+		}
+		if partSize > 0 && testCase.size > 0 && partCount(testCase.size, partSize) > maxMultipartParts {
+			t.Fatalf("Test %d: %d parts exceed the limit", i+1, partCount(testCase.size, partSize))
+		}
+	}
+}
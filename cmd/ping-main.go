@@ -0,0 +1,248 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+var pingFlags = []cli.Flag{
+	cli.IntFlag{
+		Name:  "count, c",
+		Usage: "stop after this many requests, 0 to ping until interrupted",
+		Value: 4,
+	},
+	cli.DurationFlag{
+		Name:  "interval, i",
+		Usage: "wait this long between two requests",
+		Value: time.Second,
+	},
+}
+
+// Probe the latency of an endpoint.
+var pingCmd = cli.Command{
+	Name:         "ping",
+	Usage:        "measure the round-trip latency of an alias",
+	Action:       mainPing,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(pingFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] ALIAS
+
+  Each request lists the buckets of the alias, the latency includes the
+  authentication of the request by the server.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Measure the latency of 'myminio' over 4 requests.
+     {{.Prompt}} {{.HelpName}} myminio
+
+  2. Ping 'myminio' every 5 seconds until interrupted.
+     {{.Prompt}} {{.HelpName}} --count 0 --interval 5s myminio
+
+  3. Measure the latency of 'myminio' over 20 requests and report it as JSON.
+     {{.Prompt}} {{.HelpName}} --json --count 20 --interval 100ms myminio
+`,
+}
+
+// pingMessage container for the outcome of a request.
+type pingMessage struct {
+	Status  string  `json:"status"`
+	Target  string  `json:"target"`
+	Seq     int     `json:"seq"`
+	Latency float64 `json:"latency,omitempty"`
+	Error   string  `json:"error,omitempty"`
+}
+
+func (p pingMessage) String() string {
+	if p.Error != "" {
+		return console.Colorize("PingFailed", fmt.Sprintf("%s: seq=%d %s", p.Target, p.Seq, p.Error))
+	}
+	return console.Colorize("Ping", fmt.Sprintf("%s: seq=%d time=%.3f ms", p.Target, p.Seq, p.Latency))
+}
+
+func (p pingMessage) JSON() string {
+	p.Status = "success"
+	if p.Error != "" {
+		p.Status = "error"
+	}
+	msgBytes, e := json.MarshalIndent(p, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// pingStatsMessage container for the latency statistics of the
+// requests, in milliseconds.
+type pingStatsMessage struct {
+	Status      string  `json:"status"`
+	Target      string  `json:"target"`
+	Transmitted int     `json:"transmitted"`
+	Received    int     `json:"received"`
+	Loss        float64 `json:"loss"`
+	Min         float64 `json:"min"`
+	Avg         float64 `json:"avg"`
+	Max         float64 `json:"max"`
+	StdDev      float64 `json:"stddev"`
+}
+
+func (p pingStatsMessage) String() string {
+	msg := fmt.Sprintf("--- %s ping statistics ---\n%d requests transmitted, %d received, %.1f%% failed",
+		p.Target, p.Transmitted, p.Received, p.Loss)
+	if p.Received > 0 {
+		msg += fmt.Sprintf("\nround-trip min/avg/max/stddev = %.3f/%.3f/%.3f/%.3f ms", p.Min, p.Avg, p.Max, p.StdDev)
+	}
+	return console.Colorize("Summarize", msg)
+}
+
+func (p pingStatsMessage) JSON() string {
+	p.Status = "success"
+	msgBytes, e := json.MarshalIndent(p, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// pingStats accumulates the latencies of the requests, safe for
+// concurrent use since it is also read once interrupted.
+type pingStats struct {
+	sync.Mutex
+	msg          pingStatsMessage
+	sum, sumSqrs float64
+}
+
+// add accounts a request, a zero latency for a failed one.
+func (s *pingStats) add(latency time.Duration, failed bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.msg.Transmitted++
+	if failed {
+		return
+	}
+	ms := float64(latency) / float64(time.Millisecond)
+	if s.msg.Received == 0 || ms < s.msg.Min {
+		s.msg.Min = ms
+	}
+	if ms > s.msg.Max {
+		s.msg.Max = ms
+	}
+	s.msg.Received++
+	s.sum += ms
+	s.sumSqrs += ms * ms
+}
+
+// stats returns the statistics of the requests so far.
+func (s *pingStats) stats() pingStatsMessage {
+	s.Lock()
+	defer s.Unlock()
+
+	msg := s.msg
+	if msg.Transmitted > 0 {
+		msg.Loss = float64(msg.Transmitted-msg.Received) * 100 / float64(msg.Transmitted)
+	}
+	if msg.Received > 0 {
+		n := float64(msg.Received)
+		msg.Avg = s.sum / n
+		msg.StdDev = math.Sqrt(math.Max(s.sumSqrs/n-msg.Avg*msg.Avg, 0))
+	}
+	return msg
+}
+
+// checkPingSyntax - validate all the passed arguments
+func checkPingSyntax(cliCtx *cli.Context) {
+	if cliCtx.NArg() != 1 {
+		cli.ShowCommandHelpAndExit(cliCtx, "ping", 1) // last argument is exit code
+	}
+	if cliCtx.Int("count") < 0 {
+		fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "--count cannot be negative.")
+	}
+	if cliCtx.Duration("interval") <= 0 {
+		fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "--interval must be positive.")
+	}
+}
+
+// mainPing is the handle for "mc ping" command.
+func mainPing(cliCtx *cli.Context) error {
+	checkPingSyntax(cliCtx)
+
+	console.SetColor("Ping", color.New(color.FgGreen))
+	console.SetColor("PingFailed", color.New(color.FgRed))
+	console.SetColor("Summarize", color.New(color.Bold))
+
+	target := cliCtx.Args().Get(0)
+	alias, _ := url2Alias(target)
+	if alias == "" {
+		fatalIf(errInvalidArgument().Trace(target), "`"+target+"` is not an alias of an object storage endpoint.")
+	}
+	clnt, err := newClient(alias)
+	fatalIf(err.Trace(alias), "Unable to initialize `"+alias+"`.")
+	s3Clnt, ok := clnt.(*S3Client)
+	if !ok {
+		fatalIf(errInvalidArgument().Trace(alias), "`"+alias+"` is not an alias of an object storage endpoint.")
+	}
+
+	stats := &pingStats{msg: pingStatsMessage{Target: alias}}
+	setInterruptHook(func() { printMsg(stats.stats()) })
+	defer setInterruptHook(nil)
+
+	count := cliCtx.Int("count")
+	interval := cliCtx.Duration("interval")
+	for seq := 1; count == 0 || seq <= count; seq++ {
+		if seq > 1 {
+			select {
+			case <-globalContext.Done():
+				return exitStatus(globalCancelExitStatus)
+			case <-time.After(interval):
+			}
+		}
+
+		start := time.Now()
+		_, e := s3Clnt.api.ListBuckets(globalContext)
+		latency := time.Since(start)
+
+		msg := pingMessage{Target: alias, Seq: seq}
+		if e != nil {
+			msg.Error = e.Error()
+		} else {
+			msg.Latency = float64(latency) / float64(time.Millisecond)
+		}
+		stats.add(latency, e != nil)
+		printMsg(msg)
+	}
+
+	msg := stats.stats()
+	printMsg(msg)
+	if msg.Received == 0 {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPingStats(t *testing.T) {
+	s := &pingStats{}
+	if msg := s.stats(); msg.Transmitted != 0 || msg.Loss != 0 || msg.Avg != 0 {
+		t.Fatalf("unexpected statistics without requests: %+v", msg)
+	}
+
+	s.add(2*time.Millisecond, false)
+	s.add(4*time.Millisecond, false)
+	s.add(0, true)
+	s.add(6*time.Millisecond, false)
+
+	msg := s.stats()
+	if msg.Transmitted != 4 || msg.Received != 3 || msg.Loss != 25 {
+		t.Fatalf("expected 4 transmitted, 3 received and 25%% failed, got %+v", msg)
+	}
+	if msg.Min != 2 || msg.Avg != 4 || msg.Max != 6 {
+		t.Fatalf("expected min/avg/max 2/4/6, got %v/%v/%v", msg.Min, msg.Avg, msg.Max)
+	}
+	if math.Abs(msg.StdDev-math.Sqrt(8.0/3)) > 1e-9 {
+		t.Fatalf("expected stddev %v, got %v", math.Sqrt(8.0/3), msg.StdDev)
+	}
+}
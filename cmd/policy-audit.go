@@ -0,0 +1,109 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/user"
+	"time"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// policyAuditLogFlag enables the audit log of the changes of access made
+// by the set and set-json commands of policy and anonymous.
+var policyAuditLogFlag = cli.StringFlag{
+	Name:  "audit-log",
+	Usage: "append the access before and after set and set-json to this file",
+}
+
+// policyAuditState is the access of a target before or after a change.
+type policyAuditState struct {
+	Perms  accessPerms `json:"perms,omitempty"`
+	Policy string      `json:"policy,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// policyAuditEntry is a line of the policy audit log.
+type policyAuditEntry struct {
+	Time      time.Time        `json:"time"`
+	Operation string           `json:"operation"`
+	Target    string           `json:"target"`
+	User      string           `json:"user,omitempty"`
+	AccessKey string           `json:"accessKey,omitempty"`
+	Before    policyAuditState `json:"before"`
+	After     policyAuditState `json:"after"`
+}
+
+// getPolicyAuditState returns the current access of targetURL.
+func getPolicyAuditState(ctx context.Context, targetURL string) policyAuditState {
+	perms, policy, err := doGetAccess(ctx, targetURL)
+	if err != nil {
+		return policyAuditState{Error: err.ToGoError().Error()}
+	}
+	return policyAuditState{Perms: perms, Policy: policy}
+}
+
+// policyAudit records a change of the access of a target to the audit
+// log at logPath, it does nothing when logPath is empty.
+type policyAudit struct {
+	logPath string
+	entry   policyAuditEntry
+}
+
+// newPolicyAudit captures the access of targetURL before operation.
+func newPolicyAudit(ctx context.Context, logPath, operation, targetURL string) *policyAudit {
+	a := &policyAudit{logPath: logPath, entry: policyAuditEntry{Operation: operation, Target: targetURL}}
+	if logPath != "" {
+		a.entry.Before = getPolicyAuditState(ctx, targetURL)
+	}
+	return a
+}
+
+// record captures the access of the target after the change and appends
+// the change to the audit log, failing to do so only reports an error
+// since the change has already been made.
+func (a *policyAudit) record(ctx context.Context) {
+	if a.logPath == "" {
+		return
+	}
+	entry := a.entry
+	entry.Time = UTCNow()
+	entry.After = getPolicyAuditState(ctx, entry.Target)
+	if u, e := user.Current(); e == nil {
+		entry.User = u.Username
+	}
+	if _, _, hostCfg := mustExpandAlias(entry.Target); hostCfg != nil {
+		entry.AccessKey = hostCfg.AccessKey
+	}
+
+	line, e := json.Marshal(entry)
+	if e == nil {
+		var f *os.File
+		if f, e = os.OpenFile(a.logPath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600); e == nil {
+			_, e = f.Write(append(line, '\n'))
+			if ce := f.Close(); e == nil {
+				e = ce
+			}
+		}
+	}
+	errorIf(probe.NewError(e).Trace(a.logPath), "Unable to write the policy audit log, the change of `"+entry.Target+"` is not recorded.")
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/minio/cli"
+)
+
+// policyServer serves the policy of a single bucket.
+type policyServer struct {
+	mu     sync.Mutex
+	policy string
+}
+
+func (s *policyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	query := r.URL.Query()
+	if _, ok := query["policy"]; !ok || r.URL.Path != "/bucket/" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		if s.policy == "" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`<Error><Code>NoSuchBucketPolicy</Code><Message>The bucket policy does not exist</Message></Error>`))
+			return
+		}
+		w.Write([]byte(s.policy))
+	case http.MethodPut:
+		body, _ := ioutil.ReadAll(r.Body)
+		s.policy = string(body)
+	case http.MethodDelete:
+		s.policy = ""
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestPolicyAuditLog(t *testing.T) {
+	withTempMcConfig(t)
+
+	newS3StubServer(t, "audit", &policyServer{})
+
+	// No audit log is written unless asked for.
+	runAnonymousCmd(cli.Args{"set", "upload", "audit/bucket"}, "")
+	if entries, e := ioutil.ReadDir(mustGetMcConfigDir()); e != nil {
+		t.Fatal(e)
+	} else {
+		for _, entry := range entries {
+			if strings.Contains(entry.Name(), "audit") {
+				t.Fatalf("unexpected audit log %s", entry.Name())
+			}
+		}
+	}
+
+	logPath := filepath.Join(t.TempDir(), "audit.log")
+	runAnonymousCmd(cli.Args{"set", "download", "audit/bucket"}, logPath)
+	runPolicyCmd(cli.Args{"set", "none", "audit/bucket"}, policyGetJSONOptions{}, logPath)
+	// Reading the access does not change it and is not logged.
+	runAnonymousCmd(cli.Args{"get", "audit/bucket"}, logPath)
+
+	data, e := os.ReadFile(logPath)
+	if e != nil {
+		t.Fatal(e)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 audit entries, got %d: %s", len(lines), data)
+	}
+	expected := []struct {
+		before, after accessPerms
+	}{
+		{"upload", "download"},
+		{"download", "private"},
+	}
+	for i, line := range lines {
+		var entry policyAuditEntry
+		if e := json.Unmarshal([]byte(line), &entry); e != nil {
+			t.Fatalf("Entry %d: %v", i+1, e)
+		}
+		if entry.Operation != "set" || entry.Target != "audit/bucket" || entry.AccessKey != "minio" || entry.Time.IsZero() {
+			t.Errorf("Entry %d: unexpected entry %s", i+1, line)
+		}
+		if entry.Before.Perms != expected[i].before || entry.After.Perms != expected[i].after {
+			t.Errorf("Entry %d: expected %s to %s, got %s to %s", i+1, expected[i].before, expected[i].after, entry.Before.Perms, entry.After.Perms)
+		}
+	}
+}
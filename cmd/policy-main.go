@@ -19,7 +19,12 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/fatih/color"
@@ -27,12 +32,42 @@ import (
 	json "github.com/minio/colorjson"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/console"
+	"github.com/minio/pkg/wildcard"
 )
 
 var policyFlags = []cli.Flag{
 	cli.BoolFlag{
 		Name:  "recursive, r",
-		Usage: "list recursively",
+		Usage: "list recursively, or get the permission of every sub-prefix",
+	},
+	cli.BoolFlag{
+		Name:  "no-dedup",
+		Usage: "do not deduplicate links found under overlapping policy prefixes",
+	},
+	cli.StringFlag{
+		Name:  "output-file",
+		Usage: "write the policy fetched by get-json to a file",
+	},
+	cli.BoolFlag{
+		Name:  "pretty",
+		Usage: "print the policy fetched by get-json indented (default)",
+	},
+	cli.BoolFlag{
+		Name:  "compact",
+		Usage: "print the policy fetched by get-json on a single line",
+	},
+	cli.BoolFlag{
+		Name:  "force",
+		Usage: "overwrite an existing --output-file",
+	},
+	policyAuditLogFlag,
+	cli.StringFlag{
+		Name:  "action",
+		Usage: "S3 action to test the access for with 'test', e.g. s3:GetObject",
+	},
+	cli.StringFlag{
+		Name:  "principal",
+		Usage: "principal to test the access for with 'test' (default: anonymous)",
 	},
 }
 
@@ -54,6 +89,7 @@ USAGE:
   {{.HelpName}} [FLAGS] get TARGET
   {{.HelpName}} [FLAGS] get-json TARGET
   {{.HelpName}} [FLAGS] list TARGET
+  {{.HelpName}} [FLAGS] test TARGET --action ACTION
 {{if .VisibleFlags}}
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -91,6 +127,27 @@ EXAMPLES:
 
   9. List public object URLs recursively.
      {{.Prompt}} {{.HelpName}} --recursive links s3/shared/
+
+  10. List public object URLs recursively without deduplication, useful for very large buckets.
+     {{.Prompt}} {{.HelpName}} --recursive --no-dedup links s3/shared/
+
+  11. Save bucket permissions in JSON format to a file, overwriting it if it already exists.
+     {{.Prompt}} {{.HelpName}} --output-file policy.json --force get-json s3/shared
+
+  12. Get bucket permissions in JSON format on a single line.
+     {{.Prompt}} {{.HelpName}} --compact get-json s3/shared
+
+  13. Get the permissions of all buckets whose name starts with "prod-".
+     {{.Prompt}} {{.HelpName}} get 'myminio/prod-*'
+
+  14. Set bucket to "download", recording the previous and new access in an audit log.
+     {{.Prompt}} {{.HelpName}} --audit-log /var/log/mc-policy.log set download s3/shared
+
+  15. Test if a user can upload to a prefix, showing the statement allowing or denying it.
+     {{.Prompt}} {{.HelpName}} test s3/incoming/uploads/file.txt --action s3:PutObject --principal "arn:aws:iam::123456789012:user/alice"
+
+  16. Get the effective permission of a bucket and of all its prefixes.
+     {{.Prompt}} {{.HelpName}} --recursive get s3/shared
 `,
 }
 
@@ -114,11 +171,15 @@ func (s policyRules) JSON() string {
 
 // policyMessage is container for policy command on bucket success and failure messages.
 type policyMessage struct {
+	messageBase
 	Operation string                 `json:"operation"`
 	Status    string                 `json:"status"`
 	Bucket    string                 `json:"bucket"`
 	Perms     accessPerms            `json:"permission"`
 	Policy    map[string]interface{} `json:"policy,omitempty"`
+
+	OutputFile string `json:"outputFile,omitempty"`
+	compact    bool
 }
 
 // String colorized access message.
@@ -136,9 +197,11 @@ func (s policyMessage) String() string {
 			"Access permission for `"+s.Bucket+"`"+" is set from `"+string(s.Perms)+"`")
 	}
 	if s.Operation == "get-json" {
-		policy, e := json.MarshalIndent(s.Policy, "", " ")
-		fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
-		return string(policy)
+		if s.OutputFile != "" {
+			return console.Colorize("Policy",
+				"Access permission for `"+s.Bucket+"`"+" is saved to `"+s.OutputFile+"`")
+		}
+		return string(marshalPolicy(s.Policy, s.compact))
 	}
 	// nothing to print
 	return ""
@@ -154,6 +217,7 @@ func (s policyMessage) JSON() string {
 
 // policyLinksMessage is container for policy links command
 type policyLinksMessage struct {
+	messageBase
 	Status string `json:"status"`
 	URL    string `json:"url"`
 }
@@ -171,6 +235,64 @@ func (s policyLinksMessage) JSON() string {
 	return string(policyJSONBytes)
 }
 
+// policyLinksSummaryMessage is container for the summary of policy links command
+type policyLinksSummaryMessage struct {
+	messageBase
+	Status       string `json:"status"`
+	TotalObjects int64  `json:"totalObjects"`
+	Unique       bool   `json:"unique"`
+}
+
+// String colorized summary message.
+func (s policyLinksSummaryMessage) String() string {
+	if s.Unique {
+		return console.Colorize("Policy", fmt.Sprintf("\nTotal unique public objects: %d", s.TotalObjects))
+	}
+	return console.Colorize("Policy", fmt.Sprintf("\nTotal public links: %d", s.TotalObjects))
+}
+
+// JSON jsonified summary message.
+func (s policyLinksSummaryMessage) JSON() string {
+	policyJSONBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(policyJSONBytes)
+}
+
+// marshalPolicy encodes a policy either indented or on a single line.
+func marshalPolicy(policy map[string]interface{}, compact bool) []byte {
+	var policyBytes []byte
+	var e error
+	if compact {
+		policyBytes, e = json.Marshal(policy)
+	} else {
+		policyBytes, e = json.MarshalIndent(policy, "", " ")
+	}
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return policyBytes
+}
+
+// writePolicyFile writes the policy to a file, refusing to
+// replace an existing file unless force is set.
+func writePolicyFile(filename string, policy []byte, force bool) *probe.Error {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !force {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	}
+	f, e := os.OpenFile(filename, flags, 0o644)
+	if e != nil {
+		if os.IsExist(e) {
+			return probe.NewError(fmt.Errorf("file `%s` already exists, use --force to overwrite it", filename))
+		}
+		return probe.NewError(e)
+	}
+	if _, e = f.Write(append(policy, '\n')); e != nil {
+		f.Close()
+		return probe.NewError(e)
+	}
+	return probe.NewError(f.Close())
+}
+
 // checkPolicySyntax check for incoming syntax.
 func checkPolicySyntax(ctx *cli.Context) {
 	argsLength := len(ctx.Args())
@@ -211,6 +333,15 @@ func checkPolicySyntax(ctx *cli.Context) {
 		if argsLength != 2 {
 			cli.ShowCommandHelpAndExit(ctx, "policy", 1)
 		}
+		if ctx.Bool("pretty") && ctx.Bool("compact") {
+			fatalIf(errInvalidArgument().Trace(), "--pretty and --compact cannot be specified together.")
+		}
+		if ctx.Bool("recursive") && firstArg == "get-json" {
+			fatalIf(errInvalidArgument().Trace(), "--recursive can only be used with get, not get-json.")
+		}
+		if _, _, _, ok := splitBucketGlob(secondArg); ok && ctx.Bool("recursive") {
+			fatalIf(errInvalidArgument().Trace(secondArg), "--recursive cannot be used with a bucket pattern.")
+		}
 	case "list":
 		// Always expect an argument after list cmd
 		if argsLength != 2 {
@@ -221,9 +352,23 @@ func checkPolicySyntax(ctx *cli.Context) {
 		if argsLength != 2 {
 			cli.ShowCommandHelpAndExit(ctx, "policy", 1)
 		}
+	case "test":
+		// Always expect an argument after test cmd
+		if argsLength != 2 {
+			cli.ShowCommandHelpAndExit(ctx, "policy", 1)
+		}
+		checkPolicyTestAction(ctx.String("action"))
 	default:
 		cli.ShowCommandHelpAndExit(ctx, "policy", 1)
 	}
+
+	if firstArg != "get-json" {
+		for _, flag := range []string{"output-file", "pretty", "compact", "force"} {
+			if ctx.IsSet(flag) {
+				fatalIf(errInvalidArgument().Trace(), "--"+flag+" can only be used with get-json.")
+			}
+		}
+	}
 }
 
 // Run policy list command
@@ -247,7 +392,7 @@ func runPolicyListCmd(args cli.Args) {
 }
 
 // Run policy links command
-func runPolicyLinksCmd(args cli.Args, recursive bool) {
+func runPolicyLinksCmd(args cli.Args, recursive, noDedup bool) {
 	ctx, cancelPolicyLinks := context.WithCancel(globalContext)
 	defer cancelPolicyLinks()
 
@@ -269,6 +414,14 @@ func runPolicyLinksCmd(args cli.Args, recursive bool) {
 	// construct new pathes to list public objects
 	alias, path := url2Alias(targetURL)
 
+	// Overlapping policy prefixes may match the same object more than
+	// once, keep track of the emitted urls unless asked not to.
+	var seen map[string]struct{}
+	if !noDedup {
+		seen = make(map[string]struct{})
+	}
+	var totalObjects int64
+
 	// Iterate over policy rules to fetch public urls, then search
 	// for objects under those urls
 	for k, v := range policies {
@@ -303,6 +456,14 @@ func runPolicyLinksCmd(args cli.Args, recursive bool) {
 			errorIf(probe.NewError(e), "Unable to parse url `"+content.URL.String()+"`.")
 			publicURL := u.String()
 
+			if seen != nil {
+				if _, ok := seen[publicURL]; ok {
+					continue
+				}
+				seen[publicURL] = struct{}{}
+			}
+			totalObjects++
+
 			// Construct the message to be displayed to the user
 			msg := policyLinksMessage{
 				Status: "success",
@@ -312,10 +473,102 @@ func runPolicyLinksCmd(args cli.Args, recursive bool) {
 			printMsg(msg)
 		}
 	}
+
+	printMsg(policyLinksSummaryMessage{
+		Status:       "success",
+		TotalObjects: totalObjects,
+		Unique:       !noDedup,
+	})
+}
+
+// policyGlobMessage is container for the permissions of all the
+// buckets matching a wildcard pattern.
+type policyGlobMessage struct {
+	messageBase
+	Status  string                 `json:"status"`
+	Pattern string                 `json:"pattern"`
+	Buckets map[string]accessPerms `json:"buckets"`
+}
+
+// String colorized access message.
+func (s policyGlobMessage) String() string {
+	if len(s.Buckets) == 0 {
+		return console.Colorize("Policy", "No bucket matches `"+s.Pattern+"`")
+	}
+	buckets := make([]string, 0, len(s.Buckets))
+	for bucket := range s.Buckets {
+		buckets = append(buckets, bucket)
+	}
+	sort.Strings(buckets)
+	lines := make([]string, 0, len(buckets))
+	for _, bucket := range buckets {
+		lines = append(lines, console.Colorize("Policy",
+			"Access permission for `"+bucket+"`"+" is `"+string(s.Buckets[bucket])+"`"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// JSON jsonified access message.
+func (s policyGlobMessage) JSON() string {
+	s.Status = "success"
+	policyJSONBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(policyJSONBytes)
+}
+
+// splitBucketGlob splits targetURL into its alias, bucket and prefix,
+// ok is true when the bucket name is a wildcard pattern.
+func splitBucketGlob(targetURL string) (alias, bucket, prefix string, ok bool) {
+	alias, urlPath := url2Alias(targetURL)
+	bucket = strings.TrimPrefix(filepath.ToSlash(urlPath), "/")
+	if i := strings.Index(bucket, "/"); i >= 0 {
+		bucket, prefix = bucket[:i], bucket[i+1:]
+	}
+	return alias, bucket, prefix, alias != "" && strings.ContainsAny(bucket, "*?")
+}
+
+// Run policy get on all the buckets matching a wildcard pattern
+func runPolicyGlobGetCmd(targetURL, alias, bucketPattern, prefix string) {
+	ctx, cancelPolicy := context.WithCancel(globalContext)
+	defer cancelPolicy()
+
+	clnt, err := newClient(alias)
+	fatalIf(err.Trace(alias), "Unable to initialize target `"+alias+"`.")
+
+	buckets := make(map[string]accessPerms)
+	for content := range clnt.List(ctx, ListOptions{ShowDir: DirNone}) {
+		if content.Err != nil {
+			errorIf(content.Err.Trace(alias), "Unable to list buckets of `"+alias+"`.")
+			continue
+		}
+		bucket := strings.Trim(filepath.ToSlash(content.URL.Path), "/")
+		if !wildcard.Match(bucketPattern, bucket) {
+			continue
+		}
+		bucketURL := path.Join(alias, bucket, prefix)
+		perms, _, err := doGetAccess(ctx, bucketURL)
+		if err != nil {
+			errorIf(err.Trace(bucketURL), "Unable to get policy of `"+bucketURL+"`.")
+			continue
+		}
+		buckets[bucketURL] = perms
+	}
+
+	printMsg(policyGlobMessage{
+		Pattern: targetURL,
+		Buckets: buckets,
+	})
+}
+
+// policyGetJSONOptions controls how get-json outputs the policy.
+type policyGetJSONOptions struct {
+	outputFile     string
+	compact, force bool
 }
 
 // Run policy cmd to fetch set permission
-func runPolicyCmd(args cli.Args) {
+func runPolicyCmd(args cli.Args, getJSONOpts policyGetJSONOptions, auditLog string) {
 	ctx, cancelPolicy := context.WithCancel(globalContext)
 	defer cancelPolicy()
 
@@ -325,13 +578,19 @@ func runPolicyCmd(args cli.Args) {
 	targetURL := args.Get(2)
 	if perms.isValidAccessPERM() {
 		operation = "set"
+		audit := newPolicyAudit(ctx, auditLog, operation, targetURL)
 		probeErr = doSetAccess(ctx, targetURL, perms)
 		if probeErr == nil {
+			audit.record(ctx)
 			perms, _, probeErr = doGetAccess(ctx, targetURL)
 		}
 	} else if perms.isValidAccessFile() {
-		probeErr = doSetAccessJSON(ctx, targetURL, perms)
 		operation = "set-json"
+		audit := newPolicyAudit(ctx, auditLog, operation, targetURL)
+		probeErr = doSetAccessJSON(ctx, targetURL, perms)
+		if probeErr == nil {
+			audit.record(ctx)
+		}
 	} else {
 		targetURL = args.Get(1)
 		operation = "get"
@@ -356,12 +615,18 @@ func runPolicyCmd(args cli.Args) {
 		e := json.Unmarshal([]byte(policyStr), &policyJSON)
 		fatalIf(probe.NewError(e), "Unable to unmarshal custom policy file.")
 	}
+	if operation == "get-json" && getJSONOpts.outputFile != "" {
+		probeErr = writePolicyFile(getJSONOpts.outputFile, marshalPolicy(policyJSON, getJSONOpts.compact), getJSONOpts.force)
+		fatalIf(probeErr.Trace(getJSONOpts.outputFile), "Unable to save policy of `"+targetURL+"`.")
+	}
 	printMsg(policyMessage{
-		Status:    "success",
-		Operation: operation,
-		Bucket:    targetURL,
-		Perms:     perms,
-		Policy:    policyJSON,
+		Status:     "success",
+		Operation:  operation,
+		Bucket:     targetURL,
+		Perms:      perms,
+		Policy:     policyJSON,
+		OutputFile: getJSONOpts.outputFile,
+		compact:    getJSONOpts.compact,
 	})
 }
 
@@ -371,20 +636,47 @@ func mainPolicy(ctx *cli.Context) error {
 
 	// Additional command speific theme customization.
 	console.SetColor("Policy", color.New(color.FgGreen, color.Bold))
+	console.SetColor("PolicyTestAllow", color.New(color.FgGreen, color.Bold))
+	console.SetColor("PolicyTestDeny", color.New(color.FgRed, color.Bold))
+	console.SetColor("PolicyPrefix", color.New(color.FgCyan))
+	console.SetColor("PolicyPerm", color.New(color.FgGreen, color.Bold))
 
 	switch ctx.Args().First() {
-	case "set", "set-json", "get", "get-json":
+	case "set", "set-json":
 		// policy set [download|upload|public|none] alias/bucket/prefix
 		// policy set-json path-to-policy-json-file alias/bucket/prefix
+		fatalIfReadOnly("policy " + ctx.Args().First())
+		runPolicyCmd(ctx.Args(), policyGetJSONOptions{}, ctx.String("audit-log"))
+	case "get", "get-json":
+		// policy get alias/bucket-pattern/prefix
+		if ctx.Args().First() == "get" {
+			targetURL := ctx.Args().Get(1)
+			if ctx.Bool("recursive") {
+				// policy get --recursive alias/bucket/prefix
+				runPolicyRecursiveGetCmd(targetURL, "policy")
+				return nil
+			}
+			if alias, bucket, prefix, ok := splitBucketGlob(targetURL); ok {
+				runPolicyGlobGetCmd(targetURL, alias, bucket, prefix)
+				return nil
+			}
+		}
 		// policy get alias/bucket/prefix
 		// policy get-json alias/bucket/prefix
-		runPolicyCmd(ctx.Args())
+		runPolicyCmd(ctx.Args(), policyGetJSONOptions{
+			outputFile: ctx.String("output-file"),
+			compact:    ctx.Bool("compact"),
+			force:      ctx.Bool("force"),
+		}, "")
 	case "list":
 		// policy list alias/bucket/prefix
 		runPolicyListCmd(ctx.Args().Tail())
 	case "links":
 		// policy links alias/bucket/prefix
-		runPolicyLinksCmd(ctx.Args().Tail(), ctx.Bool("recursive"))
+		runPolicyLinksCmd(ctx.Args().Tail(), ctx.Bool("recursive"), ctx.Bool("no-dedup"))
+	case "test":
+		// policy test alias/bucket/object --action s3:GetObject
+		runPolicyTestCmd(ctx.Args().Get(1), ctx.String("action"), ctx.String("principal"))
 	default:
 		// Shows command example and exit
 		cli.ShowCommandHelpAndExit(ctx, "policy", 1)
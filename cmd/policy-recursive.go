@@ -0,0 +1,200 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7/pkg/policy"
+	"github.com/minio/pkg/console"
+)
+
+// policyPrefixesMessage is container for the effective permission of
+// a prefix and of all its sub-prefixes.
+type policyPrefixesMessage struct {
+	messageBase
+	Status      string                 `json:"status"`
+	URL         string                 `json:"url"`
+	Permissions map[string]accessPerms `json:"permissions"`
+}
+
+// String colorized access map, a sub-prefix is indented below its parent.
+func (s policyPrefixesMessage) String() string {
+	prefixes := make([]string, 0, len(s.Permissions))
+	for prefix := range s.Permissions {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	root := strings.Count(strings.TrimSuffix(s.URL, "/"), "/")
+	lines := make([]string, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		depth := strings.Count(strings.TrimSuffix(prefix, "/"), "/") - root
+		if depth < 0 {
+			depth = 0
+		}
+		lines = append(lines, strings.Repeat("  ", depth)+
+			console.Colorize("PolicyPrefix", "`"+prefix+"`")+" => "+
+			console.Colorize("PolicyPerm", string(s.Permissions[prefix])))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// JSON jsonified access map.
+func (s policyPrefixesMessage) JSON() string {
+	s.Status = "success"
+	policyJSONBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(policyJSONBytes)
+}
+
+// walkSubPrefixes returns the prefixes below prefix, prefix itself
+// excluded. listLevel returns the prefixes directly below a prefix, so
+// only one level is listed at a time and the objects are not walked.
+func walkSubPrefixes(prefix string, listLevel func(prefix string) []string) []string {
+	var prefixes []string
+	for level := []string{prefix}; len(level) > 0; {
+		var next []string
+		for _, levelPrefix := range level {
+			next = append(next, listLevel(levelPrefix)...)
+		}
+		prefixes = append(prefixes, next...)
+		level = next
+	}
+	sort.Strings(prefixes)
+	return prefixes
+}
+
+// Read and write access granted by a canned permission.
+var cannedPermAccess = map[string]int{
+	string(policy.BucketPolicyReadOnly):  1,
+	string(policy.BucketPolicyWriteOnly): 2,
+	string(policy.BucketPolicyReadWrite): 3,
+}
+
+// unionPerms returns the canned permission granting the access of both perms.
+func unionPerms(perm1, perm2 string) string {
+	// The access granted by a custom permission is unknown.
+	if perm1 == "custom" || perm2 == "custom" {
+		return "custom"
+	}
+	access := cannedPermAccess[perm1] | cannedPermAccess[perm2]
+	for perm, permAccess := range cannedPermAccess {
+		if permAccess == access {
+			return perm
+		}
+	}
+	return string(policy.BucketPolicyNone)
+}
+
+// effectivePrefixPerms returns the permission of each sub-prefix of
+// prefix, whose own permission is perm. A sub-prefix inherits the
+// access of its parent, e.g. download on images/ is effective on
+// images/2022/ as well, and a custom parent keeps it custom.
+func effectivePrefixPerms(policyStr, bucket, prefix, perm string, subPrefixes []string) (map[string]string, error) {
+	perms := make(map[string]string, len(subPrefixes))
+	parentPerm := func(subPrefix string) string {
+		for parent := path.Dir(strings.TrimSuffix(subPrefix, "/")); parent != "." && parent != "/"; parent = path.Dir(parent) {
+			if p, ok := perms[parent+"/"]; ok {
+				return p
+			}
+		}
+		return perm
+	}
+	// Sorted, a parent is evaluated before its sub-prefixes.
+	for _, subPrefix := range subPrefixes {
+		subPerm, e := bucketPolicyPerm(policyStr, bucket, subPrefix)
+		if e != nil {
+			return nil, e
+		}
+		// No canned statement for the sub-prefix itself, its access is
+		// only inherited.
+		if subPerm == "custom" {
+			subPerm = string(policy.BucketPolicyNone)
+		}
+		perms[subPrefix] = unionPerms(subPerm, parentPerm(subPrefix))
+	}
+	return perms, nil
+}
+
+// Run policy get on a prefix and on all its sub-prefixes, the bucket
+// policy is fetched once and evaluated for each prefix.
+func runPolicyRecursiveGetCmd(targetURL, cmdName string) {
+	ctx, cancelPolicy := context.WithCancel(globalContext)
+	defer cancelPolicy()
+
+	clnt, err := newClient(targetURL)
+	fatalIf(err.Trace(targetURL), "Unable to initialize target `"+targetURL+"`.")
+	if clnt.GetURL().Type != objectStorage {
+		fatalIf(errInvalidArgument().Trace(targetURL), "Unable to get "+cmdName+" of a non S3 url `"+targetURL+"` recursively.")
+	}
+
+	perm, policyStr, err := clnt.GetAccess(ctx)
+	if err != nil {
+		switch err.ToGoError().(type) {
+		case APINotImplemented:
+			fatalIf(err.Trace(), "Unable to get "+cmdName+" of a non S3 url `"+targetURL+"`.")
+		default:
+			fatalIf(err.Trace(targetURL), "Unable to get "+cmdName+" for `"+targetURL+"`.")
+		}
+	}
+
+	clntURL := clnt.GetURL()
+	bucket, prefix := url2BucketAndObject(&clntURL)
+	alias, _ := url2Alias(targetURL)
+
+	listLevel := func(levelPrefix string) []string {
+		levelURL := path.Join(alias, bucket, levelPrefix) + "/"
+		levelClnt, err := newClient(levelURL)
+		if err != nil {
+			errorIf(err.Trace(levelURL), "Unable to list `"+levelURL+"`.")
+			return nil
+		}
+		var prefixes []string
+		for content := range levelClnt.List(ctx, ListOptions{ShowDir: DirFirst}) {
+			if content.Err != nil {
+				errorIf(content.Err.Trace(levelURL), "Unable to list `"+levelURL+"`.")
+				continue
+			}
+			if !content.Type.IsDir() {
+				continue
+			}
+			_, key := url2BucketAndObject(&content.URL)
+			prefixes = append(prefixes, strings.TrimSuffix(key, "/")+"/")
+		}
+		return prefixes
+	}
+
+	perms, e := effectivePrefixPerms(policyStr, bucket, prefix, perm, walkSubPrefixes(prefix, listLevel))
+	fatalIf(probe.NewError(e).Trace(targetURL), "Unable to evaluate the policy of `"+targetURL+"`.")
+
+	msg := policyPrefixesMessage{
+		URL:         targetURL,
+		Permissions: map[string]accessPerms{targetURL: stringToAccessPerm(perm)},
+	}
+	for subPrefix, subPerm := range perms {
+		msg.Permissions[path.Join(alias, bucket, subPrefix)+"/"] = stringToAccessPerm(subPerm)
+	}
+	printMsg(msg)
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/minio-go/v7/pkg/policy"
+)
+
+func TestWalkSubPrefixes(t *testing.T) {
+	tree := map[string][]string{
+		"":             {"images/", "docs/"},
+		"images/":      {"images/2022/"},
+		"images/2022/": {"images/2022/q1/"},
+	}
+	var listed []string
+	listLevel := func(prefix string) []string {
+		listed = append(listed, prefix)
+		return tree[prefix]
+	}
+	expected := []string{"docs/", "images/", "images/2022/", "images/2022/q1/"}
+	if got := walkSubPrefixes("", listLevel); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	// Each prefix is listed once, one level at a time.
+	expected = []string{"", "images/", "docs/", "images/2022/", "images/2022/q1/"}
+	if !reflect.DeepEqual(listed, expected) {
+		t.Fatalf("expected the levels %v to be listed, got %v", expected, listed)
+	}
+	if got := walkSubPrefixes("docs/", listLevel); len(got) != 0 {
+		t.Fatalf("expected no sub-prefixes, got %v", got)
+	}
+}
+
+func TestUnionPerms(t *testing.T) {
+	testCases := []struct {
+		perm1, perm2, expected string
+	}{
+		{"readonly", "writeonly", "readwrite"},
+		{"readonly", "none", "readonly"},
+		{"none", "none", "none"},
+		{"custom", "readonly", "custom"},
+		{"readwrite", "custom", "custom"},
+		{"custom", "none", "custom"},
+	}
+	for i, testCase := range testCases {
+		if got := unionPerms(testCase.perm1, testCase.perm2); got != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestEffectivePrefixPerms(t *testing.T) {
+	p := policy.BucketAccessPolicy{Version: "2012-10-17"}
+	p.Statements = policy.SetPolicy(p.Statements, policy.BucketPolicyReadOnly, "shared", "images/")
+	p.Statements = policy.SetPolicy(p.Statements, policy.BucketPolicyReadWrite, "shared", "images/uploads/")
+	policyBytes, e := json.Marshal(p)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	perms, e := effectivePrefixPerms(string(policyBytes), "shared", "", "none",
+		[]string{"docs/", "images/", "images/2022/", "images/uploads/", "images/uploads/tmp/"})
+	if e != nil {
+		t.Fatal(e)
+	}
+	expected := map[string]string{
+		"docs/":               "none",
+		"images/":             "readonly",
+		"images/2022/":        "readonly",
+		"images/uploads/":     "readwrite",
+		"images/uploads/tmp/": "readwrite",
+	}
+	if !reflect.DeepEqual(perms, expected) {
+		t.Fatalf("expected %v, got %v", expected, perms)
+	}
+
+	if perms, e = effectivePrefixPerms("", "shared", "", "none", []string{"docs/"}); e != nil || perms["docs/"] != "none" {
+		t.Fatalf("expected none without a policy, got %v, %v", perms, e)
+	}
+
+	if perms, e = effectivePrefixPerms(string(policyBytes), "shared", "", "custom", []string{"images/"}); e != nil || perms["images/"] != "custom" {
+		t.Fatalf("expected custom to be inherited, got %v, %v", perms, e)
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/bucket/policy"
+	"github.com/minio/pkg/console"
+)
+
+// Effects reported by a policy test.
+const (
+	policyTestAllow = "Allow"
+	policyTestDeny  = "Deny"
+)
+
+// policyTestResult is the outcome of the evaluation of a bucket policy
+// for a request, along with the statement deciding it. No statement
+// decides an implicit deny.
+type policyTestResult struct {
+	Effect    string
+	Index     int
+	Statement *policy.Statement
+}
+
+// evaluateBucketPolicy evaluates p for a request like the server does:
+// an explicit deny wins over any allow, a request no statement allows
+// is denied.
+func evaluateBucketPolicy(p policy.Policy, args policy.Args) policyTestResult {
+	for i, statement := range p.Statements {
+		if statement.Effect == policy.Deny && !statement.IsAllowed(args) {
+			return policyTestResult{Effect: policyTestDeny, Index: i, Statement: &p.Statements[i]}
+		}
+	}
+	for i, statement := range p.Statements {
+		if statement.Effect == policy.Allow && statement.IsAllowed(args) {
+			return policyTestResult{Effect: policyTestAllow, Index: i, Statement: &p.Statements[i]}
+		}
+	}
+	return policyTestResult{Effect: policyTestDeny, Index: -1}
+}
+
+// policyTestMessage container for the outcome of a policy test.
+type policyTestMessage struct {
+	messageBase
+	Status         string            `json:"status"`
+	Target         string            `json:"target"`
+	Action         string            `json:"action"`
+	Principal      string            `json:"principal"`
+	Effect         string            `json:"effect"`
+	StatementIndex int               `json:"statementIndex"`
+	Statement      *policy.Statement `json:"statement,omitempty"`
+}
+
+func (p policyTestMessage) String() string {
+	principal := p.Principal
+	if principal == "" {
+		principal = "anonymous"
+	}
+	msg := fmt.Sprintf("%s: `%s` on `%s` for %s", p.Effect, p.Action, p.Target, principal)
+	if p.Statement == nil {
+		return console.Colorize("PolicyTestDeny", msg+", no statement allows it.")
+	}
+	statement := fmt.Sprintf("#%d", p.StatementIndex)
+	if p.Statement.SID != "" {
+		statement += " (" + string(p.Statement.SID) + ")"
+	}
+	msg += ", by statement " + statement + ":"
+	statementBytes, e := json.Marshal(p.Statement)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return console.Colorize("PolicyTest"+p.Effect, msg) + "\n" + string(statementBytes)
+}
+
+func (p policyTestMessage) JSON() string {
+	p.Status = "success"
+	msgBytes, e := json.MarshalIndent(p, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// checkPolicyTestAction fails when action is not a known S3 action.
+func checkPolicyTestAction(action string) {
+	if action == "" {
+		fatalIf(errInvalidArgument().Trace(), "--action is required to test the access to an object, e.g. --action s3:GetObject.")
+	}
+	if !policy.Action(action).IsValid() {
+		fatalIf(errInvalidArgument().Trace(action), "Unrecognized action `"+action+"`.")
+	}
+}
+
+// runPolicyTestCmd evaluates the policy of the bucket of targetURL for
+// action on targetURL by principal, anonymous when empty.
+func runPolicyTestCmd(targetURL, action, principal string) {
+	ctx, cancelPolicyTest := context.WithCancel(globalContext)
+	defer cancelPolicyTest()
+
+	clnt, err := newClient(targetURL)
+	fatalIf(err.Trace(targetURL), "Unable to initialize `"+targetURL+"`.")
+	clntURL := clnt.GetURL()
+	bucket, object := url2BucketAndObject(&clntURL)
+
+	_, policyStr, err := clnt.GetAccess(ctx)
+	fatalIf(err.Trace(targetURL), "Unable to get the bucket policy of `"+targetURL+"`.")
+
+	var p policy.Policy
+	if policyStr != "" {
+		parsed, e := policy.ParseConfig(strings.NewReader(policyStr), bucket)
+		fatalIf(probe.NewError(e).Trace(targetURL), "Unable to parse the bucket policy of `"+targetURL+"`.")
+		p = *parsed
+	}
+
+	result := evaluateBucketPolicy(p, policy.Args{
+		AccountName:     principal,
+		Action:          policy.Action(action),
+		BucketName:      bucket,
+		ObjectName:      object,
+		ConditionValues: map[string][]string{},
+	})
+	printMsg(policyTestMessage{
+		Target:         targetURL,
+		Action:         action,
+		Principal:      principal,
+		Effect:         result.Effect,
+		StatementIndex: result.Index,
+		Statement:      result.Statement,
+	})
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/minio/pkg/bucket/policy"
+)
+
+func TestEvaluateBucketPolicy(t *testing.T) {
+	p, e := policy.ParseConfig(strings.NewReader(`{
+ "Version": "2012-10-17",
+ "Statement": [
+  {"Effect": "Allow", "Principal": {"AWS": ["*"]}, "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::bucket/public/*"]},
+  {"Sid": "secrets", "Effect": "Deny", "Principal": {"AWS": ["*"]}, "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::bucket/public/secret*"]}
+ ]
+}`), "bucket")
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	testCases := []struct {
+		action, object string
+		effect         string
+		index          int
+	}{
+		{"s3:GetObject", "public/photo.png", policyTestAllow, 0},
+		{"s3:GetObject", "public/secret.txt", policyTestDeny, 1},
+		{"s3:GetObject", "private/photo.png", policyTestDeny, -1},
+		{"s3:PutObject", "public/photo.png", policyTestDeny, -1},
+	}
+	for i, testCase := range testCases {
+		result := evaluateBucketPolicy(*p, policy.Args{
+			Action:          policy.Action(testCase.action),
+			BucketName:      "bucket",
+			ObjectName:      testCase.object,
+			ConditionValues: map[string][]string{},
+		})
+		if result.Effect != testCase.effect || result.Index != testCase.index {
+			t.Fatalf("Test %d: expected %s by statement %d, got %s by statement %d", i+1, testCase.effect, testCase.index, result.Effect, result.Index)
+		}
+		if (result.Statement == nil) != (testCase.index == -1) {
+			t.Fatalf("Test %d: unexpected statement %v", i+1, result.Statement)
+		}
+	}
+}
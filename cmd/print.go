@@ -20,8 +20,11 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
+	"regexp"
 	"strings"
 
+	"github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/console"
 )
 
@@ -31,6 +34,29 @@ type message interface {
 	String() string
 }
 
+// jsonSchemaVersion is the schema version reported by messages embedding
+// messageBase, bump it whenever the JSON fields of those messages change.
+const jsonSchemaVersion = "2"
+
+// schemaVersion always marshals to the current jsonSchemaVersion.
+type schemaVersion struct{}
+
+// MarshalJSON - implements json.Marshaler interface.
+func (schemaVersion) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonSchemaVersion)
+}
+
+// UnmarshalJSON - implements json.Unmarshaler interface, the version is informational.
+func (*schemaVersion) UnmarshalJSON([]byte) error {
+	return nil
+}
+
+// messageBase is the common envelope embedded in structured messages,
+// it lets --json consumers detect the schema version of the output.
+type messageBase struct {
+	Version schemaVersion `json:"version"`
+}
+
 // printMsg prints message string or JSON structure depending on the type of output console.
 func printMsg(msg message) {
 	var msgStr string
@@ -38,6 +64,12 @@ func printMsg(msg message) {
 		msgStr = msg.String()
 	} else {
 		msgStr = msg.JSON()
+		if globalQuery != nil {
+			var ok bool
+			if msgStr, ok = queryJSON(msgStr); !ok {
+				return
+			}
+		}
 		if globalJSONLine && strings.ContainsRune(msgStr, '\n') {
 			// Reformat.
 			var dst bytes.Buffer
@@ -48,3 +80,29 @@ func printMsg(msg message) {
 	}
 	console.Println(msgStr)
 }
+
+// ansiColorCodes matches the color sequences of colorized JSON output.
+var ansiColorCodes = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// queryJSON applies the --query expression to a JSON message, it
+// returns false when the expression selects nothing to print.
+func queryJSON(msgStr string) (string, bool) {
+	var data interface{}
+	if e := json.Unmarshal([]byte(ansiColorCodes.ReplaceAllString(msgStr, "")), &data); e != nil {
+		return msgStr, true
+	}
+	result, e := globalQuery.Search(data)
+	if e != nil {
+		errorIf(probe.NewError(e), "Unable to apply --query.")
+		return "", false
+	}
+	if result == nil {
+		return "", false
+	}
+	buf, e := colorjson.MarshalIndent(result, "", " ")
+	if e != nil {
+		errorIf(probe.NewError(e), "Unable to marshal --query result.")
+		return "", false
+	}
+	return string(buf), true
+}
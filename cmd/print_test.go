@@ -0,0 +1,129 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/jmespath/go-jmespath"
+)
+
+func TestQueryJSON(t *testing.T) {
+	defer func(q *jmespath.JMESPath) { globalQuery = q }(globalQuery)
+
+	msg := "{\x1b[32m\"status\"\x1b[0m:\"success\",\"key\":\"a.txt\",\"size\":3,\"tags\":[\"x\",\"y\"]}"
+	testCases := []struct {
+		query    string
+		expected string
+		ok       bool
+	}{
+		{"key", `"a.txt"`, true},
+		{"{k:key,s:size}", `{"k":"a.txt","s":3}`, true},
+		{"tags[1]", `"y"`, true},
+		{"missing", "", false},
+		{"size > `1`", "true", true},
+		{"abs(key)", "", false},
+	}
+	for _, tc := range testCases {
+		jp, err := jmespath.Compile(tc.query)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.query, err)
+		}
+		globalQuery = jp
+		got, ok := queryJSON(msg)
+		if ok != tc.ok {
+			t.Fatalf("%s: expected ok %v, got %v", tc.query, tc.ok, ok)
+		}
+		if got != tc.expected {
+			t.Fatalf("%s: expected %s, got %s", tc.query, tc.expected, got)
+		}
+	}
+}
+
+// jsonFields returns the sorted JSON field names of typ, with those of
+// its embedded structs.
+func jsonFields(typ reflect.Type) []string {
+	var fields []string
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if f.Anonymous && name == "" {
+			fields = append(fields, jsonFields(f.Type)...)
+			continue
+		}
+		if f.PkgPath != "" || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// TestJSONSchemaVersion fails when the JSON fields of a message carrying
+// the schema version change, jsonSchemaVersion has to be bumped with the
+// fields expected here.
+func TestJSONSchemaVersion(t *testing.T) {
+	const expectedVersion = "2"
+	if jsonSchemaVersion != expectedVersion {
+		t.Fatalf("expected schema version %s, got %s, update the expected fields along", expectedVersion, jsonSchemaVersion)
+	}
+	versionJSON, e := json.Marshal(messageBase{})
+	if e != nil || string(versionJSON) != `{"version":"`+expectedVersion+`"}` {
+		t.Fatalf("unexpected schema version %s, %v", versionJSON, e)
+	}
+
+	testCases := []struct {
+		msg    interface{}
+		fields string
+	}{
+		{quotaMessage{}, "bucket,currentQuota,currentType,dryRun,quota,status,type,version"},
+		{quotaAllMessage{}, "buckets,status,version"},
+		{ilmAddMessage{}, "id,status,target,version"},
+		{ilmEditMessage{}, "id,status,target,version"},
+		{ilmExportMessage{}, "config,status,target,version"},
+		{ilmImportMessage{}, "status,target,version"},
+		{ilmListMessage{}, "config,status,target,version"},
+		{ilmRmMessage{}, "all,id,status,target,version"},
+		{legalHoldInfoMessage{}, "error,key,legalhold,status,urlpath,version,versionID"},
+		{legalHoldCmdMessage{}, "error,key,legalhold,status,urlpath,version,versionID"},
+		{policyMessage{}, "bucket,operation,outputFile,permission,policy,status,version"},
+		{policyLinksMessage{}, "status,url,version"},
+		{policyLinksSummaryMessage{}, "status,totalObjects,unique,version"},
+		{policyGlobMessage{}, "buckets,pattern,status,version"},
+		{policyPrefixesMessage{}, "permissions,status,url,version"},
+		{policyTestMessage{}, "action,effect,principal,statement,statementIndex,status,target,version"},
+		{retentionCmdMessage{}, "error,mode,op,status,urlpath,validity,version,versionID"},
+		{retentionSummaryMessage{}, "count,op,status,urlpath,version"},
+		{retentionBucketMessage{}, "enabled,mode,op,status,validity,version"},
+		{retentionInfoMessage{}, "error,mode,status,until,urlpath,version,versionID"},
+	}
+	for _, testCase := range testCases {
+		typ := reflect.TypeOf(testCase.msg)
+		if fields := strings.Join(jsonFields(typ), ","); fields != testCase.fields {
+			t.Errorf("%s: the JSON fields changed from %s to %s, bump jsonSchemaVersion", typ.Name(), testCase.fields, fields)
+		}
+	}
+}
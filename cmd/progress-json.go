@@ -0,0 +1,144 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	json "github.com/minio/colorjson"
+)
+
+// Interval between two events of --progress-json.
+const progressJSONInterval = time.Second
+
+// progressJSONMessage is a progress event of an ongoing transfer, Speed
+// is the rate in bytes per second since the previous event.
+type progressJSONMessage struct {
+	Status      string    `json:"status"`
+	Time        time.Time `json:"time"`
+	Total       int64     `json:"total"`
+	Transferred int64     `json:"transferred"`
+	Object      string    `json:"object,omitempty"`
+	Speed       float64   `json:"speed"`
+}
+
+// progressJSON writes a progress event per line to w every
+// progressJSONInterval until finished. A nil *progressJSON
+// does nothing, so that callers need not check whether
+// --progress-json is set.
+type progressJSON struct {
+	// Keep this as first element of struct because it guarantees 64bit
+	// alignment on 32 bit machines. atomic.* functions crash if operand is not
+	// aligned at 64bit. See https://github.com/golang/go/issues/599
+	total int64
+
+	w           io.Writer
+	transferred func() int64
+	object      atomic.Value
+
+	lastTime        time.Time
+	lastTransferred int64
+
+	finishOnce sync.Once
+	doneCh     chan struct{}
+	wg         sync.WaitGroup
+}
+
+// newProgressJSON starts writing the progress events of a transfer to w,
+// transferred returns the number of bytes transferred so far.
+func newProgressJSON(w io.Writer, transferred func() int64) *progressJSON {
+	p := &progressJSON{
+		w:           w,
+		transferred: transferred,
+		lastTime:    time.Now(),
+		doneCh:      make(chan struct{}),
+	}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(progressJSONInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.doneCh:
+				return
+			case <-ticker.C:
+				p.write()
+			}
+		}
+	}()
+	return p
+}
+
+// SetTotal sets the number of bytes of the transfer.
+func (p *progressJSON) SetTotal(n int64) {
+	if p != nil {
+		atomic.StoreInt64(&p.total, n)
+	}
+}
+
+// AddTotal adds n bytes to the transfer.
+func (p *progressJSON) AddTotal(n int64) {
+	if p != nil {
+		atomic.AddInt64(&p.total, n)
+	}
+}
+
+// SetObject sets the object being transferred.
+func (p *progressJSON) SetObject(name string) {
+	if p != nil {
+		p.object.Store(name)
+	}
+}
+
+// Finish stops the periodic events and writes the last one.
+func (p *progressJSON) Finish() {
+	if p == nil {
+		return
+	}
+	p.finishOnce.Do(func() {
+		close(p.doneCh)
+		p.wg.Wait()
+		p.write()
+	})
+}
+
+// write writes the current progress event, it is not called concurrently.
+func (p *progressJSON) write() {
+	now := time.Now()
+	msg := progressJSONMessage{
+		Status:      "progress",
+		Time:        now.UTC(),
+		Total:       atomic.LoadInt64(&p.total),
+		Transferred: p.transferred(),
+	}
+	msg.Object, _ = p.object.Load().(string)
+	if elapsed := now.Sub(p.lastTime); elapsed > 0 && msg.Transferred > p.lastTransferred {
+		msg.Speed = float64(msg.Transferred-p.lastTransferred) / elapsed.Seconds()
+	}
+	p.lastTime, p.lastTransferred = now, msg.Transferred
+
+	line, e := json.Marshal(msg)
+	if e != nil {
+		return
+	}
+	p.w.Write(append(line, '\n'))
+}
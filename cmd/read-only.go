@@ -0,0 +1,160 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/minio/cli"
+)
+
+// mutatingCommands lists the commands which always modify data or
+// configuration, on the server or in the mc configuration. Commands which
+// only mutate depending on their arguments (cp, mv, mirror, od, policy,
+// anonymous, admin bucket quota, admin scanner speed, admin heal, support
+// callhome, support logs) call fatalIfReadOnly themselves.
+var mutatingCommands = []string{
+	"alias set",
+	"alias remove",
+	"alias import",
+	"config host add",
+	"config host remove",
+	"config host import",
+	"mb",
+	"rb",
+	"rm",
+	"pipe",
+	"undo",
+	"retention set",
+	"retention clear",
+	"legalhold set",
+	"legalhold clear",
+	"version enable",
+	"version suspend",
+	"ilm add",
+	"ilm edit",
+	"ilm rm",
+	"ilm import",
+	"ilm restore",
+	"encrypt set",
+	"encrypt clear",
+	"event add",
+	"event remove",
+	"tag set",
+	"tag remove",
+	"replicate add",
+	"replicate edit",
+	"replicate rm",
+	"replicate import",
+	"resync start",
+	"service restart",
+	"service stop",
+	"service freeze",
+	"service unfreeze",
+	"admin update",
+	"admin speedtest",
+	"support perf",
+	"support register",
+	"subnet register",
+	"profile start",
+	"profile stop",
+	"user add",
+	"user disable",
+	"user enable",
+	"user remove",
+	"svcacct add",
+	"svcacct rm",
+	"svcacct edit",
+	"svcacct enable",
+	"svcacct disable",
+	"group add",
+	"group remove",
+	"group enable",
+	"group disable",
+	"policy add",
+	"policy remove",
+	"policy set",
+	"policy unset",
+	"policy update",
+	"config set",
+	"config reset",
+	"config restore",
+	"config import",
+	"decommission start",
+	"decommission cancel",
+	"rebalance start",
+	"rebalance stop",
+	"replicate remove",
+	"kms key create",
+	"remote add",
+	"remote edit",
+	"remote rm",
+	"tier add",
+	"tier edit",
+	"tier rm",
+	"cluster bucket import",
+}
+
+// commandPath returns the command path without the application name,
+// e.g. "admin user add" for `mc admin user add`.
+func commandPath(ctx *cli.Context) string {
+	path := ctx.Command.Name
+	if appNames := strings.SplitN(ctx.App.Name, " ", 2); len(appNames) == 2 {
+		path = appNames[1] + " " + path
+	}
+	return path
+}
+
+// isMutatingCommand returns true if the command path ends with one of the
+// mutating commands.
+func isMutatingCommand(path string) bool {
+	for _, name := range mutatingCommands {
+		if path == name || strings.HasSuffix(path, " "+name) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkReadOnly refuses to run a mutating command when mc runs in read-only mode.
+func checkReadOnly(ctx *cli.Context) {
+	if !globalReadOnly || ctx.Command.Name == "" {
+		return
+	}
+	if path := commandPath(ctx); isMutatingCommand(path) {
+		fatalIfReadOnly(path)
+	}
+}
+
+// fatalIfReadOnly refuses to run the operation when mc runs in read-only mode.
+func fatalIfReadOnly(operation string) {
+	if globalReadOnly {
+		fatalIf(errReadOnly(operation).Trace(operation), "Unable to run `"+operation+"`.")
+	}
+}
+
+// fatalIfReadOnlyURL refuses to run the operation when mc runs in read-only
+// mode and the passed url points to a remote alias.
+func fatalIfReadOnlyURL(operation, aliasedURL string) {
+	if !globalReadOnly {
+		return
+	}
+	if _, _, hostCfg := mustExpandAlias(aliasedURL); hostCfg != nil {
+		fatalIfReadOnly(operation)
+	}
+}
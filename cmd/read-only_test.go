@@ -0,0 +1,115 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/minio/cli"
+)
+
+// readOnlyGuardedCommands call fatalIfReadOnly themselves, depending on
+// their arguments.
+var readOnlyGuardedCommands = map[string]bool{
+	"cp":                  true,
+	"mv":                  true,
+	"mirror":              true,
+	"od":                  true,
+	"anonymous":           true,
+	"policy":              true,
+	"admin bucket quota":  true,
+	"admin scanner speed": true,
+	"admin heal":          true,
+	"support callhome":    true,
+	"support logs":        true,
+}
+
+// readOnlySafeCommands never modify data or configuration. `update`
+// replaces the mc binary only.
+var readOnlySafeCommands = []string{
+	"alias list", "alias test",
+	"ls", "cat", "head", "find", "sql",
+	"stat", "tree", "du", "diff", "verify",
+	"watch", "ping", "update",
+	"retention info", "legalhold info", "version info",
+	"ilm ls", "ilm export", "encrypt info", "event list",
+	"tag list", "share download", "share upload", "share list",
+	"replicate ls", "replicate status", "replicate resync status", "replicate export",
+	"support diag", "support inspect", "support profile",
+	"admin info", "admin inspect", "admin health", "admin subnet health",
+	"admin user list", "admin user info", "admin user policy",
+	"admin user svcacct ls", "admin user svcacct info",
+	"admin group info", "admin group list",
+	"admin policy list", "admin policy info",
+	"admin replicate info", "admin replicate status",
+	"admin config get", "admin config history", "admin config export",
+	"admin decommission status", "admin rebalance status", "admin scanner status",
+	"admin prometheus generate", "admin prometheus metrics",
+	"admin kms key list", "admin kms key status",
+	"admin bucket remote ls", "admin bucket remote bandwidth",
+	"admin tier ls", "admin tier verify", "admin tier info",
+	"admin top api", "admin top locks", "admin trace", "admin console", "admin logs",
+	"admin cluster bucket export",
+	"config host list", "config host export",
+}
+
+// walkCommands calls fn with the path of every command which has no
+// subcommands.
+func walkCommands(prefix string, cmds []cli.Command, fn func(path string)) {
+	for _, cmd := range cmds {
+		path := cmd.Name
+		if prefix != "" {
+			path = prefix + " " + cmd.Name
+		}
+		if len(cmd.Subcommands) > 0 {
+			walkCommands(path, cmd.Subcommands, fn)
+			continue
+		}
+		fn(path)
+	}
+}
+
+// Every command must be known to be mutating, guarded or safe, so that
+// new commands are not overlooked by the read-only mode.
+func TestReadOnlyCommandTree(t *testing.T) {
+	safe := map[string]bool{}
+	for _, path := range readOnlySafeCommands {
+		safe[path] = true
+	}
+	matched := map[string]bool{}
+	walkCommands("", appCmds, func(path string) {
+		mutating := isMutatingCommand(path)
+		for _, name := range mutatingCommands {
+			if path == name || strings.HasSuffix(path, " "+name) {
+				matched[name] = true
+			}
+		}
+		switch {
+		case mutating && (readOnlyGuardedCommands[path] || safe[path]):
+			t.Errorf("`%s` is both in mutatingCommands and known not to always mutate", path)
+		case !mutating && !readOnlyGuardedCommands[path] && !safe[path]:
+			t.Errorf("`%s` is not classified for read-only mode, add it to mutatingCommands or guard it with fatalIfReadOnly", path)
+		}
+	})
+	for _, name := range mutatingCommands {
+		if !matched[name] {
+			t.Errorf("mutatingCommands entry `%s` matches no command", name)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Name of the file in the config folder persisting bucket regions.
+const regionCacheFile = "region-cache.json"
+
+// regionCacheEntry is the region of a bucket, valid until Expires.
+type regionCacheEntry struct {
+	Region  string    `json:"region"`
+	Expires time.Time `json:"expires"`
+}
+
+// regionCache persists the regions of buckets across mc runs, within a
+// run the clients shared by S3New already resolve a bucket region once.
+// It is neither read nor updated with --no-region-cache.
+type regionCache struct {
+	mutex   sync.Mutex
+	loaded  bool
+	entries map[string]regionCacheEntry
+}
+
+var globalRegionCache = &regionCache{}
+
+// load reads the cache file once, a missing or invalid file is an empty cache.
+func (r *regionCache) load() {
+	if r.loaded {
+		return
+	}
+	r.loaded = true
+	r.entries = make(map[string]regionCacheEntry)
+	if data, e := os.ReadFile(filepath.Join(mustGetMcConfigDir(), regionCacheFile)); e == nil {
+		json.Unmarshal(data, &r.entries)
+	}
+}
+
+// Get returns the unexpired region cached for bucket on host.
+func (r *regionCache) Get(host, bucket string) (string, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.load()
+	entry, ok := r.entries[host+"/"+bucket]
+	if !ok || UTCNow().After(entry.Expires) {
+		return "", false
+	}
+	return entry.Region, true
+}
+
+// Set caches the region of bucket on host for maxAge and saves the cache,
+// dropping the expired entries. Failing to save only loses the cache.
+func (r *regionCache) Set(host, bucket, region string, maxAge time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.load()
+	now := UTCNow()
+	for k, entry := range r.entries {
+		if now.After(entry.Expires) {
+			delete(r.entries, k)
+		}
+	}
+	r.entries[host+"/"+bucket] = regionCacheEntry{Region: region, Expires: now.Add(maxAge)}
+
+	data, e := json.Marshal(r.entries)
+	if e != nil {
+		return
+	}
+	cacheFile := filepath.Join(mustGetMcConfigDir(), regionCacheFile)
+	tmpFile := cacheFile + ".tmp"
+	if e = os.WriteFile(tmpFile, data, 0o600); e == nil {
+		os.Rename(tmpFile, cacheFile)
+	}
+}
+
+// regionCacheTransport persists the regions of the buckets resolved by
+// the GetBucketLocation requests a client makes before its first request
+// to each bucket, so that persisting them costs no additional round-trip.
+type regionCacheTransport struct {
+	http.RoundTripper
+	host string
+}
+
+// RoundTrip records the region answered to a GetBucketLocation request.
+func (t *regionCacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, e := t.RoundTripper.RoundTrip(req)
+	if e != nil || resp.StatusCode != http.StatusOK || req.Method != http.MethodGet {
+		return resp, e
+	}
+	if _, ok := req.URL.Query()["location"]; !ok {
+		return resp, e
+	}
+	bucket := strings.Trim(req.URL.Path, "/")
+	if bucket == "" {
+		// Virtual host style requests, e.g. to Aliyun OSS.
+		bucket = strings.TrimSuffix(req.URL.Host, "."+t.host)
+		if bucket == req.URL.Host {
+			return resp, e
+		}
+	}
+
+	data, e := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+	if e != nil {
+		return resp, nil
+	}
+	var location string
+	if xml.Unmarshal(data, &location) != nil {
+		return resp, nil
+	}
+	switch location {
+	case "":
+		location = "us-east-1"
+	case "EU":
+		location = "eu-west-1"
+	}
+	globalRegionCache.Set(t.host, bucket, location, globalRegionMaxAge)
+	return resp, nil
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRegionCache(t *testing.T) {
+	withTempMcConfig(t)
+
+	(&regionCache{}).Set("s3.amazonaws.com", "bucket", "eu-west-1", time.Hour)
+	(&regionCache{}).Set("s3.amazonaws.com", "expired", "us-west-2", -time.Second)
+
+	// A new cache reads the regions saved by the previous ones.
+	r := &regionCache{}
+	if region, ok := r.Get("s3.amazonaws.com", "bucket"); !ok || region != "eu-west-1" {
+		t.Fatalf("expected eu-west-1, got %q (%t)", region, ok)
+	}
+	if region, ok := r.Get("s3.amazonaws.com", "expired"); ok {
+		t.Fatalf("expected no region for an expired entry, got %q", region)
+	}
+	if region, ok := r.Get("play.min.io", "bucket"); ok {
+		t.Fatalf("expected no region for another host, got %q", region)
+	}
+}
+
+func TestRegionCacheRoundTrips(t *testing.T) {
+	withTempMcConfig(t)
+	defer func(maxAge time.Duration, noCache bool, cache *regionCache) {
+		globalRegionMaxAge, globalNoRegionCache, globalRegionCache = maxAge, noCache, cache
+	}(globalRegionMaxAge, globalNoRegionCache, globalRegionCache)
+
+	var requests, lookups int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&requests, 1)
+		if _, ok := r.URL.Query()["location"]; ok {
+			atomic.AddInt64(&lookups, 1)
+			w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">eu-west-1</LocationConstraint>`))
+			return
+		}
+		if !strings.Contains(r.Header.Get("Authorization"), "/eu-west-1/") {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("MC_HOST_far", strings.Replace(server.URL, "http://", "http://minio:minio123@", 1))
+	t.Setenv("MC_REGION", "")
+
+	// run makes the single request of a short command in a new mc run
+	// and returns the number of requests and region lookups it made.
+	run := func() (int64, int64) {
+		globalRegionCache = &regionCache{}
+		S3New = newFactory()
+		atomic.StoreInt64(&requests, 0)
+		atomic.StoreInt64(&lookups, 0)
+		clnt, err := newClient("far/bucket")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err = clnt.GetAccess(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		return atomic.LoadInt64(&requests), atomic.LoadInt64(&lookups)
+	}
+	defer func() { S3New = newFactory() }()
+
+	testCases := []struct {
+		maxAge            time.Duration
+		noCache           bool
+		requests, lookups int64
+	}{
+		// Without a persisted region every run looks it up first.
+		{0, false, 2, 1},
+		{time.Hour, false, 2, 1},
+		// The region persisted by the previous run saves a round-trip.
+		{time.Hour, false, 1, 0},
+		{time.Hour, true, 2, 1},
+		{time.Hour, false, 1, 0},
+	}
+	for i, testCase := range testCases {
+		globalRegionMaxAge, globalNoRegionCache = testCase.maxAge, testCase.noCache
+		if requests, lookups := run(); requests != testCase.requests || lookups != testCase.lookups {
+			t.Errorf("Test %d: expected %d requests and %d lookups, got %d and %d",
+				i+1, testCase.requests, testCase.lookups, requests, lookups)
+		}
+	}
+
+	// Creating a client makes no request, the region is resolved lazily.
+	globalRegionMaxAge, globalNoRegionCache = time.Hour, false
+	globalRegionCache = &regionCache{}
+	S3New = newFactory()
+	atomic.StoreInt64(&requests, 0)
+	if _, err := newClient("far/other"); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt64(&requests); n != 0 {
+		t.Fatalf("expected no request creating a client, got %d", n)
+	}
+}
@@ -115,6 +115,12 @@ func checkReplicateAddSyntax(ctx *cli.Context) {
 	if ctx.String("remote-bucket") == "" {
 		fatal(errDummy().Trace(), "--remote-bucket flag needs to be specified.")
 	}
+	if arn := ctx.String("arn"); arn != "" {
+		fatalIf(probe.NewError(validateReplicationARN(arn)), "Invalid --arn flag.")
+	}
+	if remoteBucket := ctx.String("remote-bucket"); strings.HasPrefix(remoteBucket, "arn:") {
+		fatalIf(probe.NewError(validateReplicationARN(remoteBucket)), "Invalid --remote-bucket flag.")
+	}
 }
 
 type replicateAddMessage struct {
@@ -19,7 +19,9 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
@@ -93,6 +95,49 @@ func readReplicationConfig() (*replication.Config, *probe.Error) {
 	return &cfg, nil
 }
 
+// validateReplicationARN checks that arn is of the form
+// arn:partition:service:region:account-id:resource, e.g.
+// arn:minio:replication::<id>:<bucket> or arn:aws:s3:::<bucket>.
+func validateReplicationARN(arn string) error {
+	parts := strings.Split(arn, ":")
+	if len(parts) != 6 || parts[0] != "arn" || parts[1] == "" || parts[2] == "" || parts[5] == "" {
+		return fmt.Errorf("invalid ARN `%s`, expected arn:partition:service:region:account-id:resource", arn)
+	}
+	return nil
+}
+
+// validateReplicationConfig validates a replication configuration before
+// it is sent to the server, rules must be valid, have unique IDs and
+// priorities and replicate to a valid destination ARN.
+func validateReplicationConfig(cfg *replication.Config) error {
+	if cfg.Role != "" {
+		if e := validateReplicationARN(cfg.Role); e != nil {
+			return e
+		}
+	}
+	ids := make(map[string]bool, len(cfg.Rules))
+	priorities := make(map[int]string, len(cfg.Rules))
+	for _, rule := range cfg.Rules {
+		if e := rule.Validate(); e != nil {
+			return fmt.Errorf("rule `%s`: %w", rule.ID, e)
+		}
+		if rule.ID != "" {
+			if ids[rule.ID] {
+				return fmt.Errorf("rule ID `%s` is not unique", rule.ID)
+			}
+			ids[rule.ID] = true
+		}
+		if id, ok := priorities[rule.Priority]; ok {
+			return fmt.Errorf("rules `%s` and `%s` have the same priority %d, priority must be unique", id, rule.ID, rule.Priority)
+		}
+		priorities[rule.Priority] = rule.ID
+		if e := validateReplicationARN(rule.Destination.Bucket); e != nil {
+			return fmt.Errorf("rule `%s`: %w", rule.ID, e)
+		}
+	}
+	return nil
+}
+
 func mainReplicateImport(cliCtx *cli.Context) error {
 	ctx, cancelReplicateImport := context.WithCancel(globalContext)
 	defer cancelReplicateImport()
@@ -108,6 +153,7 @@ func mainReplicateImport(cliCtx *cli.Context) error {
 	fatalIf(err, "Unable to initialize connection.")
 	rCfg, err := readReplicationConfig()
 	fatalIf(err.Trace(args...), "Unable to read replication configuration")
+	fatalIf(probe.NewError(validateReplicationConfig(rCfg)).Trace(args...), "Invalid replication configuration")
 
 	fatalIf(client.SetReplication(ctx, rCfg, replication.Options{Op: replication.ImportOption}).Trace(aliasedURL), "Unable to set replication configuration")
 	printMsg(replicateImportMessage{
@@ -23,7 +23,6 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
-	"github.com/minio/minio-go/v7"
 	"github.com/minio/pkg/console"
 )
 
@@ -48,6 +47,10 @@ var retentionClearFlags = []cli.Flag{
 		Name:  "default",
 		Usage: "set default bucket locking",
 	},
+	cli.BoolFlag{
+		Name:  "bypass",
+		Usage: "bypass governance",
+	},
 }
 
 var retentionClearCmd = cli.Command{
@@ -66,29 +69,30 @@ USAGE:
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+  Clearing a governance retention bypasses it, pass --bypass as it is going to be required. A compliance retention cannot be cleared.
 
 EXAMPLES:
   1. Clear object retention for a specific object
-     $ {{.HelpName}} myminio/mybucket/prefix/obj.csv
+     $ {{.HelpName}} myminio/mybucket/prefix/obj.csv --bypass
 
   2. Clear object retention for recursively for all objects at a given prefix
-     $ {{.HelpName}} myminio/mybucket/prefix --recursive
+     $ {{.HelpName}} myminio/mybucket/prefix --recursive --bypass
 
   3. Clear object retention for a specific version of a specific object
-     $ {{.HelpName}} myminio/mybucket/prefix/obj.csv --version-id "3Jr2x6fqlBUsVzbvPihBO3HgNpgZgAnp"
+     $ {{.HelpName}} myminio/mybucket/prefix/obj.csv --version-id "3Jr2x6fqlBUsVzbvPihBO3HgNpgZgAnp" --bypass
 
   4. Clear object retention for recursively for all versions of all objects
-     $ {{.HelpName}} myminio/mybucket/prefix --recursive --versions
+     $ {{.HelpName}} myminio/mybucket/prefix --recursive --versions --bypass
 
   5. Clear object retention for recursively for all versions created one year ago
-     $ {{.HelpName}} myminio/mybucket/prefix --recursive --versions --rewind 365d
+     $ {{.HelpName}} myminio/mybucket/prefix --recursive --versions --rewind 365d --bypass
 
   6. Clear a bucket retention configuration
      $ {{.HelpName}} --default myminio/mybucket/
 `,
 }
 
-func parseClearRetentionArgs(cliCtx *cli.Context) (target, versionID string, timeRef time.Time, withVersions, recursive, bucketMode bool) {
+func parseClearRetentionArgs(cliCtx *cli.Context) (target, versionID string, timeRef time.Time, withVersions, recursive, bypass, bucketMode bool) {
 	args := cliCtx.Args()
 
 	if len(args) != 1 {
@@ -104,18 +108,20 @@ func parseClearRetentionArgs(cliCtx *cli.Context) (target, versionID string, tim
 	timeRef = parseRewindFlag(cliCtx.String("rewind"))
 	withVersions = cliCtx.Bool("versions")
 	recursive = cliCtx.Bool("recursive")
+	bypass = cliCtx.Bool("bypass")
 	bucketMode = cliCtx.Bool("default")
 
-	if bucketMode && (versionID != "" || !timeRef.IsZero() || withVersions || recursive) {
-		fatalIf(errDummy(), "--default cannot be specified with any of --version-id, --rewind, --versions or --recursive.")
+	if bucketMode && (versionID != "" || !timeRef.IsZero() || withVersions || recursive || bypass) {
+		fatalIf(errDummy(), "--default cannot be specified with any of --version-id, --rewind, --versions, --recursive or --bypass.")
 	}
 
 	return
 }
 
-// Clear Retention for one object/version or many objects within a given prefix, bypass governance is always enabled
-func clearRetention(ctx context.Context, target, versionID string, timeRef time.Time, withOlderVersions, isRecursive bool) error {
-	return applyRetention(ctx, lockOpClear, target, versionID, timeRef, withOlderVersions, isRecursive, "", 0, minio.Days, true)
+// Clear Retention for one object/version or many objects within a given prefix, bypassing
+// governance retention when bypassGovernance is set.
+func clearRetention(ctx context.Context, target, versionID string, timeRef time.Time, withOlderVersions, isRecursive, bypassGovernance bool) error {
+	return applyRetention(ctx, lockOpClear, target, versionID, timeRef, withOlderVersions, isRecursive, "", time.Time{}, bypassGovernance)
 }
 
 func clearBucketLock(urlStr string) error {
@@ -130,7 +136,7 @@ func mainRetentionClear(cliCtx *cli.Context) error {
 	console.SetColor("RetentionSuccess", color.New(color.FgGreen, color.Bold))
 	console.SetColor("RetentionFailure", color.New(color.FgYellow))
 
-	target, versionID, rewind, withVersions, recursive, bucketMode := parseClearRetentionArgs(cliCtx)
+	target, versionID, rewind, withVersions, recursive, bypass, bucketMode := parseClearRetentionArgs(cliCtx)
 
 	fatalIfBucketLockNotEnabled(ctx, target)
 
@@ -142,5 +148,12 @@ func mainRetentionClear(cliCtx *cli.Context) error {
 		rewind = time.Now().UTC()
 	}
 
-	return clearRetention(ctx, target, versionID, rewind, withVersions, recursive)
+	// Clearing has always bypassed governance retention, keep doing so
+	// until --bypass is required.
+	if !bypass {
+		warning("Clearing retention without --bypass is deprecated, governance retention is bypassed but --bypass is going to be required in a future release.")
+		bypass = true
+	}
+
+	return clearRetention(ctx, target, versionID, rewind, withVersions, recursive, bypass)
 }
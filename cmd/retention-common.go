@@ -31,6 +31,7 @@ import (
 
 // Structured message depending on the type of console.
 type retentionCmdMessage struct {
+	messageBase
 	Op        lockOpType          `json:"op"`
 	Mode      minio.RetentionMode `json:"mode"`
 	Validity  string              `json:"validity"`
@@ -72,6 +73,32 @@ func (m retentionCmdMessage) JSON() string {
 	return string(msgBytes)
 }
 
+// Structured message summarizing a retention operation on many objects.
+type retentionSummaryMessage struct {
+	messageBase
+	Op      lockOpType `json:"op"`
+	URLPath string     `json:"urlpath"`
+	Count   int64      `json:"count"`
+	Status  string     `json:"status"`
+}
+
+// Colorized message for console printing.
+func (m retentionSummaryMessage) String() string {
+	ed := ""
+	if m.Op == lockOpClear {
+		ed = "ed"
+	}
+	return console.Colorize("RetentionSuccess",
+		fmt.Sprintf("Object retention successfully %s%s for %d object(s) under `%s`.", m.Op, ed, m.Count, m.URLPath))
+}
+
+// JSON'ified message for scripting.
+func (m retentionSummaryMessage) JSON() string {
+	msgBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
 type lockOpType string
 
 const (
@@ -82,6 +109,7 @@ const (
 
 // Structured message depending on the type of console.
 type retentionBucketMessage struct {
+	messageBase
 	Op       lockOpType          `json:"op"`
 	Enabled  string              `json:"enabled"`
 	Mode     minio.RetentionMode `json:"mode"`
@@ -170,6 +198,33 @@ func parseRetentionValidity(validityStr string) (uint64, minio.ValidityUnit, *pr
 	return validity, unit, nil
 }
 
+// parseRetentionUntil returns the retain until date of a validity formatted
+// like Nd or Ny from now, or of a date formatted like 2006-01-02 or RFC3339.
+func parseRetentionUntil(validityStr string, now time.Time) (time.Time, *probe.Error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		until, e := time.Parse(layout, validityStr)
+		if e != nil {
+			continue
+		}
+		if !until.After(now) {
+			return time.Time{}, probe.NewError(fmt.Errorf("retain until date '%s' is not in the future", validityStr))
+		}
+		return until.UTC(), nil
+	}
+
+	validity, unit, err := parseRetentionValidity(validityStr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if validity == 0 {
+		return time.Time{}, probe.NewError(fmt.Errorf("invalid validity '%v'", validity))
+	}
+	if unit == minio.Years {
+		return now.AddDate(int(validity), 0, 0), nil
+	}
+	return now.AddDate(0, 0, int(validity)), nil
+}
+
 func fatalIfBucketLockNotEnabled(ctx context.Context, aliasedURL string) {
 	enabled, err := getBucketLockStatus(ctx, aliasedURL)
 	fatalIf(err.Trace(), "Unable to get bucket lock configuration from `%s`", aliasedURL)
@@ -180,7 +235,7 @@ func fatalIfBucketLockNotEnabled(ctx context.Context, aliasedURL string) {
 
 // Apply Retention for one object/version or many objects within a given prefix.
 func applyRetention(ctx context.Context, op lockOpType, target, versionID string, timeRef time.Time, withOlderVersions, isRecursive bool,
-	mode minio.RetentionMode, validity uint64, unit minio.ValidityUnit, bypassGovernance bool,
+	mode minio.RetentionMode, until time.Time, bypassGovernance bool,
 ) error {
 	clnt, err := newClient(target)
 	if err != nil {
@@ -194,19 +249,6 @@ func applyRetention(ctx context.Context, op lockOpType, target, versionID string
 		fatal(errDummy().Trace(), "Retention is supported only for S3 servers.")
 	}
 
-	var until time.Time
-	if mode != "" {
-		timeStr, err := getRetainUntilDate(validity, unit)
-		if err != nil {
-			return err.ToGoError()
-		}
-		var e error
-		until, e = time.Parse(time.RFC3339, timeStr)
-		if e != nil {
-			return e
-		}
-	}
-
 	alias, urlStr, _ := mustExpandAlias(target)
 	if versionID != "" || !isRecursive && !withOlderVersions {
 		err := setRetentionSingle(ctx, op, alias, urlStr, versionID, mode, until, bypassGovernance)
@@ -222,7 +264,7 @@ func applyRetention(ctx context.Context, op lockOpType, target, versionID string
 	}
 
 	var cErr error
-	var atLeastOneRetentionApplied bool
+	var retentionApplied int64
 
 	for content := range clnt.List(ctx, lstOptions) {
 		if content.Err != nil {
@@ -246,12 +288,19 @@ func applyRetention(ctx context.Context, op lockOpType, target, versionID string
 			continue
 		}
 
-		atLeastOneRetentionApplied = true
+		retentionApplied++
 	}
 
-	if !atLeastOneRetentionApplied {
+	if retentionApplied == 0 {
 		errorIf(errDummy().Trace(clnt.GetURL().String()), "Unable to find any object/version to "+string(op)+" its retention.")
 		cErr = exitStatus(globalErrorExitStatus) // Set the exit status.
+	} else {
+		printMsg(retentionSummaryMessage{
+			Op:      op,
+			URLPath: target,
+			Count:   retentionApplied,
+			Status:  "success",
+		})
 	}
 
 	return cErr
@@ -0,0 +1,50 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRetentionUntil(t *testing.T) {
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		validity string
+		until    time.Time
+		success  bool
+	}{
+		{"30d", now.AddDate(0, 0, 30), true},
+		{"2Y", now.AddDate(2, 0, 0), true},
+		{"2030-12-31", time.Date(2030, time.December, 31, 0, 0, 0, 0, time.UTC), true},
+		{"2030-12-31T15:04:05+02:00", time.Date(2030, time.December, 31, 13, 4, 5, 0, time.UTC), true},
+		{"2021-12-31", time.Time{}, false},
+		{"0d", time.Time{}, false},
+		{"10w", time.Time{}, false},
+		{"d", time.Time{}, false},
+	}
+	for i, testCase := range testCases {
+		until, err := parseRetentionUntil(testCase.validity, now)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %v, got error %v", i+1, testCase.success, err)
+		}
+		if !until.Equal(testCase.until) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.until, until)
+		}
+	}
+}
@@ -56,6 +56,7 @@ var retentionInfoFlags = []cli.Flag{
 
 var retentionInfoCmd = cli.Command{
 	Name:         "info",
+	Aliases:      []string{"get"},
 	Usage:        "show retention for object(s)",
 	Action:       mainRetentionInfo,
 	OnUsageError: onUsageError,
@@ -65,7 +66,7 @@ var retentionInfoCmd = cli.Command{
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} [FLAGS] [governance | compliance] VALIDITY TARGET
+  {{.HelpName}} [FLAGS] TARGET
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -116,6 +117,7 @@ func parseInfoRetentionArgs(cliCtx *cli.Context) (target, versionID string, recu
 
 // Structured message depending on the type of console.
 type retentionInfoMessage struct {
+	messageBase
 	Mode      minio.RetentionMode `json:"mode"`
 	Until     time.Time           `json:"until"`
 	URLPath   string              `json:"urlpath"`
@@ -54,6 +54,10 @@ var retentionSetFlags = []cli.Flag{
 		Name:  "default",
 		Usage: "set bucket default retention mode",
 	},
+	cli.BoolFlag{
+		Name:  "yes, force",
+		Usage: "confirm a recursive retention set operation",
+	},
 }
 
 var retentionSetCmd = cli.Command{
@@ -74,26 +78,34 @@ FLAGS:
   {{end}}
 VALIDITY:
   This argument must be formatted like Nd or Ny where 'd' denotes days and 'y' denotes years e.g. 10d, 3y.
+  A retain until date may be given instead, formatted like 2006-01-02 or RFC3339 e.g. 2030-01-02T15:04:05Z,
+  except with --default.
 
 EXAMPLES:
   1. Set object retention for a specific object
      $ {{.HelpName}} compliance 30d myminio/mybucket/prefix/obj.csv
 
   2. Set object retention for recursively for all objects at a given prefix
-     $ {{.HelpName}} governance 30d myminio/mybucket/prefix --recursive
+     $ {{.HelpName}} governance 30d myminio/mybucket/prefix --recursive --yes
 
   3. Set object retention to a specific version of a specific object
      $ {{.HelpName}} governance 30d myminio/mybucket/prefix/obj.csv --version-id "3Jr2x6fqlBUsVzbvPihBO3HgNpgZgAnp"
 
   4. Set object retention for recursively for all versions of all objects
-     $ {{.HelpName}} governance 30d myminio/mybucket/prefix --recursive --versions
+     $ {{.HelpName}} governance 30d myminio/mybucket/prefix --recursive --versions --yes
 
   5. Set default lock retention configuration for a bucket
      $ {{.HelpName}} --default governance 30d myminio/mybucket/
+
+  6. Shorten the governance retention of all current objects at a given prefix
+     $ {{.HelpName}} governance 7d myminio/mybucket/prefix --recursive --bypass --yes
+
+  7. Retain a specific object in compliance mode until the end of 2030
+     $ {{.HelpName}} compliance 2030-12-31 myminio/mybucket/prefix/obj.csv
 `,
 }
 
-func parseSetRetentionArgs(cliCtx *cli.Context) (target, versionID string, recursive bool, timeRef time.Time, withVersions bool, mode minio.RetentionMode, validity uint64, unit minio.ValidityUnit, bypass, bucketMode bool) {
+func parseSetRetentionArgs(cliCtx *cli.Context) (target, versionID string, recursive bool, timeRef time.Time, withVersions bool, mode minio.RetentionMode, validity uint64, unit minio.ValidityUnit, until time.Time, bypass, bucketMode bool) {
 	args := cliCtx.Args()
 	if len(args) != 3 {
 		cli.ShowCommandHelpAndExit(cliCtx, "set", 1)
@@ -104,10 +116,6 @@ func parseSetRetentionArgs(cliCtx *cli.Context) (target, versionID string, recur
 		fatalIf(errInvalidArgument().Trace(args...), "invalid retention mode '%v'", mode)
 	}
 
-	var err *probe.Error
-	validity, unit, err = parseRetentionValidity(args[1])
-	fatalIf(err.Trace(args[1]), "invalid validity argument")
-
 	target = args[2]
 	if target == "" {
 		fatalIf(errInvalidArgument().Trace(), "invalid target url '%v'", target)
@@ -124,14 +132,28 @@ func parseSetRetentionArgs(cliCtx *cli.Context) (target, versionID string, recur
 		fatalIf(errDummy(), "--default cannot be specified with any of --version-id, --rewind, --versions, --recursive, --bypass.")
 	}
 
+	var err *probe.Error
+	if bucketMode {
+		// A bucket default retention is a duration, never a date.
+		validity, unit, err = parseRetentionValidity(args[1])
+	} else {
+		until, err = parseRetentionUntil(args[1], UTCNow())
+	}
+	fatalIf(err.Trace(args[1]), "invalid validity argument")
+
+	if recursive && !cliCtx.Bool("yes") {
+		fatalIf(errDummy().Trace(target),
+			"Setting retention recursively requires --yes flag. This operation applies to every object under `%s`, please review carefully.", target)
+	}
+
 	return
 }
 
 // Set Retention for one object/version or many objects within a given prefix.
 func setRetention(ctx context.Context, target, versionID string, timeRef time.Time, withOlderVersions, isRecursive bool,
-	mode minio.RetentionMode, validity uint64, unit minio.ValidityUnit, bypassGovernance bool,
+	mode minio.RetentionMode, until time.Time, bypassGovernance bool,
 ) error {
-	return applyRetention(ctx, lockOpSet, target, versionID, timeRef, withOlderVersions, isRecursive, mode, validity, unit, bypassGovernance)
+	return applyRetention(ctx, lockOpSet, target, versionID, timeRef, withOlderVersions, isRecursive, mode, until, bypassGovernance)
 }
 
 func setBucketLock(urlStr string, mode minio.RetentionMode, validity uint64, unit minio.ValidityUnit) error {
@@ -146,7 +168,7 @@ func mainRetentionSet(cliCtx *cli.Context) error {
 	console.SetColor("RetentionSuccess", color.New(color.FgGreen, color.Bold))
 	console.SetColor("RetentionFailure", color.New(color.FgYellow))
 
-	target, versionID, recursive, rewind, withVersions, mode, validity, unit, bypass, bucketMode := parseSetRetentionArgs(cliCtx)
+	target, versionID, recursive, rewind, withVersions, mode, validity, unit, until, bypass, bucketMode := parseSetRetentionArgs(cliCtx)
 
 	fatalIfBucketLockNotEnabled(ctx, target)
 
@@ -158,5 +180,5 @@ func mainRetentionSet(cliCtx *cli.Context) error {
 		rewind = time.Now().UTC()
 	}
 
-	return setRetention(ctx, target, versionID, rewind, withVersions, recursive, mode, validity, unit, bypass)
+	return setRetention(ctx, target, versionID, rewind, withVersions, recursive, mode, until, bypass)
 }
@@ -0,0 +1,154 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sync"
+	"sync/atomic"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// rmBatchSize is the maximum number of keys removed by a single
+// multi-object delete request.
+const rmBatchSize = 1000
+
+// Structured message reporting the outcome of a removal batch.
+type rmBatchMessage struct {
+	Status  string `json:"status"`
+	Batch   int64  `json:"batch"`
+	Removed int64  `json:"removed"`
+	Failed  int64  `json:"failed"`
+}
+
+// Colorized message for console printing.
+func (r rmBatchMessage) String() string {
+	msg := fmt.Sprintf("Batch %d: removed %d object(s)", r.Batch, r.Removed)
+	if r.Failed > 0 {
+		msg += fmt.Sprintf(", failed to remove %d object(s)", r.Failed)
+	}
+	return console.Colorize("Remove", msg+".")
+}
+
+// JSON'ified message for scripting.
+func (r rmBatchMessage) JSON() string {
+	r.Status = "success"
+	msgBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+type rmBatch struct {
+	id       int64
+	contents []*ClientContent
+}
+
+// batchRemover removes the contents sent to contentCh in batches of
+// rmBatchSize keys, formed in listing order, with up to workers batches
+// being removed concurrently. Successful removals are sent to resultCh
+// while failures are reported and counted without stopping the removal.
+type batchRemover struct {
+	clnt        Client
+	targetAlias string
+	opts        removeOpts
+
+	contentCh chan *ClientContent
+	resultCh  chan RemoveResult
+	failed    int64
+}
+
+func newBatchRemover(ctx context.Context, clnt Client, targetAlias string, opts removeOpts) *batchRemover {
+	r := &batchRemover{
+		clnt:        clnt,
+		targetAlias: targetAlias,
+		opts:        opts,
+		contentCh:   make(chan *ClientContent),
+		resultCh:    make(chan RemoveResult),
+	}
+
+	batchCh := make(chan rmBatch)
+	go func() {
+		defer close(batchCh)
+		batch := rmBatch{id: 1}
+		for content := range r.contentCh {
+			batch.contents = append(batch.contents, content)
+			if len(batch.contents) == rmBatchSize {
+				batchCh <- batch
+				batch = rmBatch{id: batch.id + 1}
+			}
+		}
+		if len(batch.contents) > 0 {
+			batchCh <- batch
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < opts.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batchCh {
+				r.removeBatch(ctx, batch)
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(r.resultCh)
+	}()
+
+	return r
+}
+
+// removeBatch removes all the contents of a batch and reports its outcome.
+func (r *batchRemover) removeBatch(ctx context.Context, batch rmBatch) {
+	contentCh := make(chan *ClientContent, len(batch.contents))
+	for _, content := range batch.contents {
+		contentCh <- content
+	}
+	close(contentCh)
+
+	msg := rmBatchMessage{Batch: batch.id}
+	for result := range r.clnt.Remove(ctx, r.opts.isIncomplete, false, r.opts.isBypass, false, contentCh) {
+		if result.Err != nil {
+			msg.Failed++
+			path := path.Join(r.targetAlias, result.BucketName, result.ObjectName)
+			errorIf(result.Err.Trace(path), rmFailureMessage(path, result.Err, r.opts.isBypass))
+			continue
+		}
+		msg.Removed++
+		select {
+		case r.resultCh <- result:
+		case <-ctx.Done():
+			return
+		}
+	}
+	atomic.AddInt64(&r.failed, msg.Failed)
+	printMsg(msg)
+}
+
+// failures returns the number of objects which could not be removed, it
+// is only accurate once resultCh is closed.
+func (r *batchRemover) failures() int64 {
+	return atomic.LoadInt64(&r.failed)
+}
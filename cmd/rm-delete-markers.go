@@ -0,0 +1,160 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// Structured message summarizing the removal of delete markers.
+type rmDeleteMarkersSummaryMessage struct {
+	Status  string `json:"status"`
+	URL     string `json:"url"`
+	Removed int64  `json:"removed"`
+	Failed  int64  `json:"failed"`
+}
+
+// Colorized message for console printing.
+func (r rmDeleteMarkersSummaryMessage) String() string {
+	msg := fmt.Sprintf("Removed %d delete marker(s) in `%s`", r.Removed, r.URL)
+	if r.Failed > 0 {
+		msg += fmt.Sprintf(", failed to remove %d delete marker(s)", r.Failed)
+	}
+	return console.Colorize("Remove", msg+".")
+}
+
+// JSON'ified message for scripting.
+func (r rmDeleteMarkersSummaryMessage) JSON() string {
+	r.Status = "success"
+	msgBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// isDeleteMarkerToRemove tells if a listed version is a delete marker
+// selected by the --older-than and --newer-than filters.
+func isDeleteMarkerToRemove(content *ClientContent, opts removeOpts) bool {
+	if !content.IsDeleteMarker || content.Time.IsZero() {
+		return false
+	}
+	// Skip delete markers older than --older-than parameter, if specified
+	if opts.olderThan != "" && isOlder(content.Time, opts.olderThan) {
+		return false
+	}
+	// Skip delete markers newer than --newer-than parameter, if specified
+	if opts.newerThan != "" && isNewer(content.Time, opts.newerThan) {
+		return false
+	}
+	return true
+}
+
+// removeDeleteMarkers lists all versions under url and removes only the
+// delete markers by their version ID, leaving the object versions intact.
+func removeDeleteMarkers(url string, opts removeOpts) error {
+	ctx, cancelRemove := context.WithCancel(globalContext)
+	defer cancelRemove()
+
+	targetAlias, targetURL, _ := mustExpandAlias(url)
+	clnt, pErr := newClientFromAlias(targetAlias, targetURL)
+	if pErr != nil {
+		errorIf(pErr.Trace(url), "Failed to remove delete markers in `"+url+"`.")
+		return exitStatus(globalErrorExitStatus)
+	}
+	if clnt.GetURL().Type != objectStorage {
+		errorIf(errDummy().Trace(url), "Delete markers can only be removed on object storage, `"+url+"` is not.")
+		return exitStatus(globalErrorExitStatus)
+	}
+
+	listOpts := ListOptions{
+		Recursive:         true,
+		WithOlderVersions: true,
+		WithDeleteMarkers: true,
+		TimeRef:           opts.timeRef,
+		ShowDir:           DirNone,
+	}
+
+	contentCh := make(chan *ClientContent)
+	var listErr *probe.Error
+	go func() {
+		defer close(contentCh)
+		for content := range clnt.List(ctx, listOpts) {
+			if content.Err != nil {
+				switch content.Err.ToGoError().(type) {
+				case PathInsufficientPermission:
+					errorIf(content.Err.Trace(url), "Failed to list `"+url+"`.")
+					continue
+				}
+				listErr = content.Err
+				return
+			}
+			// rm command is not supposed to remove buckets, ignore if this is a bucket name
+			if strings.LastIndex(content.URL.Path, string(content.URL.Separator)) == 0 {
+				continue
+			}
+			if !isDeleteMarkerToRemove(content, opts) {
+				continue
+			}
+			if opts.isFake {
+				printDryRunMsg(content)
+				continue
+			}
+			opts.ops.wait(ctx)
+			select {
+			case contentCh <- content:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	summary := rmDeleteMarkersSummaryMessage{URL: url}
+	isRemoveBucket := false
+	for result := range clnt.Remove(ctx, false, isRemoveBucket, opts.isBypass, false, contentCh) {
+		path := path.Join(targetAlias, result.BucketName, result.ObjectName)
+		if result.Err != nil {
+			errorIf(result.Err.Trace(path), rmFailureMessage(path, result.Err, opts.isBypass))
+			summary.Failed++
+			continue
+		}
+		summary.Removed++
+		printMsg(rmMessage{
+			Key:       path,
+			VersionID: result.ObjectVersionID,
+		})
+	}
+
+	if listErr != nil {
+		errorIf(listErr.Trace(url), "Failed to remove delete markers in `"+url+"`.")
+		return exitStatus(globalErrorExitStatus)
+	}
+	if opts.isFake {
+		return nil
+	}
+	printMsg(summary)
+	if summary.Failed > 0 {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
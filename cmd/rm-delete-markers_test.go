@@ -0,0 +1,45 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsDeleteMarkerToRemove(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		name    string
+		content *ClientContent
+		opts    removeOpts
+		remove  bool
+	}{
+		{"delete marker", &ClientContent{IsDeleteMarker: true, Time: now}, removeOpts{}, true},
+		{"object version", &ClientContent{Time: now}, removeOpts{}, false},
+		{"prefix", &ClientContent{IsDeleteMarker: true}, removeOpts{}, false},
+		{"older than filter keeps recent", &ClientContent{IsDeleteMarker: true, Time: now}, removeOpts{olderThan: "7d"}, false},
+		{"older than filter removes old", &ClientContent{IsDeleteMarker: true, Time: now.Add(-10 * 24 * time.Hour)}, removeOpts{olderThan: "7d"}, true},
+		{"newer than filter keeps old", &ClientContent{IsDeleteMarker: true, Time: now.Add(-10 * 24 * time.Hour)}, removeOpts{newerThan: "7d"}, false},
+	}
+	for _, tc := range testCases {
+		if got := isDeleteMarkerToRemove(tc.content, tc.opts); got != tc.remove {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.remove, got)
+		}
+	}
+}
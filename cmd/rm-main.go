@@ -20,6 +20,7 @@ package cmd
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -28,6 +29,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
@@ -92,10 +94,23 @@ var (
 			Name:  "bypass",
 			Usage: "bypass governance",
 		},
+		cli.BoolFlag{
+			Name:  "bypass-governance",
+			Usage: "remove object(s) under governance retention with a warning, requires --force",
+		},
 		cli.BoolFlag{
 			Name:  "non-current",
 			Usage: "remove object(s) versions that are non-current",
 		},
+		cli.IntFlag{
+			Name:  "workers",
+			Value: 1,
+			Usage: "number of concurrent batches of up to 1000 objects removed by a recursive remove on object storage",
+		},
+		cli.BoolFlag{
+			Name:  "delete-markers",
+			Usage: "remove only the delete markers of a versioned bucket, requires --recursive and --force",
+		},
 		cli.BoolFlag{
 			Name:   "force-delete",
 			Usage:  "attempt a prefix force delete, requires confirmation please use with caution",
@@ -111,7 +126,7 @@ var rmCmd = cli.Command{
 	Action:       mainRm,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(rmFlags, ioFlags...), globalFlags...),
+	Flags:        append(append(append(rmFlags, opsRateFlag), ioFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -167,6 +182,21 @@ EXAMPLES:
   14. Perform a fake removal of object(s) versions that are non-current and older than 10 days. If top-level version is a delete 
   marker, this will also be deleted when --non-current flag is specified.
       {{.Prompt}} {{.HelpName}} s3/docs/ --recursive --force --versions --non-current --older-than 10d --dry-run
+
+  15. Abort incomplete uploads older than 7 days under the prefix 'louis' and report the space reclaimed.
+      {{.Prompt}} {{.HelpName}} --incomplete --recursive --force --older-than 7d s3/jazz-songs/louis/
+
+  16. Remove all objects under the prefix 'logs/2019' with 8 concurrent batch removals.
+      {{.Prompt}} {{.HelpName}} --recursive --force --workers 8 s3/archive/logs/2019/
+
+  17. Remove all delete markers under the prefix 'louis' of the versioned bucket 'jazz-songs', keeping the object versions.
+      {{.Prompt}} {{.HelpName}} --delete-markers --recursive --force s3/jazz-songs/louis/
+
+  18. Remove all objects under the prefix 'tmp' removing at most 200 objects per second.
+      {{.Prompt}} {{.HelpName}} --recursive --force --ops-rate 200 s3/jazz-songs/tmp/
+
+  19. Remove an object under governance retention, warning that its retention is bypassed.
+      {{.Prompt}} {{.HelpName}} --bypass-governance --force s3/pop-songs/song.mp3
 `,
 }
 
@@ -199,6 +229,34 @@ func (r rmMessage) JSON() string {
 	return string(msgBytes)
 }
 
+// Structured message summarizing the removal of incomplete uploads.
+type rmIncompleteSummaryMessage struct {
+	Status         string `json:"status"`
+	AbortedUploads int64  `json:"abortedUploads"`
+	ReclaimedBytes int64  `json:"reclaimedBytes"`
+	// Set when the parts of some uploads could not be summed, more
+	// bytes than ReclaimedBytes were reclaimed then.
+	Approximate bool `json:"approximate,omitempty"`
+}
+
+// Colorized message for console printing.
+func (r rmIncompleteSummaryMessage) String() string {
+	reclaimed := humanize.IBytes(uint64(r.ReclaimedBytes))
+	if r.Approximate {
+		reclaimed = "at least " + reclaimed
+	}
+	return console.Colorize("Remove", fmt.Sprintf("Aborted %d incomplete upload(s), reclaimed %s.",
+		r.AbortedUploads, reclaimed))
+}
+
+// JSON'ified message for scripting.
+func (r rmIncompleteSummaryMessage) JSON() string {
+	r.Status = "success"
+	msgBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
 // Validate command line arguments.
 func checkRmSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 	// Set command flags from context.
@@ -209,10 +267,23 @@ func checkRmSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string
 	isVersions := cliCtx.Bool("versions")
 	isNoncurrentVersion := cliCtx.Bool("non-current")
 	isForceDel := cliCtx.Bool("force-delete")
+	isBypassGovernance := cliCtx.Bool("bypass-governance")
 	versionID := cliCtx.String("version-id")
 	rewind := cliCtx.String("rewind")
+	workers := cliCtx.Int("workers")
+	isDeleteMarkers := cliCtx.Bool("delete-markers")
 	isNamespaceRemoval := false
 
+	if workers < 1 {
+		fatalIf(errDummy().Trace(),
+			"--workers should be at least 1.")
+	}
+
+	if workers > 1 && !(isRecursive || isVersions) {
+		fatalIf(errDummy().Trace(),
+			"You cannot specify --workers without --recursive or --versions.")
+	}
+
 	if versionID != "" && (isRecursive || isVersions || rewind != "") {
 		fatalIf(errDummy().Trace(),
 			"You cannot specify --version-id with any of --versions, --rewind and --recursive flags.")
@@ -223,6 +294,16 @@ func checkRmSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string
 			"You cannot specify --non-current without --versions --recursive, please use --non-current --versions --recursive.")
 	}
 
+	if isDeleteMarkers && !(isRecursive && isForce) {
+		fatalIf(errDummy().Trace(),
+			"You cannot specify --delete-markers without --recursive --force.")
+	}
+
+	if isDeleteMarkers && (isVersions || isNoncurrentVersion || versionID != "" || cliCtx.Bool("incomplete")) {
+		fatalIf(errDummy().Trace(),
+			"You cannot specify --delete-markers with any of --versions, --non-current, --version-id and --incomplete flags.")
+	}
+
 	if isForceDel && !isForce {
 		fatalIf(errDummy().Trace(),
 			"You cannot specify --force-delete without --force.")
@@ -233,6 +314,11 @@ func checkRmSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string
 			"You cannot specify --force-delete with --recursive.")
 	}
 
+	if isBypassGovernance && !isForce {
+		fatalIf(errDummy().Trace(),
+			"Bypassing governance retention requires --force flag. The objects are removed before their retention expires, please review carefully before performing this *DANGEROUS* operation.")
+	}
+
 	for _, url := range cliCtx.Args() {
 		// clean path for aliases like s3/.
 		// Note: UNC path using / works properly in go 1.9.2 even though it breaks the UNC specification.
@@ -270,6 +356,27 @@ func checkRmSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string
 	}
 }
 
+// isErrWORMProtected tells if the removal of an object was refused because
+// of its retention or legal hold, which MinIO reports with the error code
+// InvalidRequest and a WORM protected message.
+func isErrWORMProtected(err *probe.Error) bool {
+	errResp := minio.ToErrorResponse(err.ToGoError())
+	return errResp.Code == "InvalidRequest" && strings.Contains(errResp.Message, "WORM protected")
+}
+
+// rmFailureMessage returns the message reporting the failure to remove
+// path, explaining why an object under retention was refused.
+func rmFailureMessage(path string, err *probe.Error, isBypass bool) string {
+	msg := "Failed to remove `" + path + "`."
+	if !isErrWORMProtected(err) {
+		return msg
+	}
+	if isBypass {
+		return msg + " The object is under retention or legal hold, only governance retention can be bypassed and the bypass requires the s3:BypassGovernanceRetention permission."
+	}
+	return msg + " The object is under retention or legal hold, governance retention can be bypassed with --bypass-governance."
+}
+
 // Remove a single object or a single version in a versioned bucket
 func removeSingle(url, versionID string, opts removeOpts) error {
 	ctx, cancel := context.WithCancel(globalContext)
@@ -277,7 +384,8 @@ func removeSingle(url, versionID string, opts removeOpts) error {
 
 	var (
 		// A HEAD request can fail with:
-		// - 400 Bad Request when the object SSE-C
+		// - 400 Bad Request when the object SSE-C, reported as
+		//   ObjectSSECKeyRequired when confirmed
 		// - 405 Method Not Allowed  when this is a delete marker
 		// In those cases, we still want t remove the target object/version
 		// so we simply ignore them.
@@ -293,6 +401,9 @@ func removeSingle(url, versionID string, opts removeOpts) error {
 		case http.StatusBadRequest, http.StatusMethodNotAllowed:
 			ignoreStatError = true
 		default:
+			ignoreStatError = errors.As(pErr.ToGoError(), &ObjectSSECKeyRequired{})
+		}
+		if !ignoreStatError {
 			errorIf(pErr.Trace(url), "Failed to remove `"+url+"`.")
 			return exitStatus(globalErrorExitStatus)
 		}
@@ -337,7 +448,7 @@ func removeSingle(url, versionID string, opts removeOpts) error {
 		resultCh := clnt.Remove(ctx, opts.isIncomplete, isRemoveBucket, opts.isBypass, opts.isForce && opts.isForceDel, contentCh)
 		for result := range resultCh {
 			if result.Err != nil {
-				errorIf(result.Err.Trace(url), "Failed to remove `"+url+"`.")
+				errorIf(result.Err.Trace(url), rmFailureMessage(url, result.Err, opts.isBypass))
 				switch result.Err.ToGoError().(type) {
 				case PathInsufficientPermission:
 					// Ignore Permission error.
@@ -371,6 +482,8 @@ type removeOpts struct {
 	isForceDel        bool
 	olderThan         string
 	newerThan         string
+	workers           int
+	ops               *opsLimiter
 	encKeyDB          map[string][]prefixSSEPair
 }
 
@@ -410,7 +523,36 @@ func listAndRemove(url string, opts removeOpts) error {
 	}
 	atLeastOneObjectFound := false
 
-	resultCh := clnt.Remove(ctx, opts.isIncomplete, isRemoveBucket, opts.isBypass, false, contentCh)
+	// Number and sizes of the listed incomplete uploads of each object,
+	// to report the bytes reclaimed. All the uploads of an object are
+	// aborted at once, which are summed once the listing is done.
+	var uploadCounts, uploadSizes map[string]int64
+	var abortedPaths []string
+	sizesApproximate := false
+
+	// Objects under retention or legal hold do not stop the removal of
+	// the others, the command fails once all are removed.
+	wormRefused := false
+	if opts.isIncomplete {
+		uploadCounts = make(map[string]int64)
+		uploadSizes = make(map[string]int64)
+	}
+	accountAborted := func(path string) {
+		if uploadSizes != nil {
+			abortedPaths = append(abortedPaths, path)
+		}
+	}
+
+	// Remove in concurrent batches when asked to, except on file systems
+	// where folders must be removed strictly after their contents.
+	var remover *batchRemover
+	var resultCh <-chan RemoveResult
+	if opts.workers > 1 && clnt.GetURL().Type == objectStorage && !opts.isFake {
+		remover = newBatchRemover(ctx, clnt, targetAlias, opts)
+		contentCh, resultCh = remover.contentCh, remover.resultCh
+	} else {
+		resultCh = clnt.Remove(ctx, opts.isIncomplete, isRemoveBucket, opts.isBypass, false, contentCh)
+	}
 
 	var lastPath string
 	var perObjectVersions []*ClientContent
@@ -468,6 +610,7 @@ func listAndRemove(url string, opts removeOpts) error {
 						continue
 					}
 
+					opts.ops.wait(ctx)
 					sent := false
 					for !sent {
 						select {
@@ -477,7 +620,7 @@ func listAndRemove(url string, opts removeOpts) error {
 							path := path.Join(targetAlias, result.BucketName, result.ObjectName)
 							if result.Err != nil {
 								errorIf(result.Err.Trace(path),
-									"Failed to remove `"+path+"`.")
+									rmFailureMessage(path, result.Err, opts.isBypass))
 								switch result.Err.ToGoError().(type) {
 								case PathInsufficientPermission:
 									// Ignore Permission error.
@@ -494,6 +637,7 @@ func listAndRemove(url string, opts removeOpts) error {
 								msg.DeleteMarker = true
 								msg.VersionID = result.DeleteMarkerVersionID
 							}
+							accountAborted(path)
 							printMsg(msg)
 						}
 					}
@@ -526,6 +670,27 @@ func listAndRemove(url string, opts removeOpts) error {
 		}
 
 		if !opts.isFake {
+			if uploadSizes != nil {
+				uploadPath := path.Join(targetAlias, content.URL.Path)
+				_, listed := uploadCounts[uploadPath]
+				uploadCounts[uploadPath]++
+				if listed {
+					// Already sent, its removal aborts all its uploads.
+					continue
+				}
+				// Listings do not report the size of the uploads, sum
+				// their parts before they are aborted.
+				uploadSizes[uploadPath] = content.Size
+				if s3Clnt, ok := clnt.(*S3Client); ok {
+					size, err := s3Clnt.incompleteUploadsSize(ctx, content.URL.Path)
+					if err != nil {
+						warningIf(err.Trace(uploadPath), "Unable to sum the uploaded parts of `"+uploadPath+"`, the bytes reclaimed are approximate.")
+						sizesApproximate = true
+					}
+					uploadSizes[uploadPath] = size
+				}
+			}
+			opts.ops.wait(ctx)
 			sent := false
 			for !sent {
 				select {
@@ -535,15 +700,16 @@ func listAndRemove(url string, opts removeOpts) error {
 					path := path.Join(targetAlias, result.BucketName, result.ObjectName)
 					if result.Err != nil {
 						errorIf(result.Err.Trace(path),
-							"Failed to remove `"+path+"`.")
-						switch e := result.Err.ToGoError().(type) {
+							rmFailureMessage(path, result.Err, opts.isBypass))
+						switch result.Err.ToGoError().(type) {
 						case PathInsufficientPermission:
 							// Ignore Permission error.
 							continue
-						case minio.ErrorResponse:
-							if strings.Contains(e.Message, "Object is WORM protected and cannot be overwritten") {
-								continue
-							}
+						}
+						if isErrWORMProtected(result.Err) {
+							// Keep removing the other objects.
+							wormRefused = true
+							continue
 						}
 						close(contentCh)
 						return exitStatus(globalErrorExitStatus)
@@ -556,6 +722,7 @@ func listAndRemove(url string, opts removeOpts) error {
 						msg.DeleteMarker = true
 						msg.VersionID = result.DeleteMarkerVersionID
 					}
+					accountAborted(path)
 					printMsg(msg)
 				}
 			}
@@ -589,6 +756,7 @@ func listAndRemove(url string, opts removeOpts) error {
 				continue
 			}
 
+			opts.ops.wait(ctx)
 			sent := false
 			for !sent {
 				select {
@@ -598,7 +766,7 @@ func listAndRemove(url string, opts removeOpts) error {
 					path := path.Join(targetAlias, result.BucketName, result.ObjectName)
 					if result.Err != nil {
 						errorIf(result.Err.Trace(path),
-							"Failed to remove `"+path+"`.")
+							rmFailureMessage(path, result.Err, opts.isBypass))
 						switch result.Err.ToGoError().(type) {
 						case PathInsufficientPermission:
 							// Ignore Permission error.
@@ -615,6 +783,7 @@ func listAndRemove(url string, opts removeOpts) error {
 						msg.DeleteMarker = true
 						msg.VersionID = result.DeleteMarkerVersionID
 					}
+					accountAborted(path)
 					printMsg(msg)
 				}
 			}
@@ -628,12 +797,16 @@ func listAndRemove(url string, opts removeOpts) error {
 	for result := range resultCh {
 		path := path.Join(targetAlias, result.BucketName, result.ObjectName)
 		if result.Err != nil {
-			errorIf(result.Err.Trace(path), "Failed to remove `"+path+"` recursively.")
+			errorIf(result.Err.Trace(path), rmFailureMessage(path, result.Err, opts.isBypass))
 			switch result.Err.ToGoError().(type) {
 			case PathInsufficientPermission:
 				// Ignore Permission error.
 				continue
 			}
+			if isErrWORMProtected(result.Err) {
+				wormRefused = true
+				continue
+			}
 			return exitStatus(globalErrorExitStatus)
 		}
 		msg := rmMessage{
@@ -644,9 +817,29 @@ func listAndRemove(url string, opts removeOpts) error {
 			msg.DeleteMarker = true
 			msg.VersionID = result.DeleteMarkerVersionID
 		}
+		accountAborted(path)
 		printMsg(msg)
 	}
 
+	if remover != nil && remover.failures() > 0 {
+		errorIf(errDummy().Trace(url), fmt.Sprintf("Failed to remove %d object(s) in `%s`.", remover.failures(), url))
+		return exitStatus(globalErrorExitStatus)
+	}
+
+	// Nothing is aborted by a dry run, leave the summary out.
+	if opts.isIncomplete && !opts.isFake {
+		summary := rmIncompleteSummaryMessage{Approximate: sizesApproximate}
+		for _, path := range abortedPaths {
+			summary.AbortedUploads += uploadCounts[path]
+			summary.ReclaimedBytes += uploadSizes[path]
+		}
+		printMsg(summary)
+	}
+
+	if wormRefused {
+		return exitStatus(globalErrorExitStatus)
+	}
+
 	if !atLeastOneObjectFound {
 		if opts.isForce {
 			// Do not throw an exit code with --force check unix `rm -f`
@@ -677,7 +870,7 @@ func mainRm(cliCtx *cli.Context) error {
 	isRecursive := cliCtx.Bool("recursive")
 	isFake := cliCtx.Bool("dry-run") || cliCtx.Bool("fake")
 	isStdin := cliCtx.Bool("stdin")
-	isBypass := cliCtx.Bool("bypass")
+	isBypass := cliCtx.Bool("bypass") || cliCtx.Bool("bypass-governance")
 	olderThan := cliCtx.String("older-than")
 	newerThan := cliCtx.String("newer-than")
 	isForce := cliCtx.Bool("force")
@@ -686,6 +879,9 @@ func mainRm(cliCtx *cli.Context) error {
 	withVersions := cliCtx.Bool("versions")
 	versionID := cliCtx.String("version-id")
 	rewind := parseRewindFlag(cliCtx.String("rewind"))
+	workers := cliCtx.Int("workers")
+	isDeleteMarkers := cliCtx.Bool("delete-markers")
+	ops := newOpsLimiterFromContext(cliCtx)
 
 	if withVersions && rewind.IsZero() {
 		rewind = time.Now().UTC()
@@ -694,11 +890,24 @@ func mainRm(cliCtx *cli.Context) error {
 	// Set color.
 	console.SetColor("Remove", color.New(color.FgGreen, color.Bold))
 
+	if cliCtx.Bool("bypass-governance") && !isFake {
+		warning("Bypassing governance retention, object(s) under governance retention are removed before their retention expires.")
+	}
+
 	var rerr error
 	var e error
 	// Support multiple targets.
 	for _, url := range cliCtx.Args() {
-		if isRecursive || withVersions {
+		if isDeleteMarkers {
+			e = removeDeleteMarkers(url, removeOpts{
+				timeRef:   rewind,
+				isFake:    isFake,
+				isBypass:  isBypass,
+				olderThan: olderThan,
+				newerThan: newerThan,
+				ops:       ops,
+			})
+		} else if isRecursive || withVersions {
 			e = listAndRemove(url, removeOpts{
 				timeRef:           rewind,
 				withVersions:      withVersions,
@@ -710,6 +919,8 @@ func mainRm(cliCtx *cli.Context) error {
 				isBypass:          isBypass,
 				olderThan:         olderThan,
 				newerThan:         newerThan,
+				workers:           workers,
+				ops:               ops,
 				encKeyDB:          encKeyDB,
 			})
 		} else {
@@ -736,7 +947,16 @@ func mainRm(cliCtx *cli.Context) error {
 	scanner := bufio.NewScanner(os.Stdin)
 	for scanner.Scan() {
 		url := scanner.Text()
-		if isRecursive || withVersions {
+		if isDeleteMarkers {
+			e = removeDeleteMarkers(url, removeOpts{
+				timeRef:   rewind,
+				isFake:    isFake,
+				isBypass:  isBypass,
+				olderThan: olderThan,
+				newerThan: newerThan,
+				ops:       ops,
+			})
+		} else if isRecursive || withVersions {
 			e = listAndRemove(url, removeOpts{
 				timeRef:           rewind,
 				withVersions:      withVersions,
@@ -748,6 +968,8 @@ func mainRm(cliCtx *cli.Context) error {
 				isBypass:          isBypass,
 				olderThan:         olderThan,
 				newerThan:         newerThan,
+				workers:           workers,
+				ops:               ops,
 				encKeyDB:          encKeyDB,
 			})
 		} else {
@@ -0,0 +1,102 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+)
+
+func TestRmFailureMessage(t *testing.T) {
+	worm := probe.NewError(minio.ErrorResponse{Code: "InvalidRequest", Message: "Object is WORM protected and cannot be overwritten"})
+	denied := probe.NewError(minio.ErrorResponse{Code: "AccessDenied", Message: "Access Denied."})
+	// Other refusals share the InvalidRequest code.
+	invalid := probe.NewError(minio.ErrorResponse{Code: "InvalidRequest", Message: "Missing required header for this request: Content-MD5"})
+	// The message alone does not make an error a retention refusal.
+	wormText := probe.NewError(errors.New("Object is WORM protected and cannot be overwritten"))
+
+	testCases := []struct {
+		err      *probe.Error
+		isBypass bool
+		hint     string
+	}{
+		{worm, false, "governance retention can be bypassed with --bypass-governance"},
+		{worm, true, "only governance retention can be bypassed"},
+		{denied, false, ""},
+		{denied, true, ""},
+		{wormText, false, ""},
+		{invalid, false, ""},
+	}
+	for i, testCase := range testCases {
+		msg := rmFailureMessage("s3/bucket/object", testCase.err, testCase.isBypass)
+		if !strings.HasPrefix(msg, "Failed to remove `s3/bucket/object`.") {
+			t.Fatalf("Test %d: unexpected message %s", i+1, msg)
+		}
+		if testCase.hint == "" {
+			if msg != "Failed to remove `s3/bucket/object`." {
+				t.Fatalf("Test %d: expected no hint, got %s", i+1, msg)
+			}
+		} else if !strings.Contains(msg, testCase.hint) {
+			t.Fatalf("Test %d: expected the hint %q, got %s", i+1, testCase.hint, msg)
+		}
+	}
+}
+
+func TestRemoveKeepsWORMErrorCode(t *testing.T) {
+	withTempMcConfig(t)
+
+	newS3StubServer(t, "worm", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if _, ok := query["delete"]; !ok || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`<DeleteResult><Error><Key>locked</Key><Code>InvalidRequest</Code><Message>Object is WORM protected and cannot be overwritten</Message></Error></DeleteResult>`))
+	}))
+
+	clnt, err := newClient("worm/bucket/locked")
+	if err != nil {
+		t.Fatal(err)
+	}
+	contentCh := make(chan *ClientContent, 1)
+	contentCh <- &ClientContent{URL: clnt.GetURL()}
+	close(contentCh)
+
+	var results int
+	for result := range clnt.Remove(context.Background(), false, false, false, false, contentCh) {
+		results++
+		if result.Err == nil {
+			t.Fatal("expected the removal to be refused")
+		}
+		if !isErrWORMProtected(result.Err) {
+			t.Fatalf("expected a WORM protected error, got %v", result.Err)
+		}
+		if !strings.Contains(result.Err.ToGoError().Error(), "'locked (Version ID=)' is WORM protected") {
+			t.Fatalf("expected the object name in the error, got %v", result.Err)
+		}
+	}
+	if results != 1 {
+		t.Fatalf("expected 1 result, got %d", results)
+	}
+}
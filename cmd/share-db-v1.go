@@ -33,6 +33,10 @@ type shareEntryV1 struct {
 	Date        time.Time     `json:"date"`
 	Expiry      time.Duration `json:"expiry"`
 	ContentType string        `json:"contentType,omitempty"` // Only used by upload cmd.
+
+	// Only used by download cmd, to detect objects changed since sharing.
+	Alias string `json:"alias,omitempty"`
+	ETag  string `json:"etag,omitempty"`
 }
 
 // JSON file to persist previously shared uploads.
@@ -56,14 +60,22 @@ func newShareDBV1() *shareDBV1 {
 
 // Set upload info for each share.
 func (s *shareDBV1) Set(objectURL string, shareURL string, expiry time.Duration, contentType string) {
+	s.SetObject(objectURL, shareURL, expiry, contentType, "", "", "")
+}
+
+// SetObject sets share info along with the alias, version and ETag of the shared object.
+func (s *shareDBV1) SetObject(objectURL string, shareURL string, expiry time.Duration, contentType, alias, versionID, etag string) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
 	s.Shares[shareURL] = shareEntryV1{
 		URL:         objectURL,
+		VersionID:   versionID,
 		Date:        UTCNow(),
 		Expiry:      expiry,
 		ContentType: contentType,
+		Alias:       alias,
+		ETag:        etag,
 	}
 }
 
@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"context"
+	"net/url"
 	"strings"
 	"time"
 
@@ -35,6 +36,10 @@ var shareDownloadFlags = []cli.Flag{
 		Name:  "version-id, vid",
 		Usage: "share a particular object version",
 	},
+	cli.StringFlag{
+		Name:  "rewrite-host",
+		Usage: "generate URLs for this external host, e.g. of a reverse proxy, with an optional scheme and port",
+	},
 	shareFlagExpire,
 }
 
@@ -67,6 +72,9 @@ EXAMPLES:
 
   4. Share all objects under this bucket and all its folders and sub-folders with 5 days expiry.
      {{.Prompt}} {{.HelpName}} --recursive --expire=120h s3/backup/
+
+  5. Share this object behind a reverse proxy serving the MinIO server at https://files.example.com.
+     {{.Prompt}} {{.HelpName}} --rewrite-host https://files.example.com myminio/backup/2006-Mar-1/backup.tar.gz
 `,
 }
 
@@ -101,6 +109,11 @@ func checkShareDownloadSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB
 		fatalIf(errDummy().Trace(), "--version-id cannot be specified with --recursive flag.")
 	}
 
+	if rewriteHost := cliCtx.String("rewrite-host"); rewriteHost != "" {
+		_, err := parseRewriteHost(rewriteHost)
+		fatalIf(err, "Invalid --rewrite-host `"+rewriteHost+"`, expected a host with an optional scheme and port.")
+	}
+
 	// Validate if object exists only if the `--recursive` flag was NOT specified
 	if !isRecursive {
 		for _, url := range cliCtx.Args() {
@@ -113,8 +126,8 @@ func checkShareDownloadSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB
 }
 
 // doShareURL share files from target.
-func doShareDownloadURL(ctx context.Context, targetURL, versionID string, isRecursive bool, expiry time.Duration) *probe.Error {
-	targetAlias, targetURLFull, _, err := expandAlias(targetURL)
+func doShareDownloadURL(ctx context.Context, targetURL, versionID string, isRecursive bool, expiry time.Duration, rewriteHost *url.URL) *probe.Error {
+	targetAlias, targetURLFull, hostCfg, err := expandAlias(targetURL)
 	if err != nil {
 		return err.Trace(targetURL)
 	}
@@ -183,10 +196,16 @@ func doShareDownloadURL(ctx context.Context, targetURL, versionID string, isRecu
 			// add objectURL and expiry as part of the trace arguments.
 			return err.Trace(objectURL, "expiry="+expiry.String())
 		}
+		if rewriteHost != nil {
+			bucket, _ := url2BucketAndObject(&content.URL)
+			if shareURL, err = rewritePresignedURL(shareURL, bucket, rewriteHost, hostCfg); err != nil {
+				return err.Trace(objectURL, "rewrite-host="+rewriteHost.String())
+			}
+		}
 
 		// Make new entries to shareDB.
 		contentType := "" // Not useful for download shares.
-		shareDB.Set(objectURL, shareURL, expiry, contentType)
+		shareDB.SetObject(objectURL, shareURL, expiry, contentType, targetAlias, objectVersionID, content.ETag)
 		printMsg(shareMesssage{
 			ObjectURL:   objectURL,
 			ShareURL:    shareURL,
@@ -227,8 +246,14 @@ func mainShareDownload(cliCtx *cli.Context) error {
 		fatalIf(probe.NewError(e), "Unable to parse expire=`"+cliCtx.String("expire")+"`.")
 	}
 
+	var rewriteHost *url.URL
+	if cliCtx.String("rewrite-host") != "" {
+		rewriteHost, err = parseRewriteHost(cliCtx.String("rewrite-host"))
+		fatalIf(err, "Invalid --rewrite-host `"+cliCtx.String("rewrite-host")+"`.")
+	}
+
 	for _, targetURL := range cliCtx.Args() {
-		err := doShareDownloadURL(ctx, targetURL, versionID, isRecursive, expiry)
+		err := doShareDownloadURL(ctx, targetURL, versionID, isRecursive, expiry, rewriteHost)
 		if err != nil {
 			switch err.ToGoError().(type) {
 			case APINotImplemented:
@@ -18,14 +18,29 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/minio/cli"
 	"github.com/minio/mc/pkg/probe"
 )
 
-var shareListFlags = []cli.Flag{}
+var shareListFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "verify",
+		Usage: "flag shared downloads whose object changed since it was shared",
+	},
+}
+
+// States of a shared object reported by share list --verify.
+const (
+	shareObjectUnchanged = "unchanged"
+	shareObjectChanged   = "changed"
+	shareObjectMissing   = "missing"
+	shareObjectUnknown   = "unknown"
+)
 
 // Share documents via URL.
 var shareList = cli.Command{
@@ -39,8 +54,11 @@ var shareList = cli.Command{
   {{.HelpName}} COMMAND - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} COMMAND
+  {{.HelpName}} [FLAGS] COMMAND
 
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
 COMMAND:
   upload:   list previously shared access to uploads.
   download: list previously shared access to downloads.
@@ -51,6 +69,9 @@ EXAMPLES:
 
   2. List previously shared uploads, that haven't expired yet.
       {{.Prompt}} {{.HelpName}} upload
+
+  3. List previously shared downloads and flag those whose object was modified since it was shared.
+      {{.Prompt}} {{.HelpName}} --verify download
 `,
 }
 
@@ -60,10 +81,39 @@ func checkShareListSyntax(ctx *cli.Context) {
 	if !args.Present() || (args.First() != "upload" && args.First() != "download") {
 		cli.ShowCommandHelpAndExit(ctx, "list", 1) // last argument is exit code.
 	}
+	if ctx.Bool("verify") && args.First() != "download" {
+		fatalIf(errInvalidArgument().Trace(args...), "--verify is only supported for shared downloads.")
+	}
+}
+
+// verifySharedObject compares the current ETag of a shared object
+// with the one recorded when it was shared.
+func verifySharedObject(ctx context.Context, share shareEntryV1) string {
+	// Shares created by older releases did not record the ETag.
+	if share.Alias == "" || share.ETag == "" {
+		return shareObjectUnknown
+	}
+	clnt, err := newClientFromAlias(share.Alias, share.URL)
+	if err != nil {
+		return shareObjectUnknown
+	}
+	content, err := clnt.Stat(ctx, StatOptions{versionID: share.VersionID})
+	if err != nil {
+		switch err.ToGoError().(type) {
+		case ObjectMissing, PathNotFound:
+			return shareObjectMissing
+		}
+		errorIf(err.Trace(share.URL), "Unable to verify shared object.")
+		return shareObjectUnknown
+	}
+	if strings.Trim(content.ETag, "\"") != strings.Trim(share.ETag, "\"") {
+		return shareObjectChanged
+	}
+	return shareObjectUnchanged
 }
 
 // doShareList list shared url's.
-func doShareList(cmd string) *probe.Error {
+func doShareList(ctx context.Context, cmd string, verify bool) *probe.Error {
 	if cmd != "upload" && cmd != "download" {
 		return probe.NewError(fmt.Errorf("Unknown argument `%s` passed", cmd))
 	}
@@ -91,20 +141,27 @@ func doShareList(cmd string) *probe.Error {
 
 	// Print previously shared entries.
 	for shareURL, share := range shareDB.Shares {
-		printMsg(shareMesssage{
+		msg := shareMesssage{
 			ObjectURL:   share.URL,
 			ShareURL:    shareURL,
 			TimeLeft:    share.Expiry - time.Since(share.Date),
 			ContentType: share.ContentType,
-		})
+		}
+		if verify {
+			msg.Object = verifySharedObject(ctx, share)
+		}
+		printMsg(msg)
 	}
 	return nil
 }
 
 // main entry point for share list.
-func mainShareList(ctx *cli.Context) error {
+func mainShareList(cliCtx *cli.Context) error {
+	ctx, cancelShareList := context.WithCancel(globalContext)
+	defer cancelShareList()
+
 	// validate command-line args.
-	checkShareListSyntax(ctx)
+	checkShareListSyntax(cliCtx)
 
 	// Additional command speific theme customization.
 	shareSetColor()
@@ -113,6 +170,6 @@ func mainShareList(ctx *cli.Context) error {
 	initShareConfig()
 
 	// List shares.
-	fatalIf(doShareList(ctx.Args().First()).Trace(), "Unable to list previously shared URLs.")
+	fatalIf(doShareList(ctx, cliCtx.Args().First(), cliCtx.Bool("verify")).Trace(), "Unable to list previously shared URLs.")
 	return nil
 }
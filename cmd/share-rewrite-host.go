@@ -0,0 +1,93 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7/pkg/signer"
+)
+
+// parseRewriteHost parses the value of --rewrite-host, a host with
+// an optional port and an optional http or https scheme.
+func parseRewriteHost(value string) (*url.URL, *probe.Error) {
+	rawURL := value
+	if !urlRgx.MatchString(rawURL) {
+		rawURL = "//" + rawURL
+	}
+	u, e := url.Parse(rawURL)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(value)
+	}
+	if u.Host == "" || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.User != nil {
+		return nil, errInvalidArgument().Trace(value)
+	}
+	return &url.URL{Scheme: u.Scheme, Host: u.Host}, nil
+}
+
+// rewritePresignedURL moves the path style presignedURL of an object
+// of bucket to the host, and scheme if set, of rewrite. Signature V4
+// signs the host of the URL, such URLs are signed again for the new
+// host with the same region and expiry.
+func rewritePresignedURL(presignedURL, bucket string, rewrite *url.URL, hostCfg *aliasConfigV10) (string, *probe.Error) {
+	u, e := url.Parse(presignedURL)
+	if e != nil {
+		return "", probe.NewError(e).Trace(presignedURL)
+	}
+	if !strings.HasPrefix(u.Path, "/"+bucket+"/") {
+		return "", probe.NewError(errors.New("--rewrite-host requires path style URLs, use --addressing path")).Trace(presignedURL)
+	}
+	u.Host = rewrite.Host
+	if rewrite.Scheme != "" {
+		u.Scheme = rewrite.Scheme
+	}
+
+	query := u.Query()
+	if query.Get("X-Amz-Algorithm") == "" {
+		// Signature V2 signs the path of path style URLs, not the host.
+		return u.String(), nil
+	}
+
+	// X-Amz-Credential is ACCESS-KEY/DATE/REGION/s3/aws4_request.
+	credential := strings.Split(query.Get("X-Amz-Credential"), "/")
+	if len(credential) != 5 {
+		return "", errInvalidArgument().Trace(presignedURL)
+	}
+	expires, e := strconv.ParseInt(query.Get("X-Amz-Expires"), 10, 64)
+	if e != nil {
+		return "", probe.NewError(e).Trace(presignedURL)
+	}
+	for k := range query {
+		if strings.HasPrefix(k, "X-Amz-") {
+			query.Del(k)
+		}
+	}
+	u.RawQuery = query.Encode()
+
+	req, e := http.NewRequest(http.MethodGet, u.String(), nil)
+	if e != nil {
+		return "", probe.NewError(e).Trace(presignedURL)
+	}
+	req = signer.PreSignV4(*req, hostCfg.AccessKey, hostCfg.SecretKey, hostCfg.SessionToken, credential[2], expires)
+	return req.URL.String(), nil
+}
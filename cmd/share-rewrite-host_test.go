@@ -0,0 +1,111 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+func TestParseRewriteHost(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+		success  bool
+	}{
+		{"files.example.com", "//files.example.com", true},
+		{"files.example.com:8443", "//files.example.com:8443", true},
+		{"https://files.example.com", "https://files.example.com", true},
+		{"https://files.example.com/", "https://files.example.com", true},
+		{"https://files.example.com/minio", "", false},
+		{"files.example.com?a=b", "", false},
+		{"", "", false},
+	}
+	for i, testCase := range testCases {
+		u, err := parseRewriteHost(testCase.value)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %v, got error %v", i+1, testCase.success, err)
+		}
+		if err == nil && u.String() != testCase.expected {
+			t.Fatalf("Test %d: expected `%s`, got `%s`", i+1, testCase.expected, u.String())
+		}
+	}
+}
+
+// presignGet returns a presigned URL of object on endpoint, signed
+// within the same second as the rewrite of the previous one.
+func presignGet(t *testing.T, endpoint string, secure bool, object string) string {
+	clnt, e := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4("WLGDGYAQYIGI833EV05A", "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF", ""),
+		Secure: secure,
+		Region: "us-east-1",
+	})
+	if e != nil {
+		t.Fatal(e)
+	}
+	reqParams := url.Values{"versionId": []string{"v1"}}
+	u, e := clnt.PresignedGetObject(context.Background(), "bucket", object, time.Hour, reqParams)
+	if e != nil {
+		t.Fatal(e)
+	}
+	return u.String()
+}
+
+func TestRewritePresignedURL(t *testing.T) {
+	hostCfg := &aliasConfigV10{
+		AccessKey: "WLGDGYAQYIGI833EV05A",
+		SecretKey: "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF",
+	}
+	rewrite, err := parseRewriteHost("https://files.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The rewritten URL is the URL presigned for the external host,
+	// retry if the two signatures are not made in the same second.
+	for i := 0; ; i++ {
+		internal := presignGet(t, "minio.internal:9000", false, "dir/my object.txt")
+		rewritten, err := rewritePresignedURL(internal, "bucket", rewrite, hostCfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		external := presignGet(t, "files.example.com", true, "dir/my object.txt")
+		if rewritten == external {
+			break
+		}
+		if i == 2 {
+			t.Fatalf("expected `%s`, got `%s`", external, rewritten)
+		}
+	}
+
+	if _, err = rewritePresignedURL("https://bucket.minio.internal/object?X-Amz-Algorithm=AWS4-HMAC-SHA256", "bucket", rewrite, hostCfg); err == nil {
+		t.Fatal("expected an error rewriting a virtual host style URL")
+	}
+
+	// Signature V2 does not sign the host.
+	v2 := "http://minio.internal:9000/bucket/object?AWSAccessKeyId=WLGDGYAQYIGI833EV05A&Expires=1&Signature=abc"
+	rewritten, err := rewritePresignedURL(v2, "bucket", rewrite, hostCfg)
+	if err != nil || rewritten != "https://files.example.com/bucket/object?AWSAccessKeyId=WLGDGYAQYIGI833EV05A&Expires=1&Signature=abc" {
+		t.Fatalf("unexpected rewrite `%s` of a V2 URL: %v", rewritten, err)
+	}
+}
@@ -57,6 +57,7 @@ type shareMesssage struct {
 	ShareURL    string        `json:"share"`
 	TimeLeft    time.Duration `json:"timeLeft"`
 	ContentType string        `json:"contentType,omitempty"` // Only used by upload cmd.
+	Object      string        `json:"object,omitempty"`      // Only used by list --verify.
 }
 
 // String - Themefied string message for console printing.
@@ -73,6 +74,9 @@ func (s shareMesssage) String() string {
 	shareURL = strings.Replace(shareURL, "<NAME>", console.Colorize("File", "<NAME>"), 1)
 
 	msg += console.Colorize("Share", fmt.Sprintf("Share: %s\n", shareURL))
+	if s.Object != "" {
+		msg += console.Colorize("Object-"+s.Object, fmt.Sprintf("Object: %s\n", s.Object))
+	}
 
 	return msg
 }
@@ -101,6 +105,10 @@ func shareSetColor() {
 	console.SetColor("Content-type", color.New(color.FgBlue))
 	console.SetColor("Share", color.New(color.FgGreen))
 	console.SetColor("File", color.New(color.FgRed, color.Bold))
+	console.SetColor("Object-"+shareObjectUnchanged, color.New(color.FgGreen))
+	console.SetColor("Object-"+shareObjectChanged, color.New(color.FgRed, color.Bold))
+	console.SetColor("Object-"+shareObjectMissing, color.New(color.FgRed, color.Bold))
+	console.SetColor("Object-"+shareObjectUnknown, color.New(color.FgYellow))
 }
 
 // Get share dir name.
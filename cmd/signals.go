@@ -20,8 +20,22 @@ package cmd
 import (
 	"os"
 	"os/signal"
+	"sync/atomic"
 )
 
+// interruptHook holds the func() run once a signal is trapped, before
+// exiting, e.g. to report how far a command went.
+var interruptHook atomic.Value
+
+// setInterruptHook sets the func run once a signal is trapped, nil
+// to run none.
+func setInterruptHook(hook func()) {
+	if hook == nil {
+		hook = func() {}
+	}
+	interruptHook.Store(hook)
+}
+
 // trapSignals traps the registered signals and cancel the global context.
 func trapSignals(sig ...os.Signal) {
 	// channel to receive signals.
@@ -45,6 +59,10 @@ func trapSignals(sig ...os.Signal) {
 	// Cancel the global context
 	globalCancel()
 
+	if hook, ok := interruptHook.Load().(func()); ok {
+		hook()
+	}
+
 	var exitCode int
 	switch s.String() {
 	case "interrupt":
@@ -32,9 +32,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/fatih/color"
 	"github.com/minio/cli"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio-go/v7"
+	"github.com/minio/pkg/console"
 	"github.com/minio/pkg/mimedb"
 )
 
@@ -44,6 +46,18 @@ var sqlFlags = []cli.Flag{
 		Usage: "sql query expression",
 		Value: "select * from s3object",
 	},
+	cli.StringFlag{
+		Name:  "select",
+		Usage: "comma separated columns to select, composing the query instead of --query",
+	},
+	cli.StringFlag{
+		Name:  "where",
+		Usage: "condition of the records to select, composing the query instead of --query",
+	},
+	cli.BoolFlag{
+		Name:  "infer-schema",
+		Usage: "sample the first rows of csv objects and report their columns and guessed types",
+	},
 	cli.BoolFlag{
 		Name:  "recursive, r",
 		Usage: "sql query recursively",
@@ -81,7 +95,7 @@ var sqlCmd = cli.Command{
 	Action:       mainSQL,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(sqlFlags, ioFlags...), globalFlags...),
+	Flags:        append(append(sqlFlags, ioFlags...), globalFlagsWithoutQuery()...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -123,6 +137,13 @@ EXAMPLES:
      {{.Prompt}} {{.HelpName}} --compression GZIP --csv-input "rd=\n,fh=USE,fd=;" \
            --csv-output "rd=\n" --csv-output-header "device_id,uptime,lat,lon" \
            --query "select * from S3Object" myminio/iot-devices/data.csv
+
+  7. Select two columns of the records of a csv object with a header matching a condition.
+     {{.Prompt}} {{.HelpName}} --csv-input "fh=USE" --select "device_id,uptime" \
+           --where "CAST(s.uptime AS INT) > 3600" myminio/iot-devices/data.csv
+
+  8. Report the columns of a csv object and their guessed types before writing a query.
+     {{.Prompt}} {{.HelpName}} --infer-schema myminio/iot-devices/data.csv
 `,
 }
 
@@ -420,7 +441,7 @@ func validateOpts(selOpts SelectObjectOpts, url string) {
 
 // validate args and optionally fetch the csv header of query object
 func getAndValidateArgs(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair, url string) (query string, csvHdrs []string, selOpts SelectObjectOpts) {
-	query = ctx.String("query")
+	query = getSQLQuery(ctx)
 	csvHdrs = getCSVOutputHeaders(ctx, url, encKeyDB, query)
 	selOpts = getSQLOpts(ctx, csvHdrs)
 	validateOpts(selOpts, url)
@@ -432,6 +453,12 @@ func checkSQLSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) == 0 {
 		cli.ShowCommandHelpAndExit(ctx, "sql", 1) // last argument is exit code.
 	}
+	if ctx.IsSet("query") && (ctx.IsSet("select") || ctx.IsSet("where")) {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--select and --where compose the query, they cannot be used with --query.")
+	}
+	if ctx.Bool("infer-schema") && ctx.IsSet("json-input") {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--infer-schema only samples csv objects.")
+	}
 }
 
 // mainSQL is the main entry point for sql command.
@@ -452,6 +479,26 @@ func mainSQL(cliCtx *cli.Context) error {
 	checkSQLSyntax(cliCtx)
 	// extract URLs.
 	URLs := cliCtx.Args()
+
+	if cliCtx.Bool("infer-schema") {
+		console.SetColor("SQLSchemaURL", color.New(color.Bold))
+		console.SetColor("SQLSchemaType", color.New(color.FgCyan))
+		var failed bool
+		for _, url := range URLs {
+			msg, err := inferSQLSchema(cliCtx, url, encKeyDB)
+			if err != nil {
+				errorIf(err.Trace(url), "Unable to infer the schema of "+url+".")
+				failed = true
+				continue
+			}
+			printMsg(msg)
+		}
+		if failed {
+			return exitStatus(globalErrorExitStatus)
+		}
+		return nil
+	}
+
 	writeHdr := true
 	for _, url := range URLs {
 		if _, targetContent, err := url2Stat(ctx, url, "", false, encKeyDB, time.Time{}, false); err != nil {
@@ -18,8 +18,12 @@
 package cmd
 
 import (
+	"flag"
+	"net/http"
 	"strings"
 	"testing"
+
+	"github.com/minio/cli"
 )
 
 var testParseKVArgsCases = []struct {
@@ -147,3 +151,70 @@ func TestParseSerializationOpts(t *testing.T) {
 		}
 	}
 }
+
+func TestComposeSQLQuery(t *testing.T) {
+	testCases := []struct {
+		selectCols, where, query string
+	}{
+		{"", "", "select * from S3Object s"},
+		{"id, name", "", "select s.id, s.name from S3Object s"},
+		{"device id,count(*)", "s.power > 3", `select s."device id", count(*) from S3Object s where s.power > 3`},
+		{"s.name", " s.id = '7' ", "select s.name from S3Object s where s.id = '7'"},
+	}
+	for i, testCase := range testCases {
+		if query := composeSQLQuery(testCase.selectCols, testCase.where); query != testCase.query {
+			t.Fatalf("Test %d: expected %q, got %q", i+1, testCase.query, query)
+		}
+	}
+}
+
+func TestInferCSVSchema(t *testing.T) {
+	sample := "id;name;score;ok;day\n1;alice;3.5;true;2021-01-02\n2;bob;4;;2021-02-03T10:00:00\n3;car"
+	msg, e := inferCSVSchema([]byte(sample), ';', "")
+	if e != nil {
+		t.Fatal(e)
+	}
+	// The last record is cut by the sample and dropped.
+	if !msg.Header || msg.Rows != 2 {
+		t.Fatalf("expected a header and 2 rows, got %+v", msg)
+	}
+	expected := []sqlSchemaColumn{
+		{"id", sqlTypeInt}, {"name", sqlTypeString}, {"score", sqlTypeFloat}, {"ok", sqlTypeBool}, {"day", sqlTypeTimestamp},
+	}
+	if len(msg.Columns) != len(expected) {
+		t.Fatalf("expected %d columns, got %d", len(expected), len(msg.Columns))
+	}
+	for i, col := range expected {
+		if msg.Columns[i] != col {
+			t.Fatalf("column %d: expected %+v, got %+v", i+1, col, msg.Columns[i])
+		}
+	}
+
+	// Without a header the columns are named by position.
+	if msg, e = inferCSVSchema([]byte("1,x\n2,y\n"), ',', ""); e != nil || msg.Header || msg.Columns[0].Name != "_1" || msg.Columns[1].Type != sqlTypeString {
+		t.Fatalf("unexpected schema %+v, %v", msg, e)
+	}
+	if msg, e = inferCSVSchema([]byte("a,b\nc,d\n"), ',', "USE"); e != nil || !msg.Header || msg.Rows != 1 || msg.Columns[1].Name != "b" {
+		t.Fatalf("unexpected schema %+v, %v", msg, e)
+	}
+}
+
+func TestInferSQLSchemaExitStatus(t *testing.T) {
+	withTempMcConfig(t)
+
+	newS3StubServer(t, "sql", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`))
+	}))
+
+	set := flag.NewFlagSet("sql", flag.ContinueOnError)
+	for _, f := range sqlCmd.Flags {
+		f.Apply(set)
+	}
+	if e := set.Parse([]string{"--infer-schema", "sql/bucket/missing.csv"}); e != nil {
+		t.Fatal(e)
+	}
+	if e := mainSQL(cli.NewContext(nil, set, nil)); e == nil || e.Error() != exitStatus(globalErrorExitStatus).Error() {
+		t.Fatalf("expected the error exit status, got %v", e)
+	}
+}
@@ -0,0 +1,316 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+const (
+	// Bytes of an object read by --infer-schema.
+	sqlSchemaSampleSize = 64 * 1024
+	// Rows of an object sampled by --infer-schema.
+	sqlSchemaSampleRows = 100
+)
+
+// Types guessed by --infer-schema, named after the S3 Select CAST types.
+const (
+	sqlTypeInt       = "INT"
+	sqlTypeFloat     = "FLOAT"
+	sqlTypeBool      = "BOOL"
+	sqlTypeTimestamp = "TIMESTAMP"
+	sqlTypeString    = "STRING"
+)
+
+// Column names which may be referenced without quotes in a query.
+var sqlIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// composeSQLQuery returns the query selecting the comma separated columns
+// of selectCols, all of them when empty, of the records matching where.
+func composeSQLQuery(selectCols, where string) string {
+	projection := "*"
+	if selectCols != "" {
+		var cols []string
+		for _, col := range strings.Split(selectCols, ",") {
+			col = strings.TrimSpace(col)
+			switch {
+			case col == "":
+				continue
+			case sqlIdentifier.MatchString(col):
+				col = "s." + col
+			case !strings.ContainsAny(col, `."()`):
+				col = `s."` + col + `"`
+			}
+			// Anything else, e.g. s.name or count(*), is kept as is.
+			cols = append(cols, col)
+		}
+		if len(cols) > 0 {
+			projection = strings.Join(cols, ", ")
+		}
+	}
+	query := "select " + projection + " from S3Object s"
+	if where = strings.TrimSpace(where); where != "" {
+		query += " where " + where
+	}
+	return query
+}
+
+// getSQLQuery returns the query of --query, or the one composed from
+// --select and --where.
+func getSQLQuery(ctx *cli.Context) string {
+	if ctx.IsSet("select") || ctx.IsSet("where") {
+		return composeSQLQuery(ctx.String("select"), ctx.String("where"))
+	}
+	return ctx.String("query")
+}
+
+// sqlSchemaColumn is a column of a CSV object and its guessed type.
+type sqlSchemaColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// sqlSchemaMessage container for the schema inferred from a CSV object.
+type sqlSchemaMessage struct {
+	Status  string            `json:"status"`
+	URL     string            `json:"url"`
+	Header  bool              `json:"header"`
+	Rows    int               `json:"sampledRows"`
+	Columns []sqlSchemaColumn `json:"columns"`
+}
+
+func (s sqlSchemaMessage) String() string {
+	var b strings.Builder
+	header := "no header"
+	if s.Header {
+		header = "header"
+	}
+	b.WriteString(console.Colorize("SQLSchemaURL", s.URL))
+	b.WriteString(fmt.Sprintf(": %d columns, %s, %d rows sampled", len(s.Columns), header, s.Rows))
+	for i, col := range s.Columns {
+		b.WriteString(fmt.Sprintf("\n  %3d  %s  %s", i+1, console.Colorize("SQLSchemaType", fmt.Sprintf("%-9s", col.Type)), col.Name))
+	}
+	return b.String()
+}
+
+func (s sqlSchemaMessage) JSON() string {
+	s.Status = "success"
+	msgBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// guessSQLType returns the type of a CSV value, empty for an empty value.
+func guessSQLType(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ""
+	}
+	if _, e := strconv.ParseInt(value, 10, 64); e == nil {
+		return sqlTypeInt
+	}
+	if _, e := strconv.ParseFloat(value, 64); e == nil {
+		return sqlTypeFloat
+	}
+	if _, e := strconv.ParseBool(value); e == nil {
+		return sqlTypeBool
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02"} {
+		if _, e := time.Parse(layout, value); e == nil {
+			return sqlTypeTimestamp
+		}
+	}
+	return sqlTypeString
+}
+
+// mergeSQLTypes returns the type fitting the values of both types.
+func mergeSQLTypes(a, b string) string {
+	switch {
+	case a == "":
+		return b
+	case b == "" || a == b:
+		return a
+	case (a == sqlTypeInt && b == sqlTypeFloat) || (a == sqlTypeFloat && b == sqlTypeInt):
+		return sqlTypeFloat
+	}
+	return sqlTypeString
+}
+
+// inferCSVSchema guesses the columns of the CSV records of sample, cut at
+// the last complete record. fileHeader is the FileHeader of --csv-input,
+// when empty the first record is taken as a header if it is all text above
+// a column of another type.
+func inferCSVSchema(sample []byte, delimiter rune, fileHeader string) (sqlSchemaMessage, error) {
+	if i := bytes.LastIndexByte(sample, '\n'); i >= 0 && i < len(sample)-1 {
+		sample = sample[:i+1]
+	}
+	r := csv.NewReader(bytes.NewReader(sample))
+	r.Comma = delimiter
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	var records [][]string
+	for len(records) <= sqlSchemaSampleRows {
+		record, e := r.Read()
+		if e == io.EOF {
+			break
+		}
+		if e != nil {
+			return sqlSchemaMessage{}, e
+		}
+		records = append(records, record)
+	}
+	if len(records) == 0 {
+		return sqlSchemaMessage{}, fmt.Errorf("no CSV records found")
+	}
+
+	var msg sqlSchemaMessage
+	switch strings.ToUpper(fileHeader) {
+	case "USE", "IGNORE":
+		msg.Header = true
+	case "NONE":
+	default:
+		msg.Header = len(records) > 1 && sqlHeaderDiffers(records)
+	}
+
+	rows := records
+	if msg.Header {
+		rows = records[1:]
+	}
+	if len(rows) > sqlSchemaSampleRows {
+		rows = rows[:sqlSchemaSampleRows]
+	}
+	msg.Rows = len(rows)
+
+	ncols := 0
+	for _, record := range records {
+		if len(record) > ncols {
+			ncols = len(record)
+		}
+	}
+	for i := 0; i < ncols; i++ {
+		name := fmt.Sprintf("_%d", i+1)
+		if msg.Header && i < len(records[0]) {
+			name = strings.TrimSpace(records[0][i])
+		}
+		colType := ""
+		for _, row := range rows {
+			if i < len(row) {
+				colType = mergeSQLTypes(colType, guessSQLType(row[i]))
+			}
+		}
+		if colType == "" {
+			colType = sqlTypeString
+		}
+		msg.Columns = append(msg.Columns, sqlSchemaColumn{Name: name, Type: colType})
+	}
+	return msg, nil
+}
+
+// sqlHeaderDiffers tells if the first record is all text and sits above
+// a column holding values of another type.
+func sqlHeaderDiffers(records [][]string) bool {
+	for _, value := range records[0] {
+		if guessSQLType(value) != sqlTypeString {
+			return false
+		}
+	}
+	for i := range records[0] {
+		colType := ""
+		for _, record := range records[1:] {
+			if i < len(record) {
+				colType = mergeSQLTypes(colType, guessSQLType(record[i]))
+			}
+		}
+		if colType != "" && colType != sqlTypeString {
+			return true
+		}
+	}
+	return false
+}
+
+// readSQLSample returns the first sqlSchemaSampleSize bytes of the
+// decompressed content of the object at sourceURL.
+func readSQLSample(sourceURL, compression string, encKeyDB map[string][]prefixSSEPair) ([]byte, *probe.Error) {
+	r, metadata, err := getSourceStreamMetadataFromURL(globalContext, sourceURL, "", time.Time{}, encKeyDB)
+	if err != nil {
+		return nil, err.Trace(sourceURL)
+	}
+	defer r.Close()
+
+	var rd io.Reader = r
+	ctype := metadata["Content-Type"]
+	switch {
+	case strings.EqualFold(compression, "GZIP") || strings.Contains(ctype, "gzip"):
+		gr, e := gzip.NewReader(r)
+		if e != nil {
+			return nil, probe.NewError(e).Trace(sourceURL)
+		}
+		defer gr.Close()
+		rd = gr
+	case strings.EqualFold(compression, "BZIP2") || strings.Contains(ctype, "bzip"):
+		rd = bzip2.NewReader(r)
+	}
+	sample, e := ioutil.ReadAll(io.LimitReader(rd, sqlSchemaSampleSize))
+	if e != nil {
+		return nil, probe.NewError(e).Trace(sourceURL)
+	}
+	return sample, nil
+}
+
+// inferSQLSchema samples the CSV object at sourceURL to guess its columns,
+// honoring the field delimiter and file header of --csv-input.
+func inferSQLSchema(ctx *cli.Context, sourceURL string, encKeyDB map[string][]prefixSSEPair) (sqlSchemaMessage, *probe.Error) {
+	delimiter, fileHeader := ',', ""
+	if icsv := ctx.String("csv-input"); icsv != "" {
+		kv, err := parseSerializationOpts(icsv, append(validCSVCommonKeys, validCSVInputKeys...), validCSVInputAbbrKeys)
+		if err != nil {
+			return sqlSchemaMessage{}, err.Trace(icsv)
+		}
+		if fd := []rune(kv["fielddelimiter"]); len(fd) == 1 {
+			delimiter = fd[0]
+		}
+		fileHeader = kv["fileheader"]
+	}
+
+	sample, err := readSQLSample(sourceURL, ctx.String("compression"), encKeyDB)
+	if err != nil {
+		return sqlSchemaMessage{}, err
+	}
+	msg, e := inferCSVSchema(sample, delimiter, fileHeader)
+	if e != nil {
+		return sqlSchemaMessage{}, probe.NewError(e).Trace(sourceURL)
+	}
+	msg.URL = sourceURL
+	return msg, nil
+}
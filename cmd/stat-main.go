@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"time"
 
@@ -46,6 +47,28 @@ var (
 			Name:  "recursive, r",
 			Usage: "stat all objects recursively",
 		},
+		cli.IntFlag{
+			Name:  "workers",
+			Value: 8,
+			Usage: "number of objects to stat concurrently with --recursive",
+		},
+		cli.BoolFlag{
+			Name:  "checksum",
+			Usage: "display the additional checksums stored with the object(s), e.g. CRC32C or SHA256",
+		},
+		cli.BoolFlag{
+			Name:  "wait",
+			Usage: "wait for the object(s) to exist",
+		},
+		cli.DurationFlag{
+			Name:  "timeout",
+			Usage: "exit with an error if the object(s) do not exist after this duration with --wait",
+		},
+		cli.DurationFlag{
+			Name:  "poll-interval",
+			Value: 5 * time.Second,
+			Usage: "interval between two checks of the object(s) with --wait",
+		},
 	}
 )
 
@@ -91,6 +114,20 @@ EXAMPLES:
 
   7. Stat all objects versions recursively created before 1st January 2020.
      {{.Prompt}} {{.HelpName}} --versions --rewind 2020.01.01T00:00 s3/personal-docs/
+
+  8. Audit the encryption of all objects on mybucket, SSE-C objects are reported as requiring a key
+     unless their key is passed with --encrypt-key.
+     {{.Prompt}} {{.HelpName}} --recursive s3/mybucket/
+
+  9. Snapshot the metadata of all objects under a prefix before a migration, one record per object
+     followed by the number of objects. The records come in the order the objects are stat'ed.
+     {{.Prompt}} {{.HelpName}} --recursive --json --workers 32 s3/mybucket/prefix/ > before.json
+
+ 10. Wait up to 5 minutes for an upstream job to produce an object, checking every 10 seconds.
+     {{.Prompt}} {{.HelpName}} --wait --timeout 5m --poll-interval 10s s3/mybucket/reports/daily.csv
+
+ 11. Check the integrity checksums recorded for an object uploaded with an additional checksum.
+     {{.Prompt}} {{.HelpName}} --checksum s3/mybucket/reports/daily.csv
 `,
 }
 
@@ -124,8 +161,26 @@ func parseAndCheckStatSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB
 		fatalIf(errInvalidArgument().Trace(args...), "You cannot specify --version-id with either --rewind, --versions or --recursive.")
 	}
 
+	if recursive && cliCtx.Int("workers") < 1 {
+		fatalIf(errInvalidArgument().Trace(args...), "--workers should be at least 1.")
+	}
+
+	if cliCtx.Bool("wait") {
+		if recursive || withVersions {
+			fatalIf(errInvalidArgument().Trace(args...), "You cannot specify --wait with either --versions or --recursive.")
+		}
+		if cliCtx.Duration("poll-interval") <= 0 || cliCtx.Duration("timeout") < 0 {
+			fatalIf(errInvalidArgument().Trace(args...), "--poll-interval should be positive and --timeout should not be negative.")
+		}
+		// The object(s) may not exist yet.
+		return URLs, recursive, versionID, rewind, withVersions
+	}
+
 	for _, url := range URLs {
 		_, _, err := url2Stat(ctx, url, versionID, false, encKeyDB, rewind, false)
+		if err != nil && errors.As(err.ToGoError(), &ObjectSSECKeyRequired{}) {
+			continue
+		}
 		if err != nil && !isURLPrefixExists(url, isIncomplete) {
 			fatalIf(err.Trace(url), "Unable to stat `"+url+"`.")
 		}
@@ -162,14 +217,39 @@ func mainStat(cliCtx *cli.Context) error {
 		args = []string{"."}
 	}
 
+	if cliCtx.Bool("wait") {
+		waitCtx := ctx
+		if timeout := cliCtx.Duration("timeout"); timeout > 0 {
+			var cancelWait context.CancelFunc
+			waitCtx, cancelWait = context.WithTimeout(ctx, timeout)
+			defer cancelWait()
+		}
+		for _, targetURL := range args {
+			err := waitStatURL(waitCtx, targetURL, versionID, rewind, encKeyDB, cliCtx.Duration("poll-interval"))
+			if err != nil && errors.Is(err.ToGoError(), context.DeadlineExceeded) {
+				fatalIf(err, "Timed out waiting for `"+targetURL+"`.")
+			}
+			fatalIf(err, "Unable to stat `"+targetURL+"`.")
+		}
+	}
+
 	var cErr error
 	for _, targetURL := range args {
-		contents, bstats, err := statURL(ctx, targetURL, versionID, rewind, withVersions, false, isRecursive, encKeyDB)
+		if isRecursive {
+			if e := statRecursive(ctx, targetURL, rewind, withVersions, cliCtx.Bool("checksum"), cliCtx.Int("workers"), encKeyDB); e != nil {
+				cErr = e
+			}
+			continue
+		}
+		contents, bstats, err := statURL(ctx, targetURL, versionID, rewind, withVersions, false, isRecursive, cliCtx.Bool("checksum"), encKeyDB)
 		if err != nil {
 			fatalIf(err, "Unable to stat `"+targetURL+"`.")
 		}
 		for _, content := range contents {
 			stat := parseStat(content)
+			if cliCtx.Bool("checksum") {
+				stat.setChecksums(content)
+			}
 			stat.singleObject = len(contents) == 1
 			printMsg(stat)
 		}
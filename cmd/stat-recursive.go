@@ -0,0 +1,204 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+	"golang.org/x/term"
+)
+
+// Interval between two progress lines of a recursive stat.
+const statProgressInterval = 2 * time.Second
+
+// statSummaryMessage container for the final count of a recursive stat.
+type statSummaryMessage struct {
+	Status  string `json:"status"`
+	URL     string `json:"url"`
+	Objects int64  `json:"objects"`
+	Size    int64  `json:"size"`
+	Errors  int64  `json:"errors"`
+}
+
+func (s statSummaryMessage) String() string {
+	msg := fmt.Sprintf("Total: %d objects, %s", s.Objects, humanize.IBytes(uint64(s.Size)))
+	if s.Errors > 0 {
+		msg += fmt.Sprintf(", %d errors", s.Errors)
+	}
+	return console.Colorize("Name", msg)
+}
+
+func (s statSummaryMessage) JSON() string {
+	s.Status = "success"
+	msgBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// statRecursive stats every object under targetURL with workers in
+// parallel, printing the stat of each object as soon as it is known
+// and the number of objects in the end. A progress line is written
+// to stderr when it is a terminal while stdout is redirected.
+func statRecursive(ctx context.Context, targetURL string, timeRef time.Time, withVersions, withChecksum bool, workers int, encKeyDB map[string][]prefixSSEPair) error {
+	clnt, err := newClient(targetURL)
+	if err != nil {
+		return err.ToGoError()
+	}
+	targetAlias, _, _ := mustExpandAlias(targetURL)
+
+	prefixPath := clnt.GetURL().Path
+	separator := string(clnt.GetURL().Separator)
+	if !strings.HasSuffix(prefixPath, separator) {
+		prefixPath = prefixPath[:strings.LastIndex(prefixPath, separator)+1]
+	}
+	prefixPath = filepath.ToSlash(prefixPath)
+
+	lstOptions := ListOptions{Recursive: true, ShowDir: DirNone}
+	if !timeRef.IsZero() || withVersions {
+		lstOptions.WithOlderVersions = withVersions
+		lstOptions.WithDeleteMarkers = true
+		lstOptions.TimeRef = timeRef
+	}
+
+	summary := statSummaryMessage{URL: targetURL}
+	var cErr error
+
+	stopProgress := func() {}
+	if !globalQuiet && term.IsTerminal(int(os.Stderr.Fd())) && !term.IsTerminal(int(os.Stdout.Fd())) {
+		done := make(chan struct{})
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					fmt.Fprint(os.Stderr, "\r\033[K")
+					return
+				case <-time.After(statProgressInterval):
+					fmt.Fprintf(os.Stderr, "\r\033[KStat of %d objects done...", atomic.LoadInt64(&summary.Objects))
+				}
+			}
+		}()
+		stopProgress = func() {
+			close(done)
+			wg.Wait()
+		}
+	}
+
+	contentCh := make(chan *ClientContent)
+	statCh := make(chan *ClientContent)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for content := range contentCh {
+				url := targetAlias + getKey(content)
+				_, stat, err := url2StatWithOptions(ctx, url, encKeyDB, StatOptions{
+					preserve:  true,
+					timeRef:   timeRef,
+					versionID: content.VersionID,
+					checksum:  withChecksum,
+				})
+				if err != nil {
+					if !errors.As(err.ToGoError(), &ObjectSSECKeyRequired{}) {
+						errorIf(err.Trace(url), "Unable to stat `"+url+"`.")
+						atomic.AddInt64(&summary.Errors, 1)
+						continue
+					}
+					// Report what the listing knows about SSE-C
+					// objects rather than skipping them.
+					stat = content
+					stat.EncryptionKeyRequired = true
+				}
+				statCh <- stat
+			}
+		}()
+	}
+
+	go func() {
+		defer close(statCh)
+		for content := range clnt.List(ctx, lstOptions) {
+			if content.Err != nil {
+				switch content.Err.ToGoError().(type) {
+				// handle this specifically for filesystem related errors.
+				case BrokenSymlink:
+					errorIf(content.Err.Trace(clnt.GetURL().String()), "Unable to list broken link.")
+					continue
+				case TooManyLevelsSymlink:
+					errorIf(content.Err.Trace(clnt.GetURL().String()), "Unable to list too many levels link.")
+					continue
+				case PathNotFound:
+					errorIf(content.Err.Trace(clnt.GetURL().String()), "Unable to list folder.")
+					continue
+				case PathInsufficientPermission:
+					errorIf(content.Err.Trace(clnt.GetURL().String()), "Unable to list folder.")
+					continue
+				}
+				errorIf(content.Err.Trace(clnt.GetURL().String()), "Unable to list folder.")
+				cErr = exitStatus(globalErrorExitStatus) // Set the exit status.
+				continue
+			}
+			if content.StorageClass == s3StorageClassGlacier {
+				continue
+			}
+			if content.IsDeleteMarker {
+				// A delete marker cannot be stat'ed, report
+				// what the listing knows about it.
+				statCh <- content
+				continue
+			}
+			contentCh <- content
+		}
+		close(contentCh)
+		wg.Wait()
+	}()
+
+	for stat := range statCh {
+		// Convert any os specific delimiters to "/".
+		stat.URL.Path = strings.TrimPrefix(filepath.ToSlash(stat.URL.Path), prefixPath)
+		msg := parseStat(stat)
+		if withChecksum {
+			msg.setChecksums(stat)
+		}
+		printMsg(msg)
+		atomic.AddInt64(&summary.Objects, 1)
+		summary.Size += stat.Size
+	}
+	stopProgress()
+
+	printMsg(summary)
+	if summary.Errors > 0 {
+		cErr = exitStatus(globalErrorExitStatus)
+	}
+	return cErr
+}
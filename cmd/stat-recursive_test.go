@@ -0,0 +1,92 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatRecursive(t *testing.T) {
+	withTempMcConfig(t)
+
+	out := captureJSONOutput(t)
+
+	dir := t.TempDir()
+	expected := make(map[string]int64)
+	var totalSize int64
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("dir%d/object%d", i%3, i)
+		if e := os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0o755); e != nil {
+			t.Fatal(e)
+		}
+		if e := ioutil.WriteFile(filepath.Join(dir, name), bytes.Repeat([]byte("x"), i), 0o644); e != nil {
+			t.Fatal(e)
+		}
+		expected[name] = int64(i)
+		totalSize += int64(i)
+	}
+
+	if e := statRecursive(context.Background(), dir+string(os.PathSeparator), time.Time{}, false, false, 4, nil); e != nil {
+		t.Fatal(e)
+	}
+
+	var summary statSummaryMessage
+	stats := make(map[string]int64)
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var record struct {
+			Name    string `json:"name"`
+			Size    int64  `json:"size"`
+			Objects *int64 `json:"objects"`
+		}
+		if e := json.Unmarshal([]byte(line), &record); e != nil {
+			t.Fatalf("unexpected record %s: %v", line, e)
+		}
+		if record.Objects != nil {
+			if e := json.Unmarshal([]byte(line), &summary); e != nil {
+				t.Fatal(e)
+			}
+			continue
+		}
+		if _, ok := stats[record.Name]; ok {
+			t.Fatalf("%s reported twice", record.Name)
+		}
+		stats[record.Name] = record.Size
+	}
+
+	// Every object is reported once by one of the workers.
+	if len(stats) != len(expected) {
+		t.Fatalf("expected %d stat records, got %d", len(expected), len(stats))
+	}
+	for name, size := range expected {
+		if got, ok := stats[name]; !ok || got != size {
+			t.Fatalf("expected %s of size %d, got %d (%v)", name, size, got, ok)
+		}
+	}
+	if summary.Objects != int64(len(expected)) || summary.Size != totalSize || summary.Errors != 0 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// isStatPending returns true if err means the object does not exist yet.
+func isStatPending(err *probe.Error) bool {
+	e := err.ToGoError()
+	return errors.As(e, &ObjectMissing{}) || errors.As(e, &PathNotFound{}) || errors.As(e, &BucketDoesNotExist{})
+}
+
+// waitStatURL stats targetURL every interval until it exists or ctx
+// is done, the error wraps context.DeadlineExceeded on a timeout.
+func waitStatURL(ctx context.Context, targetURL, versionID string, timeRef time.Time, encKeyDB map[string][]prefixSSEPair, interval time.Duration) *probe.Error {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return probe.NewError(ctx.Err()).Trace(targetURL)
+		case <-timer.C:
+		}
+		_, _, err := url2Stat(ctx, targetURL, versionID, false, encKeyDB, timeRef, false)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return probe.NewError(ctx.Err()).Trace(targetURL)
+		}
+		if !isStatPending(err) {
+			return err.Trace(targetURL)
+		}
+		timer.Reset(interval)
+	}
+}
@@ -20,6 +20,7 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -49,10 +50,15 @@ type statMessage struct {
 	Expiration        *time.Time        `json:"expiration,omitempty"`
 	ExpirationRuleID  string            `json:"expirationRuleID,omitempty"`
 	ReplicationStatus string            `json:"replicationStatus,omitempty"`
+	Encryption        string            `json:"encryption,omitempty"`
+	EncKeyRequired    bool              `json:"encryptionKeyRequired,omitempty"`
 	Metadata          map[string]string `json:"metadata,omitempty"`
 	VersionID         string            `json:"versionID,omitempty"`
 	DeleteMarker      bool              `json:"deleteMarker,omitempty"`
-	singleObject      bool
+	// Set only with --checksum, empty when the object has no
+	// additional checksum.
+	Checksums    *map[string]string `json:"checksums,omitempty"`
+	singleObject bool
 }
 
 func (stat statMessage) String() (msg string) {
@@ -73,6 +79,27 @@ func (stat statMessage) String() (msg string) {
 		msgBuilder.WriteString(fmt.Sprintf("%-10s: %s ", "VersionID", versionIDField) + "\n")
 	}
 	msgBuilder.WriteString(fmt.Sprintf("%-10s: %s ", "Type", stat.Type) + "\n")
+	if stat.Checksums != nil {
+		checksums := *stat.Checksums
+		if len(checksums) == 0 {
+			msgBuilder.WriteString(fmt.Sprintf("%-10s: %s ", "Checksum", "none") + "\n")
+		}
+		algorithms := make([]string, 0, len(checksums))
+		for algorithm := range checksums {
+			algorithms = append(algorithms, algorithm)
+		}
+		sort.Strings(algorithms)
+		for _, algorithm := range algorithms {
+			msgBuilder.WriteString(fmt.Sprintf("%-10s: %s %s ", "Checksum", algorithm, checksums[algorithm]) + "\n")
+		}
+	}
+	if stat.Encryption != "" {
+		encryption := stat.Encryption
+		if stat.EncKeyRequired {
+			encryption += " (encrypted, key required)"
+		}
+		msgBuilder.WriteString(fmt.Sprintf("%-10s: %s ", "Encryption", encryption) + "\n")
+	}
 	if stat.Expires != nil {
 		msgBuilder.WriteString(fmt.Sprintf("%-10s: %s ", "Expires", stat.Expires.Format(printDate)) + "\n")
 	}
@@ -141,6 +168,7 @@ func parseStat(c *ClientContent) statMessage {
 	}()
 	content.Size = c.Size
 	content.VersionID = c.VersionID
+	content.DeleteMarker = c.IsDeleteMarker
 	content.Key = getKey(c)
 	content.Metadata = c.Metadata
 	content.ETag = strings.TrimPrefix(c.ETag, "\"")
@@ -153,9 +181,50 @@ func parseStat(c *ClientContent) statMessage {
 	}
 	content.ExpirationRuleID = c.ExpirationRuleID
 	content.ReplicationStatus = c.ReplicationStatus
+	content.Encryption = encryptionType(c.Metadata)
+	if c.EncryptionKeyRequired {
+		content.Encryption = "SSE-C"
+		content.EncKeyRequired = true
+	}
 	return content
 }
 
+// setChecksums reports the additional checksums stored with the object,
+// which are then left out of its metadata.
+func (stat *statMessage) setChecksums(c *ClientContent) {
+	checksums := selectChecksums(c)
+	stat.Checksums = &checksums
+	if len(c.Metadata) == 0 {
+		return
+	}
+	metadata := make(map[string]string, len(c.Metadata))
+	for k, v := range c.Metadata {
+		if !strings.HasPrefix(strings.ToLower(k), "x-amz-checksum-") {
+			metadata[k] = v
+		}
+	}
+	stat.Metadata = metadata
+}
+
+// encryptionType returns the server side encryption type of an object,
+// SSE-C, SSE-KMS or SSE-S3, from its metadata headers.
+func encryptionType(metadata map[string]string) string {
+	for k, v := range metadata {
+		switch strings.ToLower(k) {
+		case "x-amz-server-side-encryption-customer-algorithm":
+			return "SSE-C"
+		case serverEncryptionKeyPrefix:
+			if strings.EqualFold(v, "aws:kms") {
+				return "SSE-KMS"
+			}
+			if strings.EqualFold(v, "AES256") {
+				return "SSE-S3"
+			}
+		}
+	}
+	return ""
+}
+
 // Return standardized URL to be used to compare later.
 func getStandardizedURL(targetURL string) string {
 	return filepath.FromSlash(targetURL)
@@ -164,7 +233,7 @@ func getStandardizedURL(targetURL string) string {
 // statURL - uses combination of GET listing and HEAD to fetch information of one or more objects
 // HEAD can fail with 400 with an SSE-C encrypted object but we still return information gathered
 // from GET listing.
-func statURL(ctx context.Context, targetURL, versionID string, timeRef time.Time, includeOlderVersions, isIncomplete, isRecursive bool, encKeyDB map[string][]prefixSSEPair) ([]*ClientContent, []*BucketInfo, *probe.Error) {
+func statURL(ctx context.Context, targetURL, versionID string, timeRef time.Time, includeOlderVersions, isIncomplete, isRecursive, withChecksum bool, encKeyDB map[string][]prefixSSEPair) ([]*ClientContent, []*BucketInfo, *probe.Error) {
 	var stats []*ClientContent
 	var bucketStats []*BucketInfo
 	var clnt Client
@@ -196,10 +265,10 @@ func statURL(ctx context.Context, targetURL, versionID string, timeRef time.Time
 			switch content.Err.ToGoError().(type) {
 			// handle this specifically for filesystem related errors.
 			case BrokenSymlink:
-				errorIf(content.Err.Trace(clnt.GetURL().String()), "Unable to list broken link.")
+				warningIf(content.Err.Trace(clnt.GetURL().String()), "Unable to list broken link.")
 				continue
 			case TooManyLevelsSymlink:
-				errorIf(content.Err.Trace(clnt.GetURL().String()), "Unable to list too many levels link.")
+				warningIf(content.Err.Trace(clnt.GetURL().String()), "Unable to list too many levels link.")
 				continue
 			case PathNotFound:
 				errorIf(content.Err.Trace(clnt.GetURL().String()), "Unable to list folder.")
@@ -229,9 +298,20 @@ func statURL(ctx context.Context, targetURL, versionID string, timeRef time.Time
 				continue
 			}
 		}
-		clnt, stat, err := url2Stat(ctx, url, content.VersionID, true, encKeyDB, timeRef, false)
+		clnt, stat, err := url2StatWithOptions(ctx, url, encKeyDB, StatOptions{
+			preserve:  true,
+			timeRef:   timeRef,
+			versionID: content.VersionID,
+			checksum:  withChecksum,
+		})
 		if err != nil {
-			continue
+			if !errors.As(err.ToGoError(), &ObjectSSECKeyRequired{}) {
+				continue
+			}
+			// Report what the listing knows about SSE-C
+			// objects rather than skipping them.
+			clnt, stat = nil, content
+			stat.EncryptionKeyRequired = true
 		}
 		// if stat is on a bucket and non-recursive mode, serve the bucket metadata
 		if clnt != nil && !isRecursive && stat.Type.IsDir() {
@@ -18,7 +18,10 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -68,3 +71,111 @@ func TestParseStat(t *testing.T) {
 		})
 	}
 }
+
+func TestParseStatEncryption(t *testing.T) {
+	testCases := []struct {
+		content     ClientContent
+		encryption  string
+		keyRequired bool
+	}{
+		{ClientContent{Metadata: map[string]string{"Content-Type": "text/plain"}}, "", false},
+		{ClientContent{Metadata: map[string]string{"X-Amz-Server-Side-Encryption": "AES256"}}, "SSE-S3", false},
+		{ClientContent{Metadata: map[string]string{"X-Amz-Server-Side-Encryption": "aws:kms", "X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id": "my-key"}}, "SSE-KMS", false},
+		{ClientContent{Metadata: map[string]string{"X-Amz-Server-Side-Encryption-Customer-Algorithm": "AES256"}}, "SSE-C", false},
+		{ClientContent{EncryptionKeyRequired: true}, "SSE-C", true},
+	}
+	for i, testCase := range testCases {
+		statMsg := parseStat(&testCase.content)
+		if statMsg.Encryption != testCase.encryption {
+			t.Errorf("Test %d: expecting %q, got %q", i+1, testCase.encryption, statMsg.Encryption)
+		}
+		if statMsg.EncKeyRequired != testCase.keyRequired {
+			t.Errorf("Test %d: expecting key required %t, got %t", i+1, testCase.keyRequired, statMsg.EncKeyRequired)
+		}
+	}
+}
+
+func TestParseStatDeleteMarker(t *testing.T) {
+	statMsg := parseStat(&ClientContent{URL: *newClientURL("https://play.min.io/bucket/obj"), VersionID: "v1", IsDeleteMarker: true})
+	if !statMsg.DeleteMarker {
+		t.Fatal("expecting the delete marker to be reported")
+	}
+	if !strings.Contains(statMsg.String(), "v1 (delete-marker)") {
+		t.Fatalf("expecting the version to be flagged as delete marker, got %s", statMsg.String())
+	}
+}
+
+func TestStatChecksums(t *testing.T) {
+	testCases := []struct {
+		metadata  map[string]string
+		checksums map[string]string
+		metaLeft  map[string]string
+		output    []string
+	}{
+		{
+			map[string]string{"Content-Type": "text/plain"},
+			map[string]string{},
+			map[string]string{"Content-Type": "text/plain"},
+			[]string{"Checksum  : none"},
+		},
+		{
+			map[string]string{"Content-Type": "text/plain", "X-Amz-Checksum-Crc32c": "yZRlqg==", "X-Amz-Checksum-Mode": "ENABLED"},
+			map[string]string{"CRC32C": "yZRlqg=="},
+			map[string]string{"Content-Type": "text/plain"},
+			[]string{"Checksum  : CRC32C yZRlqg=="},
+		},
+		{
+			map[string]string{"X-Amz-Checksum-Sha256": "n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg=", "X-Amz-Checksum-Sha1": "qZk+NkcGgWq6PiVxeFDCbJzQ2J0="},
+			map[string]string{"SHA256": "n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg=", "SHA1": "qZk+NkcGgWq6PiVxeFDCbJzQ2J0="},
+			map[string]string{},
+			[]string{"Checksum  : SHA1 qZk+NkcGgWq6PiVxeFDCbJzQ2J0=", "Checksum  : SHA256 n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg="},
+		},
+	}
+	for i, testCase := range testCases {
+		content := ClientContent{URL: *newClientURL("https://play.min.io/bucket/object"), Type: 0o644, Metadata: testCase.metadata}
+		statMsg := parseStat(&content)
+		if statMsg.Checksums != nil {
+			t.Fatalf("Test %d: expecting no checksums without --checksum", i+1)
+		}
+		statMsg.setChecksums(&content)
+		if !reflect.DeepEqual(*statMsg.Checksums, testCase.checksums) {
+			t.Errorf("Test %d: expecting %v, got %v", i+1, testCase.checksums, *statMsg.Checksums)
+		}
+		if !reflect.DeepEqual(statMsg.Metadata, testCase.metaLeft) {
+			t.Errorf("Test %d: expecting metadata %v, got %v", i+1, testCase.metaLeft, statMsg.Metadata)
+		}
+		output := statMsg.String()
+		last := -1
+		for _, line := range testCase.output {
+			idx := strings.Index(output, line)
+			if idx < 0 || idx < last {
+				t.Errorf("Test %d: expecting %q in order in %q", i+1, line, output)
+			}
+			last = idx
+		}
+	}
+}
+
+func TestWaitStatURL(t *testing.T) {
+	withTempMcConfig(t)
+
+	dir := t.TempDir()
+	target := filepath.Join(dir, "object")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := waitStatURL(ctx, target, "", time.Time{}, nil, 10*time.Millisecond)
+	if err == nil || !errors.Is(err.ToGoError(), context.DeadlineExceeded) {
+		t.Fatalf("expected a timeout waiting for a missing object, got %v", err)
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		os.WriteFile(target, []byte("data"), 0o644)
+	}()
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err = waitStatURL(ctx, target, "", time.Time{}, nil, 10*time.Millisecond); err != nil {
+		t.Fatalf("unexpected error waiting for the object: %v", err)
+	}
+}
@@ -89,6 +89,7 @@ func mainCallhome(ctx *cli.Context) error {
 		return nil
 	}
 
+	fatalIfReadOnly("support callhome " + arg)
 	setCallhomeConfig(alias, arg == "enable")
 
 	return nil
@@ -25,12 +25,14 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"math/rand"
 	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -57,6 +59,11 @@ var supportDiagFlags = append([]cli.Flag{
 		Value:  1 * time.Hour,
 		Hidden: true,
 	},
+	cli.IntFlag{
+		Name:  "max-attempts",
+		Usage: "request the diagnostics of the whole cluster up to N times, retrying with backoff within the deadline",
+		Value: 1,
+	},
 	cli.StringFlag{
 		Name:   "license",
 		Usage:  "SUBNET license key",
@@ -92,6 +99,9 @@ EXAMPLES:
 
   2. Generate MinIO diagnostics report for alias 'play' (https://play.min.io by default) save and upload to SUBNET manually
      {{.Prompt}} {{.HelpName}} play --airgap
+
+  3. Upload MinIO diagnostics report for 'play', retrying up to 3 times the requests to the cluster
+     {{.Prompt}} {{.HelpName}} play --max-attempts 3
 `,
 }
 
@@ -100,6 +110,9 @@ func checkSupportDiagSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) == 0 || len(ctx.Args()) > 1 {
 		cli.ShowCommandHelpAndExit(ctx, "diag", 1) // last argument is exit code
 	}
+	if ctx.Int("max-attempts") < 1 {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--max-attempts should be at least 1")
+	}
 }
 
 // compress and tar MinIO diagnostics output
@@ -128,20 +141,26 @@ func tarGZ(healthInfo interface{}, version string, filename string, showMessages
 	}
 
 	if showMessages {
-		warningMsgBoundary := "*********************************************************************************"
-		warning := warnText("                                   WARNING!!")
-		warningContents := infoText(`     ** THIS FILE MAY CONTAIN SENSITIVE INFORMATION ABOUT YOUR ENVIRONMENT **
-     ** PLEASE INSPECT CONTENTS BEFORE SHARING IT ON ANY PUBLIC FORUM **`)
-
-		warningMsgHeader := infoText(warningMsgBoundary)
-		warningMsgTrailer := infoText(warningMsgBoundary)
-		console.Printf("%s\n%s\n%s\n%s\n", warningMsgHeader, warning, warningContents, warningMsgTrailer)
+		printSensitiveInfoWarning()
 		console.Infoln("MinIO diagnostics report saved at", filename)
 	}
 
 	return nil
 }
 
+// printSensitiveInfoWarning warns that a downloaded file
+// should be inspected before sharing it.
+func printSensitiveInfoWarning() {
+	warningMsgBoundary := "*********************************************************************************"
+	warning := warnText("                                   WARNING!!")
+	warningContents := infoText(`     ** THIS FILE MAY CONTAIN SENSITIVE INFORMATION ABOUT YOUR ENVIRONMENT **
+     ** PLEASE INSPECT CONTENTS BEFORE SHARING IT ON ANY PUBLIC FORUM **`)
+
+	warningMsgHeader := infoText(warningMsgBoundary)
+	warningMsgTrailer := infoText(warningMsgBoundary)
+	console.Printf("%s\n%s\n%s\n%s\n", warningMsgHeader, warning, warningContents, warningMsgTrailer)
+}
+
 func infoText(s string) string {
 	console.SetColor("INFO", color.New(color.FgGreen, color.Bold))
 	return console.Colorize("INFO", s)
@@ -234,6 +253,7 @@ func execSupportDiag(ctx *cli.Context, client *madmin.AdminClient, alias string,
 		}
 		return
 	}
+	printDiagNodesStatus(healthInfo)
 
 	e = tarGZ(healthInfo, version, filename, !uploadToSubnet)
 	fatalIf(probe.NewError(e), "Unable to save MinIO diagnostics report")
@@ -383,16 +403,35 @@ func fetchServerDiagInfo(ctx *cli.Context, client *madmin.AdminClient) (interfac
 		}
 	}
 
+	pending := newDiagPending()
+	deadline := ctx.Duration("deadline")
+	warnTimer := time.AfterFunc(time.Duration(float64(deadline)*diagDeadlineWarning), func() {
+		if outstanding := pending.outstanding(); len(outstanding) > 0 {
+			fmt.Fprintf(os.Stderr, "%s\n\n", warnText(fmt.Sprintf("%d%% of the %s deadline elapsed, still collecting: %s",
+				int(diagDeadlineWarning*100), deadline, strings.Join(outstanding, ", "))))
+		}
+	})
+	defer warnTimer.Stop()
+
 	spinner := func(resource string, opt madmin.HealthDataType) func(bool) bool {
 		var spinStopper func()
 		done := false
 
 		_, ok := optsMap[opt] // check if option is enabled
-		if globalJSON || !ok {
+		if !ok {
 			return func(bool) bool {
 				return true
 			}
 		}
+		pending.add(resource)
+		if globalJSON {
+			return func(cond bool) bool {
+				if cond {
+					pending.done(resource)
+				}
+				return true
+			}
+		}
 
 		return func(cond bool) bool {
 			if done {
@@ -403,6 +442,7 @@ func fetchServerDiagInfo(ctx *cli.Context, client *madmin.AdminClient) (interfac
 			}
 			if cond {
 				done = true
+				pending.done(resource)
 				spinStopper()
 			}
 			return done
@@ -465,7 +505,7 @@ func fetchServerDiagInfo(ctx *cli.Context, client *madmin.AdminClient) (interfac
 
 	var err error
 	// Fetch info of all servers (cluster or single server)
-	resp, version, err := client.ServerHealthInfo(cont, *opts, ctx.Duration("deadline"))
+	resp, version, err := serverHealthInfoWithRetry(cont, client, *opts, deadline, ctx.Int("max-attempts"))
 	if err != nil {
 		cancel()
 		return nil, "", err
@@ -535,6 +575,101 @@ func fetchServerDiagInfo(ctx *cli.Context, client *madmin.AdminClient) (interfac
 	return healthInfo, version, err
 }
 
+const (
+	diagRetryUnit = time.Second
+	diagRetryCap  = 30 * time.Second
+
+	// Fraction of the deadline after which outstanding data types are reported.
+	diagDeadlineWarning = 0.8
+)
+
+// diagPending tracks the diagnostics data types still being collected.
+type diagPending struct {
+	mutex     sync.Mutex
+	resources []string
+	pending   map[string]bool
+}
+
+func newDiagPending() *diagPending {
+	return &diagPending{pending: make(map[string]bool)}
+}
+
+func (d *diagPending) add(resource string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.resources = append(d.resources, resource)
+	d.pending[resource] = true
+}
+
+func (d *diagPending) done(resource string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	delete(d.pending, resource)
+}
+
+// outstanding returns the data types not collected yet, in collection order.
+func (d *diagPending) outstanding() (resources []string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for _, resource := range d.resources {
+		if d.pending[resource] {
+			resources = append(resources, resource)
+		}
+	}
+	return resources
+}
+
+// serverHealthInfoWithRetry requests the health information of the cluster, up to
+// maxAttempts times with a jittered backoff, all the attempts sharing the deadline.
+func serverHealthInfoWithRetry(ctx context.Context, client *madmin.AdminClient, opts []madmin.HealthDataType, deadline time.Duration, maxAttempts int) (*http.Response, string, error) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	backoff := diagRetryUnit
+	end := time.Now().Add(deadline)
+	for failures := 1; ; failures++ {
+		resp, version, err := client.ServerHealthInfo(ctx, opts, time.Until(end))
+		if err == nil {
+			return resp, version, nil
+		}
+		if maxAttempts == 1 {
+			return nil, "", err
+		}
+		if failures >= maxAttempts {
+			return nil, "", fmt.Errorf("giving up after %d failed attempt(s): %w", failures, err)
+		}
+
+		// Sleep anywhere between half and the full backoff duration.
+		sleep := backoff/2 + time.Duration(r.Int63n(int64(backoff/2)+1))
+		if time.Until(end) <= sleep {
+			return nil, "", fmt.Errorf("giving up after %d failure(s), the %s deadline elapsed: %w", failures, deadline, err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, "", ctx.Err()
+		case <-time.After(sleep):
+		}
+
+		if backoff *= 2; backoff > diagRetryCap {
+			backoff = diagRetryCap
+		}
+	}
+}
+
+// printDiagNodesStatus prints the status of every node which took part in
+// the diagnostics collection, nodes which failed to report are highlighted.
+func printDiagNodesStatus(healthInfo interface{}) {
+	info, ok := healthInfo.(madmin.HealthInfo)
+	if !ok {
+		return
+	}
+	for _, srv := range info.Minio.Info.Servers {
+		if srv.State == string(madmin.ItemOnline) {
+			console.Printf("%s %s %s\n", infoText(dot), greenText(srv.Endpoint), infoText(srv.State))
+		} else {
+			console.Printf("%s %s %s\n", warnText(dot), srv.Endpoint, warnText("failed ("+srv.State+")"))
+		}
+	}
+}
+
 // HealthDataTypeSlice is a typed list of health tests
 type HealthDataTypeSlice []madmin.HealthDataType
 
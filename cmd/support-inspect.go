@@ -89,6 +89,14 @@ func checkSupportInspectSyntax(ctx *cli.Context) {
 	if ctx.IsSet("export") && globalJSON {
 		fatalIf(errInvalidArgument(), "--export=type cannot be specified with --json flag")
 	}
+	if v := ctx.String("export"); ctx.IsSet("export") && v != "json" && v != "djson" {
+		fatalIf(errInvalidArgument().Trace("export="+v), "Unable to export inspect data, only `json` and `djson` are supported.")
+	}
+
+	aliasedURL := filepath.ToSlash(ctx.Args().Get(0))
+	if splits := splitStr(aliasedURL, "/", 3); splits[1] == "" || splits[2] == "" {
+		fatalIf(errInvalidArgument().Trace(aliasedURL), "Unable to inspect `"+aliasedURL+"`, a path of the form ALIAS/BUCKET/PATH is required.")
+	}
 }
 
 // mainSupportInspect - the entry function of inspect command
@@ -163,22 +171,25 @@ func mainSupportInspect(ctx *cli.Context) error {
 
 	fatalIf(probe.NewError(moveFile(tmpFile.Name(), downloadPath)), "Unable to rename downloaded data, file exists at %s", tmpFile.Name())
 	if ctx.IsSet("export") {
+		var e error
 		switch v := ctx.String("export"); v {
 		case "json":
-			inspectToExportType(downloadPath, false)
+			e = inspectToExportType(downloadPath, false, os.Stdout)
 		case "djson":
-			inspectToExportType(downloadPath, true)
+			e = inspectToExportType(downloadPath, true, os.Stdout)
 		default:
 			os.Remove(downloadPath)
 			fatalIf(errInvalidArgument().Trace("export="+v), "Unable to export inspect data")
 		}
 		os.Remove(downloadPath)
+		fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to export inspect data")
 		return nil
 	}
 
 	hexKey := hex.EncodeToString(id[:]) + hex.EncodeToString(key[:])
 	if !globalJSON {
 		if !encrypt {
+			printSensitiveInfoWarning()
 			console.Infof("File data successfully downloaded as %s\n", console.Colorize("File", downloadPath))
 			return nil
 		}
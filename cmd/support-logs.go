@@ -97,6 +97,7 @@ func mainLogs(ctx *cli.Context) error {
 		return nil
 	}
 
+	fatalIfReadOnly("support logs " + arg)
 	configureSubnetWebhook(alias, arg == "enable")
 
 	return nil
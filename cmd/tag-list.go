@@ -46,6 +46,10 @@ var tagListFlags = []cli.Flag{
 		Name:  "versions",
 		Usage: "list tags on all versions for an object",
 	},
+	cli.BoolFlag{
+		Name:  "bucket",
+		Usage: "list tags of the bucket itself, used when target is a bucket with no key",
+	},
 }
 
 var tagListCmd = cli.Command{
@@ -85,6 +89,9 @@ EXAMPLES:
 
   6. List the tags assigned to a bucket in JSON format.
      {{.Prompt}} {{.HelpName}} --json s3/testbucket
+
+  7. List the tags assigned to a bucket, failing if the target is not a bucket.
+     {{.Prompt}} {{.HelpName}} --bucket s3/testbucket
 `,
 }
 
@@ -94,6 +101,7 @@ type tagListMessage struct {
 	Status    string            `json:"status"`
 	URL       string            `json:"url"`
 	VersionID string            `json:"versionID"`
+	Bucket    bool              `json:"bucket,omitempty"`
 }
 
 func (t tagListMessage) JSON() string {
@@ -114,8 +122,12 @@ func (t tagListMessage) String() string {
 	sort.Strings(keys)
 
 	maxKeyLen += 2 // add len(" :")
+	name := t.URL + " (" + t.VersionID + ")"
+	if t.Bucket {
+		name = t.URL
+	}
 	strs := []string{
-		fmt.Sprintf("%v%*v %v", console.Colorize("Name", "Name"), maxKeyLen-4, ":", console.Colorize("Name", t.URL+" ("+t.VersionID+")")),
+		fmt.Sprintf("%v%*v %v", console.Colorize("Name", "Name"), maxKeyLen-4, ":", console.Colorize("Name", name)),
 	}
 
 	for _, key := range keys {
@@ -152,7 +164,7 @@ func parseTagListSyntax(ctx *cli.Context) (targetURL, versionID string, timeRef
 }
 
 // showTags pretty prints tags of a bucket or a specified object/version
-func showTags(ctx context.Context, clnt Client, versionID string, verbose bool) {
+func showTags(ctx context.Context, clnt Client, versionID string, bucket bool) {
 	targetName := clnt.GetURL().String()
 	if versionID != "" {
 		targetName += " (" + versionID + ")"
@@ -172,6 +184,7 @@ func showTags(ctx context.Context, clnt Client, versionID string, verbose bool)
 		Status:    "success",
 		URL:       clnt.GetURL().String(),
 		VersionID: versionID,
+		Bucket:    bucket,
 	})
 }
 
@@ -192,8 +205,11 @@ func mainListTag(cliCtx *cli.Context) error {
 	clnt, err := newClient(targetURL)
 	fatalIf(err, "Unable to initialize target "+targetURL)
 
-	if timeRef.IsZero() && !withVersions {
-		showTags(ctx, clnt, versionID, true)
+	if cliCtx.Bool("bucket") {
+		checkBucketTagSyntax(cliCtx, clnt, "")
+		showTags(ctx, clnt, "", true)
+	} else if timeRef.IsZero() && !withVersions {
+		showTags(ctx, clnt, versionID, false)
 	} else {
 		for content := range clnt.List(ctx, ListOptions{TimeRef: timeRef, WithOlderVersions: withVersions}) {
 			if content.Err != nil {
@@ -19,6 +19,8 @@ package cmd
 
 import (
 	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7/pkg/tags"
 )
 
 var tagSubcommands = []cli.Command{
@@ -41,3 +43,23 @@ func mainTag(ctx *cli.Context) error {
 	commandNotFound(ctx, tagSubcommands)
 	return nil
 }
+
+// checkBucketTagSyntax validates the target and the optional tags of a
+// bucket tagging operation, requested using --bucket flag.
+func checkBucketTagSyntax(ctx *cli.Context, clnt Client, tagStr string) {
+	if ctx.String("version-id") != "" || ctx.String("rewind") != "" || ctx.Bool("versions") {
+		fatalIf(errDummy().Trace(), "You cannot specify --version-id, --rewind or --versions flags with --bucket")
+	}
+
+	targetURL := clnt.GetURL()
+	bucket, object := url2BucketAndObject(&targetURL)
+	if bucket == "" || object != "" {
+		fatalIf(errInvalidArgument().Trace(targetURL.String()), "Target `"+targetURL.String()+"` is not a bucket.")
+	}
+
+	if tagStr != "" {
+		// Validate keys, values and the number of tags allowed on a bucket.
+		_, e := tags.Parse(tagStr, false)
+		fatalIf(probe.NewError(e).Trace(tagStr), "Invalid bucket tags `"+tagStr+"`.")
+	}
+}
@@ -41,6 +41,10 @@ var tagRemoveFlags = []cli.Flag{
 		Name:  "versions",
 		Usage: "remote tags on multiple versions of an object",
 	},
+	cli.BoolFlag{
+		Name:  "bucket",
+		Usage: "remove tags of the bucket itself, used when target is a bucket with no key",
+	},
 }
 
 var tagRemoveCmd = cli.Command{
@@ -74,6 +78,9 @@ EXAMPLES:
 
   4. Remove the tags assigned to a bucket.
      {{.Prompt}} {{.HelpName}} play/testbucket
+
+  5. Remove the tags assigned to a bucket, failing if the target is not a bucket.
+     {{.Prompt}} {{.HelpName}} --bucket play/testbucket
 `,
 }
 
@@ -82,12 +89,17 @@ type tagRemoveMessage struct {
 	Status    string `json:"status"`
 	Name      string `json:"name"`
 	VersionID string `json:"versionID"`
+	Bucket    bool   `json:"bucket,omitempty"`
 }
 
 // tagRemoveMessage console colorized output.
 func (t tagRemoveMessage) String() string {
 	var msg string
-	msg += "Tags removed for " + t.Name
+	msg += "Tags removed for "
+	if t.Bucket {
+		msg += "bucket "
+	}
+	msg += t.Name
 	if t.VersionID != "" {
 		msg += " (" + t.VersionID + ")"
 	}
@@ -121,7 +133,7 @@ func parseRemoveTagSyntax(ctx *cli.Context) (targetURL, versionID string, timeRe
 }
 
 // Delete tags of a bucket or a specified object/version
-func deleteTags(ctx context.Context, clnt Client, versionID string, verbose bool) {
+func deleteTags(ctx context.Context, clnt Client, versionID string, bucket bool) {
 	targetName := clnt.GetURL().String()
 	if versionID != "" {
 		targetName += " (" + versionID + ")"
@@ -137,6 +149,7 @@ func deleteTags(ctx context.Context, clnt Client, versionID string, verbose bool
 		Status:    "success",
 		Name:      clnt.GetURL().String(),
 		VersionID: versionID,
+		Bucket:    bucket,
 	})
 }
 
@@ -154,8 +167,11 @@ func mainRemoveTag(cliCtx *cli.Context) error {
 	clnt, pErr := newClient(targetURL)
 	fatalIf(pErr, "Unable to initialize target "+targetURL)
 
-	if timeRef.IsZero() && !withVersions {
-		deleteTags(ctx, clnt, versionID, true)
+	if cliCtx.Bool("bucket") {
+		checkBucketTagSyntax(cliCtx, clnt, "")
+		deleteTags(ctx, clnt, "", true)
+	} else if timeRef.IsZero() && !withVersions {
+		deleteTags(ctx, clnt, versionID, false)
 	} else {
 		for content := range clnt.List(ctx, ListOptions{TimeRef: timeRef, WithOlderVersions: withVersions}) {
 			if content.Err != nil {
@@ -41,6 +41,10 @@ var tagSetFlags = []cli.Flag{
 		Name:  "versions",
 		Usage: "set tags on multiple versions for an object",
 	},
+	cli.BoolFlag{
+		Name:  "bucket",
+		Usage: "set tags on the bucket itself, used when target is a bucket with no key",
+	},
 }
 
 var tagSetCmd = cli.Command{
@@ -73,6 +77,9 @@ EXAMPLES:
 
   4. Assign tags to a bucket.
      {{.Prompt}} {{.HelpName}} myminio/testbucket "key1=value1&key2=value2&key3=value3"
+
+  5. Assign cost allocation tags to a bucket, failing if the target is not a bucket.
+     {{.Prompt}} {{.HelpName}} --bucket myminio/testbucket "project=apollo&cost-center=42"
 `,
 }
 
@@ -81,12 +88,17 @@ type tagSetMessage struct {
 	Status    string `json:"status"`
 	Name      string `json:"name"`
 	VersionID string `json:"versionID"`
+	Bucket    bool   `json:"bucket,omitempty"`
 }
 
 // tagSetMessage console colorized output.
 func (t tagSetMessage) String() string {
 	var msg string
-	msg += "Tags set for " + t.Name
+	msg += "Tags set for "
+	if t.Bucket {
+		msg += "bucket "
+	}
+	msg += t.Name
 	if t.VersionID != "" {
 		msg += " (" + t.VersionID + ")"
 	}
@@ -121,7 +133,7 @@ func parseSetTagSyntax(ctx *cli.Context) (targetURL, versionID string, timeRef t
 }
 
 // Set tags to a bucket or to a specified object/version
-func setTags(ctx context.Context, clnt Client, versionID, tags string, verbose bool) {
+func setTags(ctx context.Context, clnt Client, versionID, tags string, bucket bool) {
 	targetName := clnt.GetURL().String()
 	if versionID != "" {
 		targetName += " (" + versionID + ")"
@@ -136,6 +148,7 @@ func setTags(ctx context.Context, clnt Client, versionID, tags string, verbose b
 		Status:    "success",
 		Name:      clnt.GetURL().String(),
 		VersionID: versionID,
+		Bucket:    bucket,
 	})
 }
 
@@ -153,8 +166,11 @@ func mainSetTag(cliCtx *cli.Context) error {
 	clnt, err := newClient(targetURL)
 	fatalIf(err.Trace(cliCtx.Args()...), "Unable to initialize target "+targetURL)
 
-	if timeRef.IsZero() && !withVersions {
-		setTags(ctx, clnt, versionID, tags, true)
+	if cliCtx.Bool("bucket") {
+		checkBucketTagSyntax(cliCtx, clnt, tags)
+		setTags(ctx, clnt, "", tags, true)
+	} else if timeRef.IsZero() && !withVersions {
+		setTags(ctx, clnt, versionID, tags, false)
 	} else {
 		for content := range clnt.List(ctx, ListOptions{TimeRef: timeRef, WithOlderVersions: withVersions}) {
 			if content.Err != nil {
@@ -0,0 +1,71 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+// withTempMcConfig points the mc configuration to an empty temporary
+// directory until the end of the test.
+func withTempMcConfig(t *testing.T) {
+	t.Helper()
+	dir, load := mcCustomConfigDir, loadMcConfig
+	t.Cleanup(func() {
+		setMcConfigDir(dir)
+		loadMcConfig = load
+	})
+	setMcConfigDir(t.TempDir())
+	loadMcConfig = loadMcConfigFactory()
+}
+
+// captureJSONOutput prints the messages as JSON lines to the returned
+// buffer until the end of the test.
+func captureJSONOutput(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	jsonFlag, jsonLine, output := globalJSON, globalJSONLine, color.Output
+	t.Cleanup(func() {
+		globalJSON, globalJSONLine, color.Output = jsonFlag, jsonLine, output
+	})
+	var buf bytes.Buffer
+	globalJSON, globalJSONLine, color.Output = true, true, &buf
+	return &buf
+}
+
+// newS3StubServer starts an S3 server registered as alias, it answers
+// the bucket location lookups with us-east-1 and passes the other
+// requests to handler.
+func newS3StubServer(t *testing.T, alias string, handler http.Handler) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["location"]; ok {
+			w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv("MC_HOST_"+alias, strings.Replace(server.URL, "http://", "http://minio:minio123@", 1))
+	return server
+}
@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 	"sync/atomic"
@@ -28,7 +29,9 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	humanize "github.com/dustin/go-humanize"
+	json "github.com/minio/colorjson"
 	"github.com/minio/madmin-go"
+	"github.com/minio/mc/pkg/probe"
 	"github.com/olekukonko/tablewriter"
 )
 
@@ -63,13 +66,152 @@ func (s *topAPIStats) loadAPIBytesTX() uint64 {
 	return atomic.LoadUint64(&s.TotalBytesTX)
 }
 
+// topAPIAggregate accumulates per API and per bucket statistics
+// from a stream of trace events.
+type topAPIAggregate struct {
+	apiStatsMap    map[string]*topAPIStats
+	bucketStatsMap map[string]*topAPIStats
+}
+
+func newTopAPIAggregate() *topAPIAggregate {
+	return &topAPIAggregate{
+		apiStatsMap:    make(map[string]*topAPIStats),
+		bucketStatsMap: make(map[string]*topAPIStats),
+	}
+}
+
+// traceBucket returns the bucket name an S3 API call was made on.
+func traceBucket(t madmin.TraceInfo) string {
+	if !strings.HasPrefix(t.FuncName, "s3.") {
+		return ""
+	}
+	bucket := strings.TrimPrefix(t.ReqInfo.Path, "/")
+	if i := strings.Index(bucket, "/"); i >= 0 {
+		bucket = bucket[:i]
+	}
+	return bucket
+}
+
+func addTopAPIStats(statsMap map[string]*topAPIStats, key string, t madmin.TraceInfo) {
+	st, ok := statsMap[key]
+	if !ok {
+		st = &topAPIStats{}
+		statsMap[key] = st
+	}
+	st.addAPICall(1)
+	st.addAPIBytesRX(t.CallStats.InputBytes)
+	st.addAPIBytesTX(t.CallStats.OutputBytes)
+}
+
+func (a *topAPIAggregate) add(t madmin.TraceInfo) {
+	if t.FuncName == "" || t.FuncName == "errorResponseHandler" {
+		return
+	}
+	addTopAPIStats(a.apiStatsMap, t.FuncName, t)
+	if bucket := traceBucket(t); bucket != "" {
+		addTopAPIStats(a.bucketStatsMap, bucket, t)
+	}
+}
+
+// topAPIEntry is a single row of the API or bucket table.
+type topAPIEntry struct {
+	Name  string `json:"name"`
+	Calls uint64 `json:"calls"`
+	RX    uint64 `json:"rx"`
+	TX    uint64 `json:"tx"`
+}
+
+// sortedTopAPIEntries returns the entries of statsMap, busiest first.
+func sortedTopAPIEntries(statsMap map[string]*topAPIStats) []topAPIEntry {
+	entries := make([]topAPIEntry, 0, len(statsMap))
+	for k, stats := range statsMap {
+		entries = append(entries, topAPIEntry{
+			Name:  k,
+			Calls: stats.loadAPICall(),
+			RX:    stats.loadAPIBytesRX(),
+			TX:    stats.loadAPIBytesTX(),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Calls != entries[j].Calls {
+			return entries[i].Calls > entries[j].Calls
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+func newTopAPITable(w io.Writer) *tablewriter.Table {
+	table := tablewriter.NewWriter(w)
+	table.SetAutoWrapText(false)
+	table.SetAutoFormatHeaders(true)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetHeaderLine(false)
+	table.SetBorder(false)
+	table.SetTablePadding("\t") // pad with tabs
+	table.SetNoWhiteSpace(true)
+	return table
+}
+
+func renderTopAPITable(w io.Writer, header string, entries []topAPIEntry, render func(string) string) {
+	table := newTopAPITable(w)
+	table.SetHeader([]string{header, "CALLS", "RX", "TX"})
+	data := make([][]string, 0, len(entries))
+	for _, e := range entries {
+		data = append(data, []string{
+			e.Name,
+			render(fmt.Sprintf("%d", e.Calls)),
+			render(humanize.IBytes(e.RX)),
+			render(humanize.IBytes(e.TX)),
+		})
+	}
+	table.AppendBulk(data)
+	table.Render()
+}
+
+// topAPIMessage is a single snapshot of the busiest APIs and buckets.
+type topAPIMessage struct {
+	Status   string        `json:"status"`
+	Duration time.Duration `json:"duration"`
+	APIs     []topAPIEntry `json:"apis"`
+	Buckets  []topAPIEntry `json:"buckets"`
+}
+
+func newTopAPIMessage(a *topAPIAggregate, d time.Duration) topAPIMessage {
+	return topAPIMessage{
+		Status:   "success",
+		Duration: d,
+		APIs:     sortedTopAPIEntries(a.apiStatsMap),
+		Buckets:  sortedTopAPIEntries(a.bucketStatsMap),
+	}
+}
+
+func (t topAPIMessage) String() string {
+	var s strings.Builder
+	identity := func(v string) string { return v }
+	renderTopAPITable(&s, "API", t.APIs, identity)
+	s.WriteString("\n")
+	renderTopAPITable(&s, "BUCKET", t.Buckets, identity)
+	return s.String()
+}
+
+func (t topAPIMessage) JSON() string {
+	jsonBytes, e := json.MarshalIndent(t, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonBytes)
+}
+
 type traceUI struct {
-	spinner     spinner.Model
-	quitting    bool
-	startTime   time.Time
-	result      topAPIResult
-	lastResult  topAPIResult
-	apiStatsMap map[string]*topAPIStats
+	spinner    spinner.Model
+	quitting   bool
+	startTime  time.Time
+	result     topAPIResult
+	lastResult topAPIResult
+	stats      *topAPIAggregate
 }
 
 type topAPIResult struct {
@@ -82,8 +224,8 @@ func initTraceUI() *traceUI {
 	s.Spinner = spinner.Points
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
 	return &traceUI{
-		spinner:     s,
-		apiStatsMap: make(map[string]*topAPIStats),
+		spinner: s,
+		stats:   newTopAPIAggregate(),
 	}
 }
 
@@ -106,6 +248,10 @@ func (m *traceUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.result.apiCallInfo.Trace.FuncName != "" {
 			m.lastResult = m.result
 		}
+		if m.startTime.IsZero() && !msg.apiCallInfo.Trace.Time.IsZero() {
+			m.startTime = msg.apiCallInfo.Trace.Time
+		}
+		m.stats.add(msg.apiCallInfo.Trace)
 		if msg.final {
 			m.quitting = true
 			return m, tea.Quit
@@ -125,52 +271,9 @@ func (m *traceUI) View() string {
 	var s strings.Builder
 	s.WriteString("\n")
 
-	// Set table header
-	table := tablewriter.NewWriter(&s)
-	table.SetAutoWrapText(false)
-	table.SetAutoFormatHeaders(true)
-	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
-	table.SetAlignment(tablewriter.ALIGN_LEFT)
-	table.SetCenterSeparator("")
-	table.SetColumnSeparator("")
-	table.SetRowSeparator("")
-	table.SetHeaderLine(false)
-	table.SetBorder(false)
-	table.SetTablePadding("\t") // pad with tabs
-	table.SetNoWhiteSpace(true)
-
-	res := m.result.apiCallInfo
-	if m.startTime.IsZero() && !res.Trace.Time.IsZero() {
-		m.startTime = res.Trace.Time
-	}
-	if res.Trace.FuncName != "" && res.Trace.FuncName != "errorResponseHandler" {
-		traceSt, ok := m.apiStatsMap[res.Trace.FuncName]
-		if !ok {
-			traceSt = &topAPIStats{}
-		}
-		traceSt.addAPICall(1)
-		traceSt.addAPIBytesRX(res.Trace.CallStats.InputBytes)
-		traceSt.addAPIBytesTX(res.Trace.CallStats.OutputBytes)
-		m.apiStatsMap[res.Trace.FuncName] = traceSt
-	}
-
-	table.SetHeader([]string{"API", "CALLS", "RX", "TX"})
-	data := make([][]string, 0, len(m.apiStatsMap))
-
-	for k, stats := range m.apiStatsMap {
-		data = append(data, []string{
-			k,
-			whiteStyle.Render(fmt.Sprintf("%d", stats.loadAPICall())),
-			whiteStyle.Render(humanize.IBytes(stats.loadAPIBytesRX())),
-			whiteStyle.Render(humanize.IBytes(stats.loadAPIBytesTX())),
-		})
-	}
-	sort.Slice(data, func(i, j int) bool {
-		return data[i][0] < data[j][0]
-	})
-
-	table.AppendBulk(data)
-	table.Render()
+	renderTopAPITable(&s, "API", sortedTopAPIEntries(m.stats.apiStatsMap), whiteStyle.Render)
+	s.WriteString("\n")
+	renderTopAPITable(&s, "BUCKET", sortedTopAPIEntries(m.stats.bucketStatsMap), whiteStyle.Render)
 
 	if !m.quitting {
 		s.WriteString(fmt.Sprintf("\nTopAPI: %s", m.spinner.View()))
@@ -180,7 +283,7 @@ func (m *traceUI) View() string {
 		if m.lastResult.apiCallInfo.Trace.Time.IsZero() {
 			lastReqTime = time.Now()
 		}
-		for _, stats := range m.apiStatsMap {
+		for _, stats := range m.stats.apiStatsMap {
 			totalRX += stats.loadAPIBytesRX()
 			totalTX += stats.loadAPIBytesTX()
 			totalCalls += stats.loadAPICall()
@@ -0,0 +1,62 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestTopAPIAggregate(t *testing.T) {
+	a := newTopAPIAggregate()
+	traces := []madmin.TraceInfo{
+		{FuncName: "s3.GetObject", ReqInfo: madmin.TraceRequestInfo{Path: "/photos/2022/a.jpg"}, CallStats: madmin.TraceCallStats{OutputBytes: 100}},
+		{FuncName: "s3.GetObject", ReqInfo: madmin.TraceRequestInfo{Path: "/photos/b.jpg"}, CallStats: madmin.TraceCallStats{OutputBytes: 50}},
+		{FuncName: "s3.PutObject", ReqInfo: madmin.TraceRequestInfo{Path: "/logs/c.txt"}, CallStats: madmin.TraceCallStats{InputBytes: 10}},
+		{FuncName: "s3.ListBuckets", ReqInfo: madmin.TraceRequestInfo{Path: "/"}},
+		{FuncName: "admin.ServerInfo", ReqInfo: madmin.TraceRequestInfo{Path: "/minio/admin/v3/info"}},
+		{FuncName: "errorResponseHandler", ReqInfo: madmin.TraceRequestInfo{Path: "/photos"}},
+		{},
+	}
+	for _, trace := range traces {
+		a.add(trace)
+	}
+
+	apis := sortedTopAPIEntries(a.apiStatsMap)
+	if len(apis) != 4 {
+		t.Fatalf("expected 4 APIs, got %+v", apis)
+	}
+	if apis[0] != (topAPIEntry{Name: "s3.GetObject", Calls: 2, TX: 150}) {
+		t.Fatalf("expected s3.GetObject to be the busiest API, got %+v", apis[0])
+	}
+
+	buckets := sortedTopAPIEntries(a.bucketStatsMap)
+	expected := []topAPIEntry{
+		{Name: "photos", Calls: 2, TX: 150},
+		{Name: "logs", Calls: 1, RX: 10},
+	}
+	if len(buckets) != len(expected) {
+		t.Fatalf("expected buckets %+v, got %+v", expected, buckets)
+	}
+	for i := range expected {
+		if buckets[i] != expected[i] {
+			t.Fatalf("expected buckets %+v, got %+v", expected, buckets)
+		}
+	}
+}
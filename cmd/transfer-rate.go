@@ -0,0 +1,143 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// bytesPerSecond returns the achieved rate of transferring size bytes in elapsed.
+func bytesPerSecond(size int64, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(size) / elapsed.Seconds()
+}
+
+// humanizedRate returns a human readable rate, e.g. "12 MiB/s".
+func humanizedRate(rate float64) string {
+	return humanize.IBytes(uint64(rate)) + "/s"
+}
+
+// objectRate is the achieved transfer rate of a single object.
+type objectRate struct {
+	Object  string  `json:"object"`
+	Size    int64   `json:"size"`
+	Elapsed float64 `json:"elapsed"`
+	Rate    float64 `json:"rate"`
+}
+
+// transferRates accumulates per object transfer rates of a
+// cp or mirror run, safe for concurrent use by copy workers.
+type transferRates struct {
+	mutex            sync.Mutex
+	start            time.Time
+	objects          int64
+	bytes            int64
+	slowest, fastest *objectRate
+}
+
+func newTransferRates() *transferRates {
+	return &transferRates{start: time.Now()}
+}
+
+// record accounts a successful transfer and returns its rate. Empty or
+// instant transfers have no meaningful rate and are not ranked among the
+// slowest and fastest objects.
+func (t *transferRates) record(object string, size int64, elapsed time.Duration) objectRate {
+	r := objectRate{
+		Object:  object,
+		Size:    size,
+		Elapsed: elapsed.Seconds(),
+		Rate:    bytesPerSecond(size, elapsed),
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.objects++
+	t.bytes += size
+	if size <= 0 || elapsed <= 0 {
+		return r
+	}
+	if t.slowest == nil || r.Rate < t.slowest.Rate {
+		slowest := r
+		t.slowest = &slowest
+	}
+	if t.fastest == nil || r.Rate > t.fastest.Rate {
+		fastest := r
+		t.fastest = &fastest
+	}
+	return r
+}
+
+// summary returns the aggregate throughput since the rates were created.
+func (t *transferRates) summary() transferRateMessage {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	elapsed := time.Since(t.start)
+	return transferRateMessage{
+		TotalObjects: t.objects,
+		TotalSize:    t.bytes,
+		Elapsed:      elapsed.Seconds(),
+		Throughput:   bytesPerSecond(t.bytes, elapsed),
+		Slowest:      t.slowest,
+		Fastest:      t.fastest,
+	}
+}
+
+// transferRateMessage container for aggregate transfer rate summary.
+type transferRateMessage struct {
+	Status       string      `json:"status"`
+	TotalObjects int64       `json:"totalObjects"`
+	TotalSize    int64       `json:"totalSize"`
+	Elapsed      float64     `json:"elapsed"`
+	Throughput   float64     `json:"throughput"`
+	Slowest      *objectRate `json:"slowest,omitempty"`
+	Fastest      *objectRate `json:"fastest,omitempty"`
+}
+
+// String colorized transfer rate summary.
+func (t transferRateMessage) String() string {
+	elapsed := time.Duration(t.Elapsed * float64(time.Second)).Round(time.Millisecond)
+	msg := console.Colorize("Summarize", fmt.Sprintf("Transferred %d object(s), %s in %s, aggregate throughput: %s",
+		t.TotalObjects, humanize.IBytes(uint64(t.TotalSize)), elapsed, humanizedRate(t.Throughput)))
+	if t.Slowest != nil {
+		msg += "\n" + console.Colorize("Summarize", fmt.Sprintf("Slowest: `%s` at %s", t.Slowest.Object, humanizedRate(t.Slowest.Rate)))
+	}
+	if t.Fastest != nil {
+		msg += "\n" + console.Colorize("Summarize", fmt.Sprintf("Fastest: `%s` at %s", t.Fastest.Object, humanizedRate(t.Fastest.Rate)))
+	}
+	return msg
+}
+
+// JSON jsonified transfer rate summary.
+func (t transferRateMessage) JSON() string {
+	t.Status = "success"
+	msgBytes, e := json.MarshalIndent(t, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
@@ -290,6 +290,7 @@ func mainTree(cliCtx *cli.Context) error {
 				isIncomplete:      false,
 				isSummary:         false,
 				withOlderVersions: false,
+				withDeleteMarkers: true,
 				listZip:           false,
 				filter:            "*",
 			}
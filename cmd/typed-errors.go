@@ -46,6 +46,13 @@ var errUnrecognizedDiffType = func(diff differType) *probe.Error {
 	return probe.NewError(unrecognizedDiffTypeErr(errors.New(msg))).Untrace()
 }
 
+type readOnlyErr error
+
+var errReadOnly = func(operation string) *probe.Error {
+	msg := "`" + operation + "` modifies data or configuration and is not allowed in read-only mode (--read-only or MC_READ_ONLY)."
+	return probe.NewError(readOnlyErr(errors.New(msg))).Untrace()
+}
+
 type invalidAliasedURLErr error
 
 var errInvalidAliasedURL = func(URL string) *probe.Error {
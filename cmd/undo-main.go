@@ -18,13 +18,17 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
+	isatty "github.com/mattn/go-isatty"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
 	"github.com/minio/mc/pkg/probe"
@@ -43,7 +47,7 @@ var undoFlags = []cli.Flag{
 	},
 	cli.BoolFlag{
 		Name:  "force",
-		Usage: "force recursive operation",
+		Usage: "force recursive operation and skip the confirmation asked on a terminal",
 	},
 	cli.BoolFlag{
 		Name:  "dry-run",
@@ -73,6 +77,9 @@ EXAMPLES:
 
   2. Undo the last upload/removal change of all objects under a prefix
      {{.Prompt}} {{.HelpName}} s3/backups/prefix/ --recursive --force
+
+  3. Show the version an accidentally overwritten object would be restored to, without reverting it
+     {{.Prompt}} {{.HelpName}} --dry-run s3/backups/file.zip
 `,
 }
 
@@ -83,6 +90,7 @@ type undoMessage struct {
 	Key            string `json:"key,omitempty"`
 	VersionID      string `json:"versionId,omitempty"`
 	IsDeleteMarker bool   `json:"isDeleteMarker,omitempty"`
+	DryRun         bool   `json:"dryRun,omitempty"`
 }
 
 // String colorized string message.
@@ -95,6 +103,9 @@ func (c undoMessage) String() string {
 	} else {
 		msg += "Last " + color.BlueString("upload") + " of `" + yellow(c.Key) + "` (vid=" + c.VersionID + ") is reverted"
 	}
+	if c.DryRun {
+		msg += " (dry run)"
+	}
 	msg += "."
 	return msg
 }
@@ -108,8 +119,84 @@ func (c undoMessage) JSON() string {
 	return string(jsonMessageBytes)
 }
 
+// undoRestoredMessage container for the version an object is reverted to.
+type undoRestoredMessage struct {
+	Status         string    `json:"status"`
+	Key            string    `json:"key"`
+	VersionID      string    `json:"versionId,omitempty"`
+	LastModified   time.Time `json:"lastModified,omitempty"`
+	IsDeleteMarker bool      `json:"isDeleteMarker,omitempty"`
+	NoVersion      bool      `json:"noVersion,omitempty"`
+	DryRun         bool      `json:"dryRun,omitempty"`
+}
+
+// String colorized string message.
+func (c undoRestoredMessage) String() string {
+	msg := "`" + color.New(color.FgYellow).Sprint(c.Key) + "` "
+	if c.DryRun {
+		msg += "would be "
+	} else {
+		msg += "is "
+	}
+	return msg + undoRestoredState(c.VersionID, c.LastModified, c.IsDeleteMarker, c.NoVersion) + "."
+}
+
+// JSON jsonified content message.
+func (c undoRestoredMessage) JSON() string {
+	c.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// undoRestoredState describes the state of an object after an undo.
+func undoRestoredState(versionID string, lastModified time.Time, isDeleteMarker, noVersion bool) string {
+	switch {
+	case noVersion:
+		return "left without any version"
+	case isDeleteMarker:
+		return "left deleted"
+	}
+	return "restored to version " + versionID + " of " + lastModified.Format(printDate)
+}
+
+// newUndoRestoredMessage returns the state of the object of key once its
+// versions newer than restored are removed, restored is nil when none is left.
+func newUndoRestoredMessage(key string, restored *ClientContent, dryRun bool) undoRestoredMessage {
+	if restored == nil {
+		return undoRestoredMessage{Key: key, NoVersion: true, DryRun: dryRun}
+	}
+	return undoRestoredMessage{
+		Key:            key,
+		VersionID:      restored.VersionID,
+		LastModified:   restored.Time,
+		IsDeleteMarker: restored.IsDeleteMarker,
+		DryRun:         dryRun,
+	}
+}
+
+// isUndoInteractive tells if the undo may be confirmed on a terminal, it
+// is not asked when mc runs in a script, as before confirmations were added.
+var isUndoInteractive = func() bool {
+	return isatty.IsTerminal(os.Stdin.Fd()) || isatty.IsCygwinTerminal(os.Stdin.Fd())
+}
+
+// confirmUndo asks whether to revert the last changes of an object.
+func confirmUndo(restored undoRestoredMessage, changes int) bool {
+	fmt.Printf("Revert the last %d change(s) of `%s`, leaving it %s? y/N: ", changes,
+		color.YellowString(restored.Key),
+		strings.TrimPrefix(undoRestoredState(restored.VersionID, restored.LastModified, restored.IsDeleteMarker, restored.NoVersion), "left "))
+	answer, e := bufio.NewReader(os.Stdin).ReadString('\n')
+	if e != nil {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
 // parseUndoSyntax performs command-line input validation for cat command.
-func parseUndoSyntax(ctx *cli.Context) (targetAliasedURL string, last int, recursive, dryRun bool) {
+func parseUndoSyntax(ctx *cli.Context) (targetAliasedURL string, last int, recursive, dryRun, confirm bool) {
 	targetAliasedURL = ctx.Args().Get(0)
 	if targetAliasedURL == "" {
 		fatalIf(errInvalidArgument().Trace(), "The argument should not be empty")
@@ -127,23 +214,23 @@ func parseUndoSyntax(ctx *cli.Context) (targetAliasedURL string, last int, recur
 	}
 
 	dryRun = ctx.Bool("dry-run")
+	confirm = !force && !dryRun && !globalJSON && isUndoInteractive()
 	return
 }
 
-func undoLastNOperations(ctx context.Context, clnt Client, objectVersions []*ClientContent, last int, dryRun bool) (exitErr error) {
-	if last == 0 {
+func undoLastNOperations(ctx context.Context, clnt Client, objectVersions []*ClientContent, last int, dryRun, confirm bool) (exitErr error) {
+	if last == 0 || len(objectVersions) == 0 {
 		return
 	}
 
 	sortObjectVersions(objectVersions)
 
+	var restored *ClientContent
 	if len(objectVersions) > last {
+		restored = objectVersions[last]
 		objectVersions = objectVersions[:last]
 	}
 
-	contentCh := make(chan *ClientContent)
-	resultCh := clnt.Remove(ctx, false, false, false, false, contentCh)
-
 	prefixPath := clnt.GetURL().Path
 	prefixPath = filepath.ToSlash(prefixPath)
 	if !strings.HasSuffix(prefixPath, "/") {
@@ -151,6 +238,18 @@ func undoLastNOperations(ctx context.Context, clnt Client, objectVersions []*Cli
 	}
 	prefixPath = strings.TrimPrefix(prefixPath, "./")
 
+	// All the versions are of the same object.
+	objectKey := strings.TrimPrefix(filepath.ToSlash(objectVersions[0].URL.Path), prefixPath)
+	restoredMsg := newUndoRestoredMessage(getOSDependantKey(objectKey, false), restored, dryRun)
+	if confirm && !confirmUndo(restoredMsg, len(objectVersions)) {
+		// Declining is not a failure, the other objects are still undone.
+		console.Infoln("Undo of `" + objectKey + "` is skipped.")
+		return nil
+	}
+
+	contentCh := make(chan *ClientContent)
+	resultCh := clnt.Remove(ctx, false, false, false, false, contentCh)
+
 	go func() {
 		for _, objectVersion := range objectVersions {
 			if !dryRun {
@@ -168,6 +267,7 @@ func undoLastNOperations(ctx context.Context, clnt Client, objectVersions []*Cli
 				URL:            objectVersion.URL.String(),
 				VersionID:      objectVersion.VersionID,
 				IsDeleteMarker: objectVersion.IsDeleteMarker,
+				DryRun:         dryRun,
 			})
 
 		}
@@ -181,10 +281,13 @@ func undoLastNOperations(ctx context.Context, clnt Client, objectVersions []*Cli
 		}
 	}
 
+	if exitErr == nil {
+		printMsg(restoredMsg)
+	}
 	return
 }
 
-func undoURL(ctx context.Context, aliasedURL string, last int, recursive, dryRun bool) (exitErr error) {
+func undoURL(ctx context.Context, aliasedURL string, last int, recursive, dryRun, confirm bool) (exitErr error) {
 	clnt, err := newClient(aliasedURL)
 	fatalIf(err.Trace(aliasedURL), "Unable to initialize target `"+aliasedURL+"`.")
 
@@ -218,7 +321,7 @@ func undoURL(ctx context.Context, aliasedURL string, last int, recursive, dryRun
 
 		if lastObjectPath != content.URL.Path {
 			// Print any object in the current list before reinitializing it
-			exitErr = undoLastNOperations(ctx, clnt, perObjectVersions, last, dryRun)
+			exitErr = undoLastNOperations(ctx, clnt, perObjectVersions, last, dryRun, confirm)
 			lastObjectPath = content.URL.Path
 			perObjectVersions = []*ClientContent{}
 		}
@@ -228,7 +331,7 @@ func undoURL(ctx context.Context, aliasedURL string, last int, recursive, dryRun
 	}
 
 	// Undo the remaining versions found if any
-	exitErr = undoLastNOperations(ctx, clnt, perObjectVersions, last, dryRun)
+	exitErr = undoLastNOperations(ctx, clnt, perObjectVersions, last, dryRun, confirm)
 
 	if !atLeastOneUndoApplied {
 		errorIf(errDummy().Trace(clnt.GetURL().String()), "Unable to find any object version to undo.")
@@ -272,11 +375,11 @@ func mainUndo(cliCtx *cli.Context) error {
 	console.SetColor("Success", color.New(color.FgGreen, color.Bold))
 
 	// check 'undo' cli arguments.
-	targetAliasedURL, last, recursive, dryRun := parseUndoSyntax(cliCtx)
+	targetAliasedURL, last, recursive, dryRun, confirm := parseUndoSyntax(cliCtx)
 
 	if !checkIfBucketIsVersioned(ctx, targetAliasedURL) {
 		fatalIf(errDummy().Trace(), "Undo command works only with S3 versioned-enabled buckets.")
 	}
 
-	return undoURL(ctx, targetAliasedURL, last, recursive, dryRun)
+	return undoURL(ctx, targetAliasedURL, last, recursive, dryRun, confirm)
 }
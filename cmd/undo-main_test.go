@@ -0,0 +1,64 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/minio/cli"
+)
+
+func TestParseUndoSyntaxConfirm(t *testing.T) {
+	defer func(interactive func() bool, json bool) {
+		isUndoInteractive, globalJSON = interactive, json
+	}(isUndoInteractive, globalJSON)
+
+	testCases := []struct {
+		args        []string
+		interactive bool
+		json        bool
+		confirm     bool
+	}{
+		// Asked only on a terminal.
+		{[]string{"s3/backups/file.zip"}, true, false, true},
+		// Scripts undo without a confirmation, as before.
+		{[]string{"s3/backups/file.zip"}, false, false, false},
+		{[]string{"s3/backups/file.zip"}, true, true, false},
+		{[]string{"--force", "s3/backups/file.zip"}, true, false, false},
+		{[]string{"--dry-run", "s3/backups/file.zip"}, true, false, false},
+		{[]string{"--recursive", "--force", "s3/backups/prefix/"}, true, false, false},
+	}
+	for i, testCase := range testCases {
+		set := flag.NewFlagSet("undo", flag.ContinueOnError)
+		for _, f := range undoFlags {
+			f.Apply(set)
+		}
+		if e := set.Parse(testCase.args); e != nil {
+			t.Fatal(e)
+		}
+		interactive := testCase.interactive
+		isUndoInteractive = func() bool { return interactive }
+		globalJSON = testCase.json
+
+		_, _, _, _, confirm := parseUndoSyntax(cli.NewContext(nil, set, nil))
+		if confirm != testCase.confirm {
+			t.Errorf("Test %d: expected confirm %v, got %v", i+1, testCase.confirm, confirm)
+		}
+	}
+}
@@ -31,9 +31,48 @@ type URLs struct {
 	TotalSize        int64
 	MD5              bool
 	DisableMultipart bool
-	encKeyDB         map[string][]prefixSSEPair
-	Error            *probe.Error `json:"-"`
-	ErrorCond        differType   `json:"-"`
+	PreserveMtime    bool
+	// AutoPartSize increases the part size of uploads which
+	// would take more than the maximum number of parts.
+	AutoPartSize bool
+	// ConcurrentParts is the number of parts of an upload uploaded
+	// in parallel, including those of streams.
+	ConcurrentParts int
+	// Sparse leaves the runs of zeros of local files as holes.
+	Sparse bool
+	// ContentMD5 sends the Content-MD5 header of single PUT
+	// uploads, ContentMD5Sum is set to the hex encoded sum sent.
+	ContentMD5    bool
+	ContentMD5Sum string
+	// ChecksumResume resumes the incomplete multipart upload of the
+	// target, ReuploadedParts are the uploaded parts found corrupted.
+	ChecksumResume  bool
+	ReuploadedParts []int
+	// ChecksumRetries is the number of times a download whose md5sum
+	// differs from the ETag is downloaded again, ChecksumRetried the
+	// number of times it was.
+	ChecksumRetries int
+	ChecksumRetried int
+	// Atomic uploads to a temporary object copied over the
+	// target once complete.
+	Atomic bool
+	// DisableServerSide streams objects through the client even
+	// within the same alias, ServerSideAcrossAliases copies them
+	// server side between aliases of the same endpoint as well.
+	DisableServerSide       bool
+	ServerSideAcrossAliases bool
+	// MetadataDirective is COPY or REPLACE, REPLACE sets only the
+	// metadata given on the command line.
+	MetadataDirective string
+	// CompressionPredicted is set with --predict-compression, to whether
+	// the server should compress the target according to its configuration.
+	CompressionPredicted *bool `json:",omitempty"`
+	encKeyDB             map[string][]prefixSSEPair
+	Error                *probe.Error `json:"-"`
+	ErrorCond            differType   `json:"-"`
+	// Overwrite is set by mirror when the target object
+	// exists and is replaced.
+	Overwrite bool `json:"-"`
 }
 
 // WithError sets the error and returns object
@@ -151,8 +151,12 @@ func NewS3Config(urlStr string, aliasCfg *aliasConfigV10) *Config {
 		s3Config.SecretKey = aliasCfg.SecretKey
 		s3Config.SessionToken = aliasCfg.SessionToken
 		s3Config.Signature = aliasCfg.API
+		s3Config.Region = aliasCfg.Region
 	}
 	s3Config.Lookup = getLookupType(aliasCfg.Path)
+	if globalAddressing != "" {
+		s3Config.Lookup = addressingLookupTypes[globalAddressing]
+	}
 	return s3Config
 }
 
@@ -206,6 +210,13 @@ func getLookupType(l string) minio.BucketLookupType {
 	return minio.BucketLookupAuto
 }
 
+// addressingLookupTypes maps the --addressing values to bucket lookup types.
+var addressingLookupTypes = map[string]minio.BucketLookupType{
+	"path":    minio.BucketLookupPath,
+	"virtual": minio.BucketLookupDNS,
+	"auto":    minio.BucketLookupAuto,
+}
+
 // struct representing object prefix and sse keys association.
 type prefixSSEPair struct {
 	Prefix string
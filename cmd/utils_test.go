@@ -21,6 +21,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/encrypt"
 )
 
@@ -160,3 +161,37 @@ func TestParseAttribute(t *testing.T) {
 
 	}
 }
+
+func TestNewS3ConfigAddressing(t *testing.T) {
+	defer func() { globalAddressing = "" }()
+
+	aliasCfg := &aliasConfigV10{URL: "https://play.min.io", Path: "auto"}
+	testCases := []struct {
+		addressing string
+		expected   minio.BucketLookupType
+	}{
+		{"", minio.BucketLookupAuto},
+		{"path", minio.BucketLookupPath},
+		{"virtual", minio.BucketLookupDNS},
+		{"auto", minio.BucketLookupAuto},
+	}
+	for i, testCase := range testCases {
+		globalAddressing = testCase.addressing
+		if lookup := NewS3Config(aliasCfg.URL, aliasCfg).Lookup; lookup != testCase.expected {
+			t.Fatalf("Test %d: expected lookup %v, got %v", i+1, testCase.expected, lookup)
+		}
+	}
+
+	if err := checkVirtualHostAddressing("127.0.0.1:9000"); err == nil {
+		t.Fatal("expected virtual host addressing to fail with an IP address")
+	}
+}
+
+func TestNewS3ConfigAliasOptions(t *testing.T) {
+	aliasCfg := &aliasConfigV10{URL: "https://storage.example.com", API: "S3v2", Path: "on", Region: "eu-central"}
+	s3Config := NewS3Config(aliasCfg.URL, aliasCfg)
+	if s3Config.Signature != "S3v2" || s3Config.Lookup != minio.BucketLookupPath || s3Config.Region != "eu-central" {
+		t.Fatalf("alias options not applied, got signature %s, lookup %v and region %s",
+			s3Config.Signature, s3Config.Lookup, s3Config.Region)
+	}
+}
@@ -0,0 +1,468 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// How often the scan progress is saved to the --state-file.
+const verifyStateInterval = 10 * time.Second
+
+var verifyFlags = []cli.Flag{
+	cli.IntFlag{
+		Name:  "workers",
+		Value: 4,
+		Usage: "number of objects to read and verify concurrently",
+	},
+	cli.StringFlag{
+		Name:  "resume-from",
+		Usage: "skip objects whose key, relative to TARGET, sorts before or equal to KEY",
+	},
+	cli.StringFlag{
+		Name:  "state-file",
+		Usage: "periodically save the scan progress to FILE, and resume from it when it exists",
+	},
+}
+
+// Verify the integrity of objects.
+var verifyCmd = cli.Command{
+	Name:         "verify",
+	Usage:        "verify the integrity of objects by reading them back",
+	Action:       mainVerify,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(append(verifyFlags, ioFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Every object under TARGET is read back, objects uploaded in a single part
+  are compared against their ETag while others can only be checked for read
+  errors and are reported as unverified. Corrupted objects are reported as
+  soon as they are found.
+
+  Objects are scanned in the lexical order of their keys, --state-file records
+  the last key before which every object was scanned, so that an interrupted
+  scan restarts near where it stopped. The file is removed once a scan completes.
+  A target which is not listed in that order cannot be resumed, which is reported.
+
+ENVIRONMENT VARIABLES:
+  MC_ENCRYPT_KEY: list of comma delimited prefix=secret values
+
+EXAMPLES:
+  1. Verify all objects of the 'backups' bucket.
+     {{.Prompt}} {{.HelpName}} myminio/backups
+
+  2. Verify all objects of the 'backups' bucket reading 16 objects at a time, saving progress to a file.
+     {{.Prompt}} {{.HelpName}} --workers 16 --state-file ~/backups-verify.json myminio/backups
+
+  3. Resume the verification of the 'backups' bucket after the object '2021/06/30/db.tar'.
+     {{.Prompt}} {{.HelpName}} --resume-from 2021/06/30/db.tar myminio/backups
+`,
+}
+
+// md5ETag matches ETags computed as the md5sum of the object content.
+var md5ETag = regexp.MustCompile("^[0-9a-f]{32}$")
+
+// verifyMessage is the result of verifying one object.
+type verifyMessage struct {
+	Status string `json:"status"`
+	Key    string `json:"key"`
+	Size   int64  `json:"size"`
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+func (v verifyMessage) String() string {
+	switch v.Result {
+	case "corrupted":
+		return console.Colorize("VerifyCorrupted", fmt.Sprintf("Corrupted: `%s` %s", v.Key, v.Error))
+	case "failed":
+		return console.Colorize("VerifyFailed", fmt.Sprintf("Failed: `%s` %s", v.Key, v.Error))
+	case "unverified":
+		return console.Colorize("VerifyOK", fmt.Sprintf("Unverified: `%s`", v.Key))
+	}
+	return console.Colorize("VerifyOK", fmt.Sprintf("Verified: `%s`", v.Key))
+}
+
+func (v verifyMessage) JSON() string {
+	v.Status = "success"
+	if v.Result == "corrupted" || v.Result == "failed" {
+		v.Status = "error"
+	}
+	msgBytes, e := json.MarshalIndent(v, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// verifySummaryMessage summarizes a verification scan.
+type verifySummaryMessage struct {
+	Status     string `json:"status"`
+	Verified   int64  `json:"verified"`
+	Unverified int64  `json:"unverified"`
+	Corrupted  int64  `json:"corrupted"`
+	Failed     int64  `json:"failed"`
+	LastKey    string `json:"lastKey,omitempty"`
+}
+
+func (v verifySummaryMessage) String() string {
+	msg := fmt.Sprintf("Verified: %d, Unverified: %d, Corrupted: %d, Failed: %d",
+		v.Verified, v.Unverified, v.Corrupted, v.Failed)
+	if v.LastKey != "" {
+		msg += fmt.Sprintf("\nScan interrupted, resume with --resume-from '%s'", v.LastKey)
+	}
+	return console.Colorize("VerifySummary", msg)
+}
+
+func (v verifySummaryMessage) JSON() string {
+	v.Status = "success"
+	msgBytes, e := json.MarshalIndent(v, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// verifyState is the scan progress saved in the --state-file.
+type verifyState struct {
+	Target  string `json:"target"`
+	LastKey string `json:"lastKey"`
+}
+
+func loadVerifyState(stateFile, target string) (string, *probe.Error) {
+	data, e := os.ReadFile(stateFile)
+	if os.IsNotExist(e) {
+		return "", nil
+	}
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	var state verifyState
+	if e = json.Unmarshal(data, &state); e != nil {
+		return "", probe.NewError(e)
+	}
+	if state.Target != target {
+		return "", probe.NewError(fmt.Errorf("state file was saved for `%s`", state.Target))
+	}
+	return state.LastKey, nil
+}
+
+func saveVerifyState(stateFile, target, lastKey string) *probe.Error {
+	data, e := json.Marshal(verifyState{Target: target, LastKey: lastKey})
+	if e != nil {
+		return probe.NewError(e)
+	}
+	// Write to a temporary file first, not to lose the progress on a crash.
+	tmpFile := stateFile + ".tmp"
+	if e = os.WriteFile(tmpFile, data, 0o600); e != nil {
+		return probe.NewError(e)
+	}
+	return probe.NewError(os.Rename(tmpFile, stateFile))
+}
+
+// verifyProgress tracks the last key before which every listed object
+// was scanned, objects completing out of order with concurrent workers.
+// Keys are resumed in byte order, which is only right while they are
+// listed in that order.
+type verifyProgress struct {
+	mu        sync.Mutex
+	next      int
+	done      map[int]string
+	lastKey   string
+	resumeKey string
+	listedKey string
+	unsorted  bool
+}
+
+func newVerifyProgress(resumeKey string) *verifyProgress {
+	return &verifyProgress{done: make(map[int]string), lastKey: resumeKey, resumeKey: resumeKey}
+}
+
+// list records the listing of key and tells if it is to be scanned, keys
+// up to the resume key were scanned already.
+func (p *verifyProgress) list(key string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.listedKey != "" && key <= p.listedKey {
+		p.unsorted = true
+	}
+	p.listedKey = key
+	return p.resumeKey == "" || key > p.resumeKey
+}
+
+// resumable tells if the scan may be resumed after the last key, false
+// once keys were listed out of byte order.
+func (p *verifyProgress) resumable() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return !p.unsorted
+}
+
+// complete marks the object listed at position seq as scanned.
+func (p *verifyProgress) complete(seq int, key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done[seq] = key
+	for {
+		key, ok := p.done[p.next]
+		if !ok {
+			return
+		}
+		delete(p.done, p.next)
+		p.lastKey = key
+		p.next++
+	}
+}
+
+// last returns the key before which every listed object was scanned.
+func (p *verifyProgress) last() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.lastKey
+}
+
+type verifyTask struct {
+	seq     int
+	key     string
+	content *ClientContent
+}
+
+// verifyObject reads the object back and compares it against its ETag.
+func verifyObject(ctx context.Context, alias, aliasedKey string, content *ClientContent, encKeyDB map[string][]prefixSSEPair) verifyMessage {
+	msg := verifyMessage{Key: aliasedKey, Size: content.Size}
+
+	sse := getSSE(aliasedKey, encKeyDB[alias])
+	reader, _, err := getSourceStream(ctx, alias, content.URL.String(), "", false, sse, false, false)
+	if err != nil {
+		msg.Result, msg.Error = "failed", err.ToGoError().Error()
+		return msg
+	}
+	defer reader.Close()
+
+	hash := md5.New()
+	n, e := io.Copy(hash, reader)
+	if e != nil {
+		msg.Result, msg.Error = "corrupted", e.Error()
+		return msg
+	}
+	if n != content.Size {
+		msg.Result, msg.Error = "corrupted", fmt.Sprintf("read %d bytes, expected %d", n, content.Size)
+		return msg
+	}
+
+	etag := strings.ToLower(strings.Trim(content.ETag, "\""))
+	if !md5ETag.MatchString(etag) {
+		// Multipart uploads and filesystems have no content md5sum.
+		msg.Result = "unverified"
+		return msg
+	}
+	if sum := hex.EncodeToString(hash.Sum(nil)); sum != etag {
+		// The ETag of encrypted objects is not their md5sum.
+		_, st, err := url2Stat(ctx, aliasedKey, "", false, encKeyDB, time.Time{}, false)
+		if err == nil && encryptionType(st.Metadata) != "" {
+			msg.Result = "unverified"
+			return msg
+		}
+		msg.Result, msg.Error = "corrupted", fmt.Sprintf("md5sum %s does not match ETag %s", sum, etag)
+		return msg
+	}
+	msg.Result = "verified"
+	return msg
+}
+
+// mainVerify is the handler for "mc verify" command.
+func mainVerify(cliCtx *cli.Context) error {
+	if len(cliCtx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(cliCtx, "verify", 1) // last argument is exit code
+	}
+
+	console.SetColor("VerifyOK", color.New(color.FgGreen))
+	console.SetColor("VerifyCorrupted", color.New(color.FgRed, color.Bold))
+	console.SetColor("VerifyFailed", color.New(color.FgYellow, color.Bold))
+	console.SetColor("VerifySummary", color.New(color.Bold))
+
+	ctx, cancelVerify := context.WithCancel(globalContext)
+	defer cancelVerify()
+
+	encKeyDB, err := getEncKeys(cliCtx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	workers := cliCtx.Int("workers")
+	if workers < 1 {
+		fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "--workers should be at least 1.")
+	}
+
+	target := cliCtx.Args().Get(0)
+	if !strings.HasSuffix(target, "/") {
+		target += "/"
+	}
+
+	resumeKey := cliCtx.String("resume-from")
+	stateFile := cliCtx.String("state-file")
+	if stateFile != "" && resumeKey == "" {
+		resumeKey, err = loadVerifyState(stateFile, target)
+		fatalIf(err.Trace(stateFile), "Unable to load the scan progress.")
+	}
+
+	alias, urlStr, _ := mustExpandAlias(target)
+	clnt, err := newClientFromAlias(alias, urlStr)
+	fatalIf(err.Trace(target), "Unable to initialize target `"+target+"`.")
+	prefix := clnt.GetURL().Path
+
+	taskCh := make(chan verifyTask)
+	type verifyResult struct {
+		seq int
+		key string
+		msg verifyMessage
+	}
+	resultCh := make(chan verifyResult)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range taskCh {
+				aliasedKey := task.content.URL.Path
+				if alias != "" {
+					aliasedKey = alias + "/" + strings.TrimPrefix(aliasedKey, "/")
+				}
+				resultCh <- verifyResult{
+					seq: task.seq,
+					key: task.key,
+					msg: verifyObject(ctx, alias, aliasedKey, task.content, encKeyDB),
+				}
+			}
+		}()
+	}
+
+	progress := newVerifyProgress(resumeKey)
+	var cErr error
+	go func() {
+		defer close(resultCh)
+		// Listing stops at the first error, so that the scan is
+		// resumed from the objects which could not be listed.
+		listCtx, cancelList := context.WithCancel(ctx)
+		defer cancelList()
+		seq := 0
+		for content := range clnt.List(listCtx, ListOptions{Recursive: true, ShowDir: DirNone}) {
+			if content.Err != nil {
+				errorIf(content.Err.Trace(target), "Unable to list `"+target+"`.")
+				cErr = exitStatus(globalErrorExitStatus)
+				cancelList()
+				break
+			}
+			if content.Type.IsDir() {
+				continue
+			}
+			// Compare keys with the same separator whatever the target.
+			key := filepath.ToSlash(strings.TrimPrefix(content.URL.Path, prefix))
+			if !progress.list(key) {
+				continue
+			}
+			select {
+			case taskCh <- verifyTask{seq: seq, key: key, content: content}:
+				seq++
+			case <-ctx.Done():
+			}
+		}
+		close(taskCh)
+		wg.Wait()
+	}()
+
+	var (
+		summaryMu sync.Mutex
+		summary   verifySummaryMessage
+	)
+	// finish reports the summary and saves or clears the progress, it
+	// runs once the scan ends or when it is interrupted by a signal.
+	finish := func(interrupted bool) {
+		summaryMu.Lock()
+		defer summaryMu.Unlock()
+		resumable := progress.resumable()
+		if !resumable {
+			warning("`" + target + "` is not listed in the order of its keys, the scan cannot be resumed and --resume-from may have skipped objects.")
+		}
+		if interrupted && resumable {
+			summary.LastKey = progress.last()
+		}
+		if stateFile != "" {
+			if interrupted && resumable {
+				errorIf(saveVerifyState(stateFile, target, progress.last()).Trace(stateFile), "Unable to save the scan progress.")
+			} else {
+				os.Remove(stateFile)
+			}
+		}
+		printMsg(summary)
+	}
+	setInterruptHook(func() { finish(true) })
+
+	lastSaved := time.Now()
+	for result := range resultCh {
+		summaryMu.Lock()
+		switch result.msg.Result {
+		case "verified":
+			summary.Verified++
+		case "unverified":
+			summary.Unverified++
+		case "corrupted":
+			summary.Corrupted++
+		case "failed":
+			summary.Failed++
+		}
+		summaryMu.Unlock()
+		if result.msg.Result == "corrupted" || result.msg.Result == "failed" || globalJSON {
+			printMsg(result.msg)
+		}
+
+		progress.complete(result.seq, result.key)
+		if stateFile != "" && progress.resumable() && time.Since(lastSaved) > verifyStateInterval {
+			errorIf(saveVerifyState(stateFile, target, progress.last()).Trace(stateFile), "Unable to save the scan progress.")
+			lastSaved = time.Now()
+		}
+	}
+
+	setInterruptHook(nil)
+	finish(ctx.Err() != nil || cErr != nil)
+
+	if summary.Corrupted > 0 || summary.Failed > 0 {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return cErr
+}
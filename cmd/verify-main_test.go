@@ -0,0 +1,85 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestVerifyProgress(t *testing.T) {
+	keys := []string{"a/1", "a/2", "b", "c/1", "d"}
+	progress := newVerifyProgress("a/2")
+	var scanned []string
+	for _, key := range keys {
+		if progress.list(key) {
+			scanned = append(scanned, key)
+		}
+	}
+	if len(scanned) != 3 || scanned[0] != "b" {
+		t.Fatalf("expected the keys after the resume key to be scanned, got %v", scanned)
+	}
+
+	// Completed out of order, the last key only moves past contiguous objects.
+	progress.complete(1, "c/1")
+	if last := progress.last(); last != "a/2" {
+		t.Fatalf("expected last key a/2, got %s", last)
+	}
+	progress.complete(0, "b")
+	if last := progress.last(); last != "c/1" {
+		t.Fatalf("expected last key c/1, got %s", last)
+	}
+	progress.complete(2, "d")
+	if last := progress.last(); last != "d" || !progress.resumable() {
+		t.Fatalf("expected a resumable last key d, got %s", progress.last())
+	}
+}
+
+func TestVerifyProgressUnsorted(t *testing.T) {
+	// A file listed before a sibling directory whose keys sort before it.
+	progress := newVerifyProgress("")
+	for _, key := range []string{"a-b", "a.txt", "a/x"} {
+		progress.list(key)
+	}
+	if !progress.resumable() {
+		t.Fatal("expected keys listed in byte order to be resumable")
+	}
+	progress = newVerifyProgress("")
+	for _, key := range []string{"a/x", "a-b"} {
+		progress.list(key)
+	}
+	if progress.resumable() {
+		t.Fatal("expected keys listed out of byte order not to be resumable")
+	}
+}
+
+func TestVerifyState(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "verify.json")
+	if lastKey, err := loadVerifyState(stateFile, "myminio/backups/"); err != nil || lastKey != "" {
+		t.Fatalf("expected no progress without a state file, got %q, %v", lastKey, err)
+	}
+	if err := saveVerifyState(stateFile, "myminio/backups/", "2021/06/30/db.tar"); err != nil {
+		t.Fatal(err)
+	}
+	if lastKey, err := loadVerifyState(stateFile, "myminio/backups/"); err != nil || lastKey != "2021/06/30/db.tar" {
+		t.Fatalf("expected the saved progress, got %q, %v", lastKey, err)
+	}
+	if _, err := loadVerifyState(stateFile, "myminio/other/"); err == nil {
+		t.Fatal("expected the progress of another target to be refused")
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/shlex"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// watchEventKey returns the bucket and the object key of an event, the
+// bucket is empty for events on a local directory.
+func watchEventKey(event EventInfo) (bucket, key string) {
+	u := newClientURL(event.Path)
+	if u.Type != objectStorage {
+		return "", u.Path
+	}
+	p := strings.TrimPrefix(u.Path, string(u.Separator))
+	if i := strings.Index(p, string(u.Separator)); i >= 0 {
+		return p[:i], p[i+1:]
+	}
+	return p, ""
+}
+
+// watchExecTokens are the event tokens substituted into --exec arguments.
+var watchExecTokens = []string{"{key}", "{bucket}", "{path}", "{event}", "{size}", "{time}"}
+
+// watchExecArgs substitutes the event tokens into the arguments of the
+// already split command line, the program name is left as is. Substitution
+// happens per argument and the command is never run through a shell, so
+// event values can neither split an argument nor inject shell syntax.
+func watchExecArgs(args []string, event EventInfo) []string {
+	bucket, key := watchEventKey(event)
+	replacer := strings.NewReplacer(
+		"{key}", key,
+		"{bucket}", bucket,
+		"{path}", event.Path,
+		"{event}", string(event.Type),
+		"{size}", strconv.FormatInt(event.Size, 10),
+		"{time}", event.Time,
+	)
+	substituted := make([]string, len(args))
+	substituted[0] = args[0]
+	for i := 1; i < len(args); i++ {
+		substituted[i] = replacer.Replace(args[i])
+	}
+	return substituted
+}
+
+// watchExecMessage container for the output of an --exec command.
+type watchExecMessage struct {
+	Status string `json:"status"`
+	Path   string `json:"path"`
+	Output string `json:"output"`
+}
+
+func (w watchExecMessage) String() string {
+	return w.Output
+}
+
+func (w watchExecMessage) JSON() string {
+	w.Status = "success"
+	msgBytes, e := json.MarshalIndent(w, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// watchExecutor runs the --exec command line for each event with up to
+// a fixed number of commands running concurrently. Failures are reported
+// and counted without stopping the watch.
+type watchExecutor struct {
+	args   []string
+	slots  chan struct{}
+	wg     sync.WaitGroup
+	failed int64
+	mu     sync.Mutex
+}
+
+func newWatchExecutor(cmdLine string, maxConcurrent int) (*watchExecutor, *probe.Error) {
+	args, e := shlex.Split(cmdLine)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	if len(args) == 0 {
+		return nil, errInvalidArgument().Trace(cmdLine)
+	}
+	// An event must not choose the program which is run.
+	for _, token := range watchExecTokens {
+		if strings.Contains(args[0], token) {
+			return nil, probe.NewError(fmt.Errorf("the program name cannot contain the %s token", token))
+		}
+	}
+	return &watchExecutor{
+		args:  args,
+		slots: make(chan struct{}, maxConcurrent),
+	}, nil
+}
+
+// run waits for a free slot and then runs the command for event in the
+// background.
+func (w *watchExecutor) run(ctx context.Context, event EventInfo) {
+	select {
+	case w.slots <- struct{}{}:
+	case <-ctx.Done():
+		return
+	}
+	args := watchExecArgs(w.args, event)
+	w.wg.Add(1)
+	go func() {
+		defer func() {
+			<-w.slots
+			w.wg.Done()
+		}()
+		cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+		var out, stderr bytes.Buffer
+		cmd.Stdout = &out
+		cmd.Stderr = &stderr
+		if e := cmd.Run(); e != nil {
+			w.mu.Lock()
+			w.failed++
+			w.mu.Unlock()
+			msg := "Command for `" + event.Path + "` failed."
+			if stderr.Len() > 0 {
+				msg = "Command for `" + event.Path + "` failed: " + strings.TrimSpace(stderr.String())
+			}
+			errorIf(probe.NewError(e).Trace(args...), msg)
+			return
+		}
+		if out.Len() == 0 {
+			return
+		}
+		// Keep the output of the command within the --json event stream.
+		if globalJSON {
+			printMsg(watchExecMessage{Path: event.Path, Output: out.String()})
+			return
+		}
+		console.PrintC(out.String())
+	}()
+}
+
+// wait waits for the running commands and returns the number of failed ones.
+func (w *watchExecutor) wait() int64 {
+	w.wg.Wait()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.failed
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os/exec"
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio-go/v7/pkg/notification"
+)
+
+func TestWatchExecArgs(t *testing.T) {
+	event := EventInfo{
+		Path: "http://localhost:9000/incoming/photos/a b;rm -rf $HOME.jpg",
+		Type: notification.ObjectCreatedPut,
+		Size: 42,
+		Time: "2022-03-01T10:00:00Z",
+	}
+	got := watchExecArgs([]string{"process.sh", "{bucket}", "--key={key}", "{event}", "{size}", "{time}"}, event)
+	want := []string{"process.sh", "incoming", "--key=photos/a b;rm -rf $HOME.jpg", "s3:ObjectCreated:Put", "42", "2022-03-01T10:00:00Z"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	// The program name is never substituted.
+	if got := watchExecArgs([]string{"{key}.sh", "{key}"}, event); got[0] != "{key}.sh" {
+		t.Fatalf("expected the program name to be left as is, got %q", got)
+	}
+}
+
+func TestWatchExecutor(t *testing.T) {
+	if _, e := exec.LookPath("sh"); e != nil {
+		t.Skip("sh is not available")
+	}
+	if _, err := newWatchExecutor("", 1); err == nil {
+		t.Fatal("expected an empty --exec to be rejected")
+	}
+	if _, err := newWatchExecutor("{key} --verbose", 1); err == nil {
+		t.Fatal("expected a token in the program name to be rejected")
+	}
+
+	w, err := newWatchExecutor(`sh -c 'test "$0" = ok' {key}`, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	w.run(ctx, EventInfo{Path: "http://localhost:9000/bucket/ok"})
+	w.run(ctx, EventInfo{Path: "http://localhost:9000/bucket/bad"})
+	w.run(ctx, EventInfo{Path: "http://localhost:9000/bucket/ok"})
+	if failed := w.wait(); failed != 1 {
+		t.Fatalf("expected 1 failed command, got %d", failed)
+	}
+}
+
+func TestWatchExecMessage(t *testing.T) {
+	msg := watchExecMessage{Path: "play/bucket/object", Output: "done\n"}.JSON()
+	var decoded watchExecMessage
+	if e := json.Unmarshal([]byte(msg), &decoded); e != nil {
+		t.Fatalf("expected the output to be a JSON message, got %s: %v", msg, e)
+	}
+	if decoded.Status != "success" || decoded.Output != "done\n" || decoded.Path != "play/bucket/object" {
+		t.Fatalf("unexpected message %+v", decoded)
+	}
+}
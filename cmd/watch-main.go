@@ -20,6 +20,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -50,6 +51,15 @@ var watchFlags = []cli.Flag{
 		Name:  "recursive",
 		Usage: "recursively watch for events",
 	},
+	cli.StringFlag{
+		Name:  "exec",
+		Usage: "run a command for each event, substituting {key}, {bucket}, {path}, {event}, {size} and {time} in its arguments",
+	},
+	cli.IntFlag{
+		Name:  "max-concurrent",
+		Value: 1,
+		Usage: "maximum number of --exec commands running concurrently",
+	},
 }
 
 var watchCmd = cli.Command{
@@ -68,6 +78,11 @@ USAGE:
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+EXEC:
+  The command is run without a shell and the tokens are only substituted in its arguments, not in the
+  program name. An object key starting with '-' is still read as an option by most programs, pass the
+  tokens after '--' or as part of an argument such as --key={key} when the keys are not trusted.
+
 EXAMPLES:
   1. Watch new S3 operations on a MinIO server
      {{.Prompt}} {{.HelpName}} play/testbucket
@@ -86,6 +101,9 @@ EXAMPLES:
 
   6. Watch for events on local directory.
      {{.Prompt}} {{.HelpName}} /usr/share
+
+  7. Run a script for every new object in bucket 'incoming', with up to 4 scripts running at a time.
+     {{.Prompt}} {{.HelpName}} --events put --exec "process.sh {bucket} {key}" --max-concurrent 4 play/incoming
 `,
 }
 
@@ -94,6 +112,13 @@ func checkWatchSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
 		cli.ShowCommandHelpAndExit(ctx, "watch", 1) // last argument is exit code
 	}
+	if ctx.Int("max-concurrent") < 1 {
+		fatalIf(errInvalidArgument().Trace(strconv.Itoa(ctx.Int("max-concurrent"))),
+			"--max-concurrent should be at least 1.")
+	}
+	if ctx.IsSet("max-concurrent") && ctx.String("exec") == "" {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--max-concurrent requires --exec.")
+	}
 }
 
 // watchMessage container to hold one event notification
@@ -159,6 +184,12 @@ func mainWatch(cliCtx *cli.Context) error {
 		Suffix:    suffix,
 	}
 
+	var executor *watchExecutor
+	if cmdLine := cliCtx.String("exec"); cmdLine != "" {
+		executor, pErr = newWatchExecutor(cmdLine, cliCtx.Int("max-concurrent"))
+		fatalIf(pErr.Trace(cmdLine), "Unable to parse --exec.")
+	}
+
 	ctx, cancelWatch := context.WithCancel(globalContext)
 	defer cancelWatch()
 
@@ -197,6 +228,9 @@ func mainWatch(cliCtx *cli.Context) error {
 					msg.Source.Port = event.Port
 					msg.Source.UserAgent = event.UserAgent
 					printMsg(msg)
+					if executor != nil {
+						executor.run(ctx, event)
+					}
 				}
 			case err, ok := <-wo.Errors():
 				if !ok {
@@ -213,5 +247,12 @@ func mainWatch(cliCtx *cli.Context) error {
 	// Wait on the routine to be finished or exit.
 	wg.Wait()
 
+	if executor != nil {
+		if failed := executor.wait(); failed > 0 {
+			errorIf(errDummy().Trace(path), fmt.Sprintf("%d command(s) run by --exec failed.", failed))
+			return exitStatus(globalErrorExitStatus)
+		}
+	}
+
 	return nil
 }